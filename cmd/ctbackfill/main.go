@@ -0,0 +1,242 @@
+// cmd/ctbackfill/main.go
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/cwlclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/s3client"
+	"github.com/outofoffice3/aws-samples/geras/internal/backfill"
+	"github.com/outofoffice3/aws-samples/geras/internal/emf"
+	cloudtrailemfbatcher "github.com/outofoffice3/aws-samples/geras/internal/emfbatcher/cloudtrail"
+	"github.com/outofoffice3/aws-samples/geras/internal/generics/safemap"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	"github.com/outofoffice3/aws-samples/geras/internal/serviceconfig"
+	"github.com/outofoffice3/aws-samples/geras/internal/tokenbucket"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+var (
+	// metric names, the same as the rate limit function so backfilled and live data line up
+	metricNameCallCount      = "CallCount"
+	metricNameErrorCount     = "ErrorCount"
+	metricNameThrottledCount = "ThrottledCount"
+)
+
+const (
+	// known service variables
+	maxEvents = 10000
+	maxBytes  = 1 << 20
+
+	// the batcher is flushed by the backfill, its ticker only catches what is left
+	flushInterval = time.Hour
+
+	// emf aggregation modes
+	emfAggregationEvent  = "event"
+	emfAggregationSecond = "second"
+
+	// error messages
+	ErrMsgMissingFlag     = "missing required flag"
+	ErrMsgOneSource       = "set exactly one of -bucket and -dir"
+	ErrMsgNoRateLimitAPIs = "no rateLimitAPIs configured"
+	ErrMsgEMFAggregation  = "invalid emf aggregation mode"
+)
+
+func main() {
+	var (
+		bucket         = flag.String("bucket", "", "S3 bucket the trail delivers its log files to")
+		prefix         = flag.String("prefix", "", "key prefix of the log files, e.g. AWSLogs/<account>/CloudTrail/<region>/2025/06/")
+		bucketRegion   = flag.String("bucket-region", "us-east-1", "region of the bucket")
+		dir            = flag.String("dir", "", "local directory holding log files, instead of -bucket")
+		configPath     = flag.String("config", "", "service config file with the rateLimitAPIs, the one of the lambda layer")
+		rawRegions     = flag.String("regions", "", "comma separated regions to publish metrics to, events of other regions are skipped")
+		logGroup       = flag.String("log-group", "", "cloudwatch log group the EMF records are written to")
+		namespace      = flag.String("namespace", "", "metric namespace")
+		rawStart       = flag.String("start", "", "RFC3339 time of the oldest event, defaults to the 14 day CloudWatch Logs limit")
+		rawEnd         = flag.String("end", "", "RFC3339 time past the newest event, defaults to now")
+		emfAggregation = flag.String("emf-aggregation", emfAggregationSecond, "event writes one EMF record per event, second sums the events of every second")
+		stashDir       = flag.String("stash-dir", filepath.Join(os.TempDir(), "ctbackfill"), "directory of the EMF stash")
+		logLevelValue  = flag.String("log-level", "info", "debug, info, warn or error")
+	)
+	flag.Parse()
+
+	var logLevel logger.LogLevel
+	switch strings.ToLower(*logLevelValue) {
+	case "debug":
+		logLevel = logger.DEBUG
+	case "warn":
+		logLevel = logger.WARN
+	case "error":
+		logLevel = logger.ERROR
+	default:
+		logLevel = logger.INFO
+	}
+	logger.Init(logLevel, os.Stdout)
+	appLogger := logger.Get()
+
+	// validate the flags
+	for name, value := range map[string]string{"config": *configPath, "regions": *rawRegions, "log-group": *logGroup, "namespace": *namespace} {
+		if value == "" {
+			HandleInitError(appLogger, fmt.Errorf("%s -%s", ErrMsgMissingFlag, name))
+		}
+	}
+	if (*bucket == "") == (*dir == "") {
+		HandleInitError(appLogger, errors.New(ErrMsgOneSource))
+	}
+	if *emfAggregation != emfAggregationEvent && *emfAggregation != emfAggregationSecond {
+		HandleInitError(appLogger, errors.New(ErrMsgEMFAggregation))
+	}
+	start, err := parseTime(*rawStart)
+	if err != nil {
+		HandleInitError(appLogger, err)
+	}
+	end, err := parseTime(*rawEnd)
+	if err != nil {
+		HandleInitError(appLogger, err)
+	}
+	regions := strings.Split(*rawRegions, ",")
+
+	// load the service config and build the allow list of counted events, as the function does
+	serviceConfig, err := serviceconfig.LoadConfigFromFile(*configPath, appLogger)
+	if err != nil {
+		HandleInitError(appLogger, err)
+	}
+	if err = serviceconfig.ValidateRateLimitConfig(*serviceConfig, appLogger); err != nil {
+		HandleInitError(appLogger, err)
+	}
+	allowList, err := serviceconfig.NewRateLimitAllowList(*serviceConfig)
+	if err != nil {
+		HandleInitError(appLogger, err)
+	}
+	if len(allowList.Events()) == 0 {
+		HandleInitError(appLogger, errors.New(ErrMsgNoRateLimitAPIs))
+	}
+	appLogger.Info("loaded rate limit allow list %v", allowList.Events())
+	dimensionSets, err := cloudtrailemfbatcher.BuildDimensionSets(serviceConfig.RateLimitDimensions)
+	if err != nil {
+		HandleInitError(appLogger, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGTERM, syscall.SIGINT)
+	go func() {
+		<-sigs
+		cancel()
+	}()
+
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		HandleInitError(appLogger, err)
+	}
+
+	// backfilled records go to their own stream of the function log group
+	logStreamName := "ctbackfill-" + utils.MakeStreamName()
+	err = cwlclient.EnsureGroupAndStreamAcrossRegions(ctx, regions, *logGroup, logStreamName, makeFactory(cfg))
+	if err != nil {
+		HandleInitError(appLogger, err)
+	}
+	cwlClientMap := &safemap.TypedMap[cwlclient.CloudWatchLogsClient]{}
+	for _, region := range regions {
+		client, err := cwlclient.NewCloudWatchLogsClient(cfg, region)
+		if err != nil {
+			HandleInitError(appLogger, err)
+		}
+		cwlClientMap.Store(region, client)
+	}
+	flusher := emf.NewEMFFlusher(emf.EMFFlusherConfig{
+		CwlClientMap:  cwlClientMap,
+		LogStreamName: logStreamName,
+		LogGroupName:  *logGroup,
+		Logger:        appLogger,
+	})
+
+	// the same batcher as the function, without the reports that follow the flush interval
+	if err := os.MkdirAll(*stashDir, 0755); err != nil {
+		HandleInitError(appLogger, err)
+	}
+	batcher := cloudtrailemfbatcher.NewCTFileBatcher(cloudtrailemfbatcher.CTFileBatcherConfig{
+		ParentCtx:           ctx,
+		Namespace:           *namespace,
+		MetricName:          metricNameCallCount,
+		ErrorMetricName:     metricNameErrorCount,
+		ThrottledMetricName: metricNameThrottledCount,
+		DimensionSets:       dimensionSets,
+		Aggregate:           *emfAggregation == emfAggregationSecond,
+		BaseDir:             *stashDir,
+		MaxCount:            maxEvents,
+		MaxBytes:            maxBytes,
+		FlushInterval:       flushInterval,
+		EmfFlusher:          flusher,
+		Simulator:           tokenbucket.NewSimulator(allowList.Limits()),
+		Logger:              appLogger,
+	})
+
+	var source backfill.Source = &backfill.DirSource{Dir: *dir}
+	if *bucket != "" {
+		s3Client, err := s3client.NewS3Client(cfg, *bucketRegion)
+		if err != nil {
+			HandleInitError(appLogger, err)
+		}
+		source = &backfill.S3Source{Client: s3Client, Bucket: *bucket, Prefix: *prefix}
+	}
+
+	backfiller, err := backfill.NewBackfiller(backfill.Config{
+		Source:    source,
+		Batcher:   batcher,
+		AllowList: allowList,
+		Regions:   regions,
+		Start:     start,
+		End:       end,
+		Logger:    appLogger,
+	})
+	if err != nil {
+		HandleInitError(appLogger, err)
+	}
+
+	stats, err := backfiller.Run(ctx)
+	batcher.Stop()
+	appLogger.Info("read %d log files (%d failed), %d events: %d counted, %d unlisted, %d too old, %d out of range, %d in other regions",
+		stats.Files, stats.FailedFiles, stats.Events, stats.Counted, stats.Unlisted, stats.TooOld, stats.OutOfRange, stats.UnknownRegion)
+	if err != nil {
+		appLogger.Error("backfill incomplete, the records left in %s are flushed by the next run with the same -stash-dir: %v", *stashDir, err)
+		os.Exit(1)
+	}
+}
+
+// parseTime parses an optional RFC3339 time
+func parseTime(raw string) (time.Time, error) {
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, raw)
+}
+
+// Handle Init Error
+func HandleInitError(logger logger.Logger, err error) {
+	logger.Error("error initializing backfill: %v", err)
+	os.Exit(1)
+}
+
+func makeFactory(cfg aws.Config) cwlclient.ClientFactory {
+	return func(region string) (cwlclient.CloudWatchLogsClient, error) {
+		cfg.Region = region
+		client, err := cwlclient.NewCloudWatchLogsClient(cfg, region)
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	}
+}
@@ -2,7 +2,6 @@
 package main
 
 import (
-	"bufio"
 	"context"
 	"encoding/json"
 	"errors"
@@ -14,18 +13,17 @@ import (
 	"syscall"
 
 	"os/signal"
-	"time"
-
-	"slices"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/cwlclient"
 	"github.com/outofoffice3/aws-samples/geras/internal/emf"
+	cloudtrailemfbatcher "github.com/outofoffice3/aws-samples/geras/internal/emfbatcher/cloudtrail"
 	"github.com/outofoffice3/aws-samples/geras/internal/extension"
 	"github.com/outofoffice3/aws-samples/geras/internal/generics/safemap"
 	"github.com/outofoffice3/aws-samples/geras/internal/logger"
 	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+	"github.com/outofoffice3/aws-samples/geras/internal/wal"
 )
 
 const (
@@ -40,6 +38,10 @@ var (
 	extensionName   = filepath.Base(os.Args[0])
 	extensionClient = extension.NewClient(os.Getenv("AWS_LAMBDA_RUNTIME_API"))
 	printPrefix     = fmt.Sprintf("[%s]", extensionName)
+
+	// stashDirs are the directories of os.TempDir() the rate limit function stashes
+	// segments in: its own and the ones of the unlisted and duplicate events batchers
+	stashDirs = []string{"", "unlisted", "duplicates"}
 )
 
 func main() {
@@ -106,28 +108,16 @@ func main() {
 
 	// ── 0) ONE-TIME INIT BEFORE REGISTER ─────────────────────────────────
 
-	// a) list all stash files
+	// a) build per-region CWL client map
 	stashDir := os.TempDir()
-	files, err := filepath.Glob(filepath.Join(stashDir, "emf_*.ndjson"))
-	if err != nil {
-		log.Error("%s glob failed: %v", printPrefix, err)
-		os.Exit(1)
-	}
-	log.Debug("%s found %d files in %s", printPrefix, len(files), stashDir)
-
-	// b) build per-region CWL client map
 	cwlMap := &safemap.TypedMap[cwlclient.CloudWatchLogsClient]{}
-	for _, f := range files {
-		region := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(f), "emf_"), ".ndjson")
-		client, err := cwlclient.NewCloudWatchLogsClient(awsCfg, region)
-		if err != nil {
-			log.Error("%s CWL client creation failed for region %s: %v", printPrefix, region, err)
-			continue
-		}
-		cwlMap.Store(region, client)
-		log.Debug("%s CWL client created for region %s", printPrefix, region)
+	for _, region := range regions {
+		storeClient(awsCfg, cwlMap, region, log)
 	}
 
+	// b) list the region logs left by a previous environment
+	log.Debug("%s found %d region logs in %s", printPrefix, len(listStashLogs(stashDir, log)), stashDir)
+
 	// c) create EMF flusher
 
 	log.Debug("%s cloudwatch log group set to %s", printPrefix, logGroup)
@@ -150,47 +140,84 @@ func main() {
 	processEvents(ctx, log)
 	log.Info("%s processEvents() returned, SHUTDOWN", printPrefix)
 
-	// ── 3) ON SHUTDOWN, FLUSH ALL STASH FILES ─────────────────────────────
-	files, _ = filepath.Glob(filepath.Join(os.TempDir(), "emf_*.ndjson"))
-	log.Info("%s flushing %d files", printPrefix, len(files))
+	// ── 3) ON SHUTDOWN, FLUSH ALL STASH SEGMENTS ──────────────────────────
+	// region logs are flushed in parallel, each one oldest segment first.  Segments are
+	// removed only once their records are accepted, whatever is left is flushed by the
+	// next environment
+	logs := listStashLogs(stashDir, log)
+	log.Info("%s flushing %d region logs", printPrefix, len(logs))
 	var wg sync.WaitGroup
-	for _, path := range files {
+	for _, segments := range logs {
+		region, _ := cloudtrailemfbatcher.RegionFromSegment(segments[0])
+		if _, ok := cwlMap.Load(region); !ok {
+			storeClient(awsCfg, cwlMap, region, log)
+		}
 		wg.Add(1)
-		go func(path string) {
+		go func(region string, segments []wal.Segment) {
 			defer wg.Done()
-			f, err := os.Open(path)
-			if err != nil {
-				log.Error(printPrefix+" open %s: %v", path, err)
-				return
+			flushLog(flusher, region, segments, log)
+		}(region, segments)
+	}
+	wg.Wait()
+	log.Info(printPrefix + " flush complete, exiting")
+}
+
+// listStashLogs returns the segments of every region log in the stash directories,
+// each log oldest segment first
+func listStashLogs(baseDir string, log logger.Logger) [][]wal.Segment {
+	var logs [][]wal.Segment
+	for _, name := range stashDirs {
+		dir := filepath.Join(baseDir, name)
+		segments, err := wal.ListSegments(wal.OSFS{}, dir)
+		if err != nil {
+			log.Error("%s list segments of %s failed: %v", printPrefix, dir, err)
+			continue
+		}
+		// segments are ordered by prefix, each region log is a run of them
+		for i := 0; i < len(segments); {
+			j := i + 1
+			for j < len(segments) && segments[j].Prefix == segments[i].Prefix {
+				j++
 			}
-			defer f.Close()
-
-			var batch []emf.EMFRecord
-			scanner := bufio.NewScanner(f)
-			for scanner.Scan() {
-				var meta struct {
-					AWS struct{ Timestamp int64 } `json:"_aws"`
-				}
-				line := scanner.Bytes()
-				if err := json.Unmarshal(line, &meta); err != nil {
-					log.Error(printPrefix+" meta unmarshal %s: %v", path, err)
-					continue
-				}
-				batch = append(batch, emf.EMFRecord{
-					Payload:   slices.Clone(line),
-					TimeStamp: time.UnixMilli(meta.AWS.Timestamp),
-				})
-				log.Debug(printPrefix+" read %s: %s", path, string(line))
+			if _, ok := cloudtrailemfbatcher.RegionFromSegment(segments[i]); ok {
+				logs = append(logs, segments[i:j])
 			}
-			if len(batch) > 0 {
-				flusher.Flush(context.Background(), filepath.Base(path), batch)
-				log.Info(printPrefix+" flushed %s", path)
+			i = j
+		}
+	}
+	return logs
+}
+
+// flushLog pushes the segments of a region log oldest first.  It stops at the first
+// failure so the next environment resumes the log in order.
+func flushLog(flusher emf.EMFFlusher, region string, segments []wal.Segment, log logger.Logger) {
+	for _, segment := range segments {
+		lines, err := wal.ReadSegment(wal.OSFS{}, segment)
+		if err != nil {
+			log.Error(printPrefix+" read %s: %v", segment.Path, err)
+			return
+		}
+		var batch []emf.EMFRecord
+		for _, line := range lines {
+			record, err := emf.ParseRecord(line)
+			if err != nil {
+				log.Error(printPrefix+" parse %s: %v", segment.Path, err)
+				continue
+			}
+			batch = append(batch, record)
+		}
+		if len(batch) > 0 {
+			if err := flusher.Flush(context.Background(), region, batch); err != nil {
+				log.Error(printPrefix+" flush %s: %v", segment.Path, err)
+				return
 			}
-			os.Truncate(path, 0)
-		}(path)
+			log.Info(printPrefix+" flushed %s", segment.Path)
+		}
+		if err := os.Remove(segment.Path); err != nil {
+			log.Error(printPrefix+" remove %s: %v", segment.Path, err)
+			return
+		}
 	}
-	wg.Wait()
-	log.Info(printPrefix + " flush complete, exiting")
 }
 
 func processEvents(ctx context.Context, log logger.Logger) {
@@ -225,6 +252,17 @@ func HandleInitError(logger logger.Logger, err error) {
 	os.Exit(1)
 }
 
+// storeClient creates the CWL client of a region and stores it in the client map
+func storeClient(cfg aws.Config, cwlMap *safemap.TypedMap[cwlclient.CloudWatchLogsClient], region string, log logger.Logger) {
+	client, err := cwlclient.NewCloudWatchLogsClient(cfg, region)
+	if err != nil {
+		log.Error("%s CWL client creation failed for region %s: %v", printPrefix, region, err)
+		return
+	}
+	cwlMap.Store(region, client)
+	log.Debug("%s CWL client created for region %s", printPrefix, region)
+}
+
 func makeFactory(cfg aws.Config) cwlclient.ClientFactory {
 	return func(region string) (cwlclient.CloudWatchLogsClient, error) {
 		cfg.Region = region
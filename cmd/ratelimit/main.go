@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -13,20 +14,29 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/cwlclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/dedup"
 	"github.com/outofoffice3/aws-samples/geras/internal/emf"
 	cloudtrailemfbatcher "github.com/outofoffice3/aws-samples/geras/internal/emfbatcher/cloudtrail"
 	"github.com/outofoffice3/aws-samples/geras/internal/generics/safemap"
 
 	"github.com/outofoffice3/aws-samples/geras/internal/handlers"
 	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	"github.com/outofoffice3/aws-samples/geras/internal/serviceconfig"
+	"github.com/outofoffice3/aws-samples/geras/internal/tokenbucket"
 	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+	"github.com/outofoffice3/aws-samples/geras/internal/wal"
 )
 
 var (
-	RateLimitHandler    *handlers.RateLimitHandler
-	logStreamName       = utils.MakeStreamName()
-	flusherInterval     time.Duration
-	metricNameCallCount = "CallCount"
+	RateLimitHandler            *handlers.RateLimitHandler
+	logStreamName               = utils.MakeStreamName()
+	flusherInterval             time.Duration
+	metricNameCallCount         = "CallCount"
+	metricNameUnlistedCallCount = "UnlistedCallCount"
+	metricNameErrorCount        = "ErrorCount"
+	metricNameThrottledCount    = "ThrottledCount"
+	metricNameTopCallerCount    = "TopCallerCount"
+	metricNameDuplicateEvents   = "DuplicateEvents"
 )
 
 const (
@@ -42,10 +52,49 @@ const (
 	cloudwatchLogGroupEnv = "CLOUDWATCH_LOG_GROUP"
 	metricNamespaceEnv    = "METRIC_NAMESPACE"
 	flushIntervalEnv      = "FLUSH_INTERVAL"
+	lambdaLayerPathEnv    = "LAMBDA_LAYER_PATH"
+	unlistedEventsEnv     = "UNLISTED_EVENTS"
+	topCallersEnv         = "TOP_CALLERS"
+	emfAggregationEnv     = "EMF_AGGREGATION"
+	peakRateEnv           = "PEAK_RATE"
+	stashFsyncEnv         = "STASH_FSYNC"
+	durabilityEnv         = "DURABILITY"
+	dedupWindowEnv        = "DEDUP_WINDOW"
+	dedupSizeEnv          = "DEDUP_SIZE"
+
+	// unlisted events modes
+	unlistedEventsDrop  = "drop"
+	unlistedEventsCount = "count"
+	unlistedDirName     = "unlisted"
+
+	// duplicate events
+	duplicatesDirName  = "duplicates"
+	defaultDedupWindow = 900
+	defaultDedupSize   = 100000
+
+	// emf aggregation modes
+	emfAggregationEvent  = "event"
+	emfAggregationSecond = "second"
+
+	// peak rate modes
+	peakRateOn  = "on"
+	peakRateOff = "off"
+
+	// durability modes
+	durabilityAsync = "async"
+	durabilitySync  = "sync"
 
 	// error messages
 	ErrMsgCannotLoadEnvVar  = "cannot load env var"
 	ErrMsgServiceInitFailed = "failed to initialize service"
+	ErrMsgNoRateLimitAPIs   = "no rateLimitAPIs configured"
+	ErrMsgUnlistedEvents    = "invalid unlisted events mode"
+	ErrMsgTopCallers        = "invalid number of top callers"
+	ErrMsgEMFAggregation    = "invalid emf aggregation mode"
+	ErrMsgPeakRate          = "invalid peak rate mode"
+	ErrMsgDurability        = "invalid durability mode"
+	ErrMsgDedupWindow       = "invalid dedup window"
+	ErrMsgDedupSize         = "invalid dedup size"
 )
 
 func HandleRequest(ctx context.Context, event events.SQSEvent) (events.SQSEventResponse, error) {
@@ -128,6 +177,114 @@ func main() {
 	flusherInterval = time.Duration(secs) * time.Second
 	appLogger.Info("loaded flush interval %v", flusherInterval)
 
+	// load the service config from the lambda layer and build the allow list of counted events
+	layerPath := os.Getenv(lambdaLayerPathEnv)
+	if layerPath == "" {
+		HandleInitError(appLogger, errors.New(ErrMsgCannotLoadEnvVar))
+	}
+	serviceConfig, err := serviceconfig.LoadConfigFromFile(layerPath, appLogger)
+	if err != nil {
+		HandleInitError(appLogger, err)
+	}
+	if err = serviceconfig.ValidateRateLimitConfig(*serviceConfig, appLogger); err != nil {
+		HandleInitError(appLogger, err)
+	}
+	allowList, err := serviceconfig.NewRateLimitAllowList(*serviceConfig)
+	if err != nil {
+		HandleInitError(appLogger, err)
+	}
+	if len(allowList.Events()) == 0 {
+		HandleInitError(appLogger, errors.New(ErrMsgNoRateLimitAPIs))
+	}
+	appLogger.Info("loaded rate limit allow list %v", allowList.Events())
+	dimensionSets, err := cloudtrailemfbatcher.BuildDimensionSets(serviceConfig.RateLimitDimensions)
+	if err != nil {
+		HandleInitError(appLogger, err)
+	}
+	appLogger.Info("loaded call count dimension sets %v", dimensionSets)
+	bucketLimits := allowList.Limits()
+	appLogger.Info("simulating token buckets for %d apis", len(bucketLimits))
+
+	// events missing from the allow list are dropped unless asked to count them separately
+	unlistedEvents := strings.ToLower(os.Getenv(unlistedEventsEnv))
+	if unlistedEvents == "" {
+		unlistedEvents = unlistedEventsDrop
+	}
+	if unlistedEvents != unlistedEventsDrop && unlistedEvents != unlistedEventsCount {
+		HandleInitError(appLogger, errors.New(ErrMsgUnlistedEvents))
+	}
+	appLogger.Info("unlisted events mode %v", unlistedEvents)
+
+	// the heaviest callers of every api are reported on every flush interval when enabled
+	topCallers := 0
+	if rawTopCallers := os.Getenv(topCallersEnv); rawTopCallers != "" {
+		topCallers, err = strconv.Atoi(rawTopCallers)
+		if err != nil || topCallers < 0 {
+			HandleInitError(appLogger, errors.New(ErrMsgTopCallers))
+		}
+	}
+	appLogger.Info("reporting %d top callers per api", topCallers)
+
+	// events are written one record each unless asked to sum them per second
+	emfAggregation := strings.ToLower(os.Getenv(emfAggregationEnv))
+	if emfAggregation == "" {
+		emfAggregation = emfAggregationEvent
+	}
+	if emfAggregation != emfAggregationEvent && emfAggregation != emfAggregationSecond {
+		HandleInitError(appLogger, errors.New(ErrMsgEMFAggregation))
+	}
+	aggregate := emfAggregation == emfAggregationSecond
+	appLogger.Info("emf aggregation mode %v", emfAggregation)
+
+	// the peak and p99 calls per second of every api are reported on every flush interval unless turned off
+	peakRateMode := strings.ToLower(os.Getenv(peakRateEnv))
+	if peakRateMode == "" {
+		peakRateMode = peakRateOn
+	}
+	if peakRateMode != peakRateOn && peakRateMode != peakRateOff {
+		HandleInitError(appLogger, errors.New(ErrMsgPeakRate))
+	}
+	peakRate := peakRateMode == peakRateOn
+	appLogger.Info("peak rate mode %v", peakRateMode)
+
+	// stashed records are synced to disk when their segment is sealed unless asked otherwise
+	rawStashFsync := strings.ToLower(os.Getenv(stashFsyncEnv))
+	if rawStashFsync == "" {
+		rawStashFsync = "rotate"
+	}
+	stashFsync, err := wal.ParseFsyncPolicy(rawStashFsync)
+	if err != nil {
+		HandleInitError(appLogger, err)
+	}
+	appLogger.Info("stash fsync policy %v", rawStashFsync)
+
+	// messages are acknowledged once stashed unless asked to wait for cloudwatch logs
+	durability := strings.ToLower(os.Getenv(durabilityEnv))
+	if durability == "" {
+		durability = durabilityAsync
+	}
+	if durability != durabilityAsync && durability != durabilitySync {
+		HandleInitError(appLogger, errors.New(ErrMsgDurability))
+	}
+	appLogger.Info("durability mode %v", durability)
+
+	// eventIDs are remembered for a window to drop SQS redeliveries, 0 disables it
+	dedupWindow := defaultDedupWindow
+	if rawDedupWindow := os.Getenv(dedupWindowEnv); rawDedupWindow != "" {
+		dedupWindow, err = strconv.Atoi(rawDedupWindow)
+		if err != nil || dedupWindow < 0 {
+			HandleInitError(appLogger, errors.New(ErrMsgDedupWindow))
+		}
+	}
+	dedupSize := defaultDedupSize
+	if rawDedupSize := os.Getenv(dedupSizeEnv); rawDedupSize != "" {
+		dedupSize, err = strconv.Atoi(rawDedupSize)
+		if err != nil || dedupSize <= 0 {
+			HandleInitError(appLogger, errors.New(ErrMsgDedupSize))
+		}
+	}
+	appLogger.Info("dedup window %d seconds, up to %d event ids", dedupWindow, dedupSize)
+
 	ctx := context.Background()
 
 	// load aws config
@@ -173,22 +330,84 @@ func main() {
 
 	// create cloud trail flie batchers
 	// it will ingest cloudtrail records, convert them to emf
-	// and store them in /tmp until the batch flush conditions are met
+	// and store them in /tmp until the batch flush conditions are met.
+	// counted events with a known rate limit are replayed through their token bucket
 	cloudtrailFileBatcher := cloudtrailemfbatcher.NewCTFileBatcher(cloudtrailemfbatcher.CTFileBatcherConfig{
-		ParentCtx:     ctx,
-		Namespace:     namespace,
-		MetricName:    metricNameCallCount,
-		BaseDir:       os.TempDir(),
-		MaxCount:      maxEvents,
-		MaxBytes:      maxBytes,
-		FlushInterval: flusherInterval,
-		EmfFlusher:    flusher,
-		Logger:        appLogger,
+		ParentCtx:            ctx,
+		Namespace:            namespace,
+		MetricName:           metricNameCallCount,
+		ErrorMetricName:      metricNameErrorCount,
+		ThrottledMetricName:  metricNameThrottledCount,
+		DimensionSets:        dimensionSets,
+		TopCallers:           topCallers,
+		TopCallersMetricName: metricNameTopCallerCount,
+		Aggregate:            aggregate,
+		PeakRate:             peakRate,
+		Fsync:                stashFsync,
+		BaseDir:              os.TempDir(),
+		MaxCount:             maxEvents,
+		MaxBytes:             maxBytes,
+		FlushInterval:        flusherInterval,
+		EmfFlusher:           flusher,
+		Simulator:            tokenbucket.NewSimulator(bucketLimits),
+		Logger:               appLogger,
 	})
 
+	// unlisted events are counted under their own metric, batched in a separate
+	// directory so the two batchers never share a file
+	var unlistedFileBatcher cloudtrailemfbatcher.EMFFileBatcher
+	if unlistedEvents == unlistedEventsCount {
+		unlistedDir := filepath.Join(os.TempDir(), unlistedDirName)
+		if err := os.MkdirAll(unlistedDir, 0755); err != nil {
+			HandleInitError(appLogger, err)
+		}
+		unlistedFileBatcher = cloudtrailemfbatcher.NewCTFileBatcher(cloudtrailemfbatcher.CTFileBatcherConfig{
+			ParentCtx:     ctx,
+			Namespace:     namespace,
+			MetricName:    metricNameUnlistedCallCount,
+			Aggregate:     aggregate,
+			Fsync:         stashFsync,
+			BaseDir:       unlistedDir,
+			MaxCount:      maxEvents,
+			MaxBytes:      maxBytes,
+			FlushInterval: flusherInterval,
+			EmfFlusher:    flusher,
+			Logger:        appLogger,
+		})
+	}
+
+	// duplicate events are counted under their own metric, batched in a separate directory
+	var dedupStore dedup.Store
+	var duplicateFileBatcher cloudtrailemfbatcher.EMFFileBatcher
+	if dedupWindow > 0 {
+		dedupStore = dedup.NewWindow(dedupSize, time.Duration(dedupWindow)*time.Second)
+		duplicatesDir := filepath.Join(os.TempDir(), duplicatesDirName)
+		if err := os.MkdirAll(duplicatesDir, 0755); err != nil {
+			HandleInitError(appLogger, err)
+		}
+		duplicateFileBatcher = cloudtrailemfbatcher.NewCTFileBatcher(cloudtrailemfbatcher.CTFileBatcherConfig{
+			ParentCtx:     ctx,
+			Namespace:     namespace,
+			MetricName:    metricNameDuplicateEvents,
+			Aggregate:     aggregate,
+			Fsync:         stashFsync,
+			BaseDir:       duplicatesDir,
+			MaxCount:      maxEvents,
+			MaxBytes:      maxBytes,
+			FlushInterval: flusherInterval,
+			EmfFlusher:    flusher,
+			Logger:        appLogger,
+		})
+	}
+
 	// initialize handler
 	RateLimitHandler, err = handlers.NewRateLimitHandler(handlers.RateLimitHandlerConfig{
 		CloudTrailEmfFileBatcher: cloudtrailFileBatcher,
+		UnlistedEventBatcher:     unlistedFileBatcher,
+		DuplicateEventBatcher:    duplicateFileBatcher,
+		AllowList:                allowList,
+		Dedup:                    dedupStore,
+		SyncDurability:           durability == durabilitySync,
 		Namespace:                namespace,
 		Logger:                   appLogger,
 	})
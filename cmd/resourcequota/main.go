@@ -10,13 +10,38 @@ import (
 	"github.com/aws/aws-lambda-go/lambda"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/acmclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/apigatewayclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/apigatewayv2client"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/appsyncclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/athenaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/autoscalingclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/cloudformationclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/cwclient"
 	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/cwlclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/dynamodbclient"
 	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/ec2client"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/ecrclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/ecsclient"
 	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/efsclient"
 	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/eksclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/elasticacheclient"
 	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/elbv2client"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/eventbridgeclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/firehoseclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/glueclient"
 	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/iamclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/kinesisclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/kmsclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/lambdaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/organizationsclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/rdsclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/route53client"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/s3client"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/secretsmanagerclient"
 	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/sfnclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/snsclient"
 	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/supportclient"
 	metricemfbatcher "github.com/outofoffice3/aws-samples/geras/internal/emfbatcher/metrics"
 	"github.com/outofoffice3/aws-samples/geras/internal/generics/safemap"
@@ -28,9 +53,57 @@ import (
 	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
 	"github.com/outofoffice3/aws-samples/geras/internal/utils"
 
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/acm/certificates"
+	apigwaccountresources "github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/apigateway/accountresources"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/apigateway/httpapis"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/apigateway/restapis"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/apigateway/throttling"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/appsync/graphqlapis"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/athena/workgroups"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/cloudformation/stacks"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/cloudwatch/alarms"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/cloudwatch/dashboards"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/cloudwatchlogs/metricfilters"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/cloudwatchlogs/subscriptionfilters"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/dynamodb/capacity"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/dynamodb/tables"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/ec2/autoscalinglimits"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/ec2/launchresources"
 	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/ec2/networkinterfaces"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/ecr/images"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/ecr/repositories"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/ecs/clusters"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/ecs/services"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/eks/clusterquotas"
 	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/eks/listcluster"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/elasticache/nodes"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/eventbridge/rules"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/eventbridge/targets"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/firehose/deliverystreams"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/glue/catalog"
+	gluejobs "github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/glue/jobs"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/iam/accountresources"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/iam/attachedrolepolicies"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/iam/inventory"
 	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/iam/oidcproviders"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/kinesis/streamlimits"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/kms/aliases"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/kms/keys"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/lambda/accountsettings"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/lambda/peakconcurrency"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/organizations/accounts"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/organizations/organizationalunits"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/organizations/servicecontrolpolicies"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/rds/accountattributes"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/route53/accountlimits"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/route53/hostedzonelimits"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/s3/buckets"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/secretsmanager/secrets"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/sns/subscriptions"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/sns/topics"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/sqs/inflight"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/stepfunctions/activities"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/stepfunctions/statemachines"
 	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/support/gp3storage"
 	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/support/iamroles"
 	vpcnau "github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/vpc/nau"
@@ -62,21 +135,94 @@ const (
 	ErrMsgInitMetricBatcher         = "error initializing metric batcher"
 
 	// create client errors
-	ErrMsgCreateEC2Client          = "error creating EC2 client"
-	ErrMsgCreateServiceQuotaClient = "error creating Service Quota client"
-	ErrMsgCreateEFSClient          = "error creating EFS client"
-	ErrMsgCreateELBClient          = "error creating ELB client"
-	ErrMsgCreateSupportClient      = "error creating Support client"
-	ErrMsgCreateEKSClient          = "error creating EKS client"
+	ErrMsgCreateEC2Client            = "error creating EC2 client"
+	ErrMsgCreateAutoScalingClient    = "error creating Auto Scaling client"
+	ErrMsgCreateServiceQuotaClient   = "error creating Service Quota client"
+	ErrMsgCreateEFSClient            = "error creating EFS client"
+	ErrMsgCreateELBClient            = "error creating ELB client"
+	ErrMsgCreateSupportClient        = "error creating Support client"
+	ErrMsgCreateEKSClient            = "error creating EKS client"
+	ErrMsgCreateLambdaClient         = "error creating Lambda client"
+	ErrMsgCreateCloudWatchClient     = "error creating CloudWatch client"
+	ErrMsgCreateECSClient            = "error creating ECS client"
+	ErrMsgCreateECRClient            = "error creating ECR client"
+	ErrMsgCreateRDSClient            = "error creating RDS client"
+	ErrMsgCreateDynamoDBClient       = "error creating DynamoDB client"
+	ErrMsgCreateElastiCacheClient    = "error creating ElastiCache client"
+	ErrMsgCreateCloudFormationClient = "error creating CloudFormation client"
+	ErrMsgCreateRoute53Client        = "error creating Route53 client"
+	ErrMsgCreateS3Client             = "error creating S3 client"
+	ErrMsgCreateOrganizationsClient  = "error creating Organizations client"
+	ErrMsgCreateEventBridgeClient    = "error creating EventBridge client"
+	ErrMsgCreateSNSClient            = "error creating SNS client"
+	ErrMsgCreateSFNClient            = "error creating Step Functions client"
+	ErrMsgCreateCloudWatchLogsClient = "error creating CloudWatch Logs client"
+	ErrMsgCreateKMSClient            = "error creating KMS client"
+	ErrMsgCreateACMClient            = "error creating ACM client"
+	ErrMsgCreateSecretsManagerClient = "error creating Secrets Manager client"
+	ErrMsgCreateAPIGatewayClient     = "error creating API Gateway client"
+	ErrMsgCreateAPIGatewayV2Client   = "error creating API Gateway V2 client"
+	ErrMsgCreateAppSyncClient        = "error creating AppSync client"
+	ErrMsgCreateKinesisClient        = "error creating Kinesis client"
+	ErrMsgCreateFirehoseClient       = "error creating Firehose client"
+	ErrMsgCreateGlueClient           = "error creating Glue client"
+	ErrMsgCreateAthenaClient         = "error creating Athena client"
 
 	// create job errors
-	ErrMsgCreateNetworkInterfacesJob = "error creating EC2 job"
-	ErrMsgCreateListEKSClustersJob   = "error creating list EKS clusters job"
-	ErrMsgCreateIAMClient            = "error creating IAM client"
-	ErrMsgCreateIAMOIDCJob           = "error creating IAM OIDC job"
-	ErrMsgCreateIAMRolesJob          = "error creating IAM Roles job"
-	ErrMsgCreateGP3StorageJob        = "error creating GP3 job"
-	ErrMsgCreateVPCNAUJob            = "error creating VPC NAU job"
+	ErrMsgCreateNetworkInterfacesJob          = "error creating EC2 job"
+	ErrMsgCreateAutoScalingLimitsJob          = "error creating Auto Scaling limits job"
+	ErrMsgCreateLaunchResourcesJob            = "error creating EC2 launch resources job"
+	ErrMsgCreateListEKSClustersJob            = "error creating list EKS clusters job"
+	ErrMsgCreateEKSClusterQuotasJob           = "error creating EKS cluster quotas job"
+	ErrMsgCreateIAMClient                     = "error creating IAM client"
+	ErrMsgCreateIAMOIDCJob                    = "error creating IAM OIDC job"
+	ErrMsgCreateIAMRolesJob                   = "error creating IAM Roles job"
+	ErrMsgCreateIAMAccountResourcesJob        = "error creating IAM account resources job"
+	ErrMsgCreateIAMAttachedRolePoliciesJob    = "error creating IAM attached role policies job"
+	ErrMsgCreateGP3StorageJob                 = "error creating GP3 job"
+	ErrMsgCreateVPCNAUJob                     = "error creating VPC NAU job"
+	ErrMsgCreateLambdaSettingsJob             = "error creating Lambda account settings job"
+	ErrMsgCreateLambdaPeakJob                 = "error creating Lambda peak concurrency job"
+	ErrMsgCreateECSClustersJob                = "error creating ECS clusters job"
+	ErrMsgCreateECSServicesJob                = "error creating ECS services job"
+	ErrMsgCreateECRRepositoriesJob            = "error creating ECR repositories job"
+	ErrMsgCreateECRImagesJob                  = "error creating ECR images job"
+	ErrMsgCreateRDSAccountAttributesJob       = "error creating RDS account attributes job"
+	ErrMsgCreateDynamoDBTablesJob             = "error creating DynamoDB tables job"
+	ErrMsgCreateDynamoDBCapacityJob           = "error creating DynamoDB capacity job"
+	ErrMsgCreateElastiCacheNodesJob           = "error creating ElastiCache nodes job"
+	ErrMsgCreateCloudFormationStacksJob       = "error creating CloudFormation stacks job"
+	ErrMsgCreateRoute53AccountLimitsJob       = "error creating Route53 account limits job"
+	ErrMsgCreateRoute53HostedZoneLimitsJob    = "error creating Route53 hosted zone limits job"
+	ErrMsgCreateS3BucketsJob                  = "error creating S3 buckets job"
+	ErrMsgCreateOrganizationsAccountsJob      = "error creating Organizations accounts job"
+	ErrMsgCreateOrganizationsOUsJob           = "error creating Organizations organizational units job"
+	ErrMsgCreateOrganizationsSCPsJob          = "error creating Organizations SCPs per target job"
+	ErrMsgCreateEventBridgeRulesJob           = "error creating EventBridge rules job"
+	ErrMsgCreateEventBridgeTargetsJob         = "error creating EventBridge targets job"
+	ErrMsgCreateSNSTopicsJob                  = "error creating SNS topics job"
+	ErrMsgCreateSNSSubscriptionsJob           = "error creating SNS subscriptions job"
+	ErrMsgCreateSQSInFlightJob                = "error creating SQS in flight messages job"
+	ErrMsgCreateSFNStateMachinesJob           = "error creating Step Functions state machines job"
+	ErrMsgCreateSFNActivitiesJob              = "error creating Step Functions activities job"
+	ErrMsgCreateCloudWatchAlarmsJob           = "error creating CloudWatch alarms job"
+	ErrMsgCreateCloudWatchDashboardsJob       = "error creating CloudWatch dashboards job"
+	ErrMsgCreateCWLMetricFiltersJob           = "error creating CloudWatch Logs metric filters job"
+	ErrMsgCreateCWLSubscriptionFiltersJob     = "error creating CloudWatch Logs subscription filters job"
+	ErrMsgCreateKMSKeysJob                    = "error creating KMS keys job"
+	ErrMsgCreateKMSAliasesJob                 = "error creating KMS aliases job"
+	ErrMsgCreateACMCertificatesJob            = "error creating ACM certificates job"
+	ErrMsgCreateSecretsManagerSecretsJob      = "error creating Secrets Manager secrets job"
+	ErrMsgCreateAPIGatewayRestApisJob         = "error creating API Gateway REST APIs job"
+	ErrMsgCreateAPIGatewayHttpApisJob         = "error creating API Gateway HTTP APIs job"
+	ErrMsgCreateAPIGatewayAccountResourcesJob = "error creating API Gateway account resources job"
+	ErrMsgCreateAPIGatewayThrottlingJob       = "error creating API Gateway throttling job"
+	ErrMsgCreateAppSyncGraphqlApisJob         = "error creating AppSync GraphQL APIs job"
+	ErrMsgCreateKinesisStreamLimitsJob        = "error creating Kinesis stream limits job"
+	ErrMsgCreateFirehoseDeliveryStreamsJob    = "error creating Firehose delivery streams job"
+	ErrMsgCreateGlueCatalogJob                = "error creating Glue catalog job"
+	ErrMsgCreateGlueJobsJob                   = "error creating Glue jobs job"
+	ErrMsgCreateAthenaWorkGroupsJob           = "error creating Athena workgroups job"
 
 	// create handler error
 	ErrMsgCreateResourceQuotaHandler = "error creating resource quota handler"
@@ -345,28 +491,73 @@ func buildJobManager(input BuildJobManagerInput) *job.JobManager {
 	)
 	for _, region := range input.Regions {
 		for serviceName, svcCfg := range input.Services {
+			// global services are added once below
+			if serviceconfig.IsGlobalService(serviceName) {
+				continue
+			}
 			switch serviceName {
 			case "ec2":
+				ec2Client, err := ec2client.NewEc2Client(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateEC2Client,
+						Err:    err,
+					})
+				}
+				sqClient, err := servicequotaclient.NewServiceQuotaClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateServiceQuotaClient,
+						Err:    err,
+					})
+				}
+				asClient, err := autoscalingclient.NewAutoScalingClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateAutoScalingClient,
+						Err:    err,
+					})
+				}
 				for _, qm := range svcCfg.QuotaMetrics {
 					if qm.Name == "networkInterfaces" {
 						log.Info("creating EC2 job for region %s", region)
-						ec2Client, err := ec2client.NewEc2Client(awsCfg, region)
+						job, err := networkinterfaces.NewNetworkInterfaceJob(networkinterfaces.NetworkInterfaceJobConfig{
+							Ec2Client:           ec2Client,
+							ServiceQuotasClient: sqClient,
+							Logger:              log,
+						})
 						if err != nil {
 							fatal(FatalInput{
 								Logger: log,
-								Msg:    ErrMsgCreateEC2Client,
+								Msg:    ErrMsgCreateNetworkInterfacesJob,
 								Err:    err,
 							})
 						}
-						sqClient, err := servicequotaclient.NewServiceQuotaClient(awsCfg, region)
+						jm.AddJob(job)
+						log.Info("added network interfaces job for region %s to job manager", region)
+					}
+					if qm.Name == "autoScalingLimits" {
+						log.Info("creating Auto Scaling limits job for region %s", region)
+						job, err := autoscalinglimits.NewAutoScalingLimitsJob(autoscalinglimits.AutoScalingLimitsJobConfig{
+							AutoScalingClient: asClient,
+							Logger:            log,
+						})
 						if err != nil {
 							fatal(FatalInput{
 								Logger: log,
-								Msg:    ErrMsgCreateServiceQuotaClient,
+								Msg:    ErrMsgCreateAutoScalingLimitsJob,
 								Err:    err,
 							})
 						}
-						job, err := networkinterfaces.NewNetworkInterfaceJob(networkinterfaces.NetworkInterfaceJobConfig{
+						jm.AddJob(job)
+						log.Info("added auto scaling limits job for region %s to job manager", region)
+					}
+					if qm.Name == "launchResources" {
+						log.Info("creating EC2 launch resources job for region %s", region)
+						job, err := launchresources.NewLaunchResourcesJob(launchresources.LaunchResourcesJobConfig{
 							Ec2Client:           ec2Client,
 							ServiceQuotasClient: sqClient,
 							Logger:              log,
@@ -374,199 +565,1426 @@ func buildJobManager(input BuildJobManagerInput) *job.JobManager {
 						if err != nil {
 							fatal(FatalInput{
 								Logger: log,
-								Msg:    ErrMsgCreateNetworkInterfacesJob,
+								Msg:    ErrMsgCreateLaunchResourcesJob,
 								Err:    err,
 							})
 						}
 						jm.AddJob(job)
-						log.Info("added network interfaces job for region %s to job manager", region)
+						log.Info("added ec2 launch resources job for region %s to job manager", region)
 					}
 				}
 
 			case "eks":
+				eksClient, err := eksclient.NewEKSClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateEKSClient,
+						Err:    err,
+					})
+				}
+				sqClient, err := servicequotaclient.NewServiceQuotaClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateServiceQuotaClient,
+						Err:    err,
+					})
+				}
 				for _, qm := range svcCfg.QuotaMetrics {
 					if qm.Name == "listClusters" {
 						log.Info("creating EKS job for region %s", region)
-						eksClient, err := eksclient.NewEKSClient(awsCfg, region)
+						job, err := listcluster.NewListClusterJob(listcluster.ListClusterJobConfig{
+							EksClient:           eksClient,
+							ServiceQuotasClient: sqClient,
+							Logger:              log,
+						})
+						if err != nil {
+							fatal(FatalInput{
+								Logger: log,
+								Msg:    ErrMsgCreateListEKSClustersJob,
+								Err:    err,
+							})
+						}
+						jm.AddJob(job)
+						log.Info("added list clusters job for region %s to job manager", region)
+					}
+					if qm.Name == "clusterQuotas" {
+						log.Info("creating EKS cluster quotas job for region %s", region)
+						job, err := clusterquotas.NewClusterQuotaJob(clusterquotas.ClusterQuotaJobConfig{
+							EksClient:           eksClient,
+							ServiceQuotasClient: sqClient,
+							Logger:              log,
+						})
 						if err != nil {
 							fatal(FatalInput{
 								Logger: log,
-								Msg:    ErrMsgCreateEKSClient,
+								Msg:    ErrMsgCreateEKSClusterQuotasJob,
 								Err:    err,
 							})
 						}
-						sqClient, err := servicequotaclient.NewServiceQuotaClient(awsCfg, region)
+						jm.AddJob(job)
+						log.Info("added eks cluster quotas job for region %s to job manager", region)
+					}
+				}
+
+			case "ebs":
+				supportClient, err := supportclient.NewSupportClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateSupportClient,
+						Err:    err,
+					})
+				}
+				for _, qm := range svcCfg.QuotaMetrics {
+					if qm.Name == "gp3Storage" {
+						log.Info("creating GP3 storage job for region %s", region)
+						job, err := gp3storage.NewGp3StorageJob(gp3storage.Gp3StorageJobConfig{
+							SupportClient: supportClient,
+							Logger:        log,
+						})
 						if err != nil {
 							fatal(FatalInput{
 								Logger: log,
-								Msg:    ErrMsgCreateServiceQuotaClient,
+								Msg:    ErrMsgCreateGP3StorageJob,
 								Err:    err,
 							})
 						}
-						job, err := listcluster.NewListClusterJob(listcluster.ListClusterJobConfig{
-							EksClient:           eksClient,
-							ServiceQuotasClient: sqClient,
+						jm.AddJob(job)
+						log.Info("added gp3 storage job for region %s to job manager", region)
+					}
+				}
+
+			case "vpc":
+				ec2c, err := ec2client.NewEc2Client(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateEC2Client,
+						Err:    err,
+					})
+				}
+				efsC, err := efsclient.NewEFSClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateEFSClient,
+						Err:    err,
+					})
+				}
+				elbC, err := elbv2client.NewElbV2Client(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateELBClient,
+						Err:    err,
+					})
+				}
+				serviceQuotasClient, err := servicequotaclient.NewServiceQuotaClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateServiceQuotaClient,
+						Err:    err,
+					})
+				}
+				for _, qm := range svcCfg.QuotaMetrics {
+					if qm.Name == "nau" {
+						log.Info("creating vpc nau job for region %s", region)
+						nauCalc := nau.NewCalculator(ec2c, efsC, elbC, log)
+						job, err := vpcnau.NewVPCNAUJob(vpcnau.VPCNAUConfig{
+							NauCalculator:       nauCalc,
+							ServiceQuotasClient: serviceQuotasClient,
 							Logger:              log,
 						})
 						if err != nil {
 							fatal(FatalInput{
 								Logger: log,
-								Msg:    ErrMsgCreateListEKSClustersJob,
+								Msg:    ErrMsgCreateVPCNAUJob,
 								Err:    err,
 							})
 						}
 						jm.AddJob(job)
-						log.Info("added list clusters job for region %s to job manager", region)
+						log.Info("added vpc nau job for region %s to job manager", region)
 					}
 				}
 
-			case "iam":
+			case "lambda":
+				lambdaClient, err := lambdaclient.NewLambdaClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateLambdaClient,
+						Err:    err,
+					})
+				}
+				cwClient, err := cwclient.NewCloudWatchClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateCloudWatchClient,
+						Err:    err,
+					})
+				}
 				for _, qm := range svcCfg.QuotaMetrics {
-					if qm.Name == "oidcProviders" {
-						log.Info("creating IAM OIDC job for region %s", region)
-						iamClient, err := iamclient.NewIamClient(awsCfg, region)
+					if qm.Name == "accountSettings" {
+						log.Info("creating Lambda account settings job for region %s", region)
+						job, err := accountsettings.NewAccountSettingsJob(accountsettings.AccountSettingsJobConfig{
+							LambdaClient: lambdaClient,
+							Logger:       log,
+						})
+						if err != nil {
+							fatal(FatalInput{
+								Logger: log,
+								Msg:    ErrMsgCreateLambdaSettingsJob,
+								Err:    err,
+							})
+						}
+						jm.AddJob(job)
+						log.Info("added lambda account settings job for region %s to job manager", region)
+					}
+					if qm.Name == "peakConcurrency" {
+						log.Info("creating Lambda peak concurrency job for region %s", region)
+						job, err := peakconcurrency.NewPeakConcurrencyJob(peakconcurrency.PeakConcurrencyJobConfig{
+							LambdaClient:     lambdaClient,
+							CloudWatchClient: cwClient,
+							Logger:           log,
+						})
 						if err != nil {
 							fatal(FatalInput{
 								Logger: log,
-								Msg:    ErrMsgCreateIAMClient,
+								Msg:    ErrMsgCreateLambdaPeakJob,
 								Err:    err,
 							})
 						}
-						sqClient, err := servicequotaclient.NewServiceQuotaClient(awsCfg, iamServiceQuotaRegion)
+						jm.AddJob(job)
+						log.Info("added lambda peak concurrency job for region %s to job manager", region)
+					}
+				}
+
+			case "ecs":
+				ecsClient, err := ecsclient.NewECSClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateECSClient,
+						Err:    err,
+					})
+				}
+				sqClient, err := servicequotaclient.NewServiceQuotaClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateServiceQuotaClient,
+						Err:    err,
+					})
+				}
+				for _, qm := range svcCfg.QuotaMetrics {
+					if qm.Name == "clusters" {
+						log.Info("creating ECS clusters job for region %s", region)
+						job, err := clusters.NewClustersJob(clusters.ClustersJobConfig{
+							EcsClient:           ecsClient,
+							ServiceQuotasClient: sqClient,
+							Logger:              log,
+						})
 						if err != nil {
 							fatal(FatalInput{
 								Logger: log,
-								Msg:    ErrMsgCreateServiceQuotaClient,
+								Msg:    ErrMsgCreateECSClustersJob,
 								Err:    err,
 							})
 						}
-						job, err := oidcproviders.NewOIDCProviderJob(oidcproviders.OIDCProviderJobConfig{
-							IamClient:          iamClient,
-							ServiceQuotasCliet: sqClient,
-							Logger:             log,
+						jm.AddJob(job)
+						log.Info("added ecs clusters job for region %s to job manager", region)
+					}
+					if qm.Name == "services" {
+						log.Info("creating ECS services job for region %s", region)
+						job, err := services.NewServicesJob(services.ServicesJobConfig{
+							EcsClient:           ecsClient,
+							ServiceQuotasClient: sqClient,
+							Logger:              log,
 						})
 						if err != nil {
 							fatal(FatalInput{
 								Logger: log,
-								Msg:    ErrMsgCreateIAMOIDCJob,
+								Msg:    ErrMsgCreateECSServicesJob,
 								Err:    err,
 							})
 						}
 						jm.AddJob(job)
-						log.Info("added oidc providers job for region %s to job manager", region)
+						log.Info("added ecs services job for region %s to job manager", region)
 					}
-					if qm.Name == "iamRoles" {
-						log.Info("creating IAM Roles job for region %s", region)
-						supportClient, err := supportclient.NewSupportClient(awsCfg, region)
+				}
+
+			case "ecr":
+				ecrClient, err := ecrclient.NewECRClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateECRClient,
+						Err:    err,
+					})
+				}
+				sqClient, err := servicequotaclient.NewServiceQuotaClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateServiceQuotaClient,
+						Err:    err,
+					})
+				}
+				for _, qm := range svcCfg.QuotaMetrics {
+					if qm.Name == "repositories" {
+						log.Info("creating ECR repositories job for region %s", region)
+						job, err := repositories.NewRepositoriesJob(repositories.RepositoriesJobConfig{
+							EcrClient:           ecrClient,
+							ServiceQuotasClient: sqClient,
+							Logger:              log,
+						})
 						if err != nil {
 							fatal(FatalInput{
 								Logger: log,
-								Msg:    ErrMsgCreateSupportClient,
+								Msg:    ErrMsgCreateECRRepositoriesJob,
 								Err:    err,
 							})
 						}
-						job, err := iamroles.NewIamRoleJob(iamroles.IamRoleJobConfig{
-							SupportClient: supportClient,
-							Logger:        log,
+						jm.AddJob(job)
+						log.Info("added ecr repositories job for region %s to job manager", region)
+					}
+					if qm.Name == "images" {
+						log.Info("creating ECR images job for region %s", region)
+						job, err := images.NewImagesJob(images.ImagesJobConfig{
+							EcrClient:           ecrClient,
+							ServiceQuotasClient: sqClient,
+							Logger:              log,
 						})
 						if err != nil {
 							fatal(FatalInput{
 								Logger: log,
-								Msg:    ErrMsgCreateIAMRolesJob,
+								Msg:    ErrMsgCreateECRImagesJob,
 								Err:    err,
 							})
 						}
 						jm.AddJob(job)
-						log.Info("added iam Roles job for region %s to job manager", region)
+						log.Info("added ecr images job for region %s to job manager", region)
 					}
 				}
 
-			case "ebs":
+			case "rds":
+				rdsClient, err := rdsclient.NewRDSClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateRDSClient,
+						Err:    err,
+					})
+				}
 				for _, qm := range svcCfg.QuotaMetrics {
-					if qm.Name == "gp3Storage" {
-						log.Info("creating GP3 storage job for region %s", region)
-						supportClient, err := supportclient.NewSupportClient(awsCfg, region)
+					if qm.Name == "accountAttributes" {
+						log.Info("creating RDS account attributes job for region %s", region)
+						job, err := accountattributes.NewAccountAttributesJob(accountattributes.AccountAttributesJobConfig{
+							RdsClient: rdsClient,
+							Logger:    log,
+						})
 						if err != nil {
 							fatal(FatalInput{
 								Logger: log,
-								Msg:    ErrMsgCreateSupportClient,
+								Msg:    ErrMsgCreateRDSAccountAttributesJob,
 								Err:    err,
 							})
 						}
-						job, err := gp3storage.NewGp3StorageJob(gp3storage.Gp3StorageJobConfig{
-							SupportClient: supportClient,
-							Logger:        log,
+						jm.AddJob(job)
+						log.Info("added rds account attributes job for region %s to job manager", region)
+					}
+				}
+
+			case "dynamodb":
+				dynamoDBClient, err := dynamodbclient.NewDynamoDBClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateDynamoDBClient,
+						Err:    err,
+					})
+				}
+				sqClient, err := servicequotaclient.NewServiceQuotaClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateServiceQuotaClient,
+						Err:    err,
+					})
+				}
+				for _, qm := range svcCfg.QuotaMetrics {
+					if qm.Name == "tables" {
+						log.Info("creating DynamoDB tables job for region %s", region)
+						job, err := tables.NewTablesJob(tables.TablesJobConfig{
+							DynamoDBClient:      dynamoDBClient,
+							ServiceQuotasClient: sqClient,
+							Logger:              log,
 						})
 						if err != nil {
 							fatal(FatalInput{
 								Logger: log,
-								Msg:    ErrMsgCreateGP3StorageJob,
+								Msg:    ErrMsgCreateDynamoDBTablesJob,
 								Err:    err,
 							})
 						}
 						jm.AddJob(job)
-						log.Info("added gp3 storage job for region %s to job manager", region)
+						log.Info("added dynamodb tables job for region %s to job manager", region)
+					}
+					if qm.Name == "capacity" {
+						log.Info("creating DynamoDB capacity job for region %s", region)
+						job, err := capacity.NewCapacityJob(capacity.CapacityJobConfig{
+							DynamoDBClient: dynamoDBClient,
+							Logger:         log,
+						})
+						if err != nil {
+							fatal(FatalInput{
+								Logger: log,
+								Msg:    ErrMsgCreateDynamoDBCapacityJob,
+								Err:    err,
+							})
+						}
+						jm.AddJob(job)
+						log.Info("added dynamodb capacity job for region %s to job manager", region)
 					}
 				}
 
-			case "vpc":
+			case "elasticache":
+				elastiCacheClient, err := elasticacheclient.NewElastiCacheClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateElastiCacheClient,
+						Err:    err,
+					})
+				}
+				sqClient, err := servicequotaclient.NewServiceQuotaClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateServiceQuotaClient,
+						Err:    err,
+					})
+				}
 				for _, qm := range svcCfg.QuotaMetrics {
-					if qm.Name == "nau" {
-						log.Info("creating vpc nau job for region %s", region)
-						ec2c, err := ec2client.NewEc2Client(awsCfg, region)
+					if qm.Name == "nodes" {
+						log.Info("creating ElastiCache nodes job for region %s", region)
+						job, err := nodes.NewNodesJob(nodes.NodesJobConfig{
+							ElastiCacheClient:   elastiCacheClient,
+							ServiceQuotasClient: sqClient,
+							Logger:              log,
+						})
 						if err != nil {
 							fatal(FatalInput{
 								Logger: log,
-								Msg:    ErrMsgCreateEC2Client,
+								Msg:    ErrMsgCreateElastiCacheNodesJob,
 								Err:    err,
 							})
 						}
-						efsC, err := efsclient.NewEFSClient(awsCfg, region)
+						jm.AddJob(job)
+						log.Info("added elasticache nodes job for region %s to job manager", region)
+					}
+				}
+
+			case "cloudformation":
+				cfnClient, err := cloudformationclient.NewCloudFormationClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateCloudFormationClient,
+						Err:    err,
+					})
+				}
+				sqClient, err := servicequotaclient.NewServiceQuotaClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateServiceQuotaClient,
+						Err:    err,
+					})
+				}
+				for _, qm := range svcCfg.QuotaMetrics {
+					if qm.Name == "stacks" {
+						log.Info("creating CloudFormation stacks job for region %s", region)
+						job, err := stacks.NewStacksJob(stacks.StacksJobConfig{
+							CloudFormationClient: cfnClient,
+							ServiceQuotasClient:  sqClient,
+							Logger:               log,
+						})
 						if err != nil {
 							fatal(FatalInput{
 								Logger: log,
-								Msg:    ErrMsgCreateEFSClient,
+								Msg:    ErrMsgCreateCloudFormationStacksJob,
 								Err:    err,
 							})
 						}
-						elbC, err := elbv2client.NewElbV2Client(awsCfg, region)
+						jm.AddJob(job)
+						log.Info("added cloudformation stacks job for region %s to job manager", region)
+					}
+				}
+
+			case "eventbridge":
+				ebClient, err := eventbridgeclient.NewEventBridgeClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateEventBridgeClient,
+						Err:    err,
+					})
+				}
+				sqClient, err := servicequotaclient.NewServiceQuotaClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateServiceQuotaClient,
+						Err:    err,
+					})
+				}
+				for _, qm := range svcCfg.QuotaMetrics {
+					if qm.Name == "rules" {
+						log.Info("creating EventBridge rules job for region %s", region)
+						job, err := rules.NewRulesJob(rules.RulesJobConfig{
+							EventBridgeClient:   ebClient,
+							ServiceQuotasClient: sqClient,
+							Logger:              log,
+						})
 						if err != nil {
 							fatal(FatalInput{
 								Logger: log,
-								Msg:    ErrMsgCreateELBClient,
+								Msg:    ErrMsgCreateEventBridgeRulesJob,
 								Err:    err,
 							})
 						}
-						serviceQuotasClient, err := servicequotaclient.NewServiceQuotaClient(awsCfg, region)
+						jm.AddJob(job)
+						log.Info("added eventbridge rules job for region %s to job manager", region)
+					}
+					if qm.Name == "targets" {
+						log.Info("creating EventBridge targets job for region %s", region)
+						job, err := targets.NewTargetsJob(targets.TargetsJobConfig{
+							EventBridgeClient: ebClient,
+							Logger:            log,
+						})
 						if err != nil {
 							fatal(FatalInput{
 								Logger: log,
-								Msg:    ErrMsgCreateServiceQuotaClient,
+								Msg:    ErrMsgCreateEventBridgeTargetsJob,
 								Err:    err,
 							})
 						}
-						nauCalc := nau.NewCalculator(ec2c, efsC, elbC, log)
-						job, err := vpcnau.NewVPCNAUJob(vpcnau.VPCNAUConfig{
-							NauCalculator:       nauCalc,
-							ServiceQuotasClient: serviceQuotasClient,
+						jm.AddJob(job)
+						log.Info("added eventbridge targets job for region %s to job manager", region)
+					}
+				}
+
+			case "sns":
+				snsClient, err := snsclient.NewSNSClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateSNSClient,
+						Err:    err,
+					})
+				}
+				sqClient, err := servicequotaclient.NewServiceQuotaClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateServiceQuotaClient,
+						Err:    err,
+					})
+				}
+				for _, qm := range svcCfg.QuotaMetrics {
+					if qm.Name == "topics" {
+						log.Info("creating SNS topics job for region %s", region)
+						job, err := topics.NewTopicsJob(topics.TopicsJobConfig{
+							SNSClient:           snsClient,
+							ServiceQuotasClient: sqClient,
 							Logger:              log,
 						})
 						if err != nil {
 							fatal(FatalInput{
 								Logger: log,
-								Msg:    ErrMsgCreateVPCNAUJob,
+								Msg:    ErrMsgCreateSNSTopicsJob,
 								Err:    err,
 							})
 						}
 						jm.AddJob(job)
-						log.Info("added vpc nau job for region %s to job manager", region)
+						log.Info("added sns topics job for region %s to job manager", region)
+					}
+					if qm.Name == "subscriptions" {
+						log.Info("creating SNS subscriptions job for region %s", region)
+						job, err := subscriptions.NewSubscriptionsJob(subscriptions.SubscriptionsJobConfig{
+							SNSClient: snsClient,
+							Logger:    log,
+						})
+						if err != nil {
+							fatal(FatalInput{
+								Logger: log,
+								Msg:    ErrMsgCreateSNSSubscriptionsJob,
+								Err:    err,
+							})
+						}
+						jm.AddJob(job)
+						log.Info("added sns subscriptions job for region %s to job manager", region)
 					}
 				}
-			}
-		}
-	}
-	log.Info("all jobs added to manager")
-	return jm
+
+			case "sqs":
+				cwClient, err := cwclient.NewCloudWatchClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateCloudWatchClient,
+						Err:    err,
+					})
+				}
+				for _, qm := range svcCfg.QuotaMetrics {
+					if qm.Name == "inFlightMessages" {
+						log.Info("creating SQS in flight messages job for region %s", region)
+						job, err := inflight.NewInFlightJob(inflight.InFlightJobConfig{
+							CloudWatchClient: cwClient,
+							Logger:           log,
+						})
+						if err != nil {
+							fatal(FatalInput{
+								Logger: log,
+								Msg:    ErrMsgCreateSQSInFlightJob,
+								Err:    err,
+							})
+						}
+						jm.AddJob(job)
+						log.Info("added sqs in flight messages job for region %s to job manager", region)
+					}
+				}
+
+			case "stepfunctions":
+				sfnClient, err := sfnclient.NewSFNClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateSFNClient,
+						Err:    err,
+					})
+				}
+				for _, qm := range svcCfg.QuotaMetrics {
+					if qm.Name == "stateMachines" {
+						log.Info("creating Step Functions state machines job for region %s", region)
+						job, err := statemachines.NewStateMachinesJob(statemachines.StateMachinesJobConfig{
+							SFNClient: sfnClient,
+							Logger:    log,
+						})
+						if err != nil {
+							fatal(FatalInput{
+								Logger: log,
+								Msg:    ErrMsgCreateSFNStateMachinesJob,
+								Err:    err,
+							})
+						}
+						jm.AddJob(job)
+						log.Info("added step functions state machines job for region %s to job manager", region)
+					}
+					if qm.Name == "activities" {
+						log.Info("creating Step Functions activities job for region %s", region)
+						job, err := activities.NewActivitiesJob(activities.ActivitiesJobConfig{
+							SFNClient: sfnClient,
+							Logger:    log,
+						})
+						if err != nil {
+							fatal(FatalInput{
+								Logger: log,
+								Msg:    ErrMsgCreateSFNActivitiesJob,
+								Err:    err,
+							})
+						}
+						jm.AddJob(job)
+						log.Info("added step functions activities job for region %s to job manager", region)
+					}
+				}
+
+			case "cloudwatch":
+				cwClient, err := cwclient.NewCloudWatchClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateCloudWatchClient,
+						Err:    err,
+					})
+				}
+				for _, qm := range svcCfg.QuotaMetrics {
+					if qm.Name == "alarms" {
+						log.Info("creating CloudWatch alarms job for region %s", region)
+						job, err := alarms.NewAlarmsJob(alarms.AlarmsJobConfig{
+							CloudWatchClient: cwClient,
+							Logger:           log,
+						})
+						if err != nil {
+							fatal(FatalInput{
+								Logger: log,
+								Msg:    ErrMsgCreateCloudWatchAlarmsJob,
+								Err:    err,
+							})
+						}
+						jm.AddJob(job)
+						log.Info("added cloudwatch alarms job for region %s to job manager", region)
+					}
+				}
+
+			case "cloudwatchlogs":
+				cwlClient, err := cwlclient.NewCloudWatchLogsClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateCloudWatchLogsClient,
+						Err:    err,
+					})
+				}
+				for _, qm := range svcCfg.QuotaMetrics {
+					if qm.Name == "metricFilters" {
+						log.Info("creating CloudWatch Logs metric filters job for region %s", region)
+						job, err := metricfilters.NewMetricFiltersJob(metricfilters.MetricFiltersJobConfig{
+							CloudWatchLogsClient: cwlClient,
+							Logger:               log,
+						})
+						if err != nil {
+							fatal(FatalInput{
+								Logger: log,
+								Msg:    ErrMsgCreateCWLMetricFiltersJob,
+								Err:    err,
+							})
+						}
+						jm.AddJob(job)
+						log.Info("added cloudwatch logs metric filters job for region %s to job manager", region)
+					}
+					if qm.Name == "subscriptionFilters" {
+						log.Info("creating CloudWatch Logs subscription filters job for region %s", region)
+						job, err := subscriptionfilters.NewSubscriptionFiltersJob(subscriptionfilters.SubscriptionFiltersJobConfig{
+							CloudWatchLogsClient: cwlClient,
+							Logger:               log,
+						})
+						if err != nil {
+							fatal(FatalInput{
+								Logger: log,
+								Msg:    ErrMsgCreateCWLSubscriptionFiltersJob,
+								Err:    err,
+							})
+						}
+						jm.AddJob(job)
+						log.Info("added cloudwatch logs subscription filters job for region %s to job manager", region)
+					}
+				}
+
+			case "kms":
+				kmsClient, err := kmsclient.NewKMSClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateKMSClient,
+						Err:    err,
+					})
+				}
+				sqClient, err := servicequotaclient.NewServiceQuotaClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateServiceQuotaClient,
+						Err:    err,
+					})
+				}
+				for _, qm := range svcCfg.QuotaMetrics {
+					if qm.Name == "keys" {
+						log.Info("creating KMS keys job for region %s", region)
+						job, err := keys.NewKeysJob(keys.KeysJobConfig{
+							KMSClient:           kmsClient,
+							ServiceQuotasClient: sqClient,
+							Logger:              log,
+						})
+						if err != nil {
+							fatal(FatalInput{
+								Logger: log,
+								Msg:    ErrMsgCreateKMSKeysJob,
+								Err:    err,
+							})
+						}
+						jm.AddJob(job)
+						log.Info("added kms keys job for region %s to job manager", region)
+					}
+					if qm.Name == "aliases" {
+						log.Info("creating KMS aliases job for region %s", region)
+						job, err := aliases.NewAliasesJob(aliases.AliasesJobConfig{
+							KMSClient: kmsClient,
+							Logger:    log,
+						})
+						if err != nil {
+							fatal(FatalInput{
+								Logger: log,
+								Msg:    ErrMsgCreateKMSAliasesJob,
+								Err:    err,
+							})
+						}
+						jm.AddJob(job)
+						log.Info("added kms aliases job for region %s to job manager", region)
+					}
+				}
+
+			case "acm":
+				acmClient, err := acmclient.NewACMClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateACMClient,
+						Err:    err,
+					})
+				}
+				sqClient, err := servicequotaclient.NewServiceQuotaClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateServiceQuotaClient,
+						Err:    err,
+					})
+				}
+				for _, qm := range svcCfg.QuotaMetrics {
+					if qm.Name == "certificates" {
+						log.Info("creating ACM certificates job for region %s", region)
+						job, err := certificates.NewCertificatesJob(certificates.CertificatesJobConfig{
+							ACMClient:           acmClient,
+							ServiceQuotasClient: sqClient,
+							Logger:              log,
+						})
+						if err != nil {
+							fatal(FatalInput{
+								Logger: log,
+								Msg:    ErrMsgCreateACMCertificatesJob,
+								Err:    err,
+							})
+						}
+						jm.AddJob(job)
+						log.Info("added acm certificates job for region %s to job manager", region)
+					}
+				}
+
+			case "secretsmanager":
+				smClient, err := secretsmanagerclient.NewSecretsManagerClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateSecretsManagerClient,
+						Err:    err,
+					})
+				}
+				for _, qm := range svcCfg.QuotaMetrics {
+					if qm.Name == "secrets" {
+						log.Info("creating Secrets Manager secrets job for region %s", region)
+						job, err := secrets.NewSecretsJob(secrets.SecretsJobConfig{
+							SecretsManagerClient: smClient,
+							Logger:               log,
+						})
+						if err != nil {
+							fatal(FatalInput{
+								Logger: log,
+								Msg:    ErrMsgCreateSecretsManagerSecretsJob,
+								Err:    err,
+							})
+						}
+						jm.AddJob(job)
+						log.Info("added secrets manager secrets job for region %s to job manager", region)
+					}
+				}
+
+			case "apigateway":
+				apigwClient, err := apigatewayclient.NewAPIGatewayClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateAPIGatewayClient,
+						Err:    err,
+					})
+				}
+				sqClient, err := servicequotaclient.NewServiceQuotaClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateServiceQuotaClient,
+						Err:    err,
+					})
+				}
+				apigwV2Client, err := apigatewayv2client.NewAPIGatewayV2Client(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateAPIGatewayV2Client,
+						Err:    err,
+					})
+				}
+				for _, qm := range svcCfg.QuotaMetrics {
+					if qm.Name == "restApis" {
+						log.Info("creating API Gateway REST APIs job for region %s", region)
+						job, err := restapis.NewRestApisJob(restapis.RestApisJobConfig{
+							APIGatewayClient:    apigwClient,
+							ServiceQuotasClient: sqClient,
+							Logger:              log,
+						})
+						if err != nil {
+							fatal(FatalInput{
+								Logger: log,
+								Msg:    ErrMsgCreateAPIGatewayRestApisJob,
+								Err:    err,
+							})
+						}
+						jm.AddJob(job)
+						log.Info("added api gateway rest apis job for region %s to job manager", region)
+					}
+					if qm.Name == "httpApis" {
+						log.Info("creating API Gateway HTTP APIs job for region %s", region)
+						job, err := httpapis.NewHttpApisJob(httpapis.HttpApisJobConfig{
+							APIGatewayV2Client:  apigwV2Client,
+							ServiceQuotasClient: sqClient,
+							Logger:              log,
+						})
+						if err != nil {
+							fatal(FatalInput{
+								Logger: log,
+								Msg:    ErrMsgCreateAPIGatewayHttpApisJob,
+								Err:    err,
+							})
+						}
+						jm.AddJob(job)
+						log.Info("added api gateway http apis job for region %s to job manager", region)
+					}
+					if qm.Name == "accountResources" {
+						log.Info("creating API Gateway account resources job for region %s", region)
+						job, err := apigwaccountresources.NewAccountResourcesJob(apigwaccountresources.AccountResourcesJobConfig{
+							APIGatewayClient:    apigwClient,
+							APIGatewayV2Client:  apigwV2Client,
+							ServiceQuotasClient: sqClient,
+							Logger:              log,
+						})
+						if err != nil {
+							fatal(FatalInput{
+								Logger: log,
+								Msg:    ErrMsgCreateAPIGatewayAccountResourcesJob,
+								Err:    err,
+							})
+						}
+						jm.AddJob(job)
+						log.Info("added api gateway account resources job for region %s to job manager", region)
+					}
+					if qm.Name == "throttling" {
+						log.Info("creating API Gateway throttling job for region %s", region)
+						job, err := throttling.NewThrottlingJob(throttling.ThrottlingJobConfig{
+							APIGatewayClient: apigwClient,
+							Logger:           log,
+						})
+						if err != nil {
+							fatal(FatalInput{
+								Logger: log,
+								Msg:    ErrMsgCreateAPIGatewayThrottlingJob,
+								Err:    err,
+							})
+						}
+						jm.AddJob(job)
+						log.Info("added api gateway throttling job for region %s to job manager", region)
+					}
+				}
+
+			case "appsync":
+				appsyncClient, err := appsyncclient.NewAppSyncClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateAppSyncClient,
+						Err:    err,
+					})
+				}
+				sqClient, err := servicequotaclient.NewServiceQuotaClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateServiceQuotaClient,
+						Err:    err,
+					})
+				}
+				for _, qm := range svcCfg.QuotaMetrics {
+					if qm.Name == "graphqlApis" {
+						log.Info("creating AppSync GraphQL APIs job for region %s", region)
+						job, err := graphqlapis.NewGraphqlApisJob(graphqlapis.GraphqlApisJobConfig{
+							AppSyncClient:       appsyncClient,
+							ServiceQuotasClient: sqClient,
+							Logger:              log,
+						})
+						if err != nil {
+							fatal(FatalInput{
+								Logger: log,
+								Msg:    ErrMsgCreateAppSyncGraphqlApisJob,
+								Err:    err,
+							})
+						}
+						jm.AddJob(job)
+						log.Info("added appsync graphql apis job for region %s to job manager", region)
+					}
+				}
+
+			case "kinesis":
+				kinesisClient, err := kinesisclient.NewKinesisClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateKinesisClient,
+						Err:    err,
+					})
+				}
+				for _, qm := range svcCfg.QuotaMetrics {
+					if qm.Name == "streamLimits" {
+						log.Info("creating Kinesis stream limits job for region %s", region)
+						job, err := streamlimits.NewStreamLimitsJob(streamlimits.StreamLimitsJobConfig{
+							KinesisClient: kinesisClient,
+							Logger:        log,
+						})
+						if err != nil {
+							fatal(FatalInput{
+								Logger: log,
+								Msg:    ErrMsgCreateKinesisStreamLimitsJob,
+								Err:    err,
+							})
+						}
+						jm.AddJob(job)
+						log.Info("added kinesis stream limits job for region %s to job manager", region)
+					}
+				}
+
+			case "firehose":
+				firehoseClient, err := firehoseclient.NewFirehoseClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateFirehoseClient,
+						Err:    err,
+					})
+				}
+				sqClient, err := servicequotaclient.NewServiceQuotaClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateServiceQuotaClient,
+						Err:    err,
+					})
+				}
+				for _, qm := range svcCfg.QuotaMetrics {
+					if qm.Name == "deliveryStreams" {
+						log.Info("creating Firehose delivery streams job for region %s", region)
+						job, err := deliverystreams.NewDeliveryStreamsJob(deliverystreams.DeliveryStreamsJobConfig{
+							FirehoseClient:      firehoseClient,
+							ServiceQuotasClient: sqClient,
+							Logger:              log,
+						})
+						if err != nil {
+							fatal(FatalInput{
+								Logger: log,
+								Msg:    ErrMsgCreateFirehoseDeliveryStreamsJob,
+								Err:    err,
+							})
+						}
+						jm.AddJob(job)
+						log.Info("added firehose delivery streams job for region %s to job manager", region)
+					}
+				}
+
+			case "glue":
+				glueClient, err := glueclient.NewGlueClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateGlueClient,
+						Err:    err,
+					})
+				}
+				sqClient, err := servicequotaclient.NewServiceQuotaClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateServiceQuotaClient,
+						Err:    err,
+					})
+				}
+				for _, qm := range svcCfg.QuotaMetrics {
+					if qm.Name == "catalog" {
+						log.Info("creating Glue catalog job for region %s", region)
+						job, err := catalog.NewCatalogJob(catalog.CatalogJobConfig{
+							GlueClient:          glueClient,
+							ServiceQuotasClient: sqClient,
+							Logger:              log,
+						})
+						if err != nil {
+							fatal(FatalInput{
+								Logger: log,
+								Msg:    ErrMsgCreateGlueCatalogJob,
+								Err:    err,
+							})
+						}
+						jm.AddJob(job)
+						log.Info("added glue catalog job for region %s to job manager", region)
+					}
+					if qm.Name == "jobs" {
+						log.Info("creating Glue jobs job for region %s", region)
+						job, err := gluejobs.NewJobsJob(gluejobs.JobsJobConfig{
+							GlueClient:          glueClient,
+							ServiceQuotasClient: sqClient,
+							Logger:              log,
+						})
+						if err != nil {
+							fatal(FatalInput{
+								Logger: log,
+								Msg:    ErrMsgCreateGlueJobsJob,
+								Err:    err,
+							})
+						}
+						jm.AddJob(job)
+						log.Info("added glue jobs job for region %s to job manager", region)
+					}
+				}
+
+			case "athena":
+				athenaClient, err := athenaclient.NewAthenaClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateAthenaClient,
+						Err:    err,
+					})
+				}
+				sqClient, err := servicequotaclient.NewServiceQuotaClient(awsCfg, region)
+				if err != nil {
+					fatal(FatalInput{
+						Logger: log,
+						Msg:    ErrMsgCreateServiceQuotaClient,
+						Err:    err,
+					})
+				}
+				for _, qm := range svcCfg.QuotaMetrics {
+					if qm.Name == "workGroups" {
+						log.Info("creating Athena workgroups job for region %s", region)
+						job, err := workgroups.NewWorkGroupsJob(workgroups.WorkGroupsJobConfig{
+							AthenaClient:        athenaClient,
+							ServiceQuotasClient: sqClient,
+							Logger:              log,
+						})
+						if err != nil {
+							fatal(FatalInput{
+								Logger: log,
+								Msg:    ErrMsgCreateAthenaWorkGroupsJob,
+								Err:    err,
+							})
+						}
+						jm.AddJob(job)
+						log.Info("added athena workgroups job for region %s to job manager", region)
+					}
+				}
+			}
+		}
+	}
+
+	// global services run once, in a region we already publish metrics to
+	globalRegion := globalServiceRegion(input.Regions)
+	for serviceName, svcCfg := range input.Services {
+		switch serviceName {
+		case "cloudwatch":
+			// alarms are regional and added above, dashboards are per account
+			cwClient, err := cwclient.NewCloudWatchClient(awsCfg, globalRegion)
+			if err != nil {
+				fatal(FatalInput{
+					Logger: log,
+					Msg:    ErrMsgCreateCloudWatchClient,
+					Err:    err,
+				})
+			}
+			for _, qm := range svcCfg.QuotaMetrics {
+				if qm.Name == "dashboards" {
+					log.Info("creating CloudWatch dashboards job in global region %s", globalRegion)
+					job, err := dashboards.NewDashboardsJob(dashboards.DashboardsJobConfig{
+						CloudWatchClient: cwClient,
+						Logger:           log,
+					})
+					if err != nil {
+						fatal(FatalInput{
+							Logger: log,
+							Msg:    ErrMsgCreateCloudWatchDashboardsJob,
+							Err:    err,
+						})
+					}
+					jm.AddJob(job)
+					log.Info("added cloudwatch dashboards job in global region %s to job manager", globalRegion)
+				}
+			}
+		case "iam":
+			// every iam job reads the same listing pass through a shared inventory
+			iamClient, err := iamclient.NewIamClient(awsCfg, globalRegion)
+			if err != nil {
+				fatal(FatalInput{
+					Logger: log,
+					Msg:    ErrMsgCreateIAMClient,
+					Err:    err,
+				})
+			}
+			iamInventory := inventory.NewInventory(iamClient)
+			sqClient, err := servicequotaclient.NewServiceQuotaClient(awsCfg, iamServiceQuotaRegion)
+			if err != nil {
+				fatal(FatalInput{
+					Logger: log,
+					Msg:    ErrMsgCreateServiceQuotaClient,
+					Err:    err,
+				})
+			}
+			supportClient, err := supportclient.NewSupportClient(awsCfg, globalRegion)
+			if err != nil {
+				fatal(FatalInput{
+					Logger: log,
+					Msg:    ErrMsgCreateSupportClient,
+					Err:    err,
+				})
+			}
+			for _, qm := range svcCfg.QuotaMetrics {
+				if qm.Name == "oidcProviders" {
+					log.Info("creating IAM OIDC job in global region %s", globalRegion)
+					job, err := oidcproviders.NewOIDCProviderJob(oidcproviders.OIDCProviderJobConfig{
+						IamClient:          iamClient,
+						Inventory:          iamInventory,
+						ServiceQuotasCliet: sqClient,
+						Logger:             log,
+					})
+					if err != nil {
+						fatal(FatalInput{
+							Logger: log,
+							Msg:    ErrMsgCreateIAMOIDCJob,
+							Err:    err,
+						})
+					}
+					jm.AddJob(job)
+					log.Info("added oidc providers job in global region %s to job manager", globalRegion)
+				}
+				if qm.Name == "iamRoles" {
+					log.Info("creating IAM Roles job in global region %s", globalRegion)
+					job, err := iamroles.NewIamRoleJob(iamroles.IamRoleJobConfig{
+						SupportClient: supportClient,
+						Logger:        log,
+					})
+					if err != nil {
+						fatal(FatalInput{
+							Logger: log,
+							Msg:    ErrMsgCreateIAMRolesJob,
+							Err:    err,
+						})
+					}
+					jm.AddJob(job)
+					log.Info("added iam Roles job in global region %s to job manager", globalRegion)
+				}
+				if qm.Name == "accountResources" {
+					log.Info("creating IAM account resources job in global region %s", globalRegion)
+					job, err := accountresources.NewAccountResourcesJob(accountresources.AccountResourcesJobConfig{
+						IamClient:           iamClient,
+						Inventory:           iamInventory,
+						ServiceQuotasClient: sqClient,
+						Logger:              log,
+					})
+					if err != nil {
+						fatal(FatalInput{
+							Logger: log,
+							Msg:    ErrMsgCreateIAMAccountResourcesJob,
+							Err:    err,
+						})
+					}
+					jm.AddJob(job)
+					log.Info("added iam account resources job in global region %s to job manager", globalRegion)
+				}
+				if qm.Name == "attachedPoliciesPerRole" {
+					log.Info("creating IAM attached role policies job in global region %s", globalRegion)
+					job, err := attachedrolepolicies.NewAttachedRolePoliciesJob(attachedrolepolicies.AttachedRolePoliciesJobConfig{
+						IamClient:           iamClient,
+						Inventory:           iamInventory,
+						ServiceQuotasClient: sqClient,
+						Logger:              log,
+					})
+					if err != nil {
+						fatal(FatalInput{
+							Logger: log,
+							Msg:    ErrMsgCreateIAMAttachedRolePoliciesJob,
+							Err:    err,
+						})
+					}
+					jm.AddJob(job)
+					log.Info("added iam attached role policies job in global region %s to job manager", globalRegion)
+				}
+			}
+		case "route53":
+			route53Client, err := route53client.NewRoute53Client(awsCfg, globalRegion)
+			if err != nil {
+				fatal(FatalInput{
+					Logger: log,
+					Msg:    ErrMsgCreateRoute53Client,
+					Err:    err,
+				})
+			}
+			for _, qm := range svcCfg.QuotaMetrics {
+				if qm.Name == "accountLimits" {
+					log.Info("creating Route53 account limits job in global region %s", globalRegion)
+					job, err := accountlimits.NewAccountLimitsJob(accountlimits.AccountLimitsJobConfig{
+						Route53Client: route53Client,
+						Logger:        log,
+					})
+					if err != nil {
+						fatal(FatalInput{
+							Logger: log,
+							Msg:    ErrMsgCreateRoute53AccountLimitsJob,
+							Err:    err,
+						})
+					}
+					jm.AddJob(job)
+					log.Info("added route53 account limits job in global region %s to job manager", globalRegion)
+				}
+				if qm.Name == "hostedZoneLimits" {
+					log.Info("creating Route53 hosted zone limits job in global region %s", globalRegion)
+					job, err := hostedzonelimits.NewHostedZoneLimitsJob(hostedzonelimits.HostedZoneLimitsJobConfig{
+						Route53Client: route53Client,
+						Logger:        log,
+					})
+					if err != nil {
+						fatal(FatalInput{
+							Logger: log,
+							Msg:    ErrMsgCreateRoute53HostedZoneLimitsJob,
+							Err:    err,
+						})
+					}
+					jm.AddJob(job)
+					log.Info("added route53 hosted zone limits job in global region %s to job manager", globalRegion)
+				}
+			}
+
+		case "s3":
+			s3Client, err := s3client.NewS3Client(awsCfg, globalRegion)
+			if err != nil {
+				fatal(FatalInput{
+					Logger: log,
+					Msg:    ErrMsgCreateS3Client,
+					Err:    err,
+				})
+			}
+			sqClient, err := servicequotaclient.NewServiceQuotaClient(awsCfg, globalRegion)
+			if err != nil {
+				fatal(FatalInput{
+					Logger: log,
+					Msg:    ErrMsgCreateServiceQuotaClient,
+					Err:    err,
+				})
+			}
+			for _, qm := range svcCfg.QuotaMetrics {
+				if qm.Name == "buckets" {
+					log.Info("creating S3 buckets job in global region %s", globalRegion)
+					job, err := buckets.NewBucketsJob(buckets.BucketsJobConfig{
+						S3Client:            s3Client,
+						ServiceQuotasClient: sqClient,
+						Logger:              log,
+					})
+					if err != nil {
+						fatal(FatalInput{
+							Logger: log,
+							Msg:    ErrMsgCreateS3BucketsJob,
+							Err:    err,
+						})
+					}
+					jm.AddJob(job)
+					log.Info("added s3 buckets job in global region %s to job manager", globalRegion)
+				}
+			}
+
+		case "organizations":
+			orgClient, err := organizationsclient.NewOrganizationsClient(awsCfg, globalRegion)
+			if err != nil {
+				fatal(FatalInput{
+					Logger: log,
+					Msg:    ErrMsgCreateOrganizationsClient,
+					Err:    err,
+				})
+			}
+			sqClient, err := servicequotaclient.NewServiceQuotaClient(awsCfg, globalRegion)
+			if err != nil {
+				fatal(FatalInput{
+					Logger: log,
+					Msg:    ErrMsgCreateServiceQuotaClient,
+					Err:    err,
+				})
+			}
+			for _, qm := range svcCfg.QuotaMetrics {
+				if qm.Name == "accounts" {
+					log.Info("creating Organizations accounts job in global region %s", globalRegion)
+					job, err := accounts.NewAccountsJob(accounts.AccountsJobConfig{
+						OrganizationsClient: orgClient,
+						ServiceQuotasClient: sqClient,
+						Logger:              log,
+					})
+					if err != nil {
+						fatal(FatalInput{
+							Logger: log,
+							Msg:    ErrMsgCreateOrganizationsAccountsJob,
+							Err:    err,
+						})
+					}
+					jm.AddJob(job)
+					log.Info("added organizations accounts job in global region %s to job manager", globalRegion)
+				}
+				if qm.Name == "organizationalUnits" {
+					log.Info("creating Organizations organizational units job in global region %s", globalRegion)
+					job, err := organizationalunits.NewOrganizationalUnitsJob(organizationalunits.OrganizationalUnitsJobConfig{
+						OrganizationsClient: orgClient,
+						Logger:              log,
+					})
+					if err != nil {
+						fatal(FatalInput{
+							Logger: log,
+							Msg:    ErrMsgCreateOrganizationsOUsJob,
+							Err:    err,
+						})
+					}
+					jm.AddJob(job)
+					log.Info("added organizations organizational units job in global region %s to job manager", globalRegion)
+				}
+				if qm.Name == "scpsPerTarget" {
+					log.Info("creating Organizations SCPs per target job in global region %s", globalRegion)
+					job, err := servicecontrolpolicies.NewServiceControlPoliciesJob(servicecontrolpolicies.ServiceControlPoliciesJobConfig{
+						OrganizationsClient: orgClient,
+						Logger:              log,
+					})
+					if err != nil {
+						fatal(FatalInput{
+							Logger: log,
+							Msg:    ErrMsgCreateOrganizationsSCPsJob,
+							Err:    err,
+						})
+					}
+					jm.AddJob(job)
+					log.Info("added organizations scps per target job in global region %s to job manager", globalRegion)
+				}
+			}
+		}
+	}
+	log.Info("all jobs added to manager")
+	return jm
+}
+
+// globalServiceRegion picks the region global jobs run in. us-east-1 is preferred
+// since that is where global services report, otherwise the first configured region
+// is used so the metrics still have a batcher to land in.
+func globalServiceRegion(regions []string) string {
+	for _, region := range regions {
+		if region == "us-east-1" {
+			return region
+		}
+	}
+	if len(regions) == 0 {
+		return ""
+	}
+	return regions[0]
 }
 
 type InitResourceQuotaHandlerInput struct {
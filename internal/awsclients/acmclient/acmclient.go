@@ -0,0 +1,49 @@
+package acmclient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/acm"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// ACMClient defines an interface for using AWS acm client
+type ACMClient interface {
+	GetRegion() string
+	// ListCertificates lists the certificates of the region
+	ListCertificates(ctx context.Context, params *acm.ListCertificatesInput, optFns ...func(*acm.Options)) (*acm.ListCertificatesOutput, error)
+}
+
+// ACMClientImpl implements ACMClient interface
+type ACMClientImpl struct {
+	client *acm.Client
+	region string
+}
+
+// NewACMClient returns a new ACMClient
+func NewACMClient(cfg aws.Config, region string) (ACMClient, error) {
+	// validate region
+	if !utils.IsValidRegion(region) {
+		return nil, errors.New("acmclient creation failed. invalid region")
+	}
+
+	client := acm.NewFromConfig(cfg, func(o *acm.Options) {
+		o.Region = region
+	})
+	return &ACMClientImpl{
+		client: client,
+		region: region,
+	}, nil
+}
+
+// ListCertificates calls acm client's ListCertificates method
+func (c *ACMClientImpl) ListCertificates(ctx context.Context, params *acm.ListCertificatesInput, optFns ...func(*acm.Options)) (*acm.ListCertificatesOutput, error) {
+	return c.client.ListCertificates(ctx, params, optFns...)
+}
+
+// GetRegion returns the region of the client
+func (c *ACMClientImpl) GetRegion() string {
+	return c.region
+}
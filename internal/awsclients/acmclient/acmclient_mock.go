@@ -0,0 +1,76 @@
+package acmclient
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/acm"
+)
+
+// FakeACMClient implements the ACMClient methods, with AWS-style pagination.
+type FakeACMClient struct {
+	Region string
+
+	// pages for paginator calls:
+	ListCertificatesPageOutputs []*acm.ListCertificatesOutput
+
+	// “throw on this call index” for each paginated method:
+	ErrOnListCertificatesCall int
+
+	// ListCertificatesInputs records every ListCertificates request
+	ListCertificatesInputs []*acm.ListCertificatesInput
+
+	// internal counters:
+	callListCertificatesCount int
+}
+
+// ListCertificates pages ListCertificatesPageOutputs.
+func (f *FakeACMClient) ListCertificates(
+	ctx context.Context,
+	in *acm.ListCertificatesInput,
+	optFns ...func(*acm.Options),
+) (*acm.ListCertificatesOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	f.ListCertificatesInputs = append(f.ListCertificatesInputs, in)
+	if f.callListCertificatesCount == f.ErrOnListCertificatesCall {
+		return nil, errors.New("acm ListCertificates injected error")
+	}
+	idx, err := pageIndex(in.NextToken)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &acm.ListCertificatesOutput{}
+	if idx < len(f.ListCertificatesPageOutputs) {
+		out.CertificateSummaryList = f.ListCertificatesPageOutputs[idx].CertificateSummaryList
+	}
+	if idx+1 < len(f.ListCertificatesPageOutputs) {
+		out.NextToken = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callListCertificatesCount++
+	return out, nil
+}
+
+// Reset clears all internal counters.
+func (f *FakeACMClient) Reset() {
+	f.callListCertificatesCount = 0
+}
+
+// pageIndex converts a fake NextToken into a page index
+func pageIndex(token *string) (int, error) {
+	if token == nil {
+		return 0, nil
+	}
+	return strconv.Atoi(*token)
+}
+
+// GetRegion returns the configured region.
+func (f *FakeACMClient) GetRegion() string {
+	return f.Region
+}
@@ -0,0 +1,91 @@
+package apigatewayclient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// APIGatewayClient defines an interface for using AWS apigateway client
+type APIGatewayClient interface {
+	GetRegion() string
+	// GetAccount returns the account settings, including the account throttle limits
+	GetAccount(ctx context.Context, params *apigateway.GetAccountInput, optFns ...func(*apigateway.Options)) (*apigateway.GetAccountOutput, error)
+	// GetRestApis lists the rest apis of the region
+	GetRestApis(ctx context.Context, params *apigateway.GetRestApisInput, optFns ...func(*apigateway.Options)) (*apigateway.GetRestApisOutput, error)
+	// GetResources lists the resources of a rest api
+	GetResources(ctx context.Context, params *apigateway.GetResourcesInput, optFns ...func(*apigateway.Options)) (*apigateway.GetResourcesOutput, error)
+	// GetDomainNames lists the custom domain names of the region
+	GetDomainNames(ctx context.Context, params *apigateway.GetDomainNamesInput, optFns ...func(*apigateway.Options)) (*apigateway.GetDomainNamesOutput, error)
+	// GetVpcLinks lists the rest api vpc links of the region
+	GetVpcLinks(ctx context.Context, params *apigateway.GetVpcLinksInput, optFns ...func(*apigateway.Options)) (*apigateway.GetVpcLinksOutput, error)
+	// GetUsagePlans lists the usage plans of the region
+	GetUsagePlans(ctx context.Context, params *apigateway.GetUsagePlansInput, optFns ...func(*apigateway.Options)) (*apigateway.GetUsagePlansOutput, error)
+	// GetApiKeys lists the api keys of the region
+	GetApiKeys(ctx context.Context, params *apigateway.GetApiKeysInput, optFns ...func(*apigateway.Options)) (*apigateway.GetApiKeysOutput, error)
+}
+
+// APIGatewayClientImpl implements APIGatewayClient interface
+type APIGatewayClientImpl struct {
+	client *apigateway.Client
+	region string
+}
+
+// NewAPIGatewayClient returns a new APIGatewayClient
+func NewAPIGatewayClient(cfg aws.Config, region string) (APIGatewayClient, error) {
+	// validate region
+	if !utils.IsValidRegion(region) {
+		return nil, errors.New("apigatewayclient creation failed. invalid region")
+	}
+
+	client := apigateway.NewFromConfig(cfg, func(o *apigateway.Options) {
+		o.Region = region
+	})
+	return &APIGatewayClientImpl{
+		client: client,
+		region: region,
+	}, nil
+}
+
+// GetAccount calls apigateway client's GetAccount method
+func (c *APIGatewayClientImpl) GetAccount(ctx context.Context, params *apigateway.GetAccountInput, optFns ...func(*apigateway.Options)) (*apigateway.GetAccountOutput, error) {
+	return c.client.GetAccount(ctx, params, optFns...)
+}
+
+// GetRestApis calls apigateway client's GetRestApis method
+func (c *APIGatewayClientImpl) GetRestApis(ctx context.Context, params *apigateway.GetRestApisInput, optFns ...func(*apigateway.Options)) (*apigateway.GetRestApisOutput, error) {
+	return c.client.GetRestApis(ctx, params, optFns...)
+}
+
+// GetResources calls apigateway client's GetResources method
+func (c *APIGatewayClientImpl) GetResources(ctx context.Context, params *apigateway.GetResourcesInput, optFns ...func(*apigateway.Options)) (*apigateway.GetResourcesOutput, error) {
+	return c.client.GetResources(ctx, params, optFns...)
+}
+
+// GetDomainNames calls apigateway client's GetDomainNames method
+func (c *APIGatewayClientImpl) GetDomainNames(ctx context.Context, params *apigateway.GetDomainNamesInput, optFns ...func(*apigateway.Options)) (*apigateway.GetDomainNamesOutput, error) {
+	return c.client.GetDomainNames(ctx, params, optFns...)
+}
+
+// GetVpcLinks calls apigateway client's GetVpcLinks method
+func (c *APIGatewayClientImpl) GetVpcLinks(ctx context.Context, params *apigateway.GetVpcLinksInput, optFns ...func(*apigateway.Options)) (*apigateway.GetVpcLinksOutput, error) {
+	return c.client.GetVpcLinks(ctx, params, optFns...)
+}
+
+// GetUsagePlans calls apigateway client's GetUsagePlans method
+func (c *APIGatewayClientImpl) GetUsagePlans(ctx context.Context, params *apigateway.GetUsagePlansInput, optFns ...func(*apigateway.Options)) (*apigateway.GetUsagePlansOutput, error) {
+	return c.client.GetUsagePlans(ctx, params, optFns...)
+}
+
+// GetApiKeys calls apigateway client's GetApiKeys method
+func (c *APIGatewayClientImpl) GetApiKeys(ctx context.Context, params *apigateway.GetApiKeysInput, optFns ...func(*apigateway.Options)) (*apigateway.GetApiKeysOutput, error) {
+	return c.client.GetApiKeys(ctx, params, optFns...)
+}
+
+// GetRegion returns the region of the client
+func (c *APIGatewayClientImpl) GetRegion() string {
+	return c.region
+}
@@ -0,0 +1,268 @@
+package apigatewayclient
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway"
+	apigwTypes "github.com/aws/aws-sdk-go-v2/service/apigateway/types"
+)
+
+// FakeAPIGatewayClient implements the APIGatewayClient methods, with AWS-style pagination.
+type FakeAPIGatewayClient struct {
+	Region string
+
+	// pages for paginator calls:
+	GetRestApisPageOutputs []*apigateway.GetRestApisOutput
+	// resource pages keyed by rest api id
+	GetResourcesPageOutputs   map[string][]*apigateway.GetResourcesOutput
+	GetDomainNamesPageOutputs []*apigateway.GetDomainNamesOutput
+	GetVpcLinksPageOutputs    []*apigateway.GetVpcLinksOutput
+	GetUsagePlansPageOutputs  []*apigateway.GetUsagePlansOutput
+	GetApiKeysPageOutputs     []*apigateway.GetApiKeysOutput
+
+	// ThrottleSettings is returned by GetAccount
+	ThrottleSettings *apigwTypes.ThrottleSettings
+
+	// simple error flags:
+	ErrGetAccount bool
+
+	// “throw on this call index” for each paginated method:
+	ErrOnGetRestApisCall    int
+	ErrOnGetResourcesCall   int
+	ErrOnGetDomainNamesCall int
+	ErrOnGetVpcLinksCall    int
+	ErrOnGetUsagePlansCall  int
+	ErrOnGetApiKeysCall     int
+
+	// internal counters:
+	callGetRestApisCount    int
+	callGetResourcesCount   int
+	callGetDomainNamesCount int
+	callGetVpcLinksCount    int
+	callGetUsagePlansCount  int
+	callGetApiKeysCount     int
+}
+
+// GetRestApis pages GetRestApisPageOutputs.
+func (f *FakeAPIGatewayClient) GetRestApis(
+	ctx context.Context,
+	in *apigateway.GetRestApisInput,
+	optFns ...func(*apigateway.Options),
+) (*apigateway.GetRestApisOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callGetRestApisCount == f.ErrOnGetRestApisCall {
+		return nil, errors.New("apigateway GetRestApis injected error")
+	}
+	idx, err := pageIndex(in.Position)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &apigateway.GetRestApisOutput{}
+	if idx < len(f.GetRestApisPageOutputs) {
+		out.Items = f.GetRestApisPageOutputs[idx].Items
+	}
+	if idx+1 < len(f.GetRestApisPageOutputs) {
+		out.Position = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callGetRestApisCount++
+	return out, nil
+}
+
+// GetResources pages GetResourcesPageOutputs for the requested rest api id.
+func (f *FakeAPIGatewayClient) GetResources(
+	ctx context.Context,
+	in *apigateway.GetResourcesInput,
+	optFns ...func(*apigateway.Options),
+) (*apigateway.GetResourcesOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callGetResourcesCount == f.ErrOnGetResourcesCall {
+		return nil, errors.New("apigateway GetResources injected error")
+	}
+	idx, err := pageIndex(in.Position)
+	if err != nil {
+		return nil, err
+	}
+
+	pages := f.GetResourcesPageOutputs[aws.ToString(in.RestApiId)]
+	out := &apigateway.GetResourcesOutput{}
+	if idx < len(pages) {
+		out.Items = pages[idx].Items
+	}
+	if idx+1 < len(pages) {
+		out.Position = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callGetResourcesCount++
+	return out, nil
+}
+
+// GetDomainNames pages GetDomainNamesPageOutputs.
+func (f *FakeAPIGatewayClient) GetDomainNames(
+	ctx context.Context,
+	in *apigateway.GetDomainNamesInput,
+	optFns ...func(*apigateway.Options),
+) (*apigateway.GetDomainNamesOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callGetDomainNamesCount == f.ErrOnGetDomainNamesCall {
+		return nil, errors.New("apigateway GetDomainNames injected error")
+	}
+	idx, err := pageIndex(in.Position)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &apigateway.GetDomainNamesOutput{}
+	if idx < len(f.GetDomainNamesPageOutputs) {
+		out.Items = f.GetDomainNamesPageOutputs[idx].Items
+	}
+	if idx+1 < len(f.GetDomainNamesPageOutputs) {
+		out.Position = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callGetDomainNamesCount++
+	return out, nil
+}
+
+// GetVpcLinks pages GetVpcLinksPageOutputs.
+func (f *FakeAPIGatewayClient) GetVpcLinks(
+	ctx context.Context,
+	in *apigateway.GetVpcLinksInput,
+	optFns ...func(*apigateway.Options),
+) (*apigateway.GetVpcLinksOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callGetVpcLinksCount == f.ErrOnGetVpcLinksCall {
+		return nil, errors.New("apigateway GetVpcLinks injected error")
+	}
+	idx, err := pageIndex(in.Position)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &apigateway.GetVpcLinksOutput{}
+	if idx < len(f.GetVpcLinksPageOutputs) {
+		out.Items = f.GetVpcLinksPageOutputs[idx].Items
+	}
+	if idx+1 < len(f.GetVpcLinksPageOutputs) {
+		out.Position = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callGetVpcLinksCount++
+	return out, nil
+}
+
+// GetUsagePlans pages GetUsagePlansPageOutputs.
+func (f *FakeAPIGatewayClient) GetUsagePlans(
+	ctx context.Context,
+	in *apigateway.GetUsagePlansInput,
+	optFns ...func(*apigateway.Options),
+) (*apigateway.GetUsagePlansOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callGetUsagePlansCount == f.ErrOnGetUsagePlansCall {
+		return nil, errors.New("apigateway GetUsagePlans injected error")
+	}
+	idx, err := pageIndex(in.Position)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &apigateway.GetUsagePlansOutput{}
+	if idx < len(f.GetUsagePlansPageOutputs) {
+		out.Items = f.GetUsagePlansPageOutputs[idx].Items
+	}
+	if idx+1 < len(f.GetUsagePlansPageOutputs) {
+		out.Position = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callGetUsagePlansCount++
+	return out, nil
+}
+
+// GetApiKeys pages GetApiKeysPageOutputs.
+func (f *FakeAPIGatewayClient) GetApiKeys(
+	ctx context.Context,
+	in *apigateway.GetApiKeysInput,
+	optFns ...func(*apigateway.Options),
+) (*apigateway.GetApiKeysOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callGetApiKeysCount == f.ErrOnGetApiKeysCall {
+		return nil, errors.New("apigateway GetApiKeys injected error")
+	}
+	idx, err := pageIndex(in.Position)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &apigateway.GetApiKeysOutput{}
+	if idx < len(f.GetApiKeysPageOutputs) {
+		out.Items = f.GetApiKeysPageOutputs[idx].Items
+	}
+	if idx+1 < len(f.GetApiKeysPageOutputs) {
+		out.Position = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callGetApiKeysCount++
+	return out, nil
+}
+
+// GetAccount returns ThrottleSettings or an error.
+func (f *FakeAPIGatewayClient) GetAccount(
+	ctx context.Context,
+	in *apigateway.GetAccountInput,
+	optFns ...func(*apigateway.Options),
+) (*apigateway.GetAccountOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.ErrGetAccount {
+		return nil, errors.New("apigateway GetAccount injected error")
+	}
+	return &apigateway.GetAccountOutput{ThrottleSettings: f.ThrottleSettings}, nil
+}
+
+// Reset clears all internal counters.
+func (f *FakeAPIGatewayClient) Reset() {
+	f.callGetRestApisCount = 0
+	f.callGetResourcesCount = 0
+	f.callGetDomainNamesCount = 0
+	f.callGetVpcLinksCount = 0
+	f.callGetUsagePlansCount = 0
+	f.callGetApiKeysCount = 0
+}
+
+// pageIndex converts a fake NextToken into a page index
+func pageIndex(token *string) (int, error) {
+	if token == nil {
+		return 0, nil
+	}
+	return strconv.Atoi(*token)
+}
+
+// GetRegion returns the configured region.
+func (f *FakeAPIGatewayClient) GetRegion() string {
+	return f.Region
+}
@@ -0,0 +1,63 @@
+package apigatewayv2client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// APIGatewayV2Client defines an interface for using AWS apigatewayv2 client
+type APIGatewayV2Client interface {
+	GetRegion() string
+	// GetApis lists the http and websocket apis of the region
+	GetApis(ctx context.Context, params *apigatewayv2.GetApisInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetApisOutput, error)
+	// GetRoutes lists the routes of an api
+	GetRoutes(ctx context.Context, params *apigatewayv2.GetRoutesInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetRoutesOutput, error)
+	// GetVpcLinks lists the http api vpc links of the region
+	GetVpcLinks(ctx context.Context, params *apigatewayv2.GetVpcLinksInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetVpcLinksOutput, error)
+}
+
+// APIGatewayV2ClientImpl implements APIGatewayV2Client interface
+type APIGatewayV2ClientImpl struct {
+	client *apigatewayv2.Client
+	region string
+}
+
+// NewAPIGatewayV2Client returns a new APIGatewayV2Client
+func NewAPIGatewayV2Client(cfg aws.Config, region string) (APIGatewayV2Client, error) {
+	// validate region
+	if !utils.IsValidRegion(region) {
+		return nil, errors.New("apigatewayv2client creation failed. invalid region")
+	}
+
+	client := apigatewayv2.NewFromConfig(cfg, func(o *apigatewayv2.Options) {
+		o.Region = region
+	})
+	return &APIGatewayV2ClientImpl{
+		client: client,
+		region: region,
+	}, nil
+}
+
+// GetApis calls apigatewayv2 client's GetApis method
+func (c *APIGatewayV2ClientImpl) GetApis(ctx context.Context, params *apigatewayv2.GetApisInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetApisOutput, error) {
+	return c.client.GetApis(ctx, params, optFns...)
+}
+
+// GetRoutes calls apigatewayv2 client's GetRoutes method
+func (c *APIGatewayV2ClientImpl) GetRoutes(ctx context.Context, params *apigatewayv2.GetRoutesInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetRoutesOutput, error) {
+	return c.client.GetRoutes(ctx, params, optFns...)
+}
+
+// GetVpcLinks calls apigatewayv2 client's GetVpcLinks method
+func (c *APIGatewayV2ClientImpl) GetVpcLinks(ctx context.Context, params *apigatewayv2.GetVpcLinksInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetVpcLinksOutput, error) {
+	return c.client.GetVpcLinks(ctx, params, optFns...)
+}
+
+// GetRegion returns the region of the client
+func (c *APIGatewayV2ClientImpl) GetRegion() string {
+	return c.region
+}
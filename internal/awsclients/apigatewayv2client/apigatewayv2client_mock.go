@@ -0,0 +1,142 @@
+package apigatewayv2client
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+)
+
+// FakeAPIGatewayV2Client implements the APIGatewayV2Client methods, with AWS-style pagination.
+type FakeAPIGatewayV2Client struct {
+	Region string
+
+	// pages for paginator calls:
+	GetApisPageOutputs []*apigatewayv2.GetApisOutput
+	// route pages keyed by api id
+	GetRoutesPageOutputs   map[string][]*apigatewayv2.GetRoutesOutput
+	GetVpcLinksPageOutputs []*apigatewayv2.GetVpcLinksOutput
+
+	// “throw on this call index” for each paginated method:
+	ErrOnGetApisCall     int
+	ErrOnGetRoutesCall   int
+	ErrOnGetVpcLinksCall int
+
+	// internal counters:
+	callGetApisCount     int
+	callGetRoutesCount   int
+	callGetVpcLinksCount int
+}
+
+// GetApis pages GetApisPageOutputs.
+func (f *FakeAPIGatewayV2Client) GetApis(
+	ctx context.Context,
+	in *apigatewayv2.GetApisInput,
+	optFns ...func(*apigatewayv2.Options),
+) (*apigatewayv2.GetApisOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callGetApisCount == f.ErrOnGetApisCall {
+		return nil, errors.New("apigatewayv2 GetApis injected error")
+	}
+	idx, err := pageIndex(in.NextToken)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &apigatewayv2.GetApisOutput{}
+	if idx < len(f.GetApisPageOutputs) {
+		out.Items = f.GetApisPageOutputs[idx].Items
+	}
+	if idx+1 < len(f.GetApisPageOutputs) {
+		out.NextToken = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callGetApisCount++
+	return out, nil
+}
+
+// GetRoutes pages GetRoutesPageOutputs for the requested api id.
+func (f *FakeAPIGatewayV2Client) GetRoutes(
+	ctx context.Context,
+	in *apigatewayv2.GetRoutesInput,
+	optFns ...func(*apigatewayv2.Options),
+) (*apigatewayv2.GetRoutesOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callGetRoutesCount == f.ErrOnGetRoutesCall {
+		return nil, errors.New("apigatewayv2 GetRoutes injected error")
+	}
+	idx, err := pageIndex(in.NextToken)
+	if err != nil {
+		return nil, err
+	}
+
+	pages := f.GetRoutesPageOutputs[aws.ToString(in.ApiId)]
+	out := &apigatewayv2.GetRoutesOutput{}
+	if idx < len(pages) {
+		out.Items = pages[idx].Items
+	}
+	if idx+1 < len(pages) {
+		out.NextToken = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callGetRoutesCount++
+	return out, nil
+}
+
+// GetVpcLinks pages GetVpcLinksPageOutputs.
+func (f *FakeAPIGatewayV2Client) GetVpcLinks(
+	ctx context.Context,
+	in *apigatewayv2.GetVpcLinksInput,
+	optFns ...func(*apigatewayv2.Options),
+) (*apigatewayv2.GetVpcLinksOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callGetVpcLinksCount == f.ErrOnGetVpcLinksCall {
+		return nil, errors.New("apigatewayv2 GetVpcLinks injected error")
+	}
+	idx, err := pageIndex(in.NextToken)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &apigatewayv2.GetVpcLinksOutput{}
+	if idx < len(f.GetVpcLinksPageOutputs) {
+		out.Items = f.GetVpcLinksPageOutputs[idx].Items
+	}
+	if idx+1 < len(f.GetVpcLinksPageOutputs) {
+		out.NextToken = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callGetVpcLinksCount++
+	return out, nil
+}
+
+// Reset clears all internal counters.
+func (f *FakeAPIGatewayV2Client) Reset() {
+	f.callGetApisCount = 0
+	f.callGetRoutesCount = 0
+	f.callGetVpcLinksCount = 0
+}
+
+// pageIndex converts a fake NextToken into a page index
+func pageIndex(token *string) (int, error) {
+	if token == nil {
+		return 0, nil
+	}
+	return strconv.Atoi(*token)
+}
+
+// GetRegion returns the configured region.
+func (f *FakeAPIGatewayV2Client) GetRegion() string {
+	return f.Region
+}
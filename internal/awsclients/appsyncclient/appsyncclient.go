@@ -0,0 +1,49 @@
+package appsyncclient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/appsync"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// AppSyncClient defines an interface for using AWS appsync client
+type AppSyncClient interface {
+	GetRegion() string
+	// ListGraphqlApis lists the graphql apis of the region
+	ListGraphqlApis(ctx context.Context, params *appsync.ListGraphqlApisInput, optFns ...func(*appsync.Options)) (*appsync.ListGraphqlApisOutput, error)
+}
+
+// AppSyncClientImpl implements AppSyncClient interface
+type AppSyncClientImpl struct {
+	client *appsync.Client
+	region string
+}
+
+// NewAppSyncClient returns a new AppSyncClient
+func NewAppSyncClient(cfg aws.Config, region string) (AppSyncClient, error) {
+	// validate region
+	if !utils.IsValidRegion(region) {
+		return nil, errors.New("appsyncclient creation failed. invalid region")
+	}
+
+	client := appsync.NewFromConfig(cfg, func(o *appsync.Options) {
+		o.Region = region
+	})
+	return &AppSyncClientImpl{
+		client: client,
+		region: region,
+	}, nil
+}
+
+// ListGraphqlApis calls appsync client's ListGraphqlApis method
+func (c *AppSyncClientImpl) ListGraphqlApis(ctx context.Context, params *appsync.ListGraphqlApisInput, optFns ...func(*appsync.Options)) (*appsync.ListGraphqlApisOutput, error) {
+	return c.client.ListGraphqlApis(ctx, params, optFns...)
+}
+
+// GetRegion returns the region of the client
+func (c *AppSyncClientImpl) GetRegion() string {
+	return c.region
+}
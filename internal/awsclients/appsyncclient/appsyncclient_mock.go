@@ -0,0 +1,72 @@
+package appsyncclient
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/appsync"
+)
+
+// FakeAppSyncClient implements the AppSyncClient methods, with AWS-style pagination.
+type FakeAppSyncClient struct {
+	Region string
+
+	// pages for paginator calls:
+	ListGraphqlApisPageOutputs []*appsync.ListGraphqlApisOutput
+
+	// “throw on this call index” for each paginated method:
+	ErrOnListGraphqlApisCall int
+
+	// internal counters:
+	callListGraphqlApisCount int
+}
+
+// ListGraphqlApis pages ListGraphqlApisPageOutputs.
+func (f *FakeAppSyncClient) ListGraphqlApis(
+	ctx context.Context,
+	in *appsync.ListGraphqlApisInput,
+	optFns ...func(*appsync.Options),
+) (*appsync.ListGraphqlApisOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callListGraphqlApisCount == f.ErrOnListGraphqlApisCall {
+		return nil, errors.New("appsync ListGraphqlApis injected error")
+	}
+	idx, err := pageIndex(in.NextToken)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &appsync.ListGraphqlApisOutput{}
+	if idx < len(f.ListGraphqlApisPageOutputs) {
+		out.GraphqlApis = f.ListGraphqlApisPageOutputs[idx].GraphqlApis
+	}
+	if idx+1 < len(f.ListGraphqlApisPageOutputs) {
+		out.NextToken = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callListGraphqlApisCount++
+	return out, nil
+}
+
+// Reset clears all internal counters.
+func (f *FakeAppSyncClient) Reset() {
+	f.callListGraphqlApisCount = 0
+}
+
+// pageIndex converts a fake NextToken into a page index
+func pageIndex(token *string) (int, error) {
+	if token == nil {
+		return 0, nil
+	}
+	return strconv.Atoi(*token)
+}
+
+// GetRegion returns the configured region.
+func (f *FakeAppSyncClient) GetRegion() string {
+	return f.Region
+}
@@ -0,0 +1,49 @@
+package athenaclient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/athena"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// AthenaClient defines an interface for using AWS athena client
+type AthenaClient interface {
+	GetRegion() string
+	// ListWorkGroups lists the workgroups of the region
+	ListWorkGroups(ctx context.Context, params *athena.ListWorkGroupsInput, optFns ...func(*athena.Options)) (*athena.ListWorkGroupsOutput, error)
+}
+
+// AthenaClientImpl implements AthenaClient interface
+type AthenaClientImpl struct {
+	client *athena.Client
+	region string
+}
+
+// NewAthenaClient returns a new AthenaClient
+func NewAthenaClient(cfg aws.Config, region string) (AthenaClient, error) {
+	// validate region
+	if !utils.IsValidRegion(region) {
+		return nil, errors.New("athenaclient creation failed. invalid region")
+	}
+
+	client := athena.NewFromConfig(cfg, func(o *athena.Options) {
+		o.Region = region
+	})
+	return &AthenaClientImpl{
+		client: client,
+		region: region,
+	}, nil
+}
+
+// ListWorkGroups calls athena client's ListWorkGroups method
+func (c *AthenaClientImpl) ListWorkGroups(ctx context.Context, params *athena.ListWorkGroupsInput, optFns ...func(*athena.Options)) (*athena.ListWorkGroupsOutput, error) {
+	return c.client.ListWorkGroups(ctx, params, optFns...)
+}
+
+// GetRegion returns the region of the client
+func (c *AthenaClientImpl) GetRegion() string {
+	return c.region
+}
@@ -0,0 +1,72 @@
+package athenaclient
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/athena"
+)
+
+// FakeAthenaClient implements the AthenaClient methods, with AWS-style pagination.
+type FakeAthenaClient struct {
+	Region string
+
+	// pages for paginator calls:
+	ListWorkGroupsPageOutputs []*athena.ListWorkGroupsOutput
+
+	// “throw on this call index” for each paginated method:
+	ErrOnListWorkGroupsCall int
+
+	// internal counters:
+	callListWorkGroupsCount int
+}
+
+// ListWorkGroups pages ListWorkGroupsPageOutputs.
+func (f *FakeAthenaClient) ListWorkGroups(
+	ctx context.Context,
+	in *athena.ListWorkGroupsInput,
+	optFns ...func(*athena.Options),
+) (*athena.ListWorkGroupsOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callListWorkGroupsCount == f.ErrOnListWorkGroupsCall {
+		return nil, errors.New("athena ListWorkGroups injected error")
+	}
+	idx, err := pageIndex(in.NextToken)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &athena.ListWorkGroupsOutput{}
+	if idx < len(f.ListWorkGroupsPageOutputs) {
+		out.WorkGroups = f.ListWorkGroupsPageOutputs[idx].WorkGroups
+	}
+	if idx+1 < len(f.ListWorkGroupsPageOutputs) {
+		out.NextToken = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callListWorkGroupsCount++
+	return out, nil
+}
+
+// Reset clears all internal counters.
+func (f *FakeAthenaClient) Reset() {
+	f.callListWorkGroupsCount = 0
+}
+
+// pageIndex converts a fake NextToken into a page index
+func pageIndex(token *string) (int, error) {
+	if token == nil {
+		return 0, nil
+	}
+	return strconv.Atoi(*token)
+}
+
+// GetRegion returns the configured region.
+func (f *FakeAthenaClient) GetRegion() string {
+	return f.Region
+}
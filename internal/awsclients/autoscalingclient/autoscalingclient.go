@@ -0,0 +1,49 @@
+package autoscalingclient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// AutoScalingClient defines an interface for using AWS autoscaling client
+type AutoScalingClient interface {
+	GetRegion() string
+	// DescribeAccountLimits returns the auto scaling group and launch configuration limits of the account with current usage
+	DescribeAccountLimits(ctx context.Context, params *autoscaling.DescribeAccountLimitsInput, optFns ...func(*autoscaling.Options)) (*autoscaling.DescribeAccountLimitsOutput, error)
+}
+
+// AutoScalingClientImpl implements AutoScalingClient interface
+type AutoScalingClientImpl struct {
+	client *autoscaling.Client
+	region string
+}
+
+// NewAutoScalingClient returns a new AutoScalingClient
+func NewAutoScalingClient(cfg aws.Config, region string) (AutoScalingClient, error) {
+	// validate region
+	if !utils.IsValidRegion(region) {
+		return nil, errors.New("autoscalingclient creation failed. invalid region")
+	}
+
+	client := autoscaling.NewFromConfig(cfg, func(o *autoscaling.Options) {
+		o.Region = region
+	})
+	return &AutoScalingClientImpl{
+		client: client,
+		region: region,
+	}, nil
+}
+
+// DescribeAccountLimits calls autoscaling client's DescribeAccountLimits method
+func (c *AutoScalingClientImpl) DescribeAccountLimits(ctx context.Context, params *autoscaling.DescribeAccountLimitsInput, optFns ...func(*autoscaling.Options)) (*autoscaling.DescribeAccountLimitsOutput, error) {
+	return c.client.DescribeAccountLimits(ctx, params, optFns...)
+}
+
+// GetRegion returns the region of the client
+func (c *AutoScalingClientImpl) GetRegion() string {
+	return c.region
+}
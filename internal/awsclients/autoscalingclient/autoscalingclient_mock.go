@@ -0,0 +1,45 @@
+package autoscalingclient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+)
+
+// FakeAutoScalingClient implements the AutoScalingClient methods for testing.
+type FakeAutoScalingClient struct {
+	Region string
+
+	// simple (non-paginated) responses:
+	AccountLimits *autoscaling.DescribeAccountLimitsOutput
+
+	// simple error flags:
+	ErrDescribeAccountLimits bool
+}
+
+// DescribeAccountLimits returns AccountLimits or an error.
+func (f *FakeAutoScalingClient) DescribeAccountLimits(
+	ctx context.Context,
+	in *autoscaling.DescribeAccountLimitsInput,
+	optFns ...func(*autoscaling.Options),
+) (*autoscaling.DescribeAccountLimitsOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	if f.ErrDescribeAccountLimits {
+		return nil, errors.New("autoscaling DescribeAccountLimits injected error")
+	}
+	if f.AccountLimits == nil {
+		return &autoscaling.DescribeAccountLimitsOutput{}, nil
+	}
+	return f.AccountLimits, nil
+}
+
+// GetRegion returns the configured region.
+func (f *FakeAutoScalingClient) GetRegion() string {
+	return f.Region
+}
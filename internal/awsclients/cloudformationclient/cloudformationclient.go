@@ -0,0 +1,70 @@
+package cloudformationclient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// CloudFormationClient defines an interface for using AWS cloudformation client
+type CloudFormationClient interface {
+	GetRegion() string
+	// DescribeAccountLimits returns the stack and output limits of the account
+	DescribeAccountLimits(ctx context.Context, params *cloudformation.DescribeAccountLimitsInput, optFns ...func(*cloudformation.Options)) (*cloudformation.DescribeAccountLimitsOutput, error)
+	// ListStacks lists stack summaries filtered by status
+	ListStacks(ctx context.Context, params *cloudformation.ListStacksInput, optFns ...func(*cloudformation.Options)) (*cloudformation.ListStacksOutput, error)
+	// ListStackSets lists stack set summaries
+	ListStackSets(ctx context.Context, params *cloudformation.ListStackSetsInput, optFns ...func(*cloudformation.Options)) (*cloudformation.ListStackSetsOutput, error)
+	// ListStackResources lists the resources of a stack
+	ListStackResources(ctx context.Context, params *cloudformation.ListStackResourcesInput, optFns ...func(*cloudformation.Options)) (*cloudformation.ListStackResourcesOutput, error)
+}
+
+// CloudFormationClientImpl implements CloudFormationClient interface
+type CloudFormationClientImpl struct {
+	client *cloudformation.Client
+	region string
+}
+
+// NewCloudFormationClient returns a new CloudFormationClient
+func NewCloudFormationClient(cfg aws.Config, region string) (CloudFormationClient, error) {
+	// validate region
+	if !utils.IsValidRegion(region) {
+		return nil, errors.New("cloudformationclient creation failed. invalid region")
+	}
+
+	client := cloudformation.NewFromConfig(cfg, func(o *cloudformation.Options) {
+		o.Region = region
+	})
+	return &CloudFormationClientImpl{
+		client: client,
+		region: region,
+	}, nil
+}
+
+// DescribeAccountLimits calls cloudformation client's DescribeAccountLimits method
+func (c *CloudFormationClientImpl) DescribeAccountLimits(ctx context.Context, params *cloudformation.DescribeAccountLimitsInput, optFns ...func(*cloudformation.Options)) (*cloudformation.DescribeAccountLimitsOutput, error) {
+	return c.client.DescribeAccountLimits(ctx, params, optFns...)
+}
+
+// ListStacks calls cloudformation client's ListStacks method
+func (c *CloudFormationClientImpl) ListStacks(ctx context.Context, params *cloudformation.ListStacksInput, optFns ...func(*cloudformation.Options)) (*cloudformation.ListStacksOutput, error) {
+	return c.client.ListStacks(ctx, params, optFns...)
+}
+
+// ListStackSets calls cloudformation client's ListStackSets method
+func (c *CloudFormationClientImpl) ListStackSets(ctx context.Context, params *cloudformation.ListStackSetsInput, optFns ...func(*cloudformation.Options)) (*cloudformation.ListStackSetsOutput, error) {
+	return c.client.ListStackSets(ctx, params, optFns...)
+}
+
+// ListStackResources calls cloudformation client's ListStackResources method
+func (c *CloudFormationClientImpl) ListStackResources(ctx context.Context, params *cloudformation.ListStackResourcesInput, optFns ...func(*cloudformation.Options)) (*cloudformation.ListStackResourcesOutput, error) {
+	return c.client.ListStackResources(ctx, params, optFns...)
+}
+
+// GetRegion returns the region of the client
+func (c *CloudFormationClientImpl) GetRegion() string {
+	return c.region
+}
@@ -0,0 +1,176 @@
+package cloudformationclient
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	cfnTypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+)
+
+// FakeCloudFormationClient implements the CloudFormationClient methods, with AWS-style pagination.
+type FakeCloudFormationClient struct {
+	Region string
+
+	// simple (non-paginated) responses:
+	AccountLimits []cfnTypes.AccountLimit
+
+	// pages for paginator calls:
+	ListStacksPageOutputs    []*cloudformation.ListStacksOutput
+	ListStackSetsPageOutputs []*cloudformation.ListStackSetsOutput
+	// per-stack resource pages keyed by stack name
+	ListStackResourcesPageOutputs map[string][]*cloudformation.ListStackResourcesOutput
+
+	// “throw on this call index” for each paginated method:
+	ErrOnListStacksCall         int
+	ErrOnListStackSetsCall      int
+	ErrOnListStackResourcesCall int
+
+	// simple error flags:
+	ErrDescribeAccountLimits bool
+
+	// internal counters:
+	callListStacksCount         int
+	callListStackSetsCount      int
+	callListStackResourcesCount int
+}
+
+// DescribeAccountLimits returns AccountLimits or an error.
+func (f *FakeCloudFormationClient) DescribeAccountLimits(
+	ctx context.Context,
+	in *cloudformation.DescribeAccountLimitsInput,
+	optFns ...func(*cloudformation.Options),
+) (*cloudformation.DescribeAccountLimitsOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	if f.ErrDescribeAccountLimits {
+		return nil, errors.New("cloudformation DescribeAccountLimits injected error")
+	}
+	return &cloudformation.DescribeAccountLimitsOutput{AccountLimits: f.AccountLimits}, nil
+}
+
+// ListStacks pages ListStacksPageOutputs, honoring StackStatusFilter like the service does.
+func (f *FakeCloudFormationClient) ListStacks(
+	ctx context.Context,
+	in *cloudformation.ListStacksInput,
+	optFns ...func(*cloudformation.Options),
+) (*cloudformation.ListStacksOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callListStacksCount == f.ErrOnListStacksCall {
+		return nil, errors.New("cloudformation ListStacks injected error")
+	}
+	idx, err := pageIndex(in.NextToken)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &cloudformation.ListStacksOutput{}
+	if idx < len(f.ListStacksPageOutputs) {
+		for _, summary := range f.ListStacksPageOutputs[idx].StackSummaries {
+			if len(in.StackStatusFilter) == 0 || slices.Contains(in.StackStatusFilter, summary.StackStatus) {
+				out.StackSummaries = append(out.StackSummaries, summary)
+			}
+		}
+	}
+	if idx+1 < len(f.ListStacksPageOutputs) {
+		out.NextToken = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callListStacksCount++
+	return out, nil
+}
+
+// ListStackSets pages ListStackSetsPageOutputs, honoring the Status filter.
+func (f *FakeCloudFormationClient) ListStackSets(
+	ctx context.Context,
+	in *cloudformation.ListStackSetsInput,
+	optFns ...func(*cloudformation.Options),
+) (*cloudformation.ListStackSetsOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callListStackSetsCount == f.ErrOnListStackSetsCall {
+		return nil, errors.New("cloudformation ListStackSets injected error")
+	}
+	idx, err := pageIndex(in.NextToken)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &cloudformation.ListStackSetsOutput{}
+	if idx < len(f.ListStackSetsPageOutputs) {
+		for _, summary := range f.ListStackSetsPageOutputs[idx].Summaries {
+			if in.Status == "" || summary.Status == in.Status {
+				out.Summaries = append(out.Summaries, summary)
+			}
+		}
+	}
+	if idx+1 < len(f.ListStackSetsPageOutputs) {
+		out.NextToken = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callListStackSetsCount++
+	return out, nil
+}
+
+// ListStackResources pages ListStackResourcesPageOutputs for the requested stack.
+func (f *FakeCloudFormationClient) ListStackResources(
+	ctx context.Context,
+	in *cloudformation.ListStackResourcesInput,
+	optFns ...func(*cloudformation.Options),
+) (*cloudformation.ListStackResourcesOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callListStackResourcesCount == f.ErrOnListStackResourcesCall {
+		return nil, errors.New("cloudformation ListStackResources injected error")
+	}
+	idx, err := pageIndex(in.NextToken)
+	if err != nil {
+		return nil, err
+	}
+
+	pages := f.ListStackResourcesPageOutputs[aws.ToString(in.StackName)]
+	out := &cloudformation.ListStackResourcesOutput{}
+	if idx < len(pages) {
+		out.StackResourceSummaries = pages[idx].StackResourceSummaries
+	}
+	if idx+1 < len(pages) {
+		out.NextToken = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callListStackResourcesCount++
+	return out, nil
+}
+
+// Reset clears all internal counters.
+func (f *FakeCloudFormationClient) Reset() {
+	f.callListStacksCount = 0
+	f.callListStackSetsCount = 0
+	f.callListStackResourcesCount = 0
+}
+
+// pageIndex converts a fake NextToken into a page index
+func pageIndex(token *string) (int, error) {
+	if token == nil {
+		return 0, nil
+	}
+	return strconv.Atoi(*token)
+}
+
+// GetRegion returns the configured region.
+func (f *FakeCloudFormationClient) GetRegion() string {
+	return f.Region
+}
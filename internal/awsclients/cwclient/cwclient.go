@@ -0,0 +1,63 @@
+package cwclient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// CloudWatchClient defines an interface for using AWS cloudwatch client
+type CloudWatchClient interface {
+	GetRegion() string
+	// GetMetricData retrieves metric values
+	GetMetricData(ctx context.Context, params *cloudwatch.GetMetricDataInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.GetMetricDataOutput, error)
+	// DescribeAlarms describes metric and composite alarms
+	DescribeAlarms(ctx context.Context, params *cloudwatch.DescribeAlarmsInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.DescribeAlarmsOutput, error)
+	// ListDashboards lists dashboards
+	ListDashboards(ctx context.Context, params *cloudwatch.ListDashboardsInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.ListDashboardsOutput, error)
+}
+
+// CloudWatchClientImpl implements the CloudWatchClient interface
+type CloudWatchClientImpl struct {
+	region string
+	client *cloudwatch.Client
+}
+
+// NewCloudWatchClient creates a new CloudWatchClient
+func NewCloudWatchClient(cfg aws.Config, region string) (CloudWatchClient, error) {
+	// validate region
+	if !utils.IsValidRegion(region) {
+		return nil, errors.New("cloudwatchclient creation failed. invalid region")
+	}
+
+	client := cloudwatch.NewFromConfig(cfg, func(o *cloudwatch.Options) {
+		o.Region = region
+	})
+	return &CloudWatchClientImpl{
+		client: client,
+		region: region,
+	}, nil
+}
+
+// GetMetricData retrieves metric values
+func (c *CloudWatchClientImpl) GetMetricData(ctx context.Context, params *cloudwatch.GetMetricDataInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.GetMetricDataOutput, error) {
+	return c.client.GetMetricData(ctx, params, optFns...)
+}
+
+// DescribeAlarms describes metric and composite alarms
+func (c *CloudWatchClientImpl) DescribeAlarms(ctx context.Context, params *cloudwatch.DescribeAlarmsInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.DescribeAlarmsOutput, error) {
+	return c.client.DescribeAlarms(ctx, params, optFns...)
+}
+
+// ListDashboards lists dashboards
+func (c *CloudWatchClientImpl) ListDashboards(ctx context.Context, params *cloudwatch.ListDashboardsInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.ListDashboardsOutput, error) {
+	return c.client.ListDashboards(ctx, params, optFns...)
+}
+
+// GetRegion returns the region of the client
+func (c *CloudWatchClientImpl) GetRegion() string {
+	return c.region
+}
@@ -0,0 +1,154 @@
+package cwclient
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwTypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+)
+
+// FakeCloudWatchClient implements the CloudWatchClient methods for testing.
+type FakeCloudWatchClient struct {
+	Region string
+
+	// MetricDataValues is returned for every metric data query, keyed by query id
+	MetricDataValues map[string][]float64
+	// MetricDataResults replaces MetricDataValues for the query ids it holds, for
+	// expressions returning one result per group
+	MetricDataResults map[string][]cwTypes.MetricDataResult
+
+	// pages for paginator calls:
+	DescribeAlarmsPageOutputs []*cloudwatch.DescribeAlarmsOutput
+	ListDashboardsPageOutputs []*cloudwatch.ListDashboardsOutput
+
+	// simple error flags:
+	ErrGetMetricData bool
+
+	// “throw on this call index” for each paginated method:
+	ErrOnDescribeAlarmsCall int
+	ErrOnListDashboardsCall int
+
+	// GetMetricDataInputs records every GetMetricData request
+	GetMetricDataInputs []*cloudwatch.GetMetricDataInput
+	// DescribeAlarmsInputs records every DescribeAlarms request
+	DescribeAlarmsInputs []*cloudwatch.DescribeAlarmsInput
+
+	// internal counters:
+	callDescribeAlarmsCount int
+	callListDashboardsCount int
+}
+
+// GetMetricData returns MetricDataResults or MetricDataValues for each query id or an error.
+func (f *FakeCloudWatchClient) GetMetricData(
+	ctx context.Context,
+	in *cloudwatch.GetMetricDataInput,
+	optFns ...func(*cloudwatch.Options),
+) (*cloudwatch.GetMetricDataOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	f.GetMetricDataInputs = append(f.GetMetricDataInputs, in)
+	if f.ErrGetMetricData {
+		return nil, errors.New("cloudwatch GetMetricData injected error")
+	}
+	out := &cloudwatch.GetMetricDataOutput{}
+	for _, q := range in.MetricDataQueries {
+		id := aws.ToString(q.Id)
+		if results, ok := f.MetricDataResults[id]; ok {
+			out.MetricDataResults = append(out.MetricDataResults, results...)
+			continue
+		}
+		out.MetricDataResults = append(out.MetricDataResults, cwTypes.MetricDataResult{
+			Id:     aws.String(id),
+			Values: f.MetricDataValues[id],
+		})
+	}
+	return out, nil
+}
+
+// DescribeAlarms pages DescribeAlarmsPageOutputs.
+func (f *FakeCloudWatchClient) DescribeAlarms(
+	ctx context.Context,
+	in *cloudwatch.DescribeAlarmsInput,
+	optFns ...func(*cloudwatch.Options),
+) (*cloudwatch.DescribeAlarmsOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	f.DescribeAlarmsInputs = append(f.DescribeAlarmsInputs, in)
+	if f.callDescribeAlarmsCount == f.ErrOnDescribeAlarmsCall {
+		return nil, errors.New("cloudwatch DescribeAlarms injected error")
+	}
+	idx, err := pageIndex(in.NextToken)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &cloudwatch.DescribeAlarmsOutput{}
+	if idx < len(f.DescribeAlarmsPageOutputs) {
+		out.MetricAlarms = f.DescribeAlarmsPageOutputs[idx].MetricAlarms
+		out.CompositeAlarms = f.DescribeAlarmsPageOutputs[idx].CompositeAlarms
+	}
+	if idx+1 < len(f.DescribeAlarmsPageOutputs) {
+		out.NextToken = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callDescribeAlarmsCount++
+	return out, nil
+}
+
+// ListDashboards pages ListDashboardsPageOutputs.
+func (f *FakeCloudWatchClient) ListDashboards(
+	ctx context.Context,
+	in *cloudwatch.ListDashboardsInput,
+	optFns ...func(*cloudwatch.Options),
+) (*cloudwatch.ListDashboardsOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callListDashboardsCount == f.ErrOnListDashboardsCall {
+		return nil, errors.New("cloudwatch ListDashboards injected error")
+	}
+	idx, err := pageIndex(in.NextToken)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &cloudwatch.ListDashboardsOutput{}
+	if idx < len(f.ListDashboardsPageOutputs) {
+		out.DashboardEntries = f.ListDashboardsPageOutputs[idx].DashboardEntries
+	}
+	if idx+1 < len(f.ListDashboardsPageOutputs) {
+		out.NextToken = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callListDashboardsCount++
+	return out, nil
+}
+
+// Reset clears all internal counters.
+func (f *FakeCloudWatchClient) Reset() {
+	f.callDescribeAlarmsCount = 0
+	f.callListDashboardsCount = 0
+}
+
+// pageIndex converts a fake NextToken into a page index
+func pageIndex(token *string) (int, error) {
+	if token == nil {
+		return 0, nil
+	}
+	return strconv.Atoi(*token)
+}
+
+// GetRegion returns the configured region.
+func (f *FakeCloudWatchClient) GetRegion() string {
+	return f.Region
+}
@@ -24,6 +24,10 @@ type CloudWatchLogsClient interface {
 	DescribeLogStreams(ctx context.Context, params *cloudwatchlogs.DescribeLogStreamsInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogStreamsOutput, error)
 	// Create Log stream
 	CreateLogStream(ctx context.Context, params *cloudwatchlogs.CreateLogStreamInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.CreateLogStreamOutput, error)
+	// DescribeMetricFilters describes metric filters, across all log groups when no log group is given
+	DescribeMetricFilters(ctx context.Context, params *cloudwatchlogs.DescribeMetricFiltersInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeMetricFiltersOutput, error)
+	// DescribeSubscriptionFilters describes the subscription filters of a log group
+	DescribeSubscriptionFilters(ctx context.Context, params *cloudwatchlogs.DescribeSubscriptionFiltersInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeSubscriptionFiltersOutput, error)
 }
 
 // CloudWatchLogsClientImpl implements the CloudWatchLogsClient interface
@@ -75,6 +79,16 @@ func (c *CloudWatchLogsClientImpl) CreateLogStream(ctx context.Context, params *
 	return c.client.CreateLogStream(ctx, params, optFns...)
 }
 
+// DescribeMetricFilters describes metric filters
+func (c *CloudWatchLogsClientImpl) DescribeMetricFilters(ctx context.Context, params *cloudwatchlogs.DescribeMetricFiltersInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeMetricFiltersOutput, error) {
+	return c.client.DescribeMetricFilters(ctx, params, optFns...)
+}
+
+// DescribeSubscriptionFilters describes subscription filters
+func (c *CloudWatchLogsClientImpl) DescribeSubscriptionFilters(ctx context.Context, params *cloudwatchlogs.DescribeSubscriptionFiltersInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeSubscriptionFiltersOutput, error) {
+	return c.client.DescribeSubscriptionFilters(ctx, params, optFns...)
+}
+
 // / EnsureLogGroupExists will page through DescribeLogGroups via
 // the SDK‐provided paginator, and CreateLogGroup if no exact match.
 func EnsureLogGroupExists(ctx context.Context, client CloudWatchLogsClient, groupName string) error {
@@ -0,0 +1,179 @@
+package cwlclient
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+)
+
+// FakeCloudWatchLogsClient implements the CloudWatchLogsClient describe methods, with AWS-style pagination.
+// The write methods succeed without recording anything.
+type FakeCloudWatchLogsClient struct {
+	Region string
+
+	// pages for paginator calls:
+	DescribeLogGroupsPageOutputs     []*cloudwatchlogs.DescribeLogGroupsOutput
+	DescribeMetricFiltersPageOutputs []*cloudwatchlogs.DescribeMetricFiltersOutput
+	// subscription filter pages keyed by log group name
+	DescribeSubscriptionFiltersPageOutputs map[string][]*cloudwatchlogs.DescribeSubscriptionFiltersOutput
+
+	// “throw on this call index” for each paginated method:
+	ErrOnDescribeLogGroupsCall           int
+	ErrOnDescribeMetricFiltersCall       int
+	ErrOnDescribeSubscriptionFiltersCall int
+
+	// internal counters:
+	callDescribeLogGroupsCount           int
+	callDescribeMetricFiltersCount       int
+	callDescribeSubscriptionFiltersCount int
+}
+
+// PutLogEvents accepts every batch.
+func (f *FakeCloudWatchLogsClient) PutLogEvents(
+	ctx context.Context,
+	in *cloudwatchlogs.PutLogEventsInput,
+	optFns ...func(*cloudwatchlogs.Options),
+) (*cloudwatchlogs.PutLogEventsOutput, error) {
+	return &cloudwatchlogs.PutLogEventsOutput{}, ctx.Err()
+}
+
+// CreateLogGroup always succeeds.
+func (f *FakeCloudWatchLogsClient) CreateLogGroup(
+	ctx context.Context,
+	in *cloudwatchlogs.CreateLogGroupInput,
+	optFns ...func(*cloudwatchlogs.Options),
+) (*cloudwatchlogs.CreateLogGroupOutput, error) {
+	return &cloudwatchlogs.CreateLogGroupOutput{}, ctx.Err()
+}
+
+// DescribeLogStreams returns no streams.
+func (f *FakeCloudWatchLogsClient) DescribeLogStreams(
+	ctx context.Context,
+	in *cloudwatchlogs.DescribeLogStreamsInput,
+	optFns ...func(*cloudwatchlogs.Options),
+) (*cloudwatchlogs.DescribeLogStreamsOutput, error) {
+	return &cloudwatchlogs.DescribeLogStreamsOutput{}, ctx.Err()
+}
+
+// CreateLogStream always succeeds.
+func (f *FakeCloudWatchLogsClient) CreateLogStream(
+	ctx context.Context,
+	in *cloudwatchlogs.CreateLogStreamInput,
+	optFns ...func(*cloudwatchlogs.Options),
+) (*cloudwatchlogs.CreateLogStreamOutput, error) {
+	return &cloudwatchlogs.CreateLogStreamOutput{}, ctx.Err()
+}
+
+// DescribeLogGroups pages DescribeLogGroupsPageOutputs.
+func (f *FakeCloudWatchLogsClient) DescribeLogGroups(
+	ctx context.Context,
+	in *cloudwatchlogs.DescribeLogGroupsInput,
+	optFns ...func(*cloudwatchlogs.Options),
+) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callDescribeLogGroupsCount == f.ErrOnDescribeLogGroupsCall {
+		return nil, errors.New("cloudwatchlogs DescribeLogGroups injected error")
+	}
+	idx, err := pageIndex(in.NextToken)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &cloudwatchlogs.DescribeLogGroupsOutput{}
+	if idx < len(f.DescribeLogGroupsPageOutputs) {
+		out.LogGroups = f.DescribeLogGroupsPageOutputs[idx].LogGroups
+	}
+	if idx+1 < len(f.DescribeLogGroupsPageOutputs) {
+		out.NextToken = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callDescribeLogGroupsCount++
+	return out, nil
+}
+
+// DescribeMetricFilters pages DescribeMetricFiltersPageOutputs.
+func (f *FakeCloudWatchLogsClient) DescribeMetricFilters(
+	ctx context.Context,
+	in *cloudwatchlogs.DescribeMetricFiltersInput,
+	optFns ...func(*cloudwatchlogs.Options),
+) (*cloudwatchlogs.DescribeMetricFiltersOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callDescribeMetricFiltersCount == f.ErrOnDescribeMetricFiltersCall {
+		return nil, errors.New("cloudwatchlogs DescribeMetricFilters injected error")
+	}
+	idx, err := pageIndex(in.NextToken)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &cloudwatchlogs.DescribeMetricFiltersOutput{}
+	if idx < len(f.DescribeMetricFiltersPageOutputs) {
+		out.MetricFilters = f.DescribeMetricFiltersPageOutputs[idx].MetricFilters
+	}
+	if idx+1 < len(f.DescribeMetricFiltersPageOutputs) {
+		out.NextToken = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callDescribeMetricFiltersCount++
+	return out, nil
+}
+
+// DescribeSubscriptionFilters pages DescribeSubscriptionFiltersPageOutputs for the requested log group.
+func (f *FakeCloudWatchLogsClient) DescribeSubscriptionFilters(
+	ctx context.Context,
+	in *cloudwatchlogs.DescribeSubscriptionFiltersInput,
+	optFns ...func(*cloudwatchlogs.Options),
+) (*cloudwatchlogs.DescribeSubscriptionFiltersOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callDescribeSubscriptionFiltersCount == f.ErrOnDescribeSubscriptionFiltersCall {
+		return nil, errors.New("cloudwatchlogs DescribeSubscriptionFilters injected error")
+	}
+	idx, err := pageIndex(in.NextToken)
+	if err != nil {
+		return nil, err
+	}
+
+	pages := f.DescribeSubscriptionFiltersPageOutputs[aws.ToString(in.LogGroupName)]
+	out := &cloudwatchlogs.DescribeSubscriptionFiltersOutput{}
+	if idx < len(pages) {
+		out.SubscriptionFilters = pages[idx].SubscriptionFilters
+	}
+	if idx+1 < len(pages) {
+		out.NextToken = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callDescribeSubscriptionFiltersCount++
+	return out, nil
+}
+
+// Reset clears all internal counters.
+func (f *FakeCloudWatchLogsClient) Reset() {
+	f.callDescribeLogGroupsCount = 0
+	f.callDescribeMetricFiltersCount = 0
+	f.callDescribeSubscriptionFiltersCount = 0
+}
+
+// pageIndex converts a fake NextToken into a page index
+func pageIndex(token *string) (int, error) {
+	if token == nil {
+		return 0, nil
+	}
+	return strconv.Atoi(*token)
+}
+
+// GetRegion returns the configured region.
+func (f *FakeCloudWatchLogsClient) GetRegion() string {
+	return f.Region
+}
@@ -0,0 +1,63 @@
+package dynamodbclient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// DynamoDBClient defines an interface for using AWS dynamodb client
+type DynamoDBClient interface {
+	GetRegion() string
+	// DescribeLimits returns the account and table capacity maximums
+	DescribeLimits(ctx context.Context, params *dynamodb.DescribeLimitsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeLimitsOutput, error)
+	// ListTables lists table names in the region
+	ListTables(ctx context.Context, params *dynamodb.ListTablesInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ListTablesOutput, error)
+	// DescribeTable describes a single table
+	DescribeTable(ctx context.Context, params *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error)
+}
+
+// DynamoDBClientImpl implements DynamoDBClient interface
+type DynamoDBClientImpl struct {
+	client *dynamodb.Client
+	region string
+}
+
+// NewDynamoDBClient returns a new DynamoDBClient
+func NewDynamoDBClient(cfg aws.Config, region string) (DynamoDBClient, error) {
+	// validate region
+	if !utils.IsValidRegion(region) {
+		return nil, errors.New("dynamodbclient creation failed. invalid region")
+	}
+
+	client := dynamodb.NewFromConfig(cfg, func(o *dynamodb.Options) {
+		o.Region = region
+	})
+	return &DynamoDBClientImpl{
+		client: client,
+		region: region,
+	}, nil
+}
+
+// DescribeLimits calls dynamodb client's DescribeLimits method
+func (c *DynamoDBClientImpl) DescribeLimits(ctx context.Context, params *dynamodb.DescribeLimitsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeLimitsOutput, error) {
+	return c.client.DescribeLimits(ctx, params, optFns...)
+}
+
+// ListTables calls dynamodb client's ListTables method
+func (c *DynamoDBClientImpl) ListTables(ctx context.Context, params *dynamodb.ListTablesInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ListTablesOutput, error) {
+	return c.client.ListTables(ctx, params, optFns...)
+}
+
+// DescribeTable calls dynamodb client's DescribeTable method
+func (c *DynamoDBClientImpl) DescribeTable(ctx context.Context, params *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error) {
+	return c.client.DescribeTable(ctx, params, optFns...)
+}
+
+// GetRegion returns the region of the client
+func (c *DynamoDBClientImpl) GetRegion() string {
+	return c.region
+}
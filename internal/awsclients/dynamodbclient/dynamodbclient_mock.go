@@ -0,0 +1,124 @@
+package dynamodbclient
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	dynamodbTypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// FakeDynamoDBClient implements the DynamoDBClient methods, with AWS-style pagination.
+type FakeDynamoDBClient struct {
+	Region string
+
+	// simple (non-paginated) responses:
+	Limits dynamodb.DescribeLimitsOutput
+	// table descriptions keyed by table name
+	Tables map[string]dynamodbTypes.TableDescription
+
+	// pages for paginator calls:
+	ListTablesPageOutputs []*dynamodb.ListTablesOutput
+
+	// “throw on this call index” for each method:
+	ErrOnListTablesCall    int
+	ErrOnDescribeTableCall int
+
+	// simple error flags:
+	ErrDescribeLimits bool
+
+	// internal counters:
+	callListTablesCount    int
+	callDescribeTableCount int
+}
+
+// DescribeLimits returns Limits or an error.
+func (f *FakeDynamoDBClient) DescribeLimits(
+	ctx context.Context,
+	in *dynamodb.DescribeLimitsInput,
+	optFns ...func(*dynamodb.Options),
+) (*dynamodb.DescribeLimitsOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	if f.ErrDescribeLimits {
+		return nil, errors.New("dynamodb DescribeLimits injected error")
+	}
+	out := f.Limits
+	return &out, nil
+}
+
+// ListTables pages ListTablesPageOutputs, using ExclusiveStartTableName as the page index.
+func (f *FakeDynamoDBClient) ListTables(
+	ctx context.Context,
+	in *dynamodb.ListTablesInput,
+	optFns ...func(*dynamodb.Options),
+) (*dynamodb.ListTablesOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	if f.callListTablesCount == f.ErrOnListTablesCall {
+		return nil, errors.New("dynamodb ListTables injected error")
+	}
+
+	idx := 0
+	if in.ExclusiveStartTableName != nil {
+		i, err := strconv.Atoi(*in.ExclusiveStartTableName)
+		if err != nil {
+			return nil, err
+		}
+		idx = i
+	}
+
+	out := &dynamodb.ListTablesOutput{}
+	if idx < len(f.ListTablesPageOutputs) {
+		out.TableNames = f.ListTablesPageOutputs[idx].TableNames
+	}
+	if idx+1 < len(f.ListTablesPageOutputs) {
+		out.LastEvaluatedTableName = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callListTablesCount++
+	return out, nil
+}
+
+// DescribeTable returns the table from Tables or an error.
+func (f *FakeDynamoDBClient) DescribeTable(
+	ctx context.Context,
+	in *dynamodb.DescribeTableInput,
+	optFns ...func(*dynamodb.Options),
+) (*dynamodb.DescribeTableOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	if f.callDescribeTableCount == f.ErrOnDescribeTableCall {
+		return nil, errors.New("dynamodb DescribeTable injected error")
+	}
+	f.callDescribeTableCount++
+	table, ok := f.Tables[aws.ToString(in.TableName)]
+	if !ok {
+		return nil, &dynamodbTypes.ResourceNotFoundException{Message: aws.String("table not found")}
+	}
+	return &dynamodb.DescribeTableOutput{Table: &table}, nil
+}
+
+// Reset clears all internal counters.
+func (f *FakeDynamoDBClient) Reset() {
+	f.callListTablesCount = 0
+	f.callDescribeTableCount = 0
+}
+
+// GetRegion returns the configured region.
+func (f *FakeDynamoDBClient) GetRegion() string {
+	return f.Region
+}
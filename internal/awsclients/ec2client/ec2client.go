@@ -18,6 +18,11 @@ type Ec2Client interface {
 	DescribeVpcEndpoints(ctx context.Context, params *ec2.DescribeVpcEndpointsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeVpcEndpointsOutput, error)
 	DescribeSubnets(ctx context.Context, params *ec2.DescribeSubnetsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSubnetsOutput, error)
 	DescribeTransitGatewayVpcAttachments(ctx context.Context, params *ec2.DescribeTransitGatewayVpcAttachmentsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeTransitGatewayVpcAttachmentsOutput, error)
+	DescribeLaunchTemplates(ctx context.Context, params *ec2.DescribeLaunchTemplatesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeLaunchTemplatesOutput, error)
+	DescribeSpotFleetRequests(ctx context.Context, params *ec2.DescribeSpotFleetRequestsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSpotFleetRequestsOutput, error)
+	DescribeFleets(ctx context.Context, params *ec2.DescribeFleetsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeFleetsOutput, error)
+	DescribePlacementGroups(ctx context.Context, params *ec2.DescribePlacementGroupsInput, optFns ...func(*ec2.Options)) (*ec2.DescribePlacementGroupsOutput, error)
+	DescribeKeyPairs(ctx context.Context, params *ec2.DescribeKeyPairsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeKeyPairsOutput, error)
 }
 
 // Ec2ClientImpl implements Ec2Client interface
@@ -75,3 +80,28 @@ func (c *Ec2ClientImpl) DescribeVpcs(ctx context.Context, params *ec2.DescribeVp
 func (c *Ec2ClientImpl) DescribeTransitGatewayVpcAttachments(ctx context.Context, params *ec2.DescribeTransitGatewayVpcAttachmentsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeTransitGatewayVpcAttachmentsOutput, error) {
 	return c.client.DescribeTransitGatewayVpcAttachments(ctx, params, optFns...)
 }
+
+// DescribeLaunchTemplates calls ec2 client's DescribeLaunchTemplates method
+func (c *Ec2ClientImpl) DescribeLaunchTemplates(ctx context.Context, params *ec2.DescribeLaunchTemplatesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeLaunchTemplatesOutput, error) {
+	return c.client.DescribeLaunchTemplates(ctx, params, optFns...)
+}
+
+// DescribeSpotFleetRequests calls ec2 client's DescribeSpotFleetRequests method
+func (c *Ec2ClientImpl) DescribeSpotFleetRequests(ctx context.Context, params *ec2.DescribeSpotFleetRequestsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSpotFleetRequestsOutput, error) {
+	return c.client.DescribeSpotFleetRequests(ctx, params, optFns...)
+}
+
+// DescribeFleets calls ec2 client's DescribeFleets method
+func (c *Ec2ClientImpl) DescribeFleets(ctx context.Context, params *ec2.DescribeFleetsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeFleetsOutput, error) {
+	return c.client.DescribeFleets(ctx, params, optFns...)
+}
+
+// DescribePlacementGroups calls ec2 client's DescribePlacementGroups method
+func (c *Ec2ClientImpl) DescribePlacementGroups(ctx context.Context, params *ec2.DescribePlacementGroupsInput, optFns ...func(*ec2.Options)) (*ec2.DescribePlacementGroupsOutput, error) {
+	return c.client.DescribePlacementGroups(ctx, params, optFns...)
+}
+
+// DescribeKeyPairs calls ec2 client's DescribeKeyPairs method
+func (c *Ec2ClientImpl) DescribeKeyPairs(ctx context.Context, params *ec2.DescribeKeyPairsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeKeyPairsOutput, error) {
+	return c.client.DescribeKeyPairs(ctx, params, optFns...)
+}
@@ -19,27 +19,40 @@ type FakeEC2Client struct {
 	DescribeNetworkInterfacesPages            []*ec2.DescribeNetworkInterfacesOutput
 	DescribeSubnetsPages                      []*ec2.DescribeSubnetsOutput
 	DescribeTransitGatewayVpcAttachmentsPages []*ec2.DescribeTransitGatewayVpcAttachmentsOutput
+	DescribeLaunchTemplatesPages              []*ec2.DescribeLaunchTemplatesOutput
+	DescribeSpotFleetRequestsPages            []*ec2.DescribeSpotFleetRequestsOutput
+	DescribeFleetsPages                       []*ec2.DescribeFleetsOutput
 
 	// simple (non-paginated) responses:
-	NatGateways  []ec2Types.NatGateway
-	VpcEndpoints []ec2Types.VpcEndpoint
+	NatGateways     []ec2Types.NatGateway
+	VpcEndpoints    []ec2Types.VpcEndpoint
+	PlacementGroups []ec2Types.PlacementGroup
+	KeyPairs        []ec2Types.KeyPairInfo
 
 	// “throw on this call index” for each paginated method:
-	ErrOnDescribeVpcsCall         int
-	ErrOnDescribeENICall          int
-	ErrOnDescribeSubnetCall       int
-	ErrOnDescribeTGWVpcAttachCall int
+	ErrOnDescribeVpcsCall              int
+	ErrOnDescribeENICall               int
+	ErrOnDescribeSubnetCall            int
+	ErrOnDescribeTGWVpcAttachCall      int
+	ErrOnDescribeLaunchTemplatesCall   int
+	ErrOnDescribeSpotFleetRequestsCall int
+	ErrOnDescribeFleetsCall            int
 
 	// simple error flags:
-	ErrNat         bool
-	ErrVPCEndpoint bool
+	ErrNat             bool
+	ErrVPCEndpoint     bool
+	ErrPlacementGroups bool
+	ErrKeyPairs        bool
 
 	// internal counters:
-	callVpcsCount             int
-	callENICount              int
-	callSubnetCount           int
-	callTGWVpcAttachCount     int
-	callDescribeVpcsNextCount int
+	callVpcsCount              int
+	callENICount               int
+	callSubnetCount            int
+	callTGWVpcAttachCount      int
+	callDescribeVpcsNextCount  int
+	callLaunchTemplatesCount   int
+	callSpotFleetRequestsCount int
+	callFleetsCount            int
 }
 
 // DescribeVpcs paginates DescribeVpcsPages, honoring NextToken and injected errors.
@@ -242,12 +255,171 @@ func (f *FakeEC2Client) DescribeVpcEndpoints(
 	return &ec2.DescribeVpcEndpointsOutput{VpcEndpoints: f.VpcEndpoints}, nil
 }
 
+// DescribeLaunchTemplates pages DescribeLaunchTemplatesPages.
+func (f *FakeEC2Client) DescribeLaunchTemplates(
+	ctx context.Context,
+	in *ec2.DescribeLaunchTemplatesInput,
+	optFns ...func(*ec2.Options),
+) (*ec2.DescribeLaunchTemplatesOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	if f.callLaunchTemplatesCount == f.ErrOnDescribeLaunchTemplatesCall {
+		return nil, errors.New("ec2 DescribeLaunchTemplates injected error")
+	}
+
+	idx := 0
+	if in.NextToken != nil {
+		i, err := strconv.Atoi(*in.NextToken)
+		if err != nil {
+			return nil, err
+		}
+		idx = i
+	}
+
+	var out *ec2.DescribeLaunchTemplatesOutput
+	if idx < len(f.DescribeLaunchTemplatesPages) {
+		page := f.DescribeLaunchTemplatesPages[idx]
+		out = &ec2.DescribeLaunchTemplatesOutput{LaunchTemplates: page.LaunchTemplates}
+	} else {
+		out = &ec2.DescribeLaunchTemplatesOutput{}
+	}
+
+	if idx+1 < len(f.DescribeLaunchTemplatesPages) {
+		out.NextToken = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callLaunchTemplatesCount++
+	return out, nil
+}
+
+// DescribeSpotFleetRequests pages DescribeSpotFleetRequestsPages.
+func (f *FakeEC2Client) DescribeSpotFleetRequests(
+	ctx context.Context,
+	in *ec2.DescribeSpotFleetRequestsInput,
+	optFns ...func(*ec2.Options),
+) (*ec2.DescribeSpotFleetRequestsOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	if f.callSpotFleetRequestsCount == f.ErrOnDescribeSpotFleetRequestsCall {
+		return nil, errors.New("ec2 DescribeSpotFleetRequests injected error")
+	}
+
+	idx := 0
+	if in.NextToken != nil {
+		i, err := strconv.Atoi(*in.NextToken)
+		if err != nil {
+			return nil, err
+		}
+		idx = i
+	}
+
+	var out *ec2.DescribeSpotFleetRequestsOutput
+	if idx < len(f.DescribeSpotFleetRequestsPages) {
+		page := f.DescribeSpotFleetRequestsPages[idx]
+		out = &ec2.DescribeSpotFleetRequestsOutput{SpotFleetRequestConfigs: page.SpotFleetRequestConfigs}
+	} else {
+		out = &ec2.DescribeSpotFleetRequestsOutput{}
+	}
+
+	if idx+1 < len(f.DescribeSpotFleetRequestsPages) {
+		out.NextToken = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callSpotFleetRequestsCount++
+	return out, nil
+}
+
+// DescribeFleets pages DescribeFleetsPages.
+func (f *FakeEC2Client) DescribeFleets(
+	ctx context.Context,
+	in *ec2.DescribeFleetsInput,
+	optFns ...func(*ec2.Options),
+) (*ec2.DescribeFleetsOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	if f.callFleetsCount == f.ErrOnDescribeFleetsCall {
+		return nil, errors.New("ec2 DescribeFleets injected error")
+	}
+
+	idx := 0
+	if in.NextToken != nil {
+		i, err := strconv.Atoi(*in.NextToken)
+		if err != nil {
+			return nil, err
+		}
+		idx = i
+	}
+
+	var out *ec2.DescribeFleetsOutput
+	if idx < len(f.DescribeFleetsPages) {
+		page := f.DescribeFleetsPages[idx]
+		out = &ec2.DescribeFleetsOutput{Fleets: page.Fleets}
+	} else {
+		out = &ec2.DescribeFleetsOutput{}
+	}
+
+	if idx+1 < len(f.DescribeFleetsPages) {
+		out.NextToken = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callFleetsCount++
+	return out, nil
+}
+
+// DescribePlacementGroups returns the static slice or an error.
+func (f *FakeEC2Client) DescribePlacementGroups(
+	ctx context.Context,
+	in *ec2.DescribePlacementGroupsInput,
+	optFns ...func(*ec2.Options),
+) (*ec2.DescribePlacementGroupsOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	if f.ErrPlacementGroups {
+		return nil, errors.New("ec2 DescribePlacementGroups injected error")
+	}
+	return &ec2.DescribePlacementGroupsOutput{PlacementGroups: f.PlacementGroups}, nil
+}
+
+// DescribeKeyPairs returns the static slice or an error.
+func (f *FakeEC2Client) DescribeKeyPairs(
+	ctx context.Context,
+	in *ec2.DescribeKeyPairsInput,
+	optFns ...func(*ec2.Options),
+) (*ec2.DescribeKeyPairsOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	if f.ErrKeyPairs {
+		return nil, errors.New("ec2 DescribeKeyPairs injected error")
+	}
+	return &ec2.DescribeKeyPairsOutput{KeyPairs: f.KeyPairs}, nil
+}
+
 // Reset clears all internal counters.
 func (f *FakeEC2Client) Reset() {
 	f.callVpcsCount = 0
 	f.callENICount = 0
 	f.callSubnetCount = 0
 	f.callTGWVpcAttachCount = 0
+	f.callLaunchTemplatesCount = 0
+	f.callSpotFleetRequestsCount = 0
+	f.callFleetsCount = 0
 }
 
 // GetRegion returns the configured region.
@@ -0,0 +1,56 @@
+package ecrclient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// ECRClient defines an interface for using AWS ecr client
+type ECRClient interface {
+	GetRegion() string
+	// DescribeRepositories lists the repositories in the registry
+	DescribeRepositories(ctx context.Context, params *ecr.DescribeRepositoriesInput, optFns ...func(*ecr.Options)) (*ecr.DescribeRepositoriesOutput, error)
+	// DescribeImages lists the images in a repository
+	DescribeImages(ctx context.Context, params *ecr.DescribeImagesInput, optFns ...func(*ecr.Options)) (*ecr.DescribeImagesOutput, error)
+}
+
+// ECRClientImpl implements ECRClient interface
+type ECRClientImpl struct {
+	client *ecr.Client
+	region string
+}
+
+// NewECRClient returns a new ECRClient
+func NewECRClient(cfg aws.Config, region string) (ECRClient, error) {
+	// validate region
+	if !utils.IsValidRegion(region) {
+		return nil, errors.New("ecrclient creation failed. invalid region")
+	}
+
+	client := ecr.NewFromConfig(cfg, func(o *ecr.Options) {
+		o.Region = region
+	})
+	return &ECRClientImpl{
+		client: client,
+		region: region,
+	}, nil
+}
+
+// DescribeRepositories calls ecr client's DescribeRepositories method
+func (c *ECRClientImpl) DescribeRepositories(ctx context.Context, params *ecr.DescribeRepositoriesInput, optFns ...func(*ecr.Options)) (*ecr.DescribeRepositoriesOutput, error) {
+	return c.client.DescribeRepositories(ctx, params, optFns...)
+}
+
+// DescribeImages calls ecr client's DescribeImages method
+func (c *ECRClientImpl) DescribeImages(ctx context.Context, params *ecr.DescribeImagesInput, optFns ...func(*ecr.Options)) (*ecr.DescribeImagesOutput, error) {
+	return c.client.DescribeImages(ctx, params, optFns...)
+}
+
+// GetRegion returns the region of the client
+func (c *ECRClientImpl) GetRegion() string {
+	return c.region
+}
@@ -0,0 +1,108 @@
+package ecrclient
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+)
+
+// FakeECRClient implements the ECRClient methods, with AWS-style pagination.
+type FakeECRClient struct {
+	Region string
+
+	// pages for paginator calls:
+	DescribeRepositoriesPageOutputs []*ecr.DescribeRepositoriesOutput
+	// per-repository image pages keyed by repository name
+	DescribeImagesPageOutputs map[string][]*ecr.DescribeImagesOutput
+
+	// “throw on this call index” for each method:
+	ErrOnDescribeRepositoriesCall int
+	ErrOnDescribeImagesCall       int
+
+	// internal counters:
+	callDescribeRepositoriesCount int
+	callDescribeImagesCount       int
+}
+
+// DescribeRepositories pages DescribeRepositoriesPageOutputs using NextToken.
+func (f *FakeECRClient) DescribeRepositories(
+	ctx context.Context,
+	input *ecr.DescribeRepositoriesInput,
+	optFns ...func(*ecr.Options),
+) (*ecr.DescribeRepositoriesOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callDescribeRepositoriesCount == f.ErrOnDescribeRepositoriesCall {
+		return nil, errors.New("error describing ecr repositories")
+	}
+	idx, err := pageIndex(input.NextToken)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &ecr.DescribeRepositoriesOutput{}
+	if idx < len(f.DescribeRepositoriesPageOutputs) {
+		out.Repositories = f.DescribeRepositoriesPageOutputs[idx].Repositories
+	}
+	if idx+1 < len(f.DescribeRepositoriesPageOutputs) {
+		out.NextToken = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callDescribeRepositoriesCount++
+	return out, nil
+}
+
+// DescribeImages pages DescribeImagesPageOutputs for the requested repository.
+func (f *FakeECRClient) DescribeImages(
+	ctx context.Context,
+	input *ecr.DescribeImagesInput,
+	optFns ...func(*ecr.Options),
+) (*ecr.DescribeImagesOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callDescribeImagesCount == f.ErrOnDescribeImagesCall {
+		return nil, errors.New("error describing ecr images")
+	}
+	idx, err := pageIndex(input.NextToken)
+	if err != nil {
+		return nil, err
+	}
+
+	pages := f.DescribeImagesPageOutputs[aws.ToString(input.RepositoryName)]
+	out := &ecr.DescribeImagesOutput{}
+	if idx < len(pages) {
+		out.ImageDetails = pages[idx].ImageDetails
+	}
+	if idx+1 < len(pages) {
+		out.NextToken = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callDescribeImagesCount++
+	return out, nil
+}
+
+// Reset clears all internal counters.
+func (f *FakeECRClient) Reset() {
+	f.callDescribeRepositoriesCount = 0
+	f.callDescribeImagesCount = 0
+}
+
+// pageIndex converts a fake NextToken into a page index
+func pageIndex(token *string) (int, error) {
+	if token == nil {
+		return 0, nil
+	}
+	return strconv.Atoi(*token)
+}
+
+// GetRegion returns the client's configured region.
+func (f *FakeECRClient) GetRegion() string {
+	return f.Region
+}
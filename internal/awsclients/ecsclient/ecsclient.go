@@ -0,0 +1,70 @@
+package ecsclient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// ECSClient defines an interface for using AWS ecs client
+type ECSClient interface {
+	GetRegion() string
+	// ListClusters lists cluster arns in the region
+	ListClusters(ctx context.Context, params *ecs.ListClustersInput, optFns ...func(*ecs.Options)) (*ecs.ListClustersOutput, error)
+	// DescribeClusters describes up to 100 clusters
+	DescribeClusters(ctx context.Context, params *ecs.DescribeClustersInput, optFns ...func(*ecs.Options)) (*ecs.DescribeClustersOutput, error)
+	// ListServices lists service arns in a cluster
+	ListServices(ctx context.Context, params *ecs.ListServicesInput, optFns ...func(*ecs.Options)) (*ecs.ListServicesOutput, error)
+	// DescribeServices describes up to 10 services in a cluster
+	DescribeServices(ctx context.Context, params *ecs.DescribeServicesInput, optFns ...func(*ecs.Options)) (*ecs.DescribeServicesOutput, error)
+}
+
+// ECSClientImpl implements ECSClient interface
+type ECSClientImpl struct {
+	client *ecs.Client
+	region string
+}
+
+// NewECSClient returns a new ECSClient
+func NewECSClient(cfg aws.Config, region string) (ECSClient, error) {
+	// validate region
+	if !utils.IsValidRegion(region) {
+		return nil, errors.New("ecsclient creation failed. invalid region")
+	}
+
+	client := ecs.NewFromConfig(cfg, func(o *ecs.Options) {
+		o.Region = region
+	})
+	return &ECSClientImpl{
+		client: client,
+		region: region,
+	}, nil
+}
+
+// ListClusters calls ecs client's ListClusters method
+func (c *ECSClientImpl) ListClusters(ctx context.Context, params *ecs.ListClustersInput, optFns ...func(*ecs.Options)) (*ecs.ListClustersOutput, error) {
+	return c.client.ListClusters(ctx, params, optFns...)
+}
+
+// DescribeClusters calls ecs client's DescribeClusters method
+func (c *ECSClientImpl) DescribeClusters(ctx context.Context, params *ecs.DescribeClustersInput, optFns ...func(*ecs.Options)) (*ecs.DescribeClustersOutput, error) {
+	return c.client.DescribeClusters(ctx, params, optFns...)
+}
+
+// ListServices calls ecs client's ListServices method
+func (c *ECSClientImpl) ListServices(ctx context.Context, params *ecs.ListServicesInput, optFns ...func(*ecs.Options)) (*ecs.ListServicesOutput, error) {
+	return c.client.ListServices(ctx, params, optFns...)
+}
+
+// DescribeServices calls ecs client's DescribeServices method
+func (c *ECSClientImpl) DescribeServices(ctx context.Context, params *ecs.DescribeServicesInput, optFns ...func(*ecs.Options)) (*ecs.DescribeServicesOutput, error) {
+	return c.client.DescribeServices(ctx, params, optFns...)
+}
+
+// GetRegion returns the region of the client
+func (c *ECSClientImpl) GetRegion() string {
+	return c.region
+}
@@ -0,0 +1,179 @@
+package ecsclient
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	ecsTypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+)
+
+// FakeECSClient implements the ECSClient methods, with AWS-style pagination.
+type FakeECSClient struct {
+	Region string
+
+	// pages for paginator calls:
+	ListClustersPageOutputs []*ecs.ListClustersOutput
+	// per-cluster service pages keyed by cluster arn
+	ListServicesPageOutputs map[string][]*ecs.ListServicesOutput
+
+	// described resources keyed by arn
+	Clusters map[string]ecsTypes.Cluster
+	Services map[string]ecsTypes.Service
+
+	// “throw on this call index” for each method:
+	ErrOnListClustersCall     int
+	ErrOnDescribeClustersCall int
+	ErrOnListServicesCall     int
+	ErrOnDescribeServicesCall int
+
+	// internal counters:
+	callListClustersCount     int
+	callDescribeClustersCount int
+	callListServicesCount     int
+	callDescribeServicesCount int
+}
+
+// ListClusters pages ListClustersPageOutputs using NextToken.
+func (f *FakeECSClient) ListClusters(
+	ctx context.Context,
+	input *ecs.ListClustersInput,
+	optFns ...func(*ecs.Options),
+) (*ecs.ListClustersOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callListClustersCount == f.ErrOnListClustersCall {
+		return nil, errors.New("error listing ecs clusters")
+	}
+	idx, err := pageIndex(input.NextToken)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &ecs.ListClustersOutput{}
+	if idx < len(f.ListClustersPageOutputs) {
+		out.ClusterArns = f.ListClustersPageOutputs[idx].ClusterArns
+	}
+	if idx+1 < len(f.ListClustersPageOutputs) {
+		out.NextToken = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callListClustersCount++
+	return out, nil
+}
+
+// DescribeClusters returns the requested clusters, unknown arns are reported as failures.
+func (f *FakeECSClient) DescribeClusters(
+	ctx context.Context,
+	input *ecs.DescribeClustersInput,
+	optFns ...func(*ecs.Options),
+) (*ecs.DescribeClustersOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callDescribeClustersCount == f.ErrOnDescribeClustersCall {
+		return nil, errors.New("error describing ecs clusters")
+	}
+	if len(input.Clusters) > 100 {
+		return nil, errors.New("too many clusters requested")
+	}
+
+	out := &ecs.DescribeClustersOutput{}
+	for _, arn := range input.Clusters {
+		if cluster, ok := f.Clusters[arn]; ok {
+			out.Clusters = append(out.Clusters, cluster)
+			continue
+		}
+		out.Failures = append(out.Failures, ecsTypes.Failure{Arn: aws.String(arn), Reason: aws.String("MISSING")})
+	}
+	f.callDescribeClustersCount++
+	return out, nil
+}
+
+// ListServices pages ListServicesPageOutputs for the requested cluster.
+func (f *FakeECSClient) ListServices(
+	ctx context.Context,
+	input *ecs.ListServicesInput,
+	optFns ...func(*ecs.Options),
+) (*ecs.ListServicesOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callListServicesCount == f.ErrOnListServicesCall {
+		return nil, errors.New("error listing ecs services")
+	}
+	idx, err := pageIndex(input.NextToken)
+	if err != nil {
+		return nil, err
+	}
+
+	pages := f.ListServicesPageOutputs[aws.ToString(input.Cluster)]
+	out := &ecs.ListServicesOutput{}
+	if idx < len(pages) {
+		out.ServiceArns = pages[idx].ServiceArns
+	}
+	if idx+1 < len(pages) {
+		out.NextToken = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callListServicesCount++
+	return out, nil
+}
+
+// DescribeServices returns the requested services, unknown arns are reported as failures.
+func (f *FakeECSClient) DescribeServices(
+	ctx context.Context,
+	input *ecs.DescribeServicesInput,
+	optFns ...func(*ecs.Options),
+) (*ecs.DescribeServicesOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callDescribeServicesCount == f.ErrOnDescribeServicesCall {
+		return nil, errors.New("error describing ecs services")
+	}
+	if len(input.Services) > 10 {
+		return nil, errors.New("too many services requested")
+	}
+
+	out := &ecs.DescribeServicesOutput{}
+	for _, arn := range input.Services {
+		if service, ok := f.Services[arn]; ok {
+			out.Services = append(out.Services, service)
+			continue
+		}
+		out.Failures = append(out.Failures, ecsTypes.Failure{Arn: aws.String(arn), Reason: aws.String("MISSING")})
+	}
+	f.callDescribeServicesCount++
+	return out, nil
+}
+
+// Reset clears all internal counters.
+func (f *FakeECSClient) Reset() {
+	f.callListClustersCount = 0
+	f.callDescribeClustersCount = 0
+	f.callListServicesCount = 0
+	f.callDescribeServicesCount = 0
+}
+
+// pageIndex converts a fake NextToken into a page index
+func pageIndex(token *string) (int, error) {
+	if token == nil {
+		return 0, nil
+	}
+	return strconv.Atoi(*token)
+}
+
+// GetRegion returns the client's configured region.
+func (f *FakeECSClient) GetRegion() string {
+	return f.Region
+}
@@ -13,6 +13,10 @@ import (
 type EKSClient interface {
 	GetRegion() string
 	ListClusters(ctx context.Context, params *eks.ListClustersInput, optFns ...func(*eks.Options)) (*eks.ListClustersOutput, error)
+	ListNodegroups(ctx context.Context, params *eks.ListNodegroupsInput, optFns ...func(*eks.Options)) (*eks.ListNodegroupsOutput, error)
+	ListFargateProfiles(ctx context.Context, params *eks.ListFargateProfilesInput, optFns ...func(*eks.Options)) (*eks.ListFargateProfilesOutput, error)
+	DescribeFargateProfile(ctx context.Context, params *eks.DescribeFargateProfileInput, optFns ...func(*eks.Options)) (*eks.DescribeFargateProfileOutput, error)
+	ListAccessEntries(ctx context.Context, params *eks.ListAccessEntriesInput, optFns ...func(*eks.Options)) (*eks.ListAccessEntriesOutput, error)
 }
 
 type EKSClientImpl struct {
@@ -40,6 +44,26 @@ func (e *EKSClientImpl) ListClusters(ctx context.Context, params *eks.ListCluste
 	return e.client.ListClusters(ctx, params, optFns...)
 }
 
+// ListNodegroups lists the managed node groups of a cluster
+func (e *EKSClientImpl) ListNodegroups(ctx context.Context, params *eks.ListNodegroupsInput, optFns ...func(*eks.Options)) (*eks.ListNodegroupsOutput, error) {
+	return e.client.ListNodegroups(ctx, params, optFns...)
+}
+
+// ListFargateProfiles lists the fargate profiles of a cluster
+func (e *EKSClientImpl) ListFargateProfiles(ctx context.Context, params *eks.ListFargateProfilesInput, optFns ...func(*eks.Options)) (*eks.ListFargateProfilesOutput, error) {
+	return e.client.ListFargateProfiles(ctx, params, optFns...)
+}
+
+// DescribeFargateProfile describes a single fargate profile
+func (e *EKSClientImpl) DescribeFargateProfile(ctx context.Context, params *eks.DescribeFargateProfileInput, optFns ...func(*eks.Options)) (*eks.DescribeFargateProfileOutput, error) {
+	return e.client.DescribeFargateProfile(ctx, params, optFns...)
+}
+
+// ListAccessEntries lists the access entries of a cluster
+func (e *EKSClientImpl) ListAccessEntries(ctx context.Context, params *eks.ListAccessEntriesInput, optFns ...func(*eks.Options)) (*eks.ListAccessEntriesOutput, error) {
+	return e.client.ListAccessEntries(ctx, params, optFns...)
+}
+
 func (e *EKSClientImpl) GetRegion() string {
 	return e.region
 }
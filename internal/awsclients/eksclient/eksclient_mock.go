@@ -7,6 +7,7 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/eks"
+	eksTypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
 )
 
 // FakeEKSClient implements the necessary EKS API for testing, with AWS-style pagination.
@@ -15,6 +16,27 @@ type FakeEKSClient struct {
 	ListClustersPageOutputs []*eks.ListClustersOutput
 	ErrOnListClustersCall   int
 	callCount               int
+
+	// per-cluster pages keyed by cluster name
+	ListNodegroupsPageOutputs      map[string][]*eks.ListNodegroupsOutput
+	ListFargateProfilesPageOutputs map[string][]*eks.ListFargateProfilesOutput
+	ListAccessEntriesPageOutputs   map[string][]*eks.ListAccessEntriesOutput
+	// fargate profiles keyed by profile name
+	FargateProfiles map[string]*eksTypes.FargateProfile
+	// clusters using CONFIG_MAP authentication, which reject ListAccessEntries
+	ConfigMapClusters map[string]bool
+
+	// "throw on this call index" for each per-cluster method
+	ErrOnListNodegroupsCall         int
+	ErrOnListFargateProfilesCall    int
+	ErrOnDescribeFargateProfileCall int
+	ErrOnListAccessEntriesCall      int
+
+	// internal counters
+	callNodegroupsCount             int
+	callFargateProfilesCount        int
+	callDescribeFargateProfileCount int
+	callAccessEntriesCount          int
 }
 
 // ListClusters simulates paginated ListClusters calls using NextToken.
@@ -62,9 +84,140 @@ func (f *FakeEKSClient) ListClusters(
 	return out, nil
 }
 
-// Reset clears the internal call counter.
+// ListNodegroups pages ListNodegroupsPageOutputs for the requested cluster.
+func (f *FakeEKSClient) ListNodegroups(
+	ctx context.Context,
+	input *eks.ListNodegroupsInput,
+	optFns ...func(*eks.Options),
+) (*eks.ListNodegroupsOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callNodegroupsCount == f.ErrOnListNodegroupsCall {
+		return nil, errors.New("error listing eks node groups")
+	}
+	idx, err := pageIndex(input.NextToken)
+	if err != nil {
+		return nil, err
+	}
+
+	pages := f.ListNodegroupsPageOutputs[aws.ToString(input.ClusterName)]
+	out := &eks.ListNodegroupsOutput{}
+	if idx < len(pages) {
+		out.Nodegroups = pages[idx].Nodegroups
+	}
+	if idx+1 < len(pages) {
+		out.NextToken = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callNodegroupsCount++
+	return out, nil
+}
+
+// ListFargateProfiles pages ListFargateProfilesPageOutputs for the requested cluster.
+func (f *FakeEKSClient) ListFargateProfiles(
+	ctx context.Context,
+	input *eks.ListFargateProfilesInput,
+	optFns ...func(*eks.Options),
+) (*eks.ListFargateProfilesOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callFargateProfilesCount == f.ErrOnListFargateProfilesCall {
+		return nil, errors.New("error listing eks fargate profiles")
+	}
+	idx, err := pageIndex(input.NextToken)
+	if err != nil {
+		return nil, err
+	}
+
+	pages := f.ListFargateProfilesPageOutputs[aws.ToString(input.ClusterName)]
+	out := &eks.ListFargateProfilesOutput{}
+	if idx < len(pages) {
+		out.FargateProfileNames = pages[idx].FargateProfileNames
+	}
+	if idx+1 < len(pages) {
+		out.NextToken = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callFargateProfilesCount++
+	return out, nil
+}
+
+// DescribeFargateProfile returns the profile stored in FargateProfiles.
+func (f *FakeEKSClient) DescribeFargateProfile(
+	ctx context.Context,
+	input *eks.DescribeFargateProfileInput,
+	optFns ...func(*eks.Options),
+) (*eks.DescribeFargateProfileOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callDescribeFargateProfileCount == f.ErrOnDescribeFargateProfileCall {
+		return nil, errors.New("error describing eks fargate profile")
+	}
+	f.callDescribeFargateProfileCount++
+	profile, ok := f.FargateProfiles[aws.ToString(input.FargateProfileName)]
+	if !ok {
+		return nil, errors.New("fargate profile not found")
+	}
+	return &eks.DescribeFargateProfileOutput{FargateProfile: profile}, nil
+}
+
+// ListAccessEntries pages ListAccessEntriesPageOutputs for the requested cluster.
+func (f *FakeEKSClient) ListAccessEntries(
+	ctx context.Context,
+	input *eks.ListAccessEntriesInput,
+	optFns ...func(*eks.Options),
+) (*eks.ListAccessEntriesOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callAccessEntriesCount == f.ErrOnListAccessEntriesCall {
+		return nil, errors.New("error listing eks access entries")
+	}
+	if f.ConfigMapClusters[aws.ToString(input.ClusterName)] {
+		f.callAccessEntriesCount++
+		return nil, &eksTypes.InvalidRequestException{Message: aws.String("The cluster's authentication mode must be set to one of [API, API_AND_CONFIG_MAP] to perform this operation.")}
+	}
+	idx, err := pageIndex(input.NextToken)
+	if err != nil {
+		return nil, err
+	}
+
+	pages := f.ListAccessEntriesPageOutputs[aws.ToString(input.ClusterName)]
+	out := &eks.ListAccessEntriesOutput{}
+	if idx < len(pages) {
+		out.AccessEntries = pages[idx].AccessEntries
+	}
+	if idx+1 < len(pages) {
+		out.NextToken = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callAccessEntriesCount++
+	return out, nil
+}
+
+// Reset clears the internal call counters.
 func (f *FakeEKSClient) Reset() {
 	f.callCount = 0
+	f.callNodegroupsCount = 0
+	f.callFargateProfilesCount = 0
+	f.callDescribeFargateProfileCount = 0
+	f.callAccessEntriesCount = 0
+}
+
+// pageIndex converts a fake NextToken into a page index.
+func pageIndex(token *string) (int, error) {
+	if token == nil {
+		return 0, nil
+	}
+	return strconv.Atoi(*token)
 }
 
 // GetRegion returns the client's configured region.
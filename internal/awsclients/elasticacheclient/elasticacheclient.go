@@ -0,0 +1,49 @@
+package elasticacheclient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/elasticache"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// ElastiCacheClient defines an interface for using AWS elasticache client
+type ElastiCacheClient interface {
+	GetRegion() string
+	// DescribeCacheClusters lists the cache clusters in the region
+	DescribeCacheClusters(ctx context.Context, params *elasticache.DescribeCacheClustersInput, optFns ...func(*elasticache.Options)) (*elasticache.DescribeCacheClustersOutput, error)
+}
+
+// ElastiCacheClientImpl implements ElastiCacheClient interface
+type ElastiCacheClientImpl struct {
+	client *elasticache.Client
+	region string
+}
+
+// NewElastiCacheClient returns a new ElastiCacheClient
+func NewElastiCacheClient(cfg aws.Config, region string) (ElastiCacheClient, error) {
+	// validate region
+	if !utils.IsValidRegion(region) {
+		return nil, errors.New("elasticacheclient creation failed. invalid region")
+	}
+
+	client := elasticache.NewFromConfig(cfg, func(o *elasticache.Options) {
+		o.Region = region
+	})
+	return &ElastiCacheClientImpl{
+		client: client,
+		region: region,
+	}, nil
+}
+
+// DescribeCacheClusters calls elasticache client's DescribeCacheClusters method
+func (c *ElastiCacheClientImpl) DescribeCacheClusters(ctx context.Context, params *elasticache.DescribeCacheClustersInput, optFns ...func(*elasticache.Options)) (*elasticache.DescribeCacheClustersOutput, error) {
+	return c.client.DescribeCacheClusters(ctx, params, optFns...)
+}
+
+// GetRegion returns the region of the client
+func (c *ElastiCacheClientImpl) GetRegion() string {
+	return c.region
+}
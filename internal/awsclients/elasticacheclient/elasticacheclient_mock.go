@@ -0,0 +1,70 @@
+package elasticacheclient
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/elasticache"
+)
+
+// FakeElastiCacheClient implements the ElastiCacheClient methods, with AWS-style pagination.
+type FakeElastiCacheClient struct {
+	Region string
+
+	// pages for paginator calls:
+	DescribeCacheClustersPageOutputs []*elasticache.DescribeCacheClustersOutput
+
+	// “throw on this call index” for each method:
+	ErrOnDescribeCacheClustersCall int
+
+	// internal counters:
+	callDescribeCacheClustersCount int
+}
+
+// DescribeCacheClusters pages DescribeCacheClustersPageOutputs using Marker.
+func (f *FakeElastiCacheClient) DescribeCacheClusters(
+	ctx context.Context,
+	in *elasticache.DescribeCacheClustersInput,
+	optFns ...func(*elasticache.Options),
+) (*elasticache.DescribeCacheClustersOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	if f.callDescribeCacheClustersCount == f.ErrOnDescribeCacheClustersCall {
+		return nil, errors.New("elasticache DescribeCacheClusters injected error")
+	}
+
+	idx := 0
+	if in.Marker != nil {
+		i, err := strconv.Atoi(*in.Marker)
+		if err != nil {
+			return nil, err
+		}
+		idx = i
+	}
+
+	out := &elasticache.DescribeCacheClustersOutput{}
+	if idx < len(f.DescribeCacheClustersPageOutputs) {
+		out.CacheClusters = f.DescribeCacheClustersPageOutputs[idx].CacheClusters
+	}
+	if idx+1 < len(f.DescribeCacheClustersPageOutputs) {
+		out.Marker = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callDescribeCacheClustersCount++
+	return out, nil
+}
+
+// Reset clears all internal counters.
+func (f *FakeElastiCacheClient) Reset() {
+	f.callDescribeCacheClustersCount = 0
+}
+
+// GetRegion returns the configured region.
+func (f *FakeElastiCacheClient) GetRegion() string {
+	return f.Region
+}
@@ -0,0 +1,63 @@
+package eventbridgeclient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// EventBridgeClient defines an interface for using AWS eventbridge client
+type EventBridgeClient interface {
+	GetRegion() string
+	// ListEventBuses lists the event buses of the region
+	ListEventBuses(ctx context.Context, params *eventbridge.ListEventBusesInput, optFns ...func(*eventbridge.Options)) (*eventbridge.ListEventBusesOutput, error)
+	// ListRules lists the rules of an event bus
+	ListRules(ctx context.Context, params *eventbridge.ListRulesInput, optFns ...func(*eventbridge.Options)) (*eventbridge.ListRulesOutput, error)
+	// ListTargetsByRule lists the targets of a rule
+	ListTargetsByRule(ctx context.Context, params *eventbridge.ListTargetsByRuleInput, optFns ...func(*eventbridge.Options)) (*eventbridge.ListTargetsByRuleOutput, error)
+}
+
+// EventBridgeClientImpl implements EventBridgeClient interface
+type EventBridgeClientImpl struct {
+	client *eventbridge.Client
+	region string
+}
+
+// NewEventBridgeClient returns a new EventBridgeClient
+func NewEventBridgeClient(cfg aws.Config, region string) (EventBridgeClient, error) {
+	// validate region
+	if !utils.IsValidRegion(region) {
+		return nil, errors.New("eventbridgeclient creation failed. invalid region")
+	}
+
+	client := eventbridge.NewFromConfig(cfg, func(o *eventbridge.Options) {
+		o.Region = region
+	})
+	return &EventBridgeClientImpl{
+		client: client,
+		region: region,
+	}, nil
+}
+
+// ListEventBuses calls eventbridge client's ListEventBuses method
+func (c *EventBridgeClientImpl) ListEventBuses(ctx context.Context, params *eventbridge.ListEventBusesInput, optFns ...func(*eventbridge.Options)) (*eventbridge.ListEventBusesOutput, error) {
+	return c.client.ListEventBuses(ctx, params, optFns...)
+}
+
+// ListRules calls eventbridge client's ListRules method
+func (c *EventBridgeClientImpl) ListRules(ctx context.Context, params *eventbridge.ListRulesInput, optFns ...func(*eventbridge.Options)) (*eventbridge.ListRulesOutput, error) {
+	return c.client.ListRules(ctx, params, optFns...)
+}
+
+// ListTargetsByRule calls eventbridge client's ListTargetsByRule method
+func (c *EventBridgeClientImpl) ListTargetsByRule(ctx context.Context, params *eventbridge.ListTargetsByRuleInput, optFns ...func(*eventbridge.Options)) (*eventbridge.ListTargetsByRuleOutput, error) {
+	return c.client.ListTargetsByRule(ctx, params, optFns...)
+}
+
+// GetRegion returns the region of the client
+func (c *EventBridgeClientImpl) GetRegion() string {
+	return c.region
+}
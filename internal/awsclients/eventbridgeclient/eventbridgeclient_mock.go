@@ -0,0 +1,144 @@
+package eventbridgeclient
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+)
+
+// FakeEventBridgeClient implements the EventBridgeClient methods, with AWS-style pagination.
+type FakeEventBridgeClient struct {
+	Region string
+
+	// pages for paginated calls:
+	ListEventBusesPageOutputs []*eventbridge.ListEventBusesOutput
+	// rule pages keyed by event bus name
+	ListRulesPageOutputs map[string][]*eventbridge.ListRulesOutput
+	// target pages keyed by event bus name, then rule name
+	ListTargetsByRulePageOutputs map[string]map[string][]*eventbridge.ListTargetsByRuleOutput
+
+	// “throw on this call index” for each paginated method:
+	ErrOnListEventBusesCall    int
+	ErrOnListRulesCall         int
+	ErrOnListTargetsByRuleCall int
+
+	// internal counters:
+	callListEventBusesCount    int
+	callListRulesCount         int
+	callListTargetsByRuleCount int
+}
+
+// ListEventBuses pages ListEventBusesPageOutputs.
+func (f *FakeEventBridgeClient) ListEventBuses(
+	ctx context.Context,
+	in *eventbridge.ListEventBusesInput,
+	optFns ...func(*eventbridge.Options),
+) (*eventbridge.ListEventBusesOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callListEventBusesCount == f.ErrOnListEventBusesCall {
+		return nil, errors.New("eventbridge ListEventBuses injected error")
+	}
+	idx, err := pageIndex(in.NextToken)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &eventbridge.ListEventBusesOutput{}
+	if idx < len(f.ListEventBusesPageOutputs) {
+		out.EventBuses = f.ListEventBusesPageOutputs[idx].EventBuses
+	}
+	if idx+1 < len(f.ListEventBusesPageOutputs) {
+		out.NextToken = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callListEventBusesCount++
+	return out, nil
+}
+
+// ListRules pages the rules of the requested event bus.
+func (f *FakeEventBridgeClient) ListRules(
+	ctx context.Context,
+	in *eventbridge.ListRulesInput,
+	optFns ...func(*eventbridge.Options),
+) (*eventbridge.ListRulesOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callListRulesCount == f.ErrOnListRulesCall {
+		return nil, errors.New("eventbridge ListRules injected error")
+	}
+	idx, err := pageIndex(in.NextToken)
+	if err != nil {
+		return nil, err
+	}
+
+	pages := f.ListRulesPageOutputs[aws.ToString(in.EventBusName)]
+	out := &eventbridge.ListRulesOutput{}
+	if idx < len(pages) {
+		out.Rules = pages[idx].Rules
+	}
+	if idx+1 < len(pages) {
+		out.NextToken = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callListRulesCount++
+	return out, nil
+}
+
+// ListTargetsByRule pages the targets of the requested rule.
+func (f *FakeEventBridgeClient) ListTargetsByRule(
+	ctx context.Context,
+	in *eventbridge.ListTargetsByRuleInput,
+	optFns ...func(*eventbridge.Options),
+) (*eventbridge.ListTargetsByRuleOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callListTargetsByRuleCount == f.ErrOnListTargetsByRuleCall {
+		return nil, errors.New("eventbridge ListTargetsByRule injected error")
+	}
+	idx, err := pageIndex(in.NextToken)
+	if err != nil {
+		return nil, err
+	}
+
+	pages := f.ListTargetsByRulePageOutputs[aws.ToString(in.EventBusName)][aws.ToString(in.Rule)]
+	out := &eventbridge.ListTargetsByRuleOutput{}
+	if idx < len(pages) {
+		out.Targets = pages[idx].Targets
+	}
+	if idx+1 < len(pages) {
+		out.NextToken = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callListTargetsByRuleCount++
+	return out, nil
+}
+
+// Reset clears all internal counters.
+func (f *FakeEventBridgeClient) Reset() {
+	f.callListEventBusesCount = 0
+	f.callListRulesCount = 0
+	f.callListTargetsByRuleCount = 0
+}
+
+// pageIndex converts a fake NextToken into a page index
+func pageIndex(token *string) (int, error) {
+	if token == nil {
+		return 0, nil
+	}
+	return strconv.Atoi(*token)
+}
+
+// GetRegion returns the configured region.
+func (f *FakeEventBridgeClient) GetRegion() string {
+	return f.Region
+}
@@ -0,0 +1,49 @@
+package firehoseclient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/firehose"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// FirehoseClient defines an interface for using AWS firehose client
+type FirehoseClient interface {
+	GetRegion() string
+	// ListDeliveryStreams lists the delivery streams of the region
+	ListDeliveryStreams(ctx context.Context, params *firehose.ListDeliveryStreamsInput, optFns ...func(*firehose.Options)) (*firehose.ListDeliveryStreamsOutput, error)
+}
+
+// FirehoseClientImpl implements FirehoseClient interface
+type FirehoseClientImpl struct {
+	client *firehose.Client
+	region string
+}
+
+// NewFirehoseClient returns a new FirehoseClient
+func NewFirehoseClient(cfg aws.Config, region string) (FirehoseClient, error) {
+	// validate region
+	if !utils.IsValidRegion(region) {
+		return nil, errors.New("firehoseclient creation failed. invalid region")
+	}
+
+	client := firehose.NewFromConfig(cfg, func(o *firehose.Options) {
+		o.Region = region
+	})
+	return &FirehoseClientImpl{
+		client: client,
+		region: region,
+	}, nil
+}
+
+// ListDeliveryStreams calls firehose client's ListDeliveryStreams method
+func (c *FirehoseClientImpl) ListDeliveryStreams(ctx context.Context, params *firehose.ListDeliveryStreamsInput, optFns ...func(*firehose.Options)) (*firehose.ListDeliveryStreamsOutput, error) {
+	return c.client.ListDeliveryStreams(ctx, params, optFns...)
+}
+
+// GetRegion returns the region of the client
+func (c *FirehoseClientImpl) GetRegion() string {
+	return c.region
+}
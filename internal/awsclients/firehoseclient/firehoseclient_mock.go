@@ -0,0 +1,75 @@
+package firehoseclient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/firehose"
+)
+
+// FakeFirehoseClient implements the FirehoseClient methods, with firehose-style pagination.
+// ListDeliveryStreams continues after ExclusiveStartDeliveryStreamName, so the next page is
+// the one following the page whose last stream name was passed in.
+type FakeFirehoseClient struct {
+	Region string
+
+	// pages for paginator calls:
+	ListDeliveryStreamsPageOutputs []*firehose.ListDeliveryStreamsOutput
+
+	// “throw on this call index” for each paginated method:
+	ErrOnListDeliveryStreamsCall int
+
+	// internal counters:
+	callListDeliveryStreamsCount int
+}
+
+// ListDeliveryStreams pages ListDeliveryStreamsPageOutputs.
+func (f *FakeFirehoseClient) ListDeliveryStreams(
+	ctx context.Context,
+	in *firehose.ListDeliveryStreamsInput,
+	optFns ...func(*firehose.Options),
+) (*firehose.ListDeliveryStreamsOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callListDeliveryStreamsCount == f.ErrOnListDeliveryStreamsCall {
+		return nil, errors.New("firehose ListDeliveryStreams injected error")
+	}
+
+	idx := 0
+	if start := aws.ToString(in.ExclusiveStartDeliveryStreamName); start != "" {
+		idx = -1
+		for i, page := range f.ListDeliveryStreamsPageOutputs {
+			if n := len(page.DeliveryStreamNames); n > 0 && page.DeliveryStreamNames[n-1] == start {
+				idx = i + 1
+				break
+			}
+		}
+		if idx < 0 {
+			return nil, errors.New("firehose ListDeliveryStreams unknown start stream " + start)
+		}
+	}
+
+	out := &firehose.ListDeliveryStreamsOutput{
+		DeliveryStreamNames:    []string{},
+		HasMoreDeliveryStreams: aws.Bool(idx+1 < len(f.ListDeliveryStreamsPageOutputs)),
+	}
+	if idx < len(f.ListDeliveryStreamsPageOutputs) {
+		out.DeliveryStreamNames = f.ListDeliveryStreamsPageOutputs[idx].DeliveryStreamNames
+	}
+	f.callListDeliveryStreamsCount++
+	return out, nil
+}
+
+// Reset clears all internal counters.
+func (f *FakeFirehoseClient) Reset() {
+	f.callListDeliveryStreamsCount = 0
+}
+
+// GetRegion returns the configured region.
+func (f *FakeFirehoseClient) GetRegion() string {
+	return f.Region
+}
@@ -0,0 +1,63 @@
+package glueclient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/glue"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// GlueClient defines an interface for using AWS glue client
+type GlueClient interface {
+	GetRegion() string
+	// GetDatabases lists the data catalog databases of the region
+	GetDatabases(ctx context.Context, params *glue.GetDatabasesInput, optFns ...func(*glue.Options)) (*glue.GetDatabasesOutput, error)
+	// GetTables lists the tables of a data catalog database
+	GetTables(ctx context.Context, params *glue.GetTablesInput, optFns ...func(*glue.Options)) (*glue.GetTablesOutput, error)
+	// ListJobs lists the job names of the region
+	ListJobs(ctx context.Context, params *glue.ListJobsInput, optFns ...func(*glue.Options)) (*glue.ListJobsOutput, error)
+}
+
+// GlueClientImpl implements GlueClient interface
+type GlueClientImpl struct {
+	client *glue.Client
+	region string
+}
+
+// NewGlueClient returns a new GlueClient
+func NewGlueClient(cfg aws.Config, region string) (GlueClient, error) {
+	// validate region
+	if !utils.IsValidRegion(region) {
+		return nil, errors.New("glueclient creation failed. invalid region")
+	}
+
+	client := glue.NewFromConfig(cfg, func(o *glue.Options) {
+		o.Region = region
+	})
+	return &GlueClientImpl{
+		client: client,
+		region: region,
+	}, nil
+}
+
+// GetDatabases calls glue client's GetDatabases method
+func (c *GlueClientImpl) GetDatabases(ctx context.Context, params *glue.GetDatabasesInput, optFns ...func(*glue.Options)) (*glue.GetDatabasesOutput, error) {
+	return c.client.GetDatabases(ctx, params, optFns...)
+}
+
+// GetTables calls glue client's GetTables method
+func (c *GlueClientImpl) GetTables(ctx context.Context, params *glue.GetTablesInput, optFns ...func(*glue.Options)) (*glue.GetTablesOutput, error) {
+	return c.client.GetTables(ctx, params, optFns...)
+}
+
+// ListJobs calls glue client's ListJobs method
+func (c *GlueClientImpl) ListJobs(ctx context.Context, params *glue.ListJobsInput, optFns ...func(*glue.Options)) (*glue.ListJobsOutput, error) {
+	return c.client.ListJobs(ctx, params, optFns...)
+}
+
+// GetRegion returns the region of the client
+func (c *GlueClientImpl) GetRegion() string {
+	return c.region
+}
@@ -0,0 +1,142 @@
+package glueclient
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/glue"
+)
+
+// FakeGlueClient implements the GlueClient methods, with AWS-style pagination.
+type FakeGlueClient struct {
+	Region string
+
+	// pages for paginator calls:
+	GetDatabasesPageOutputs []*glue.GetDatabasesOutput
+	// keyed by database name
+	GetTablesPageOutputs map[string][]*glue.GetTablesOutput
+	ListJobsPageOutputs  []*glue.ListJobsOutput
+
+	// “throw on this call index” for each paginated method:
+	ErrOnGetDatabasesCall int
+	ErrOnGetTablesCall    int
+	ErrOnListJobsCall     int
+
+	// internal counters:
+	callGetDatabasesCount int
+	callGetTablesCount    int
+	callListJobsCount     int
+}
+
+// GetDatabases pages GetDatabasesPageOutputs.
+func (f *FakeGlueClient) GetDatabases(
+	ctx context.Context,
+	in *glue.GetDatabasesInput,
+	optFns ...func(*glue.Options),
+) (*glue.GetDatabasesOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callGetDatabasesCount == f.ErrOnGetDatabasesCall {
+		return nil, errors.New("glue GetDatabases injected error")
+	}
+	idx, err := pageIndex(in.NextToken)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &glue.GetDatabasesOutput{}
+	if idx < len(f.GetDatabasesPageOutputs) {
+		out.DatabaseList = f.GetDatabasesPageOutputs[idx].DatabaseList
+	}
+	if idx+1 < len(f.GetDatabasesPageOutputs) {
+		out.NextToken = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callGetDatabasesCount++
+	return out, nil
+}
+
+// GetTables pages GetTablesPageOutputs for the requested keyed by database name.
+func (f *FakeGlueClient) GetTables(
+	ctx context.Context,
+	in *glue.GetTablesInput,
+	optFns ...func(*glue.Options),
+) (*glue.GetTablesOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callGetTablesCount == f.ErrOnGetTablesCall {
+		return nil, errors.New("glue GetTables injected error")
+	}
+	idx, err := pageIndex(in.NextToken)
+	if err != nil {
+		return nil, err
+	}
+
+	pages := f.GetTablesPageOutputs[aws.ToString(in.DatabaseName)]
+	out := &glue.GetTablesOutput{}
+	if idx < len(pages) {
+		out.TableList = pages[idx].TableList
+	}
+	if idx+1 < len(pages) {
+		out.NextToken = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callGetTablesCount++
+	return out, nil
+}
+
+// ListJobs pages ListJobsPageOutputs.
+func (f *FakeGlueClient) ListJobs(
+	ctx context.Context,
+	in *glue.ListJobsInput,
+	optFns ...func(*glue.Options),
+) (*glue.ListJobsOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callListJobsCount == f.ErrOnListJobsCall {
+		return nil, errors.New("glue ListJobs injected error")
+	}
+	idx, err := pageIndex(in.NextToken)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &glue.ListJobsOutput{}
+	if idx < len(f.ListJobsPageOutputs) {
+		out.JobNames = f.ListJobsPageOutputs[idx].JobNames
+	}
+	if idx+1 < len(f.ListJobsPageOutputs) {
+		out.NextToken = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callListJobsCount++
+	return out, nil
+}
+
+// Reset clears all internal counters.
+func (f *FakeGlueClient) Reset() {
+	f.callGetDatabasesCount = 0
+	f.callGetTablesCount = 0
+	f.callListJobsCount = 0
+}
+
+// pageIndex converts a fake NextToken into a page index
+func pageIndex(token *string) (int, error) {
+	if token == nil {
+		return 0, nil
+	}
+	return strconv.Atoi(*token)
+}
+
+// GetRegion returns the configured region.
+func (f *FakeGlueClient) GetRegion() string {
+	return f.Region
+}
@@ -16,6 +16,16 @@ type IamClient interface {
 	ListOpenIDConnectProviders(ctx context.Context, params *iam.ListOpenIDConnectProvidersInput, optFns ...func(*iam.Options)) (*iam.ListOpenIDConnectProvidersOutput, error)
 	// Implement ListRoles
 	ListRoles(ctx context.Context, params *iam.ListRolesInput, optFns ...func(*iam.Options)) (*iam.ListRolesOutput, error)
+	// Implement ListPolicies
+	ListPolicies(ctx context.Context, params *iam.ListPoliciesInput, optFns ...func(*iam.Options)) (*iam.ListPoliciesOutput, error)
+	// Implement ListInstanceProfiles
+	ListInstanceProfiles(ctx context.Context, params *iam.ListInstanceProfilesInput, optFns ...func(*iam.Options)) (*iam.ListInstanceProfilesOutput, error)
+	// Implement ListSAMLProviders
+	ListSAMLProviders(ctx context.Context, params *iam.ListSAMLProvidersInput, optFns ...func(*iam.Options)) (*iam.ListSAMLProvidersOutput, error)
+	// Implement ListServerCertificates
+	ListServerCertificates(ctx context.Context, params *iam.ListServerCertificatesInput, optFns ...func(*iam.Options)) (*iam.ListServerCertificatesOutput, error)
+	// Implement ListAttachedRolePolicies
+	ListAttachedRolePolicies(ctx context.Context, params *iam.ListAttachedRolePoliciesInput, optFns ...func(*iam.Options)) (*iam.ListAttachedRolePoliciesOutput, error)
 }
 
 // IamClientImpl implements IamClient interface
@@ -50,6 +60,31 @@ func (c *IamClientImpl) ListRoles(ctx context.Context, params *iam.ListRolesInpu
 	return c.client.ListRoles(ctx, params, optFns...)
 }
 
+// Implement ListPolicies
+func (c *IamClientImpl) ListPolicies(ctx context.Context, params *iam.ListPoliciesInput, optFns ...func(*iam.Options)) (*iam.ListPoliciesOutput, error) {
+	return c.client.ListPolicies(ctx, params, optFns...)
+}
+
+// Implement ListInstanceProfiles
+func (c *IamClientImpl) ListInstanceProfiles(ctx context.Context, params *iam.ListInstanceProfilesInput, optFns ...func(*iam.Options)) (*iam.ListInstanceProfilesOutput, error) {
+	return c.client.ListInstanceProfiles(ctx, params, optFns...)
+}
+
+// Implement ListSAMLProviders
+func (c *IamClientImpl) ListSAMLProviders(ctx context.Context, params *iam.ListSAMLProvidersInput, optFns ...func(*iam.Options)) (*iam.ListSAMLProvidersOutput, error) {
+	return c.client.ListSAMLProviders(ctx, params, optFns...)
+}
+
+// Implement ListServerCertificates
+func (c *IamClientImpl) ListServerCertificates(ctx context.Context, params *iam.ListServerCertificatesInput, optFns ...func(*iam.Options)) (*iam.ListServerCertificatesOutput, error) {
+	return c.client.ListServerCertificates(ctx, params, optFns...)
+}
+
+// Implement ListAttachedRolePolicies
+func (c *IamClientImpl) ListAttachedRolePolicies(ctx context.Context, params *iam.ListAttachedRolePoliciesInput, optFns ...func(*iam.Options)) (*iam.ListAttachedRolePoliciesOutput, error) {
+	return c.client.ListAttachedRolePolicies(ctx, params, optFns...)
+}
+
 // GetRegion returns the region of the client
 func (c *IamClientImpl) GetRegion() string {
 	return c.region
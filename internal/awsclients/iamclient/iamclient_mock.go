@@ -3,55 +3,264 @@ package iamclient
 import (
 	"context"
 	"errors"
-	"log"
+	"strconv"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamTypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
 )
 
-// FakeIamClient implements the necessary IAM API for testing.
+// FakeIamClient implements the IamClient methods, with AWS-style pagination.
 type FakeIamClient struct {
 	Region string
-	// PageOutputs holds the pages to return.
-	IamRolesPageOutputs                  []*iam.ListRolesOutput
-	ListOpenIDConnectProviderPageOutputs *iam.ListOpenIDConnectProvidersOutput
-	// ErrorOnCall simulates an error on the specified call index.
-	ErrorOnCall int
-	CallCount   int
+
+	// pages for paginator calls:
+	ListRolesPageOutputs              []*iam.ListRolesOutput
+	ListPoliciesPageOutputs           []*iam.ListPoliciesOutput
+	ListInstanceProfilesPageOutputs   []*iam.ListInstanceProfilesOutput
+	ListServerCertificatesPageOutputs []*iam.ListServerCertificatesOutput
+	// attached policy pages keyed by role name
+	ListAttachedRolePoliciesPageOutputs map[string][]*iam.ListAttachedRolePoliciesOutput
+
+	// unpaginated list results:
+	OIDCProviders []iamTypes.OpenIDConnectProviderListEntry
+	SAMLProviders []iamTypes.SAMLProviderListEntry
+
+	// simple error flags:
+	ErrListOpenIDConnectProviders bool
+	ErrListSAMLProviders          bool
+
+	// “throw on this call index” for each paginated method:
+	ErrOnListRolesCall                int
+	ErrOnListPoliciesCall             int
+	ErrOnListInstanceProfilesCall     int
+	ErrOnListServerCertificatesCall   int
+	ErrOnListAttachedRolePoliciesCall int
+
+	// internal counters:
+	callListRolesCount                  int
+	callListPoliciesCount               int
+	callListInstanceProfilesCount       int
+	callListServerCertificatesCount     int
+	callListAttachedRolePoliciesCount   int
+	callListOpenIDConnectProvidersCount int
+}
+
+// ListRoles pages ListRolesPageOutputs.
+func (f *FakeIamClient) ListRoles(
+	ctx context.Context,
+	in *iam.ListRolesInput,
+	optFns ...func(*iam.Options),
+) (*iam.ListRolesOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callListRolesCount == f.ErrOnListRolesCall {
+		return nil, errors.New("iam ListRoles injected error")
+	}
+	idx, err := pageIndex(in.Marker)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &iam.ListRolesOutput{}
+	if idx < len(f.ListRolesPageOutputs) {
+		out.Roles = f.ListRolesPageOutputs[idx].Roles
+	}
+	if idx+1 < len(f.ListRolesPageOutputs) {
+		out.IsTruncated = true
+		out.Marker = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callListRolesCount++
+	return out, nil
+}
+
+// ListPolicies pages ListPoliciesPageOutputs.
+func (f *FakeIamClient) ListPolicies(
+	ctx context.Context,
+	in *iam.ListPoliciesInput,
+	optFns ...func(*iam.Options),
+) (*iam.ListPoliciesOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callListPoliciesCount == f.ErrOnListPoliciesCall {
+		return nil, errors.New("iam ListPolicies injected error")
+	}
+	idx, err := pageIndex(in.Marker)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &iam.ListPoliciesOutput{}
+	if idx < len(f.ListPoliciesPageOutputs) {
+		out.Policies = f.ListPoliciesPageOutputs[idx].Policies
+	}
+	if idx+1 < len(f.ListPoliciesPageOutputs) {
+		out.IsTruncated = true
+		out.Marker = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callListPoliciesCount++
+	return out, nil
 }
 
-func (f *FakeIamClient) ListRoles(ctx context.Context, input *iam.ListRolesInput, optFns ...func(*iam.Options)) (*iam.ListRolesOutput, error) {
+// ListInstanceProfiles pages ListInstanceProfilesPageOutputs.
+func (f *FakeIamClient) ListInstanceProfiles(
+	ctx context.Context,
+	in *iam.ListInstanceProfilesInput,
+	optFns ...func(*iam.Options),
+) (*iam.ListInstanceProfilesOutput, error) {
 	select {
 	case <-ctx.Done():
 		return nil, ctx.Err()
 	default:
 	}
-	// If the call count equals ErrorOnCall, simulate an error.
-	if f.CallCount == f.ErrorOnCall {
-		return nil, errors.New("pagination error")
+	if f.callListInstanceProfilesCount == f.ErrOnListInstanceProfilesCall {
+		return nil, errors.New("iam ListInstanceProfiles injected error")
+	}
+	idx, err := pageIndex(in.Marker)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &iam.ListInstanceProfilesOutput{}
+	if idx < len(f.ListInstanceProfilesPageOutputs) {
+		out.InstanceProfiles = f.ListInstanceProfilesPageOutputs[idx].InstanceProfiles
 	}
-	if f.CallCount < len(f.IamRolesPageOutputs) {
-		log.Printf("Returning page %d", f.CallCount)
-		output := f.IamRolesPageOutputs[f.CallCount]
-		f.CallCount++
-		return output, nil
+	if idx+1 < len(f.ListInstanceProfilesPageOutputs) {
+		out.IsTruncated = true
+		out.Marker = aws.String(strconv.Itoa(idx + 1))
 	}
-	// No more pages.
-	return nil, nil
+	f.callListInstanceProfilesCount++
+	return out, nil
 }
 
-func (f *FakeIamClient) ListOpenIDConnectProviders(ctx context.Context, input *iam.ListOpenIDConnectProvidersInput, optFns ...func(*iam.Options)) (*iam.ListOpenIDConnectProvidersOutput, error) {
+// ListServerCertificates pages ListServerCertificatesPageOutputs.
+func (f *FakeIamClient) ListServerCertificates(
+	ctx context.Context,
+	in *iam.ListServerCertificatesInput,
+	optFns ...func(*iam.Options),
+) (*iam.ListServerCertificatesOutput, error) {
 	select {
 	case <-ctx.Done():
 		return nil, ctx.Err()
 	default:
 	}
-	if f.CallCount == f.ErrorOnCall {
-		return nil, errors.New("list oidc proviers error")
+	if f.callListServerCertificatesCount == f.ErrOnListServerCertificatesCall {
+		return nil, errors.New("iam ListServerCertificates injected error")
+	}
+	idx, err := pageIndex(in.Marker)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &iam.ListServerCertificatesOutput{}
+	if idx < len(f.ListServerCertificatesPageOutputs) {
+		out.ServerCertificateMetadataList = f.ListServerCertificatesPageOutputs[idx].ServerCertificateMetadataList
+	}
+	if idx+1 < len(f.ListServerCertificatesPageOutputs) {
+		out.IsTruncated = true
+		out.Marker = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callListServerCertificatesCount++
+	return out, nil
+}
+
+// ListAttachedRolePolicies pages ListAttachedRolePoliciesPageOutputs for the requested role name.
+func (f *FakeIamClient) ListAttachedRolePolicies(
+	ctx context.Context,
+	in *iam.ListAttachedRolePoliciesInput,
+	optFns ...func(*iam.Options),
+) (*iam.ListAttachedRolePoliciesOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callListAttachedRolePoliciesCount == f.ErrOnListAttachedRolePoliciesCall {
+		return nil, errors.New("iam ListAttachedRolePolicies injected error")
+	}
+	idx, err := pageIndex(in.Marker)
+	if err != nil {
+		return nil, err
+	}
+
+	pages := f.ListAttachedRolePoliciesPageOutputs[aws.ToString(in.RoleName)]
+	out := &iam.ListAttachedRolePoliciesOutput{}
+	if idx < len(pages) {
+		out.AttachedPolicies = pages[idx].AttachedPolicies
+	}
+	if idx+1 < len(pages) {
+		out.IsTruncated = true
+		out.Marker = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callListAttachedRolePoliciesCount++
+	return out, nil
+}
+
+// ListOpenIDConnectProviders returns OIDCProviders or an error.
+func (f *FakeIamClient) ListOpenIDConnectProviders(
+	ctx context.Context,
+	in *iam.ListOpenIDConnectProvidersInput,
+	optFns ...func(*iam.Options),
+) (*iam.ListOpenIDConnectProvidersOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	f.callListOpenIDConnectProvidersCount++
+	if f.ErrListOpenIDConnectProviders {
+		return nil, errors.New("iam ListOpenIDConnectProviders injected error")
+	}
+	return &iam.ListOpenIDConnectProvidersOutput{OpenIDConnectProviderList: f.OIDCProviders}, nil
+}
+
+// ListSAMLProviders returns SAMLProviders or an error.
+func (f *FakeIamClient) ListSAMLProviders(
+	ctx context.Context,
+	in *iam.ListSAMLProvidersInput,
+	optFns ...func(*iam.Options),
+) (*iam.ListSAMLProvidersOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.ErrListSAMLProviders {
+		return nil, errors.New("iam ListSAMLProviders injected error")
+	}
+	return &iam.ListSAMLProvidersOutput{SAMLProviderList: f.SAMLProviders}, nil
+}
+
+// ListOpenIDConnectProvidersCalls returns how many times ListOpenIDConnectProviders was called.
+func (f *FakeIamClient) ListOpenIDConnectProvidersCalls() int {
+	return f.callListOpenIDConnectProvidersCount
+}
+
+// Reset clears all internal counters.
+func (f *FakeIamClient) Reset() {
+	f.callListRolesCount = 0
+	f.callListPoliciesCount = 0
+	f.callListInstanceProfilesCount = 0
+	f.callListServerCertificatesCount = 0
+	f.callListAttachedRolePoliciesCount = 0
+	f.callListOpenIDConnectProvidersCount = 0
+}
+
+// pageIndex converts a fake NextToken into a page index
+func pageIndex(token *string) (int, error) {
+	if token == nil {
+		return 0, nil
 	}
-	return f.ListOpenIDConnectProviderPageOutputs, nil
+	return strconv.Atoi(*token)
 }
 
-// get region
+// GetRegion returns the configured region.
 func (f *FakeIamClient) GetRegion() string {
 	return f.Region
 }
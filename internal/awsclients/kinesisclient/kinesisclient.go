@@ -0,0 +1,49 @@
+package kinesisclient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// KinesisClient defines an interface for using AWS kinesis client
+type KinesisClient interface {
+	GetRegion() string
+	// DescribeLimits returns the shard and on-demand stream limits of the region with current usage
+	DescribeLimits(ctx context.Context, params *kinesis.DescribeLimitsInput, optFns ...func(*kinesis.Options)) (*kinesis.DescribeLimitsOutput, error)
+}
+
+// KinesisClientImpl implements KinesisClient interface
+type KinesisClientImpl struct {
+	client *kinesis.Client
+	region string
+}
+
+// NewKinesisClient returns a new KinesisClient
+func NewKinesisClient(cfg aws.Config, region string) (KinesisClient, error) {
+	// validate region
+	if !utils.IsValidRegion(region) {
+		return nil, errors.New("kinesisclient creation failed. invalid region")
+	}
+
+	client := kinesis.NewFromConfig(cfg, func(o *kinesis.Options) {
+		o.Region = region
+	})
+	return &KinesisClientImpl{
+		client: client,
+		region: region,
+	}, nil
+}
+
+// DescribeLimits calls kinesis client's DescribeLimits method
+func (c *KinesisClientImpl) DescribeLimits(ctx context.Context, params *kinesis.DescribeLimitsInput, optFns ...func(*kinesis.Options)) (*kinesis.DescribeLimitsOutput, error) {
+	return c.client.DescribeLimits(ctx, params, optFns...)
+}
+
+// GetRegion returns the region of the client
+func (c *KinesisClientImpl) GetRegion() string {
+	return c.region
+}
@@ -0,0 +1,45 @@
+package kinesisclient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/service/kinesis"
+)
+
+// FakeKinesisClient implements the KinesisClient methods for testing.
+type FakeKinesisClient struct {
+	Region string
+
+	// simple (non-paginated) responses:
+	Limits *kinesis.DescribeLimitsOutput
+
+	// simple error flags:
+	ErrDescribeLimits bool
+}
+
+// DescribeLimits returns Limits or an error.
+func (f *FakeKinesisClient) DescribeLimits(
+	ctx context.Context,
+	in *kinesis.DescribeLimitsInput,
+	optFns ...func(*kinesis.Options),
+) (*kinesis.DescribeLimitsOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	if f.ErrDescribeLimits {
+		return nil, errors.New("kinesis DescribeLimits injected error")
+	}
+	if f.Limits == nil {
+		return &kinesis.DescribeLimitsOutput{}, nil
+	}
+	return f.Limits, nil
+}
+
+// GetRegion returns the configured region.
+func (f *FakeKinesisClient) GetRegion() string {
+	return f.Region
+}
@@ -0,0 +1,56 @@
+package kmsclient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// KMSClient defines an interface for using AWS kms client
+type KMSClient interface {
+	GetRegion() string
+	// ListKeys lists the kms keys of the region
+	ListKeys(ctx context.Context, params *kms.ListKeysInput, optFns ...func(*kms.Options)) (*kms.ListKeysOutput, error)
+	// ListAliases lists the aliases of the region
+	ListAliases(ctx context.Context, params *kms.ListAliasesInput, optFns ...func(*kms.Options)) (*kms.ListAliasesOutput, error)
+}
+
+// KMSClientImpl implements KMSClient interface
+type KMSClientImpl struct {
+	client *kms.Client
+	region string
+}
+
+// NewKMSClient returns a new KMSClient
+func NewKMSClient(cfg aws.Config, region string) (KMSClient, error) {
+	// validate region
+	if !utils.IsValidRegion(region) {
+		return nil, errors.New("kmsclient creation failed. invalid region")
+	}
+
+	client := kms.NewFromConfig(cfg, func(o *kms.Options) {
+		o.Region = region
+	})
+	return &KMSClientImpl{
+		client: client,
+		region: region,
+	}, nil
+}
+
+// ListKeys calls kms client's ListKeys method
+func (c *KMSClientImpl) ListKeys(ctx context.Context, params *kms.ListKeysInput, optFns ...func(*kms.Options)) (*kms.ListKeysOutput, error) {
+	return c.client.ListKeys(ctx, params, optFns...)
+}
+
+// ListAliases calls kms client's ListAliases method
+func (c *KMSClientImpl) ListAliases(ctx context.Context, params *kms.ListAliasesInput, optFns ...func(*kms.Options)) (*kms.ListAliasesOutput, error) {
+	return c.client.ListAliases(ctx, params, optFns...)
+}
+
+// GetRegion returns the region of the client
+func (c *KMSClientImpl) GetRegion() string {
+	return c.region
+}
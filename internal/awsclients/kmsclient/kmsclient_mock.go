@@ -0,0 +1,108 @@
+package kmsclient
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+)
+
+// FakeKMSClient implements the KMSClient methods, with AWS-style pagination.
+type FakeKMSClient struct {
+	Region string
+
+	// pages for paginator calls:
+	ListKeysPageOutputs    []*kms.ListKeysOutput
+	ListAliasesPageOutputs []*kms.ListAliasesOutput
+
+	// “throw on this call index” for each paginated method:
+	ErrOnListKeysCall    int
+	ErrOnListAliasesCall int
+
+	// internal counters:
+	callListKeysCount    int
+	callListAliasesCount int
+}
+
+// ListKeys pages ListKeysPageOutputs.
+func (f *FakeKMSClient) ListKeys(
+	ctx context.Context,
+	in *kms.ListKeysInput,
+	optFns ...func(*kms.Options),
+) (*kms.ListKeysOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callListKeysCount == f.ErrOnListKeysCall {
+		return nil, errors.New("kms ListKeys injected error")
+	}
+	idx, err := pageIndex(in.Marker)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &kms.ListKeysOutput{}
+	if idx < len(f.ListKeysPageOutputs) {
+		out.Keys = f.ListKeysPageOutputs[idx].Keys
+	}
+	if idx+1 < len(f.ListKeysPageOutputs) {
+		out.Truncated = true
+		out.NextMarker = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callListKeysCount++
+	return out, nil
+}
+
+// ListAliases pages ListAliasesPageOutputs.
+func (f *FakeKMSClient) ListAliases(
+	ctx context.Context,
+	in *kms.ListAliasesInput,
+	optFns ...func(*kms.Options),
+) (*kms.ListAliasesOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callListAliasesCount == f.ErrOnListAliasesCall {
+		return nil, errors.New("kms ListAliases injected error")
+	}
+	idx, err := pageIndex(in.Marker)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &kms.ListAliasesOutput{}
+	if idx < len(f.ListAliasesPageOutputs) {
+		out.Aliases = f.ListAliasesPageOutputs[idx].Aliases
+	}
+	if idx+1 < len(f.ListAliasesPageOutputs) {
+		out.Truncated = true
+		out.NextMarker = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callListAliasesCount++
+	return out, nil
+}
+
+// Reset clears all internal counters.
+func (f *FakeKMSClient) Reset() {
+	f.callListKeysCount = 0
+	f.callListAliasesCount = 0
+}
+
+// pageIndex converts a fake NextToken into a page index
+func pageIndex(token *string) (int, error) {
+	if token == nil {
+		return 0, nil
+	}
+	return strconv.Atoi(*token)
+}
+
+// GetRegion returns the configured region.
+func (f *FakeKMSClient) GetRegion() string {
+	return f.Region
+}
@@ -0,0 +1,63 @@
+package lambdaclient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// LambdaClient defines an interface for using AWS lambda client
+type LambdaClient interface {
+	GetRegion() string
+	// GetAccountSettings returns account limits and usage
+	GetAccountSettings(ctx context.Context, params *lambda.GetAccountSettingsInput, optFns ...func(*lambda.Options)) (*lambda.GetAccountSettingsOutput, error)
+	// ListFunctions lists functions in the region
+	ListFunctions(ctx context.Context, params *lambda.ListFunctionsInput, optFns ...func(*lambda.Options)) (*lambda.ListFunctionsOutput, error)
+	// GetFunctionConcurrency returns the reserved concurrency of a function
+	GetFunctionConcurrency(ctx context.Context, params *lambda.GetFunctionConcurrencyInput, optFns ...func(*lambda.Options)) (*lambda.GetFunctionConcurrencyOutput, error)
+}
+
+// LambdaClientImpl implements LambdaClient interface
+type LambdaClientImpl struct {
+	client *lambda.Client
+	region string
+}
+
+// NewLambdaClient returns a new LambdaClient
+func NewLambdaClient(cfg aws.Config, region string) (LambdaClient, error) {
+	// validate region
+	if !utils.IsValidRegion(region) {
+		return nil, errors.New("lambdaclient creation failed. invalid region")
+	}
+
+	client := lambda.NewFromConfig(cfg, func(o *lambda.Options) {
+		o.Region = region
+	})
+	return &LambdaClientImpl{
+		client: client,
+		region: region,
+	}, nil
+}
+
+// GetAccountSettings calls lambda client's GetAccountSettings method
+func (c *LambdaClientImpl) GetAccountSettings(ctx context.Context, params *lambda.GetAccountSettingsInput, optFns ...func(*lambda.Options)) (*lambda.GetAccountSettingsOutput, error) {
+	return c.client.GetAccountSettings(ctx, params, optFns...)
+}
+
+// ListFunctions calls lambda client's ListFunctions method
+func (c *LambdaClientImpl) ListFunctions(ctx context.Context, params *lambda.ListFunctionsInput, optFns ...func(*lambda.Options)) (*lambda.ListFunctionsOutput, error) {
+	return c.client.ListFunctions(ctx, params, optFns...)
+}
+
+// GetFunctionConcurrency calls lambda client's GetFunctionConcurrency method
+func (c *LambdaClientImpl) GetFunctionConcurrency(ctx context.Context, params *lambda.GetFunctionConcurrencyInput, optFns ...func(*lambda.Options)) (*lambda.GetFunctionConcurrencyOutput, error) {
+	return c.client.GetFunctionConcurrency(ctx, params, optFns...)
+}
+
+// GetRegion returns the region of the client
+func (c *LambdaClientImpl) GetRegion() string {
+	return c.region
+}
@@ -0,0 +1,127 @@
+package lambdaclient
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdaTypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+// FakeLambdaClient implements the LambdaClient methods, with AWS-style pagination.
+type FakeLambdaClient struct {
+	Region string
+
+	// simple (non-paginated) responses:
+	AccountLimit lambdaTypes.AccountLimit
+	AccountUsage lambdaTypes.AccountUsage
+	// reserved concurrency keyed by function name, missing means unreserved
+	FunctionConcurrency map[string]int32
+
+	// pages for paginator calls:
+	ListFunctionsPages []*lambda.ListFunctionsOutput
+
+	// “throw on this call index” for each paginated method:
+	ErrOnListFunctionsCall int
+
+	// simple error flags:
+	ErrAccountSettings     bool
+	ErrFunctionConcurrency bool
+
+	// internal counters:
+	callListFunctionsCount int
+}
+
+// GetAccountSettings returns the static account limit and usage or an error.
+func (f *FakeLambdaClient) GetAccountSettings(
+	ctx context.Context,
+	in *lambda.GetAccountSettingsInput,
+	optFns ...func(*lambda.Options),
+) (*lambda.GetAccountSettingsOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	if f.ErrAccountSettings {
+		return nil, errors.New("lambda GetAccountSettings injected error")
+	}
+	limit := f.AccountLimit
+	usage := f.AccountUsage
+	return &lambda.GetAccountSettingsOutput{AccountLimit: &limit, AccountUsage: &usage}, nil
+}
+
+// ListFunctions pages ListFunctionsPages, honoring Marker and injected errors.
+func (f *FakeLambdaClient) ListFunctions(
+	ctx context.Context,
+	in *lambda.ListFunctionsInput,
+	optFns ...func(*lambda.Options),
+) (*lambda.ListFunctionsOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	if f.callListFunctionsCount == f.ErrOnListFunctionsCall {
+		return nil, errors.New("lambda ListFunctions injected error")
+	}
+
+	idx := 0
+	if in.Marker != nil {
+		i, err := strconv.Atoi(*in.Marker)
+		if err != nil {
+			return nil, err
+		}
+		idx = i
+	}
+
+	var out *lambda.ListFunctionsOutput
+	if idx < len(f.ListFunctionsPages) {
+		page := f.ListFunctionsPages[idx]
+		out = &lambda.ListFunctionsOutput{Functions: page.Functions}
+	} else {
+		out = &lambda.ListFunctionsOutput{}
+	}
+
+	if idx+1 < len(f.ListFunctionsPages) {
+		out.NextMarker = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callListFunctionsCount++
+	return out, nil
+}
+
+// GetFunctionConcurrency returns the reserved concurrency for a function or an error.
+func (f *FakeLambdaClient) GetFunctionConcurrency(
+	ctx context.Context,
+	in *lambda.GetFunctionConcurrencyInput,
+	optFns ...func(*lambda.Options),
+) (*lambda.GetFunctionConcurrencyOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	if f.ErrFunctionConcurrency {
+		return nil, errors.New("lambda GetFunctionConcurrency injected error")
+	}
+	out := &lambda.GetFunctionConcurrencyOutput{}
+	if reserved, ok := f.FunctionConcurrency[aws.ToString(in.FunctionName)]; ok {
+		out.ReservedConcurrentExecutions = aws.Int32(reserved)
+	}
+	return out, nil
+}
+
+// Reset clears all internal counters.
+func (f *FakeLambdaClient) Reset() {
+	f.callListFunctionsCount = 0
+}
+
+// GetRegion returns the configured region.
+func (f *FakeLambdaClient) GetRegion() string {
+	return f.Region
+}
@@ -0,0 +1,27 @@
+package lambdaclient_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/lambdaclient"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test NewLambdaClient
+func TestNewLambdaClient_Success(t *testing.T) {
+	// Create a new LambdaClient successfully
+	client, err := lambdaclient.NewLambdaClient(aws.Config{}, "us-east-1")
+	assert.NoError(t, err, "should not return error creating lambda client")
+	assert.NotNil(t, client, "lambda client should not be nil")
+	assert.IsType(t, &lambdaclient.LambdaClientImpl{}, client, "lambda client should be of type LambdaClientImpl")
+	assert.Equal(t, "us-east-1", client.GetRegion(), "region should be us-east-1")
+}
+
+// Test NewLambdaClient with invalid region
+func TestNewLambdaClient_InvalidRegion(t *testing.T) {
+	// Create a new LambdaClient with an invalid region
+	client, err := lambdaclient.NewLambdaClient(aws.Config{}, "invalid-region")
+	assert.Error(t, err, "should return error creating lambda client")
+	assert.Nil(t, client, "lambda client should be nil")
+}
@@ -0,0 +1,86 @@
+package organizationsclient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+	orgTypes "github.com/aws/aws-sdk-go-v2/service/organizations/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// OrganizationsClient defines an interface for using AWS organizations client
+type OrganizationsClient interface {
+	GetRegion() string
+	// ListAccounts lists the accounts of the organization
+	ListAccounts(ctx context.Context, params *organizations.ListAccountsInput, optFns ...func(*organizations.Options)) (*organizations.ListAccountsOutput, error)
+	// ListRoots lists the roots of the organization
+	ListRoots(ctx context.Context, params *organizations.ListRootsInput, optFns ...func(*organizations.Options)) (*organizations.ListRootsOutput, error)
+	// ListOrganizationalUnitsForParent lists the organizational units directly under a root or OU
+	ListOrganizationalUnitsForParent(ctx context.Context, params *organizations.ListOrganizationalUnitsForParentInput, optFns ...func(*organizations.Options)) (*organizations.ListOrganizationalUnitsForParentOutput, error)
+	// ListPolicies lists the policies of the given type
+	ListPolicies(ctx context.Context, params *organizations.ListPoliciesInput, optFns ...func(*organizations.Options)) (*organizations.ListPoliciesOutput, error)
+	// ListTargetsForPolicy lists the roots, OUs and accounts a policy is attached to
+	ListTargetsForPolicy(ctx context.Context, params *organizations.ListTargetsForPolicyInput, optFns ...func(*organizations.Options)) (*organizations.ListTargetsForPolicyOutput, error)
+}
+
+// OrganizationsClientImpl implements OrganizationsClient interface
+type OrganizationsClientImpl struct {
+	client *organizations.Client
+	region string
+}
+
+// NewOrganizationsClient returns a new OrganizationsClient
+func NewOrganizationsClient(cfg aws.Config, region string) (OrganizationsClient, error) {
+	// validate region
+	if !utils.IsValidRegion(region) {
+		return nil, errors.New("organizationsclient creation failed. invalid region")
+	}
+
+	client := organizations.NewFromConfig(cfg, func(o *organizations.Options) {
+		o.Region = region
+	})
+	return &OrganizationsClientImpl{
+		client: client,
+		region: region,
+	}, nil
+}
+
+// ListAccounts calls organizations client's ListAccounts method
+func (c *OrganizationsClientImpl) ListAccounts(ctx context.Context, params *organizations.ListAccountsInput, optFns ...func(*organizations.Options)) (*organizations.ListAccountsOutput, error) {
+	return c.client.ListAccounts(ctx, params, optFns...)
+}
+
+// ListRoots calls organizations client's ListRoots method
+func (c *OrganizationsClientImpl) ListRoots(ctx context.Context, params *organizations.ListRootsInput, optFns ...func(*organizations.Options)) (*organizations.ListRootsOutput, error) {
+	return c.client.ListRoots(ctx, params, optFns...)
+}
+
+// ListOrganizationalUnitsForParent calls organizations client's ListOrganizationalUnitsForParent method
+func (c *OrganizationsClientImpl) ListOrganizationalUnitsForParent(ctx context.Context, params *organizations.ListOrganizationalUnitsForParentInput, optFns ...func(*organizations.Options)) (*organizations.ListOrganizationalUnitsForParentOutput, error) {
+	return c.client.ListOrganizationalUnitsForParent(ctx, params, optFns...)
+}
+
+// ListPolicies calls organizations client's ListPolicies method
+func (c *OrganizationsClientImpl) ListPolicies(ctx context.Context, params *organizations.ListPoliciesInput, optFns ...func(*organizations.Options)) (*organizations.ListPoliciesOutput, error) {
+	return c.client.ListPolicies(ctx, params, optFns...)
+}
+
+// ListTargetsForPolicy calls organizations client's ListTargetsForPolicy method
+func (c *OrganizationsClientImpl) ListTargetsForPolicy(ctx context.Context, params *organizations.ListTargetsForPolicyInput, optFns ...func(*organizations.Options)) (*organizations.ListTargetsForPolicyOutput, error) {
+	return c.client.ListTargetsForPolicy(ctx, params, optFns...)
+}
+
+// GetRegion returns the region of the client
+func (c *OrganizationsClientImpl) GetRegion() string {
+	return c.region
+}
+
+// IsOrganizationUnavailable reports whether err means the organization cannot be read
+// from this account, either because it is not the management (or delegated
+// administrator) account, the account is not in an organization, or permissions are missing
+func IsOrganizationUnavailable(err error) bool {
+	var notInUse *orgTypes.AWSOrganizationsNotInUseException
+	return errors.As(err, &notInUse) || utils.IsAccessDenied(err)
+}
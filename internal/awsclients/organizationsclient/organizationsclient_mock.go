@@ -0,0 +1,204 @@
+package organizationsclient
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+)
+
+// FakeOrganizationsClient implements the OrganizationsClient methods, with AWS-style pagination.
+type FakeOrganizationsClient struct {
+	Region string
+
+	// pages for paginator calls:
+	ListAccountsPageOutputs []*organizations.ListAccountsOutput
+	ListRootsPageOutputs    []*organizations.ListRootsOutput
+	// child OU pages keyed by parent (root or OU) id
+	ListOrganizationalUnitsForParentPageOutputs map[string][]*organizations.ListOrganizationalUnitsForParentOutput
+	ListPoliciesPageOutputs                     []*organizations.ListPoliciesOutput
+	// target pages keyed by policy id
+	ListTargetsForPolicyPageOutputs map[string][]*organizations.ListTargetsForPolicyOutput
+
+	// “throw on this call index” for each paginated method:
+	ErrOnListAccountsCall                     int
+	ErrOnListRootsCall                        int
+	ErrOnListOrganizationalUnitsForParentCall int
+	ErrOnListPoliciesCall                     int
+	ErrOnListTargetsForPolicyCall             int
+
+	// CallError, when set, is returned by every call, e.g. to simulate a member account
+	CallError error
+
+	// internal counters:
+	callListAccountsCount                     int
+	callListRootsCount                        int
+	callListOrganizationalUnitsForParentCount int
+	callListPoliciesCount                     int
+	callListTargetsForPolicyCount             int
+}
+
+// ListAccounts pages ListAccountsPageOutputs.
+func (f *FakeOrganizationsClient) ListAccounts(
+	ctx context.Context,
+	in *organizations.ListAccountsInput,
+	optFns ...func(*organizations.Options),
+) (*organizations.ListAccountsOutput, error) {
+	if err := f.checkCall(ctx, &f.callListAccountsCount, f.ErrOnListAccountsCall, "ListAccounts"); err != nil {
+		return nil, err
+	}
+	idx, err := pageIndex(in.NextToken)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &organizations.ListAccountsOutput{}
+	if idx < len(f.ListAccountsPageOutputs) {
+		out.Accounts = f.ListAccountsPageOutputs[idx].Accounts
+	}
+	out.NextToken = nextToken(idx, len(f.ListAccountsPageOutputs))
+	return out, nil
+}
+
+// ListRoots pages ListRootsPageOutputs.
+func (f *FakeOrganizationsClient) ListRoots(
+	ctx context.Context,
+	in *organizations.ListRootsInput,
+	optFns ...func(*organizations.Options),
+) (*organizations.ListRootsOutput, error) {
+	if err := f.checkCall(ctx, &f.callListRootsCount, f.ErrOnListRootsCall, "ListRoots"); err != nil {
+		return nil, err
+	}
+	idx, err := pageIndex(in.NextToken)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &organizations.ListRootsOutput{}
+	if idx < len(f.ListRootsPageOutputs) {
+		out.Roots = f.ListRootsPageOutputs[idx].Roots
+	}
+	out.NextToken = nextToken(idx, len(f.ListRootsPageOutputs))
+	return out, nil
+}
+
+// ListOrganizationalUnitsForParent pages the child OUs of the requested parent.
+func (f *FakeOrganizationsClient) ListOrganizationalUnitsForParent(
+	ctx context.Context,
+	in *organizations.ListOrganizationalUnitsForParentInput,
+	optFns ...func(*organizations.Options),
+) (*organizations.ListOrganizationalUnitsForParentOutput, error) {
+	if err := f.checkCall(ctx, &f.callListOrganizationalUnitsForParentCount, f.ErrOnListOrganizationalUnitsForParentCall, "ListOrganizationalUnitsForParent"); err != nil {
+		return nil, err
+	}
+	idx, err := pageIndex(in.NextToken)
+	if err != nil {
+		return nil, err
+	}
+
+	pages := f.ListOrganizationalUnitsForParentPageOutputs[aws.ToString(in.ParentId)]
+	out := &organizations.ListOrganizationalUnitsForParentOutput{}
+	if idx < len(pages) {
+		out.OrganizationalUnits = pages[idx].OrganizationalUnits
+	}
+	out.NextToken = nextToken(idx, len(pages))
+	return out, nil
+}
+
+// ListPolicies pages ListPoliciesPageOutputs, honoring the Filter like the service does.
+func (f *FakeOrganizationsClient) ListPolicies(
+	ctx context.Context,
+	in *organizations.ListPoliciesInput,
+	optFns ...func(*organizations.Options),
+) (*organizations.ListPoliciesOutput, error) {
+	if err := f.checkCall(ctx, &f.callListPoliciesCount, f.ErrOnListPoliciesCall, "ListPolicies"); err != nil {
+		return nil, err
+	}
+	idx, err := pageIndex(in.NextToken)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &organizations.ListPoliciesOutput{}
+	if idx < len(f.ListPoliciesPageOutputs) {
+		for _, policy := range f.ListPoliciesPageOutputs[idx].Policies {
+			if policy.Type == in.Filter {
+				out.Policies = append(out.Policies, policy)
+			}
+		}
+	}
+	out.NextToken = nextToken(idx, len(f.ListPoliciesPageOutputs))
+	return out, nil
+}
+
+// ListTargetsForPolicy pages the targets of the requested policy.
+func (f *FakeOrganizationsClient) ListTargetsForPolicy(
+	ctx context.Context,
+	in *organizations.ListTargetsForPolicyInput,
+	optFns ...func(*organizations.Options),
+) (*organizations.ListTargetsForPolicyOutput, error) {
+	if err := f.checkCall(ctx, &f.callListTargetsForPolicyCount, f.ErrOnListTargetsForPolicyCall, "ListTargetsForPolicy"); err != nil {
+		return nil, err
+	}
+	idx, err := pageIndex(in.NextToken)
+	if err != nil {
+		return nil, err
+	}
+
+	pages := f.ListTargetsForPolicyPageOutputs[aws.ToString(in.PolicyId)]
+	out := &organizations.ListTargetsForPolicyOutput{}
+	if idx < len(pages) {
+		out.Targets = pages[idx].Targets
+	}
+	out.NextToken = nextToken(idx, len(pages))
+	return out, nil
+}
+
+// checkCall honors ctx cancellation, CallError and the injected error index, then counts the call
+func (f *FakeOrganizationsClient) checkCall(ctx context.Context, counter *int, errOn int, name string) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	if f.CallError != nil {
+		return f.CallError
+	}
+	if *counter == errOn {
+		return errors.New("organizations " + name + " injected error")
+	}
+	*counter++
+	return nil
+}
+
+// Reset clears all internal counters.
+func (f *FakeOrganizationsClient) Reset() {
+	f.callListAccountsCount = 0
+	f.callListRootsCount = 0
+	f.callListOrganizationalUnitsForParentCount = 0
+	f.callListPoliciesCount = 0
+	f.callListTargetsForPolicyCount = 0
+}
+
+// pageIndex converts a fake NextToken into a page index
+func pageIndex(token *string) (int, error) {
+	if token == nil {
+		return 0, nil
+	}
+	return strconv.Atoi(*token)
+}
+
+// nextToken returns the fake NextToken following page idx, or nil on the last page
+func nextToken(idx, pages int) *string {
+	if idx+1 < pages {
+		return aws.String(strconv.Itoa(idx + 1))
+	}
+	return nil
+}
+
+// GetRegion returns the configured region.
+func (f *FakeOrganizationsClient) GetRegion() string {
+	return f.Region
+}
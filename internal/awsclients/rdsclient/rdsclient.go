@@ -0,0 +1,49 @@
+package rdsclient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// RDSClient defines an interface for using AWS rds client
+type RDSClient interface {
+	GetRegion() string
+	// DescribeAccountAttributes returns account quotas with their usage and maximum
+	DescribeAccountAttributes(ctx context.Context, params *rds.DescribeAccountAttributesInput, optFns ...func(*rds.Options)) (*rds.DescribeAccountAttributesOutput, error)
+}
+
+// RDSClientImpl implements RDSClient interface
+type RDSClientImpl struct {
+	client *rds.Client
+	region string
+}
+
+// NewRDSClient returns a new RDSClient
+func NewRDSClient(cfg aws.Config, region string) (RDSClient, error) {
+	// validate region
+	if !utils.IsValidRegion(region) {
+		return nil, errors.New("rdsclient creation failed. invalid region")
+	}
+
+	client := rds.NewFromConfig(cfg, func(o *rds.Options) {
+		o.Region = region
+	})
+	return &RDSClientImpl{
+		client: client,
+		region: region,
+	}, nil
+}
+
+// DescribeAccountAttributes calls rds client's DescribeAccountAttributes method
+func (c *RDSClientImpl) DescribeAccountAttributes(ctx context.Context, params *rds.DescribeAccountAttributesInput, optFns ...func(*rds.Options)) (*rds.DescribeAccountAttributesOutput, error) {
+	return c.client.DescribeAccountAttributes(ctx, params, optFns...)
+}
+
+// GetRegion returns the region of the client
+func (c *RDSClientImpl) GetRegion() string {
+	return c.region
+}
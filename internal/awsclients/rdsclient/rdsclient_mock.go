@@ -0,0 +1,43 @@
+package rdsclient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	rdsTypes "github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+// FakeRDSClient implements the RDSClient methods for testing.
+type FakeRDSClient struct {
+	Region string
+
+	// simple (non-paginated) responses:
+	AccountQuotas []rdsTypes.AccountQuota
+
+	// simple error flags:
+	ErrDescribeAccountAttributes bool
+}
+
+// DescribeAccountAttributes returns AccountQuotas or an error.
+func (f *FakeRDSClient) DescribeAccountAttributes(
+	ctx context.Context,
+	in *rds.DescribeAccountAttributesInput,
+	optFns ...func(*rds.Options),
+) (*rds.DescribeAccountAttributesOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	if f.ErrDescribeAccountAttributes {
+		return nil, errors.New("rds DescribeAccountAttributes injected error")
+	}
+	return &rds.DescribeAccountAttributesOutput{AccountQuotas: f.AccountQuotas}, nil
+}
+
+// GetRegion returns the configured region.
+func (f *FakeRDSClient) GetRegion() string {
+	return f.Region
+}
@@ -0,0 +1,63 @@
+package route53client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// Route53Client defines an interface for using AWS route53 client
+type Route53Client interface {
+	GetRegion() string
+	// GetAccountLimit returns the usage and limit of an account level route53 resource
+	GetAccountLimit(ctx context.Context, params *route53.GetAccountLimitInput, optFns ...func(*route53.Options)) (*route53.GetAccountLimitOutput, error)
+	// GetHostedZoneLimit returns the usage and limit of a resource in a hosted zone
+	GetHostedZoneLimit(ctx context.Context, params *route53.GetHostedZoneLimitInput, optFns ...func(*route53.Options)) (*route53.GetHostedZoneLimitOutput, error)
+	// ListHostedZones lists the hosted zones of the account
+	ListHostedZones(ctx context.Context, params *route53.ListHostedZonesInput, optFns ...func(*route53.Options)) (*route53.ListHostedZonesOutput, error)
+}
+
+// Route53ClientImpl implements Route53Client interface
+type Route53ClientImpl struct {
+	client *route53.Client
+	region string
+}
+
+// NewRoute53Client returns a new Route53Client
+func NewRoute53Client(cfg aws.Config, region string) (Route53Client, error) {
+	// validate region
+	if !utils.IsValidRegion(region) {
+		return nil, errors.New("route53client creation failed. invalid region")
+	}
+
+	client := route53.NewFromConfig(cfg, func(o *route53.Options) {
+		o.Region = region
+	})
+	return &Route53ClientImpl{
+		client: client,
+		region: region,
+	}, nil
+}
+
+// GetAccountLimit calls route53 client's GetAccountLimit method
+func (c *Route53ClientImpl) GetAccountLimit(ctx context.Context, params *route53.GetAccountLimitInput, optFns ...func(*route53.Options)) (*route53.GetAccountLimitOutput, error) {
+	return c.client.GetAccountLimit(ctx, params, optFns...)
+}
+
+// GetHostedZoneLimit calls route53 client's GetHostedZoneLimit method
+func (c *Route53ClientImpl) GetHostedZoneLimit(ctx context.Context, params *route53.GetHostedZoneLimitInput, optFns ...func(*route53.Options)) (*route53.GetHostedZoneLimitOutput, error) {
+	return c.client.GetHostedZoneLimit(ctx, params, optFns...)
+}
+
+// ListHostedZones calls route53 client's ListHostedZones method
+func (c *Route53ClientImpl) ListHostedZones(ctx context.Context, params *route53.ListHostedZonesInput, optFns ...func(*route53.Options)) (*route53.ListHostedZonesOutput, error) {
+	return c.client.ListHostedZones(ctx, params, optFns...)
+}
+
+// GetRegion returns the region of the client
+func (c *Route53ClientImpl) GetRegion() string {
+	return c.region
+}
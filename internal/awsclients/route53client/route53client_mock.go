@@ -0,0 +1,136 @@
+package route53client
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	r53Types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+)
+
+// FakeRoute53Client implements the Route53Client methods, with AWS-style marker pagination.
+type FakeRoute53Client struct {
+	Region string
+
+	// simple (non-paginated) responses:
+	AccountLimits map[r53Types.AccountLimitType]*route53.GetAccountLimitOutput
+	// hosted zone limits keyed by hosted zone id, then limit type
+	HostedZoneLimits map[string]map[r53Types.HostedZoneLimitType]*route53.GetHostedZoneLimitOutput
+
+	// pages for paginator calls:
+	ListHostedZonesPageOutputs []*route53.ListHostedZonesOutput
+
+	// “throw on this call index” for each counted method:
+	ErrOnListHostedZonesCall    int
+	ErrOnGetHostedZoneLimitCall int
+
+	// simple error flags:
+	ErrGetAccountLimit bool
+
+	// hosted zone ids passed to GetHostedZoneLimit, in call order
+	RequestedHostedZoneIds []string
+
+	// internal counters:
+	callListHostedZonesCount    int
+	callGetHostedZoneLimitCount int
+}
+
+// GetAccountLimit returns the AccountLimits entry for the requested type or an error.
+func (f *FakeRoute53Client) GetAccountLimit(
+	ctx context.Context,
+	in *route53.GetAccountLimitInput,
+	optFns ...func(*route53.Options),
+) (*route53.GetAccountLimitOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	if f.ErrGetAccountLimit {
+		return nil, errors.New("route53 GetAccountLimit injected error")
+	}
+	out, ok := f.AccountLimits[in.Type]
+	if !ok {
+		return nil, errors.New("route53 GetAccountLimit unknown limit type")
+	}
+	return out, nil
+}
+
+// GetHostedZoneLimit returns the HostedZoneLimits entry for the requested zone and type.
+func (f *FakeRoute53Client) GetHostedZoneLimit(
+	ctx context.Context,
+	in *route53.GetHostedZoneLimitInput,
+	optFns ...func(*route53.Options),
+) (*route53.GetHostedZoneLimitOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callGetHostedZoneLimitCount == f.ErrOnGetHostedZoneLimitCall {
+		return nil, errors.New("route53 GetHostedZoneLimit injected error")
+	}
+	f.callGetHostedZoneLimitCount++
+
+	zoneId := aws.ToString(in.HostedZoneId)
+	f.RequestedHostedZoneIds = append(f.RequestedHostedZoneIds, zoneId)
+	out, ok := f.HostedZoneLimits[zoneId][in.Type]
+	if !ok {
+		return nil, errors.New("route53 GetHostedZoneLimit no such hosted zone")
+	}
+	return out, nil
+}
+
+// ListHostedZones pages ListHostedZonesPageOutputs using Marker/NextMarker.
+func (f *FakeRoute53Client) ListHostedZones(
+	ctx context.Context,
+	in *route53.ListHostedZonesInput,
+	optFns ...func(*route53.Options),
+) (*route53.ListHostedZonesOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callListHostedZonesCount == f.ErrOnListHostedZonesCall {
+		return nil, errors.New("route53 ListHostedZones injected error")
+	}
+	idx, err := pageIndex(in.Marker)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &route53.ListHostedZonesOutput{}
+	if idx < len(f.ListHostedZonesPageOutputs) {
+		out.HostedZones = f.ListHostedZonesPageOutputs[idx].HostedZones
+	}
+	if idx+1 < len(f.ListHostedZonesPageOutputs) {
+		out.IsTruncated = true
+		out.NextMarker = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callListHostedZonesCount++
+	return out, nil
+}
+
+// Reset clears all internal counters and recorded requests.
+func (f *FakeRoute53Client) Reset() {
+	f.callListHostedZonesCount = 0
+	f.callGetHostedZoneLimitCount = 0
+	f.RequestedHostedZoneIds = nil
+}
+
+// pageIndex converts a fake NextMarker into a page index
+func pageIndex(token *string) (int, error) {
+	if token == nil {
+		return 0, nil
+	}
+	return strconv.Atoi(*token)
+}
+
+// GetRegion returns the configured region.
+func (f *FakeRoute53Client) GetRegion() string {
+	return f.Region
+}
@@ -16,6 +16,10 @@ type S3Client interface {
 	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
 	// putObject
 	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+	// ListBuckets lists the buckets owned by the account
+	ListBuckets(ctx context.Context, params *s3.ListBucketsInput, optFns ...func(*s3.Options)) (*s3.ListBucketsOutput, error)
+	// ListObjectsV2 lists the objects of a bucket
+	ListObjectsV2(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error)
 }
 
 type S3ClientImpl struct {
@@ -49,6 +53,16 @@ func (s *S3ClientImpl) PutObject(ctx context.Context, params *s3.PutObjectInput,
 	return s.client.PutObject(ctx, params, optFns...)
 }
 
+// list buckets
+func (s *S3ClientImpl) ListBuckets(ctx context.Context, params *s3.ListBucketsInput, optFns ...func(*s3.Options)) (*s3.ListBucketsOutput, error) {
+	return s.client.ListBuckets(ctx, params, optFns...)
+}
+
+// list objects
+func (s *S3ClientImpl) ListObjectsV2(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+	return s.client.ListObjectsV2(ctx, params, optFns...)
+}
+
 // get region
 func (s *S3ClientImpl) GetRegion() string {
 	return s.region
@@ -0,0 +1,144 @@
+package s3client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// FakeS3Client implements the S3Client methods, with AWS-style pagination for ListBuckets
+// and ListObjectsV2.
+type FakeS3Client struct {
+	Region string
+
+	// Objects holds the object bodies by key, served by GetObject and ListObjectsV2
+	Objects map[string][]byte
+	// ObjectsPageSize is the number of keys per ListObjectsV2 page, 0 returns one page
+	ObjectsPageSize int
+
+	// pages for paginator calls:
+	ListBucketsPageOutputs []*s3.ListBucketsOutput
+
+	// “throw on this call index” for each paginated method:
+	ErrOnListBucketsCall int
+	// ListBucketsError, when set, is returned by ListBuckets instead of a page
+	ListBucketsError error
+
+	// internal counters:
+	callListBucketsCount int
+}
+
+// GetObject returns the body of a key of Objects.
+func (f *FakeS3Client) GetObject(
+	ctx context.Context,
+	in *s3.GetObjectInput,
+	optFns ...func(*s3.Options),
+) (*s3.GetObjectOutput, error) {
+	body, ok := f.Objects[aws.ToString(in.Key)]
+	if !ok {
+		return nil, &types.NoSuchKey{Message: in.Key}
+	}
+	return &s3.GetObjectOutput{Body: io.NopCloser(bytes.NewReader(body))}, nil
+}
+
+// ListObjectsV2 pages the keys of Objects matching the prefix, in order, using ContinuationToken.
+func (f *FakeS3Client) ListObjectsV2(
+	ctx context.Context,
+	in *s3.ListObjectsV2Input,
+	optFns ...func(*s3.Options),
+) (*s3.ListObjectsV2Output, error) {
+	var keys []string
+	for key := range f.Objects {
+		if strings.HasPrefix(key, aws.ToString(in.Prefix)) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	idx, err := pageIndex(in.ContinuationToken)
+	if err != nil {
+		return nil, err
+	}
+	size := f.ObjectsPageSize
+	if size <= 0 {
+		size = max(len(keys), 1)
+	}
+	start, end := min(idx*size, len(keys)), min((idx+1)*size, len(keys))
+	out := &s3.ListObjectsV2Output{}
+	for _, key := range keys[start:end] {
+		out.Contents = append(out.Contents, types.Object{Key: aws.String(key), Size: aws.Int64(int64(len(f.Objects[key])))})
+	}
+	if end < len(keys) {
+		out.IsTruncated = aws.Bool(true)
+		out.NextContinuationToken = aws.String(strconv.Itoa(idx + 1))
+	}
+	return out, nil
+}
+
+// PutObject is not used by the quota jobs.
+func (f *FakeS3Client) PutObject(
+	ctx context.Context,
+	in *s3.PutObjectInput,
+	optFns ...func(*s3.Options),
+) (*s3.PutObjectOutput, error) {
+	return nil, errors.New("s3 PutObject not implemented by fake")
+}
+
+// ListBuckets pages ListBucketsPageOutputs using ContinuationToken.
+func (f *FakeS3Client) ListBuckets(
+	ctx context.Context,
+	in *s3.ListBucketsInput,
+	optFns ...func(*s3.Options),
+) (*s3.ListBucketsOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.ListBucketsError != nil {
+		return nil, f.ListBucketsError
+	}
+	if f.callListBucketsCount == f.ErrOnListBucketsCall {
+		return nil, errors.New("s3 ListBuckets injected error")
+	}
+	idx, err := pageIndex(in.ContinuationToken)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &s3.ListBucketsOutput{}
+	if idx < len(f.ListBucketsPageOutputs) {
+		out.Buckets = f.ListBucketsPageOutputs[idx].Buckets
+	}
+	if idx+1 < len(f.ListBucketsPageOutputs) {
+		out.ContinuationToken = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callListBucketsCount++
+	return out, nil
+}
+
+// Reset clears all internal counters.
+func (f *FakeS3Client) Reset() {
+	f.callListBucketsCount = 0
+}
+
+// pageIndex converts a fake ContinuationToken into a page index
+func pageIndex(token *string) (int, error) {
+	if token == nil {
+		return 0, nil
+	}
+	return strconv.Atoi(*token)
+}
+
+// GetRegion returns the configured region.
+func (f *FakeS3Client) GetRegion() string {
+	return f.Region
+}
@@ -0,0 +1,49 @@
+package secretsmanagerclient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// SecretsManagerClient defines an interface for using AWS secretsmanager client
+type SecretsManagerClient interface {
+	GetRegion() string
+	// ListSecrets lists the secrets of the region
+	ListSecrets(ctx context.Context, params *secretsmanager.ListSecretsInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.ListSecretsOutput, error)
+}
+
+// SecretsManagerClientImpl implements SecretsManagerClient interface
+type SecretsManagerClientImpl struct {
+	client *secretsmanager.Client
+	region string
+}
+
+// NewSecretsManagerClient returns a new SecretsManagerClient
+func NewSecretsManagerClient(cfg aws.Config, region string) (SecretsManagerClient, error) {
+	// validate region
+	if !utils.IsValidRegion(region) {
+		return nil, errors.New("secretsmanagerclient creation failed. invalid region")
+	}
+
+	client := secretsmanager.NewFromConfig(cfg, func(o *secretsmanager.Options) {
+		o.Region = region
+	})
+	return &SecretsManagerClientImpl{
+		client: client,
+		region: region,
+	}, nil
+}
+
+// ListSecrets calls secretsmanager client's ListSecrets method
+func (c *SecretsManagerClientImpl) ListSecrets(ctx context.Context, params *secretsmanager.ListSecretsInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.ListSecretsOutput, error) {
+	return c.client.ListSecrets(ctx, params, optFns...)
+}
+
+// GetRegion returns the region of the client
+func (c *SecretsManagerClientImpl) GetRegion() string {
+	return c.region
+}
@@ -0,0 +1,72 @@
+package secretsmanagerclient
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+)
+
+// FakeSecretsManagerClient implements the SecretsManagerClient methods, with AWS-style pagination.
+type FakeSecretsManagerClient struct {
+	Region string
+
+	// pages for paginator calls:
+	ListSecretsPageOutputs []*secretsmanager.ListSecretsOutput
+
+	// “throw on this call index” for each paginated method:
+	ErrOnListSecretsCall int
+
+	// internal counters:
+	callListSecretsCount int
+}
+
+// ListSecrets pages ListSecretsPageOutputs.
+func (f *FakeSecretsManagerClient) ListSecrets(
+	ctx context.Context,
+	in *secretsmanager.ListSecretsInput,
+	optFns ...func(*secretsmanager.Options),
+) (*secretsmanager.ListSecretsOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callListSecretsCount == f.ErrOnListSecretsCall {
+		return nil, errors.New("secretsmanager ListSecrets injected error")
+	}
+	idx, err := pageIndex(in.NextToken)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &secretsmanager.ListSecretsOutput{}
+	if idx < len(f.ListSecretsPageOutputs) {
+		out.SecretList = f.ListSecretsPageOutputs[idx].SecretList
+	}
+	if idx+1 < len(f.ListSecretsPageOutputs) {
+		out.NextToken = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callListSecretsCount++
+	return out, nil
+}
+
+// Reset clears all internal counters.
+func (f *FakeSecretsManagerClient) Reset() {
+	f.callListSecretsCount = 0
+}
+
+// pageIndex converts a fake NextToken into a page index
+func pageIndex(token *string) (int, error) {
+	if token == nil {
+		return 0, nil
+	}
+	return strconv.Atoi(*token)
+}
+
+// GetRegion returns the configured region.
+func (f *FakeSecretsManagerClient) GetRegion() string {
+	return f.Region
+}
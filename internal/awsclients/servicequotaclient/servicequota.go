@@ -6,6 +6,7 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/servicequotas"
+	serviceQuotaTypes "github.com/aws/aws-sdk-go-v2/service/servicequotas/types"
 	"github.com/outofoffice3/aws-samples/geras/internal/utils"
 )
 
@@ -43,3 +44,35 @@ func (s *ServiceQuotasImpl) GetServiceQuota(ctx context.Context, params *service
 func (s *ServiceQuotasImpl) GetRegion() string {
 	return s.region
 }
+
+// QuotaSpec identifies a single service quota along with the AWS default
+// value used when service quotas cannot report an applied value.
+type QuotaSpec struct {
+	ServiceCode string
+	QuotaCode   string
+	Default     float64
+}
+
+// GetQuotaValue returns the applied value for the given quota.  If the spec has
+// no quota code, or service quotas returns NoSuchResourceException (quotas that
+// were never adjusted often have no applied value), the spec default is returned.
+func GetQuotaValue(ctx context.Context, client ServiceQuotasClient, spec QuotaSpec) (float64, error) {
+	if spec.QuotaCode == "" {
+		return spec.Default, nil
+	}
+	output, err := client.GetServiceQuota(ctx, &servicequotas.GetServiceQuotaInput{
+		ServiceCode: aws.String(spec.ServiceCode),
+		QuotaCode:   aws.String(spec.QuotaCode),
+	})
+	if err != nil {
+		var notFound *serviceQuotaTypes.NoSuchResourceException
+		if errors.As(err, &notFound) && spec.Default > 0 {
+			return spec.Default, nil
+		}
+		return 0, err
+	}
+	if output.Quota == nil || output.Quota.Value == nil || *output.Quota.Value <= 0 {
+		return spec.Default, nil
+	}
+	return *output.Quota.Value, nil
+}
@@ -14,15 +14,30 @@ type FakeServiceQuotaClient struct {
 	Region      string
 	QuotaValue  float64
 	ReturnError bool
+	// QuotaValues overrides QuotaValue per quota code
+	QuotaValues map[string]float64
+	// MissingQuotaCodes return NoSuchResourceException for the given quota codes
+	MissingQuotaCodes map[string]bool
+	// RequestedQuotaCodes records every quota code that was requested
+	RequestedQuotaCodes []string
 }
 
 func (f *FakeServiceQuotaClient) GetServiceQuota(ctx context.Context, input *servicequotas.GetServiceQuotaInput, optFns ...func(*servicequotas.Options)) (*servicequotas.GetServiceQuotaOutput, error) {
+	code := aws.ToString(input.QuotaCode)
+	f.RequestedQuotaCodes = append(f.RequestedQuotaCodes, code)
 	if f.ReturnError {
 		return nil, errors.New("service quota error")
 	}
+	if f.MissingQuotaCodes[code] {
+		return nil, &serviceQuotaTypes.NoSuchResourceException{Message: aws.String("quota not found")}
+	}
+	value := f.QuotaValue
+	if v, ok := f.QuotaValues[code]; ok {
+		value = v
+	}
 	return &servicequotas.GetServiceQuotaOutput{
 		Quota: &serviceQuotaTypes.ServiceQuota{
-			Value: aws.Float64(f.QuotaValue),
+			Value: aws.Float64(value),
 		},
 	}, nil
 }
@@ -40,3 +40,34 @@ func TestGetServiceQuotaInterface(t *testing.T) {
 	assert.IsType(t, &servicequotas.GetServiceQuotaOutput{}, quota, "quota is not of type ServiceQuota")
 	assert.Equal(t, "us-east-1", client.GetRegion(), "region is not us-east-1")
 }
+
+// TestGetQuotaValue tests resolving quota values with defaults
+func TestGetQuotaValue(t *testing.T) {
+	fake := &servicequotaclient.FakeServiceQuotaClient{
+		Region:            "us-east-1",
+		QuotaValue:        10,
+		QuotaValues:       map[string]float64{"L-2": 25},
+		MissingQuotaCodes: map[string]bool{"L-3": true},
+	}
+
+	// applied value for the quota code
+	value, err := servicequotaclient.GetQuotaValue(context.Background(), fake, servicequotaclient.QuotaSpec{ServiceCode: "svc", QuotaCode: "L-2", Default: 5})
+	assert.NoError(t, err, "should not error getting quota value")
+	assert.Equal(t, 25.0, value, "should return applied quota value")
+
+	// missing quota falls back to the default
+	value, err = servicequotaclient.GetQuotaValue(context.Background(), fake, servicequotaclient.QuotaSpec{ServiceCode: "svc", QuotaCode: "L-3", Default: 5})
+	assert.NoError(t, err, "should not error on missing quota")
+	assert.Equal(t, 5.0, value, "should return default quota value")
+
+	// no quota code never calls service quotas
+	value, err = servicequotaclient.GetQuotaValue(context.Background(), fake, servicequotaclient.QuotaSpec{Default: 7})
+	assert.NoError(t, err, "should not error without quota code")
+	assert.Equal(t, 7.0, value, "should return default quota value")
+	assert.Equal(t, []string{"L-2", "L-3"}, fake.RequestedQuotaCodes, "should only call service quotas for quota codes")
+
+	// other errors are returned
+	fake.ReturnError = true
+	_, err = servicequotaclient.GetQuotaValue(context.Background(), fake, servicequotaclient.QuotaSpec{ServiceCode: "svc", QuotaCode: "L-2", Default: 5})
+	assert.Error(t, err, "should return service quota error")
+}
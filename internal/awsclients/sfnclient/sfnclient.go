@@ -0,0 +1,56 @@
+package sfnclient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// SFNClient defines an interface for using AWS sfn client
+type SFNClient interface {
+	GetRegion() string
+	// ListStateMachines lists the state machines of the region
+	ListStateMachines(ctx context.Context, params *sfn.ListStateMachinesInput, optFns ...func(*sfn.Options)) (*sfn.ListStateMachinesOutput, error)
+	// ListActivities lists the activities of the region
+	ListActivities(ctx context.Context, params *sfn.ListActivitiesInput, optFns ...func(*sfn.Options)) (*sfn.ListActivitiesOutput, error)
+}
+
+// SFNClientImpl implements SFNClient interface
+type SFNClientImpl struct {
+	client *sfn.Client
+	region string
+}
+
+// NewSFNClient returns a new SFNClient
+func NewSFNClient(cfg aws.Config, region string) (SFNClient, error) {
+	// validate region
+	if !utils.IsValidRegion(region) {
+		return nil, errors.New("sfnclient creation failed. invalid region")
+	}
+
+	client := sfn.NewFromConfig(cfg, func(o *sfn.Options) {
+		o.Region = region
+	})
+	return &SFNClientImpl{
+		client: client,
+		region: region,
+	}, nil
+}
+
+// ListStateMachines calls sfn client's ListStateMachines method
+func (c *SFNClientImpl) ListStateMachines(ctx context.Context, params *sfn.ListStateMachinesInput, optFns ...func(*sfn.Options)) (*sfn.ListStateMachinesOutput, error) {
+	return c.client.ListStateMachines(ctx, params, optFns...)
+}
+
+// ListActivities calls sfn client's ListActivities method
+func (c *SFNClientImpl) ListActivities(ctx context.Context, params *sfn.ListActivitiesInput, optFns ...func(*sfn.Options)) (*sfn.ListActivitiesOutput, error) {
+	return c.client.ListActivities(ctx, params, optFns...)
+}
+
+// GetRegion returns the region of the client
+func (c *SFNClientImpl) GetRegion() string {
+	return c.region
+}
@@ -0,0 +1,106 @@
+package sfnclient
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+)
+
+// FakeSFNClient implements the SFNClient methods, with AWS-style pagination.
+type FakeSFNClient struct {
+	Region string
+
+	// pages for paginator calls:
+	ListStateMachinesPageOutputs []*sfn.ListStateMachinesOutput
+	ListActivitiesPageOutputs    []*sfn.ListActivitiesOutput
+
+	// “throw on this call index” for each paginated method:
+	ErrOnListStateMachinesCall int
+	ErrOnListActivitiesCall    int
+
+	// internal counters:
+	callListStateMachinesCount int
+	callListActivitiesCount    int
+}
+
+// ListStateMachines pages ListStateMachinesPageOutputs.
+func (f *FakeSFNClient) ListStateMachines(
+	ctx context.Context,
+	in *sfn.ListStateMachinesInput,
+	optFns ...func(*sfn.Options),
+) (*sfn.ListStateMachinesOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callListStateMachinesCount == f.ErrOnListStateMachinesCall {
+		return nil, errors.New("sfn ListStateMachines injected error")
+	}
+	idx, err := pageIndex(in.NextToken)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &sfn.ListStateMachinesOutput{}
+	if idx < len(f.ListStateMachinesPageOutputs) {
+		out.StateMachines = f.ListStateMachinesPageOutputs[idx].StateMachines
+	}
+	if idx+1 < len(f.ListStateMachinesPageOutputs) {
+		out.NextToken = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callListStateMachinesCount++
+	return out, nil
+}
+
+// ListActivities pages ListActivitiesPageOutputs.
+func (f *FakeSFNClient) ListActivities(
+	ctx context.Context,
+	in *sfn.ListActivitiesInput,
+	optFns ...func(*sfn.Options),
+) (*sfn.ListActivitiesOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callListActivitiesCount == f.ErrOnListActivitiesCall {
+		return nil, errors.New("sfn ListActivities injected error")
+	}
+	idx, err := pageIndex(in.NextToken)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &sfn.ListActivitiesOutput{}
+	if idx < len(f.ListActivitiesPageOutputs) {
+		out.Activities = f.ListActivitiesPageOutputs[idx].Activities
+	}
+	if idx+1 < len(f.ListActivitiesPageOutputs) {
+		out.NextToken = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callListActivitiesCount++
+	return out, nil
+}
+
+// Reset clears all internal counters.
+func (f *FakeSFNClient) Reset() {
+	f.callListStateMachinesCount = 0
+	f.callListActivitiesCount = 0
+}
+
+// pageIndex converts a fake NextToken into a page index
+func pageIndex(token *string) (int, error) {
+	if token == nil {
+		return 0, nil
+	}
+	return strconv.Atoi(*token)
+}
+
+// GetRegion returns the configured region.
+func (f *FakeSFNClient) GetRegion() string {
+	return f.Region
+}
@@ -0,0 +1,56 @@
+package snsclient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// SNSClient defines an interface for using AWS sns client
+type SNSClient interface {
+	GetRegion() string
+	// ListTopics lists the topics of the region
+	ListTopics(ctx context.Context, params *sns.ListTopicsInput, optFns ...func(*sns.Options)) (*sns.ListTopicsOutput, error)
+	// ListSubscriptions lists the subscriptions of every topic in the region
+	ListSubscriptions(ctx context.Context, params *sns.ListSubscriptionsInput, optFns ...func(*sns.Options)) (*sns.ListSubscriptionsOutput, error)
+}
+
+// SNSClientImpl implements SNSClient interface
+type SNSClientImpl struct {
+	client *sns.Client
+	region string
+}
+
+// NewSNSClient returns a new SNSClient
+func NewSNSClient(cfg aws.Config, region string) (SNSClient, error) {
+	// validate region
+	if !utils.IsValidRegion(region) {
+		return nil, errors.New("snsclient creation failed. invalid region")
+	}
+
+	client := sns.NewFromConfig(cfg, func(o *sns.Options) {
+		o.Region = region
+	})
+	return &SNSClientImpl{
+		client: client,
+		region: region,
+	}, nil
+}
+
+// ListTopics calls sns client's ListTopics method
+func (c *SNSClientImpl) ListTopics(ctx context.Context, params *sns.ListTopicsInput, optFns ...func(*sns.Options)) (*sns.ListTopicsOutput, error) {
+	return c.client.ListTopics(ctx, params, optFns...)
+}
+
+// ListSubscriptions calls sns client's ListSubscriptions method
+func (c *SNSClientImpl) ListSubscriptions(ctx context.Context, params *sns.ListSubscriptionsInput, optFns ...func(*sns.Options)) (*sns.ListSubscriptionsOutput, error) {
+	return c.client.ListSubscriptions(ctx, params, optFns...)
+}
+
+// GetRegion returns the region of the client
+func (c *SNSClientImpl) GetRegion() string {
+	return c.region
+}
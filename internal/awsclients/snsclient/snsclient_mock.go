@@ -0,0 +1,106 @@
+package snsclient
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+)
+
+// FakeSNSClient implements the SNSClient methods, with AWS-style pagination.
+type FakeSNSClient struct {
+	Region string
+
+	// pages for paginator calls:
+	ListTopicsPageOutputs        []*sns.ListTopicsOutput
+	ListSubscriptionsPageOutputs []*sns.ListSubscriptionsOutput
+
+	// “throw on this call index” for each paginated method:
+	ErrOnListTopicsCall        int
+	ErrOnListSubscriptionsCall int
+
+	// internal counters:
+	callListTopicsCount        int
+	callListSubscriptionsCount int
+}
+
+// ListTopics pages ListTopicsPageOutputs.
+func (f *FakeSNSClient) ListTopics(
+	ctx context.Context,
+	in *sns.ListTopicsInput,
+	optFns ...func(*sns.Options),
+) (*sns.ListTopicsOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callListTopicsCount == f.ErrOnListTopicsCall {
+		return nil, errors.New("sns ListTopics injected error")
+	}
+	idx, err := pageIndex(in.NextToken)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &sns.ListTopicsOutput{}
+	if idx < len(f.ListTopicsPageOutputs) {
+		out.Topics = f.ListTopicsPageOutputs[idx].Topics
+	}
+	if idx+1 < len(f.ListTopicsPageOutputs) {
+		out.NextToken = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callListTopicsCount++
+	return out, nil
+}
+
+// ListSubscriptions pages ListSubscriptionsPageOutputs.
+func (f *FakeSNSClient) ListSubscriptions(
+	ctx context.Context,
+	in *sns.ListSubscriptionsInput,
+	optFns ...func(*sns.Options),
+) (*sns.ListSubscriptionsOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if f.callListSubscriptionsCount == f.ErrOnListSubscriptionsCall {
+		return nil, errors.New("sns ListSubscriptions injected error")
+	}
+	idx, err := pageIndex(in.NextToken)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &sns.ListSubscriptionsOutput{}
+	if idx < len(f.ListSubscriptionsPageOutputs) {
+		out.Subscriptions = f.ListSubscriptionsPageOutputs[idx].Subscriptions
+	}
+	if idx+1 < len(f.ListSubscriptionsPageOutputs) {
+		out.NextToken = aws.String(strconv.Itoa(idx + 1))
+	}
+	f.callListSubscriptionsCount++
+	return out, nil
+}
+
+// Reset clears all internal counters.
+func (f *FakeSNSClient) Reset() {
+	f.callListTopicsCount = 0
+	f.callListSubscriptionsCount = 0
+}
+
+// pageIndex converts a fake NextToken into a page index
+func pageIndex(token *string) (int, error) {
+	if token == nil {
+		return 0, nil
+	}
+	return strconv.Atoi(*token)
+}
+
+// GetRegion returns the configured region.
+func (f *FakeSNSClient) GetRegion() string {
+	return f.Region
+}
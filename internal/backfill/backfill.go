@@ -0,0 +1,303 @@
+package backfill
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	cloudtrailemfbatcher "github.com/outofoffice3/aws-samples/geras/internal/emfbatcher/cloudtrail"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	"github.com/outofoffice3/aws-samples/geras/internal/serviceconfig"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+)
+
+const (
+	// MaxEventAge is the age past which CloudWatch Logs rejects log events
+	MaxEventAge = 14 * 24 * time.Hour
+	// ageMargin keeps events from ageing past MaxEventAge while the backfill runs
+	ageMargin = time.Hour
+	// maxBatchSpan is the longest time span of the log events of one PutLogEvents call
+	maxBatchSpan = 24 * time.Hour
+)
+
+var (
+	ErrNoSource    = errors.New("backfill source is nil")
+	ErrNoBatcher   = errors.New("backfill batcher is nil")
+	ErrNoAllowList = errors.New("backfill allow list is nil")
+	ErrNoRegions   = errors.New("backfill regions are empty")
+)
+
+// Config configures a Backfiller.  Now defaults to time.Now and End to Now.
+type Config struct {
+	Source    Source
+	Batcher   cloudtrailemfbatcher.FlushingEMFFileBatcher
+	AllowList *serviceconfig.RateLimitAllowList
+	// Regions with a CloudWatch Logs client, the events of other regions are skipped
+	Regions []string
+	// Start and End bound the event times, Start is raised to the CloudWatch Logs age limit
+	Start  time.Time
+	End    time.Time
+	Now    time.Time
+	Logger logger.Logger
+}
+
+// Stats counts the files and events of a backfill
+type Stats struct {
+	Files         int
+	FailedFiles   int
+	Events        int
+	Counted       int
+	Unlisted      int
+	TooOld        int
+	OutOfRange    int
+	UnknownRegion int
+}
+
+// span is the time span of the events written to a region since its last flush
+type span struct {
+	first, last time.Time
+}
+
+// Backfiller reads CloudTrail log files and feeds the events of the rate limit apis to a
+// file batcher, so they go through the same EMF conversion and flush as live events, with
+// their original timestamps.
+type Backfiller struct {
+	source    Source
+	batcher   cloudtrailemfbatcher.FlushingEMFFileBatcher
+	allowList *serviceconfig.RateLimitAllowList
+	regions   map[string]struct{}
+	start     time.Time
+	end       time.Time
+	logger    logger.Logger
+
+	spans map[string]*span // region -> events written since the last flush
+	stats Stats
+}
+
+// NewBackfiller validates the config and returns a Backfiller
+func NewBackfiller(config Config) (*Backfiller, error) {
+	switch {
+	case config.Source == nil:
+		return nil, ErrNoSource
+	case config.Batcher == nil:
+		return nil, ErrNoBatcher
+	case config.AllowList == nil:
+		return nil, ErrNoAllowList
+	case len(config.Regions) == 0:
+		return nil, ErrNoRegions
+	}
+	if config.Logger == nil {
+		config.Logger = logger.Get()
+	}
+	if config.Now.IsZero() {
+		config.Now = time.Now()
+	}
+	if config.End.IsZero() || config.End.After(config.Now) {
+		config.End = config.Now
+	}
+	// CloudWatch Logs rejects events older than 14 days, keep a margin for the run itself
+	if oldest := config.Now.Add(-MaxEventAge + ageMargin); config.Start.Before(oldest) {
+		config.Start = oldest
+	}
+	if !config.Start.Before(config.End) {
+		return nil, fmt.Errorf("backfill start %v is not before end %v", config.Start, config.End)
+	}
+
+	regions := make(map[string]struct{}, len(config.Regions))
+	for _, r := range config.Regions {
+		regions[r] = struct{}{}
+	}
+	return &Backfiller{
+		source:    config.Source,
+		batcher:   config.Batcher,
+		allowList: config.AllowList,
+		regions:   regions,
+		start:     config.Start,
+		end:       config.End,
+		logger:    config.Logger,
+		spans:     make(map[string]*span),
+	}, nil
+}
+
+// Run reads every log file of the source, oldest delivery first, and flushes every region
+// once done.  A file that cannot be read is logged and skipped.  It returns the regions
+// that could not be flushed as an error, their records are kept by the batcher.
+func (b *Backfiller) Run(ctx context.Context) (Stats, error) {
+	names, err := b.source.List(ctx)
+	if err != nil {
+		return b.stats, fmt.Errorf("cannot list log files: %w", err)
+	}
+	var files []string
+	for _, name := range names {
+		if isLogFile(name) {
+			files = append(files, name)
+		}
+	}
+	sortByDelivery(files)
+	b.logger.Info("backfilling %d log files from %v to %v", len(files), b.start, b.end)
+
+	for _, name := range files {
+		if err := ctx.Err(); err != nil {
+			return b.stats, err
+		}
+		b.stats.Files++
+		if err := b.readFile(ctx, name); err != nil {
+			b.stats.FailedFiles++
+			b.logger.Error("cannot read log file %s, skipping it: %v", name, err)
+		}
+	}
+
+	var errs []error
+	for region := range b.spans {
+		if err := b.batcher.FlushRegion(ctx, region); err != nil {
+			errs = append(errs, fmt.Errorf("flush region %s: %w", region, err))
+		}
+	}
+	return b.stats, errors.Join(errs...)
+}
+
+// readFile feeds the events of one log file to the batcher.  The whole file is decoded
+// first, so a file that fails midway adds none of its events.
+func (b *Backfiller) readFile(ctx context.Context, name string) error {
+	f, err := b.source.Open(ctx, name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	var records []sharedtypes.CloudTrailEvent
+	if err := ReadRecords(f, func(ct sharedtypes.CloudTrailEvent) {
+		records = append(records, ct)
+	}); err != nil {
+		return err
+	}
+	for _, ct := range records {
+		b.add(ctx, ct)
+	}
+	return nil
+}
+
+// add filters one event and feeds it to the batcher
+func (b *Backfiller) add(ctx context.Context, ct sharedtypes.CloudTrailEvent) {
+	b.stats.Events++
+	switch {
+	case !b.allowList.Allowed(ct.EventSource, ct.EventName):
+		b.stats.Unlisted++
+		return
+	case ct.EventTime.Before(b.start):
+		b.stats.TooOld++
+		return
+	case !ct.EventTime.Before(b.end):
+		b.stats.OutOfRange++
+		return
+	}
+	if _, ok := b.regions[ct.AWSRegion]; !ok {
+		b.stats.UnknownRegion++
+		return
+	}
+
+	// one PutLogEvents call cannot span more than 24 hours, so the region is flushed
+	// before an event would stretch its pending records past it
+	s, ok := b.spans[ct.AWSRegion]
+	switch {
+	case !ok:
+		b.spans[ct.AWSRegion] = &span{first: ct.EventTime, last: ct.EventTime}
+	case spanOf(s, ct.EventTime) >= maxBatchSpan:
+		if err := b.batcher.FlushRegion(ctx, ct.AWSRegion); err != nil {
+			b.logger.Error("failed to flush region %s, its records are kept for the next flush: %v", ct.AWSRegion, err)
+		}
+		*s = span{first: ct.EventTime, last: ct.EventTime}
+	default:
+		s.first = minTime(s.first, ct.EventTime)
+		s.last = maxTime(s.last, ct.EventTime)
+	}
+
+	if err := b.batcher.Add(ctx, ct.AWSRegion, ct); err != nil {
+		b.logger.Error("cannot write event %s of region %s: %v", ct.EventID, ct.AWSRegion, err)
+		return
+	}
+	b.stats.Counted++
+}
+
+// spanOf returns the time span of a span extended to t
+func spanOf(s *span, t time.Time) time.Duration {
+	return maxTime(s.last, t).Sub(minTime(s.first, t))
+}
+
+func minTime(a, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+	return a
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+// ReadRecords decodes the Records of a CloudTrail log file, gzipped or not, one at a time,
+// so large files are not held in memory.
+func ReadRecords(r io.Reader, fn func(sharedtypes.CloudTrailEvent)) error {
+	br := bufio.NewReader(r)
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		r = gz
+	} else {
+		r = br
+	}
+
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if key, _ := tok.(string); key != "Records" {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := expectDelim(dec, '['); err != nil {
+			return err
+		}
+		for dec.More() {
+			var ct sharedtypes.CloudTrailEvent
+			if err := dec.Decode(&ct); err != nil {
+				return err
+			}
+			fn(ct)
+		}
+		if err := expectDelim(dec, ']'); err != nil {
+			return err
+		}
+	}
+	return expectDelim(dec, '}')
+}
+
+// expectDelim reads the next token and checks it is the delimiter
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != want {
+		return fmt.Errorf("expected %v in cloudtrail log file, found %v", want, tok)
+	}
+	return nil
+}
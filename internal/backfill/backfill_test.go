@@ -0,0 +1,262 @@
+package backfill
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/s3client"
+	"github.com/outofoffice3/aws-samples/geras/internal/serviceconfig"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+)
+
+var now = time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+// fakeBatcher records the events added and the regions flushed, in order
+type fakeBatcher struct {
+	mu      sync.Mutex
+	log     []string // "add:<eventID>" or "flush:<region>"
+	failing map[string]bool
+}
+
+func (f *fakeBatcher) Add(_ context.Context, region string, ct sharedtypes.CloudTrailEvent) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.log = append(f.log, "add:"+ct.EventID)
+	return nil
+}
+
+func (f *fakeBatcher) FlushRegion(_ context.Context, region string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.log = append(f.log, "flush:"+region)
+	if f.failing[region] {
+		return errors.New("flush failed")
+	}
+	return nil
+}
+
+func newAllowList(t *testing.T) *serviceconfig.RateLimitAllowList {
+	allowList, err := serviceconfig.NewRateLimitAllowList(serviceconfig.TopLevelServiceConfig{
+		Services: map[string]serviceconfig.ServiceConfig{
+			"sts": {RateLimitAPIs: []serviceconfig.RateLimitAPIs{{Name: "assumeRole"}}},
+		},
+	})
+	require.NoError(t, err)
+	return allowList
+}
+
+func event(id, name, region string, t time.Time) sharedtypes.CloudTrailEvent {
+	return sharedtypes.CloudTrailEvent{EventID: id, EventSource: "sts.amazonaws.com", EventName: name, AWSRegion: region, EventTime: t}
+}
+
+// logFile returns a gzipped CloudTrail log file holding the events
+func logFile(t *testing.T, events ...sharedtypes.CloudTrailEvent) []byte {
+	data, err := json.Marshal(map[string]any{"Records": events})
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err = gz.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func writeFile(t *testing.T, dir, name string, data []byte) {
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, data, 0644))
+}
+
+func TestReadRecords(t *testing.T) {
+	events := []sharedtypes.CloudTrailEvent{
+		event("a", "AssumeRole", "us-east-1", now),
+		event("b", "GetCallerIdentity", "us-east-1", now),
+	}
+	plain, err := json.Marshal(map[string]any{"Other": []int{1}, "Records": events, "After": "x"})
+	require.NoError(t, err)
+
+	for name, data := range map[string][]byte{"gzipped": logFile(t, events...), "plain": plain} {
+		t.Run(name, func(t *testing.T) {
+			var got []sharedtypes.CloudTrailEvent
+			require.NoError(t, ReadRecords(bytes.NewReader(data), func(ct sharedtypes.CloudTrailEvent) {
+				got = append(got, ct)
+			}))
+			assert.Equal(t, events, got)
+		})
+	}
+
+	for name, data := range map[string]string{
+		"not json":    "nope",
+		"not object":  `[]`,
+		"bad records": `{"Records": {}}`,
+		"truncated":   `{"Records": [{"eventID": "a"}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, ReadRecords(strings.NewReader(data), func(sharedtypes.CloudTrailEvent) {}))
+		})
+	}
+}
+
+func TestNewBackfiller(t *testing.T) {
+	valid := Config{
+		Source:    &DirSource{Dir: t.TempDir()},
+		Batcher:   &fakeBatcher{},
+		AllowList: newAllowList(t),
+		Regions:   []string{"us-east-1"},
+		Now:       now,
+	}
+	b, err := NewBackfiller(valid)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-MaxEventAge+ageMargin), b.start, "start is raised to the age limit")
+	assert.Equal(t, now, b.end)
+
+	for name, mutate := range map[string]func(c *Config){
+		"no source":    func(c *Config) { c.Source = nil },
+		"no batcher":   func(c *Config) { c.Batcher = nil },
+		"no allowlist": func(c *Config) { c.AllowList = nil },
+		"no regions":   func(c *Config) { c.Regions = nil },
+		"empty range":  func(c *Config) { c.End = now.Add(-20 * 24 * time.Hour) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			config := valid
+			mutate(&config)
+			_, err := NewBackfiller(config)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestBackfiller_Run(t *testing.T) {
+	dir := t.TempDir()
+	prefix := "AWSLogs/111122223333/CloudTrail/us-east-1/2025/06/14/"
+	// the later delivery sorts first by name but is read last
+	writeFile(t, dir, prefix+"111122223333_CloudTrail_us-east-1_20250614T1010Z_b.json.gz", logFile(t,
+		event("late", "AssumeRole", "us-east-1", now.Add(-26*time.Hour)),
+	))
+	writeFile(t, dir, prefix+"999988887777_CloudTrail_us-east-1_20250614T1000Z_a.json.gz", logFile(t,
+		event("counted", "AssumeRole", "us-east-1", now.Add(-26*time.Hour)),
+		event("unlisted", "GetCallerIdentity", "us-east-1", now.Add(-26*time.Hour)),
+		event("too-old", "AssumeRole", "us-east-1", now.Add(-15*24*time.Hour)),
+		event("future", "AssumeRole", "us-east-1", now.Add(time.Minute)),
+		event("other-region", "AssumeRole", "ap-south-1", now.Add(-26*time.Hour)),
+	))
+	writeFile(t, dir, "AWSLogs/111122223333/CloudTrail-Digest/us-east-1/digest.json.gz", []byte("not a log file"))
+	writeFile(t, dir, prefix+"111122223333_CloudTrail_us-east-1_20250614T1020Z_c.json.gz", []byte("corrupt"))
+	// a file that fails midway adds none of its events
+	first, err := json.Marshal(event("truncated", "AssumeRole", "us-east-1", now.Add(-26*time.Hour)))
+	require.NoError(t, err)
+	writeFile(t, dir, prefix+"111122223333_CloudTrail_us-east-1_20250614T1030Z_d.json", append([]byte(`{"Records": [`), append(first, `, {"eventID": `...)...))
+
+	batcher := &fakeBatcher{}
+	b, err := NewBackfiller(Config{
+		Source:    &DirSource{Dir: dir},
+		Batcher:   batcher,
+		AllowList: newAllowList(t),
+		Regions:   []string{"us-east-1"},
+		Now:       now,
+	})
+	require.NoError(t, err)
+
+	stats, err := b.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, Stats{
+		Files:         4,
+		FailedFiles:   2,
+		Events:        6,
+		Counted:       2,
+		Unlisted:      1,
+		TooOld:        1,
+		OutOfRange:    1,
+		UnknownRegion: 1,
+	}, stats)
+	assert.Equal(t, []string{"add:counted", "add:late", "flush:us-east-1"}, batcher.log)
+}
+
+func TestBackfiller_FlushesBefore24Hours(t *testing.T) {
+	dir := t.TempDir()
+	start := now.Add(-10 * 24 * time.Hour)
+	writeFile(t, dir, "a.json", logFile(t,
+		event("1", "AssumeRole", "us-east-1", start),
+		event("2", "AssumeRole", "us-east-1", start.Add(23*time.Hour)),
+		// an earlier event does not move the end of the span
+		event("3", "AssumeRole", "us-east-1", start.Add(-30*time.Minute)),
+		// 24 hours after the first pending event
+		event("4", "AssumeRole", "us-east-1", start.Add(23*time.Hour+30*time.Minute)),
+		event("5", "AssumeRole", "eu-west-1", start.Add(48*time.Hour)),
+		event("6", "AssumeRole", "us-east-1", start.Add(24*time.Hour)),
+	))
+
+	batcher := &fakeBatcher{failing: map[string]bool{"eu-west-1": true}}
+	b, err := NewBackfiller(Config{
+		Source:    &DirSource{Dir: dir},
+		Batcher:   batcher,
+		AllowList: newAllowList(t),
+		Regions:   []string{"us-east-1", "eu-west-1"},
+		Now:       now,
+	})
+	require.NoError(t, err)
+
+	_, err = b.Run(context.Background())
+	assert.ErrorContains(t, err, "eu-west-1", "the region that failed its last flush is reported")
+	assert.Equal(t, []string{"add:1", "add:2", "add:3", "flush:us-east-1", "add:4", "add:5", "add:6"}, batcher.log[:7])
+	assert.ElementsMatch(t, []string{"flush:us-east-1", "flush:eu-west-1"}, batcher.log[7:])
+}
+
+func TestS3Source(t *testing.T) {
+	client := &s3client.FakeS3Client{
+		Objects: map[string][]byte{
+			"trail/b.json.gz": logFile(t, event("b", "AssumeRole", "us-east-1", now)),
+			"trail/a.json.gz": logFile(t, event("a", "AssumeRole", "us-east-1", now)),
+			"trail/c.json.gz": logFile(t, event("c", "AssumeRole", "us-east-1", now)),
+			"other/d.json.gz": logFile(t, event("d", "AssumeRole", "us-east-1", now)),
+		},
+		ObjectsPageSize: 2,
+	}
+	source := &S3Source{Client: client, Bucket: "bucket", Prefix: "trail/"}
+	names, err := source.List(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"trail/a.json.gz", "trail/b.json.gz", "trail/c.json.gz"}, names)
+
+	batcher := &fakeBatcher{}
+	b, err := NewBackfiller(Config{
+		Source:    source,
+		Batcher:   batcher,
+		AllowList: newAllowList(t),
+		Regions:   []string{"us-east-1"},
+		Start:     now.Add(-time.Hour),
+		Now:       now.Add(time.Hour),
+	})
+	require.NoError(t, err)
+	stats, err := b.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, stats.Counted)
+	assert.Equal(t, []string{"add:a", "add:b", "add:c", "flush:us-east-1"}, batcher.log)
+}
+
+func TestSortByDelivery(t *testing.T) {
+	names := []string{
+		"x/no-time.json.gz",
+		"x/2_CloudTrail_us-east-1_20250101T0010Z_a.json.gz",
+		"y/1_CloudTrail_eu-west-1_20250101T0005Z_b.json.gz",
+		"x/1_CloudTrail_us-east-1_20250101T0010Z_c.json.gz",
+	}
+	sortByDelivery(names)
+	assert.Equal(t, []string{
+		"y/1_CloudTrail_eu-west-1_20250101T0005Z_b.json.gz",
+		"x/1_CloudTrail_us-east-1_20250101T0010Z_c.json.gz",
+		"x/2_CloudTrail_us-east-1_20250101T0010Z_a.json.gz",
+		"x/no-time.json.gz",
+	}, names)
+}
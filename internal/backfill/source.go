@@ -0,0 +1,114 @@
+package backfill
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/s3client"
+)
+
+// Source lists and opens CloudTrail log files
+type Source interface {
+	// List returns the names of the files of the source
+	List(ctx context.Context) ([]string, error)
+	// Open returns the content of a file, gzipped or not
+	Open(ctx context.Context, name string) (io.ReadCloser, error)
+}
+
+// S3Source reads the log files delivered by a trail under a prefix of its bucket
+type S3Source struct {
+	Client s3client.S3Client
+	Bucket string
+	Prefix string
+}
+
+func (s *S3Source) List(ctx context.Context) ([]string, error) {
+	var keys []string
+	paginator := s3.NewListObjectsV2Paginator(s.Client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.Bucket),
+		Prefix: aws.String(s.Prefix),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range page.Contents {
+			keys = append(keys, aws.ToString(object.Key))
+		}
+	}
+	return keys, nil
+}
+
+func (s *S3Source) Open(ctx context.Context, name string) (io.ReadCloser, error) {
+	out, err := s.Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.Bucket),
+		Key:    aws.String(name),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.Body, nil
+}
+
+// DirSource reads the log files found under a local directory, e.g. a copy of a trail bucket
+type DirSource struct {
+	Dir string
+}
+
+func (d *DirSource) List(_ context.Context) ([]string, error) {
+	var names []string
+	err := filepath.WalkDir(d.Dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() {
+			names = append(names, path)
+		}
+		return nil
+	})
+	return names, err
+}
+
+func (d *DirSource) Open(_ context.Context, name string) (io.ReadCloser, error) {
+	return os.Open(name)
+}
+
+// isLogFile reports whether a file holds CloudTrail events, rather than digests or insights
+func isLogFile(name string) bool {
+	if strings.Contains(name, "CloudTrail-Digest") || strings.Contains(name, "CloudTrail-Insight") {
+		return false
+	}
+	return strings.HasSuffix(name, ".json.gz") || strings.HasSuffix(name, ".json")
+}
+
+// logFileTime matches the delivery time in the name of a log file,
+// <account>_CloudTrail_<region>_<YYYYMMDDTHHmmZ>_<id>.json.gz
+var logFileTime = regexp.MustCompile(`_(\d{8}T\d{4}Z)_`)
+
+// sortByDelivery orders log files by their delivery time, so the files of every account
+// and region are read in time order.  Files without a delivery time come last, by name.
+func sortByDelivery(names []string) {
+	deliveryTime := func(name string) string {
+		if m := logFileTime.FindStringSubmatch(filepath.Base(name)); m != nil {
+			return m[1]
+		}
+		return "~" // after any timestamp
+	}
+	sort.SliceStable(names, func(i, j int) bool {
+		ti, tj := deliveryTime(names[i]), deliveryTime(names[j])
+		if ti != tj {
+			return ti < tj
+		}
+		return names[i] < names[j]
+	})
+}
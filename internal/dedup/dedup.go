@@ -0,0 +1,126 @@
+package dedup
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Store records the ids of the events already counted.  The in memory Window only sees
+// the redeliveries to its own sandbox, a Store shared between sandboxes, e.g. a DynamoDB
+// table with a TTL, also sees the ones delivered to another sandbox.
+type Store interface {
+	// Seen records id as seen at now and reports whether it was already seen within the window
+	Seen(ctx context.Context, id string, now time.Time) (bool, error)
+	// Forget removes id, so its next delivery is counted again
+	Forget(ctx context.Context, id string) error
+}
+
+// entry is one id of the window and when it was first seen
+type entry struct {
+	id string
+	at time.Time
+}
+
+// Window is a bounded, time windowed, in memory Store.
+// An id is a duplicate when it is seen again before ttl elapsed since it was first seen.
+// When more than size ids are held, the oldest is evicted early.
+type Window struct {
+	size int
+	ttl  time.Duration
+
+	mu    sync.Mutex
+	ids   map[string]*list.Element
+	order *list.List // entries, oldest first
+}
+
+// NewWindow returns a Window holding at most size ids for ttl each
+func NewWindow(size int, ttl time.Duration) *Window {
+	return &Window{
+		size:  size,
+		ttl:   ttl,
+		ids:   make(map[string]*list.Element),
+		order: list.New(),
+	}
+}
+
+func (w *Window) Seen(_ context.Context, id string, now time.Time) (bool, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.expire(now)
+	if el, ok := w.ids[id]; ok {
+		if w.expired(el.Value.(entry), now) {
+			// only reachable when the clock went backwards, as older entries expire first
+			w.remove(el)
+		} else {
+			return true, nil
+		}
+	}
+	for w.size > 0 && w.order.Len() >= w.size {
+		w.remove(w.order.Front())
+	}
+	w.ids[id] = w.order.PushBack(entry{id: id, at: now})
+	return false, nil
+}
+
+func (w *Window) Forget(_ context.Context, id string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if el, ok := w.ids[id]; ok {
+		w.remove(el)
+	}
+	return nil
+}
+
+// Len returns the number of ids held
+func (w *Window) Len() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.order.Len()
+}
+
+// expire removes the ids older than the window
+func (w *Window) expire(now time.Time) {
+	for el := w.order.Front(); el != nil && w.expired(el.Value.(entry), now); el = w.order.Front() {
+		w.remove(el)
+	}
+}
+
+func (w *Window) expired(e entry, now time.Time) bool {
+	return !now.Before(e.at.Add(w.ttl))
+}
+
+func (w *Window) remove(el *list.Element) {
+	delete(w.ids, el.Value.(entry).id)
+	w.order.Remove(el)
+}
+
+// tiered checks a local store first and a shared one only for the ids new to it
+type tiered struct {
+	local  Store
+	shared Store
+}
+
+// NewTiered returns a Store backed by a local and a shared store.  Most redeliveries go
+// back to the same sandbox, so the local store saves most calls to the shared one.
+// It returns local when shared is nil.
+func NewTiered(local, shared Store) Store {
+	if shared == nil {
+		return local
+	}
+	return &tiered{local: local, shared: shared}
+}
+
+func (t *tiered) Seen(ctx context.Context, id string, now time.Time) (bool, error) {
+	if dup, err := t.local.Seen(ctx, id, now); err != nil || dup {
+		return dup, err
+	}
+	return t.shared.Seen(ctx, id, now)
+}
+
+func (t *tiered) Forget(ctx context.Context, id string) error {
+	return errors.Join(t.local.Forget(ctx, id), t.shared.Forget(ctx, id))
+}
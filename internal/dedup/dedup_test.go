@@ -0,0 +1,121 @@
+package dedup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var t0 = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func seen(t *testing.T, s Store, id string, now time.Time) bool {
+	t.Helper()
+	dup, err := s.Seen(context.Background(), id, now)
+	require.NoError(t, err)
+	return dup
+}
+
+func TestWindow_Duplicates(t *testing.T) {
+	w := NewWindow(10, time.Minute)
+	assert.False(t, seen(t, w, "a", t0))
+	assert.True(t, seen(t, w, "a", t0.Add(time.Second)))
+	assert.False(t, seen(t, w, "b", t0.Add(time.Second)))
+	assert.Equal(t, 2, w.Len())
+}
+
+func TestWindow_Boundary(t *testing.T) {
+	w := NewWindow(10, time.Minute)
+	assert.False(t, seen(t, w, "a", t0))
+
+	// the window runs from the first sighting, a duplicate does not extend it
+	assert.True(t, seen(t, w, "a", t0.Add(30*time.Second)))
+	assert.True(t, seen(t, w, "a", t0.Add(time.Minute-time.Nanosecond)))
+
+	// at exactly ttl the id has expired and counts as new again
+	assert.False(t, seen(t, w, "a", t0.Add(time.Minute)))
+	assert.True(t, seen(t, w, "a", t0.Add(time.Minute+time.Second)))
+}
+
+func TestWindow_ExpiredIdsAreDropped(t *testing.T) {
+	w := NewWindow(100, time.Minute)
+	for i := 0; i < 10; i++ {
+		seen(t, w, fmt.Sprint(i), t0.Add(time.Duration(i)*time.Second))
+	}
+	// the first five are older than the window
+	seen(t, w, "new", t0.Add(time.Minute+4*time.Second))
+	assert.Equal(t, 6, w.Len())
+	assert.False(t, seen(t, w, "0", t0.Add(time.Minute+4*time.Second)))
+	assert.True(t, seen(t, w, "5", t0.Add(time.Minute+4*time.Second)))
+}
+
+func TestWindow_Eviction(t *testing.T) {
+	w := NewWindow(3, time.Hour)
+	for _, id := range []string{"a", "b", "c"} {
+		assert.False(t, seen(t, w, id, t0))
+	}
+	// d evicts a, the oldest id, before its window ends
+	assert.False(t, seen(t, w, "d", t0))
+	assert.Equal(t, 3, w.Len())
+	assert.True(t, seen(t, w, "b", t0))
+	assert.True(t, seen(t, w, "d", t0))
+
+	// a counts as new again and evicts b
+	assert.False(t, seen(t, w, "a", t0))
+	assert.False(t, seen(t, w, "b", t0))
+}
+
+func TestWindow_ClockGoesBackwards(t *testing.T) {
+	w := NewWindow(10, time.Minute)
+	seen(t, w, "a", t0.Add(time.Minute))
+	assert.True(t, seen(t, w, "a", t0), "an earlier time is still within the window")
+}
+
+func TestWindow_Forget(t *testing.T) {
+	w := NewWindow(10, time.Minute)
+	seen(t, w, "a", t0)
+	require.NoError(t, w.Forget(context.Background(), "a"))
+	require.NoError(t, w.Forget(context.Background(), "missing"))
+	assert.False(t, seen(t, w, "a", t0))
+}
+
+// failingStore fails every call
+type failingStore struct{ calls int }
+
+func (f *failingStore) Seen(context.Context, string, time.Time) (bool, error) {
+	f.calls++
+	return false, errors.New("unavailable")
+}
+func (f *failingStore) Forget(context.Context, string) error {
+	f.calls++
+	return errors.New("unavailable")
+}
+
+func TestTiered(t *testing.T) {
+	local := NewWindow(10, time.Minute)
+	assert.Same(t, local, NewTiered(local, nil))
+
+	shared := NewWindow(10, time.Minute)
+	// another sandbox already counted b
+	seen(t, shared, "b", t0)
+
+	s := NewTiered(local, shared)
+	assert.False(t, seen(t, s, "a", t0))
+	assert.True(t, seen(t, s, "a", t0), "found locally")
+	assert.True(t, seen(t, s, "b", t0), "found in the shared store")
+
+	require.NoError(t, s.Forget(context.Background(), "a"))
+	assert.False(t, seen(t, shared, "a", t0), "forgotten in both stores")
+
+	// the shared store is only asked about ids new to the local one
+	failing := &failingStore{}
+	s = NewTiered(NewWindow(10, time.Minute), failing)
+	_, err := s.Seen(context.Background(), "c", t0)
+	assert.Error(t, err)
+	assert.True(t, seen(t, s, "c", t0))
+	assert.Equal(t, 1, failing.calls)
+}
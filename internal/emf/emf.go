@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -20,20 +21,35 @@ import (
 )
 
 const (
-	MetricUnitCount = "Count"
+	MetricUnitCount          = "Count"
+	MetricUnitPercent        = "Percent"
+	MetricUnitCountPerSecond = "Count/Second"
 
 	// error messages
 	noClientFoundForRegionErr = "no client found for region"
 )
 
 // EMFInput holds the minimal inputs needed to build your metric.
+// DimensionSets lists the dimension names the metric is published under, by default
+// one set of all the Dimensions.  ExtraMetrics are written to the same document.
 type EMFInput struct {
-	Namespace  string
-	MetricName string
-	Value      float64
-	Unit       string
-	Dimensions [][]string
-	Timestamp  time.Time
+	Namespace     string
+	MetricName    string
+	Value         float64
+	Unit          string
+	Dimensions    [][]string
+	DimensionSets [][]string
+	Timestamp     time.Time
+	ExtraMetrics  []EMFMetric
+}
+
+// EMFMetric is an additional metric of an EMF document.
+// It shares the dimension sets of the document unless DimensionSets is set.
+type EMFMetric struct {
+	Name          string
+	Value         float64
+	Unit          string
+	DimensionSets [][]string
 }
 
 // EMFRecord contains the EMF document
@@ -63,19 +79,39 @@ func Build(input EMFInput, logger logger.Logger) (EMFRecord, error) {
 			dimNames = append(dimNames, name)
 		}
 	}
+	dimensionSets := input.DimensionSets
+	if len(dimensionSets) == 0 {
+		dimensionSets = [][]string{dimNames}
+	}
 
-	doc["_aws"] = map[string]any{
-		"Timestamp": ts.UnixMilli(),
-		"CloudWatchMetrics": []any{
-			map[string]any{
-				"Namespace":  input.Namespace,
-				"Dimensions": [][]string{dimNames},
-				"Metrics": []map[string]string{
-					{"Name": input.MetricName, "Unit": input.Unit},
-				},
-			},
+	// metrics sharing the document dimension sets go in one directive,
+	// every extra metric with its own dimension sets gets another one
+	directive := map[string]any{
+		"Namespace":  input.Namespace,
+		"Dimensions": dimensionSets,
+		"Metrics": []map[string]string{
+			{"Name": input.MetricName, "Unit": input.Unit},
 		},
 	}
+	directives := []any{directive}
+	for _, m := range input.ExtraMetrics {
+		doc[m.Name] = m.Value
+		metric := map[string]string{"Name": m.Name, "Unit": m.Unit}
+		if len(m.DimensionSets) == 0 {
+			directive["Metrics"] = append(directive["Metrics"].([]map[string]string), metric)
+			continue
+		}
+		directives = append(directives, map[string]any{
+			"Namespace":  input.Namespace,
+			"Dimensions": m.DimensionSets,
+			"Metrics":    []map[string]string{metric},
+		})
+	}
+
+	doc["_aws"] = map[string]any{
+		"Timestamp":         ts.UnixMilli(),
+		"CloudWatchMetrics": directives,
+	}
 
 	data, err := json.Marshal(doc)
 	if err != nil {
@@ -87,6 +123,19 @@ func Build(input EMFInput, logger logger.Logger) (EMFRecord, error) {
 	}, nil
 }
 
+// ParseRecord reads an EMF document written by Build back into a record
+func ParseRecord(line []byte) (EMFRecord, error) {
+	var env struct {
+		AWS struct {
+			Timestamp int64 `json:"Timestamp"`
+		} `json:"_aws"`
+	}
+	if err := json.Unmarshal(line, &env); err != nil {
+		return EMFRecord{}, err
+	}
+	return EMFRecord{Payload: slices.Clone(line), TimeStamp: time.UnixMilli(env.AWS.Timestamp)}, nil
+}
+
 // ConvertSQSMessageToEMF take one SQS-wrapped CloudTrail event and returns
 // the JSON EMF document (ready to ship to cloudwatchlogs)
 func ConvertSQSMessageToEMF(ctx context.Context, msg events.SQSMessage,
@@ -46,6 +46,12 @@ func (m *mockCWLClient) DescribeLogStreams(ctx context.Context, params *cloudwat
 func (m *mockCWLClient) CreateLogStream(ctx context.Context, params *cloudwatchlogs.CreateLogStreamInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.CreateLogStreamOutput, error) {
 	return nil, nil
 }
+func (m *mockCWLClient) DescribeMetricFilters(ctx context.Context, params *cloudwatchlogs.DescribeMetricFiltersInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeMetricFiltersOutput, error) {
+	return nil, nil
+}
+func (m *mockCWLClient) DescribeSubscriptionFilters(ctx context.Context, params *cloudwatchlogs.DescribeSubscriptionFiltersInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeSubscriptionFiltersOutput, error) {
+	return nil, nil
+}
 
 func TestBuild(t *testing.T) {
 	logger := &mockLogger{}
@@ -82,6 +88,42 @@ func TestBuild(t *testing.T) {
 		assert.False(t, out.TimeStamp.IsZero())
 	})
 
+	t.Run("extra metrics", func(t *testing.T) {
+		in := EMFInput{
+			Namespace:  "test",
+			MetricName: "myMetric",
+			Value:      1,
+			Unit:       "Count",
+			Dimensions: [][]string{{"eventName", "AssumeRole"}},
+			Timestamp:  now,
+			ExtraMetrics: []EMFMetric{
+				{Name: "otherMetric", Value: 42.5, Unit: "Percent"},
+			},
+		}
+
+		out, err := Build(in, logger)
+		assert.NoError(t, err)
+
+		var doc struct {
+			MyMetric    float64 `json:"myMetric"`
+			OtherMetric float64 `json:"otherMetric"`
+			AWS         struct {
+				CloudWatchMetrics []struct {
+					Dimensions [][]string          `json:"Dimensions"`
+					Metrics    []map[string]string `json:"Metrics"`
+				} `json:"CloudWatchMetrics"`
+			} `json:"_aws"`
+		}
+		assert.NoError(t, json.Unmarshal(out.Payload, &doc))
+		assert.Equal(t, 1.0, doc.MyMetric)
+		assert.Equal(t, 42.5, doc.OtherMetric)
+		assert.Equal(t, [][]string{{"eventName"}}, doc.AWS.CloudWatchMetrics[0].Dimensions)
+		assert.Equal(t, []map[string]string{
+			{"Name": "myMetric", "Unit": "Count"},
+			{"Name": "otherMetric", "Unit": "Percent"},
+		}, doc.AWS.CloudWatchMetrics[0].Metrics)
+	})
+
 	t.Run("marshal failure", func(t *testing.T) {
 		in := EMFInput{
 			Namespace:  "test",
@@ -0,0 +1,160 @@
+package cloudtrailemfbatcher
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/outofoffice3/aws-samples/geras/internal/tokenbucket"
+)
+
+const (
+	aggregateCall = iota
+	aggregateError
+)
+
+// aggregateKey identifies the events of one second with the same dimension values
+type aggregateKey struct {
+	kind   int
+	second int64
+	dims   string
+}
+
+// aggregate sums the events of one second with the same dimension values.
+// The bucket keeps its lowest remaining tokens and highest utilization, so the
+// Minimum and Maximum statistics match the ones of one record per event.
+type aggregate struct {
+	timestamp time.Time
+	dims      [][]string
+	count     float64
+	throttled float64
+
+	hasBucket      bool
+	minRemaining   float64
+	maxUtilization float64
+}
+
+// aggregator holds the aggregates of every region until the region is flushed
+type aggregator struct {
+	mu      sync.Mutex
+	regions map[string]map[aggregateKey]*aggregate
+}
+
+func newAggregator() *aggregator {
+	return &aggregator{regions: make(map[string]map[aggregateKey]*aggregate)}
+}
+
+// get returns the aggregate of the event second and dimension values, creating it when missing
+func (ag *aggregator) get(region string, kind int, t time.Time, dims [][]string) *aggregate {
+	aggregates, ok := ag.regions[region]
+	if !ok {
+		aggregates = make(map[aggregateKey]*aggregate)
+		ag.regions[region] = aggregates
+	}
+	second := t.Truncate(time.Second)
+	parts := make([]string, 0, len(dims))
+	for _, dim := range dims {
+		parts = append(parts, strings.Join(dim, "="))
+	}
+	key := aggregateKey{kind: kind, second: second.Unix(), dims: strings.Join(parts, "\x00")}
+	a, ok := aggregates[key]
+	if !ok {
+		a = &aggregate{timestamp: second, dims: dims}
+		aggregates[key] = a
+	}
+	return a
+}
+
+// addCall counts one call and its token bucket sample, if any
+func (ag *aggregator) addCall(region string, t time.Time, dims [][]string, sample *tokenbucket.Sample) {
+	ag.mu.Lock()
+	defer ag.mu.Unlock()
+	a := ag.get(region, aggregateCall, t, dims)
+	a.count++
+	if sample == nil {
+		return
+	}
+	if !a.hasBucket {
+		a.hasBucket = true
+		a.minRemaining = sample.Remaining
+		a.maxUtilization = sample.Utilization
+		return
+	}
+	a.minRemaining = min(a.minRemaining, sample.Remaining)
+	a.maxUtilization = max(a.maxUtilization, sample.Utilization)
+}
+
+// addError counts one failed call
+func (ag *aggregator) addError(region string, t time.Time, dims [][]string, throttled bool) {
+	ag.mu.Lock()
+	defer ag.mu.Unlock()
+	a := ag.get(region, aggregateError, t, dims)
+	a.count++
+	if throttled {
+		a.throttled++
+	}
+}
+
+// restore adds back aggregates drained from a region that could not be written, merging
+// them with the ones counted since
+func (ag *aggregator) restore(region string, kind int, aggregates []*aggregate) {
+	ag.mu.Lock()
+	defer ag.mu.Unlock()
+	for _, r := range aggregates {
+		a := ag.get(region, kind, r.timestamp, r.dims)
+		a.count += r.count
+		a.throttled += r.throttled
+		if !r.hasBucket {
+			continue
+		}
+		if !a.hasBucket {
+			a.hasBucket = true
+			a.minRemaining = r.minRemaining
+			a.maxUtilization = r.maxUtilization
+			continue
+		}
+		a.minRemaining = min(a.minRemaining, r.minRemaining)
+		a.maxUtilization = max(a.maxUtilization, r.maxUtilization)
+	}
+}
+
+// size returns the number of aggregates held for a region
+func (ag *aggregator) size(region string) int {
+	ag.mu.Lock()
+	defer ag.mu.Unlock()
+	return len(ag.regions[region])
+}
+
+// regionNames returns the regions holding aggregates
+func (ag *aggregator) regionNames() []string {
+	ag.mu.Lock()
+	defer ag.mu.Unlock()
+	out := make([]string, 0, len(ag.regions))
+	for r := range ag.regions {
+		out = append(out, r)
+	}
+	return out
+}
+
+// drain removes and returns the call and error aggregates of a region, oldest first
+func (ag *aggregator) drain(region string) (calls, errs []*aggregate) {
+	ag.mu.Lock()
+	aggregates := ag.regions[region]
+	delete(ag.regions, region)
+	ag.mu.Unlock()
+
+	for key, a := range aggregates {
+		if key.kind == aggregateCall {
+			calls = append(calls, a)
+		} else {
+			errs = append(errs, a)
+		}
+	}
+	byTime := func(list []*aggregate) func(i, j int) bool {
+		return func(i, j int) bool { return list[i].timestamp.Before(list[j].timestamp) }
+	}
+	sort.SliceStable(calls, byTime(calls))
+	sort.SliceStable(errs, byTime(errs))
+	return calls, errs
+}
@@ -0,0 +1,274 @@
+package cloudtrailemfbatcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/outofoffice3/aws-samples/geras/internal/emf"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/tokenbucket"
+)
+
+// newComparedBatcher creates a batcher with every metric enabled, aggregating or not
+func newComparedBatcher(t *testing.T, ff *fakeFlusher, aggregate bool) *CTFileBatcher {
+	sets, err := BuildDimensionSets([][]string{{DimensionUserIdentityARN}, {DimensionEventSource, DimensionUserAgent}})
+	require.NoError(t, err)
+	return NewCTFileBatcher(CTFileBatcherConfig{
+		ParentCtx:           context.Background(),
+		Namespace:           "N",
+		MetricName:          "CallCount",
+		ErrorMetricName:     "ErrorCount",
+		ThrottledMetricName: "ThrottledCount",
+		DimensionSets:       sets,
+		BaseDir:             t.TempDir(),
+		MaxCount:            1_000_000,
+		FlushInterval:       time.Hour,
+		EmfFlusher:          ff,
+		Simulator: tokenbucket.NewSimulator(map[string]tokenbucket.Limit{
+			tokenbucket.Key("sts.amazonaws.com", "AssumeRole"):        {BucketSize: 50, RefillRate: 5},
+			tokenbucket.Key("ec2.amazonaws.com", "DescribeInstances"): {BucketSize: 20, RefillRate: 2},
+		}),
+		Aggregate: aggregate,
+		Logger:    logger.Get(),
+	})
+}
+
+// randomEvents returns a reproducible mix of apis, regions, callers and errors over three minutes
+func randomEvents(n int) []sharedtypes.CloudTrailEvent {
+	r := rand.New(rand.NewSource(42))
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	apis := []struct{ source, name string }{
+		{"sts.amazonaws.com", "AssumeRole"},
+		{"ec2.amazonaws.com", "DescribeInstances"},
+		{"kms.amazonaws.com", "Decrypt"},
+	}
+	errorCodes := []string{"", "", "", "ThrottlingException", "Client.RequestLimitExceeded", "AccessDenied"}
+	events := make([]sharedtypes.CloudTrailEvent, 0, n)
+	offset := time.Duration(0)
+	for i := 0; i < n; i++ {
+		offset += time.Duration(r.Intn(400)) * time.Millisecond
+		api := apis[r.Intn(len(apis))]
+		events = append(events, sharedtypes.CloudTrailEvent{
+			EventSource:  api.source,
+			EventName:    api.name,
+			AWSRegion:    []string{"us-east-1", "eu-west-1"}[r.Intn(2)],
+			EventTime:    start.Add(offset),
+			UserAgent:    []string{"aws-cli/2.15.0 Python/3.11", "aws-sdk-go-v2/1.36.3"}[r.Intn(2)],
+			UserIdentity: sharedtypes.UserIdentityDetail{ARN: fmt.Sprintf("arn:aws:sts::123456789012:assumed-role/Role%d/session-%d", r.Intn(3), i)},
+			ErrorCode:    errorCodes[r.Intn(len(errorCodes))],
+		})
+	}
+	return events
+}
+
+// metricStats reduces flushed records to the statistics of every metric, dimension values and minute:
+// the sum of the counts, the minimum of BucketRemaining and the maximum of RateLimitUtilization
+func metricStats(t *testing.T, ff *fakeFlusher) (map[string]float64, int) {
+	ff.mu.Lock()
+	defer ff.mu.Unlock()
+	stats := make(map[string]float64)
+	records := 0
+	for region, batches := range ff.calls {
+		for _, batch := range batches {
+			for _, rec := range batch {
+				records++
+				var doc map[string]any
+				require.NoError(t, json.Unmarshal(rec.Payload, &doc))
+				var meta struct {
+					Timestamp         int64 `json:"Timestamp"`
+					CloudWatchMetrics []struct {
+						Dimensions [][]string `json:"Dimensions"`
+						Metrics    []struct {
+							Name string `json:"Name"`
+						} `json:"Metrics"`
+					} `json:"CloudWatchMetrics"`
+				}
+				raw, _ := json.Marshal(doc["_aws"])
+				require.NoError(t, json.Unmarshal(raw, &meta))
+				minute := time.UnixMilli(meta.Timestamp).Truncate(time.Minute).Unix()
+				for _, directive := range meta.CloudWatchMetrics {
+					for _, set := range directive.Dimensions {
+						var dims []string
+						for _, name := range set {
+							dims = append(dims, name+"="+doc[name].(string))
+						}
+						sort.Strings(dims)
+						for _, m := range directive.Metrics {
+							key := fmt.Sprintf("%s|%s|%s|%d", region, m.Name, strings.Join(dims, ","), minute)
+							value := doc[m.Name].(float64)
+							current, seen := stats[key]
+							switch {
+							case !seen:
+								stats[key] = value
+							case m.Name == MetricNameBucketRemaining:
+								stats[key] = min(current, value)
+							case m.Name == MetricNameRateLimitUtilization:
+								stats[key] = max(current, value)
+							default:
+								stats[key] = current + value
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	return stats, records
+}
+
+func TestCTFileBatcher_AggregateMatchesPerEvent(t *testing.T) {
+	perEventFlusher, aggregateFlusher := newFakeFlusher(), newFakeFlusher()
+	perEvent := newComparedBatcher(t, perEventFlusher, false)
+	aggregated := newComparedBatcher(t, aggregateFlusher, true)
+
+	for _, ct := range randomEvents(5000) {
+		perEvent.Add(context.Background(), ct.AWSRegion, ct)
+		aggregated.Add(context.Background(), ct.AWSRegion, ct)
+	}
+	perEvent.Stop()
+	aggregated.Stop()
+
+	want, perEventRecords := metricStats(t, perEventFlusher)
+	got, aggregateRecords := metricStats(t, aggregateFlusher)
+	assert.Equal(t, want, got)
+	assert.Less(t, aggregateRecords, perEventRecords)
+
+	// sanity check that every metric was exercised
+	names := map[string]bool{}
+	for key := range want {
+		names[strings.Split(key, "|")[1]] = true
+	}
+	assert.Equal(t, map[string]bool{
+		"CallCount": true, "ErrorCount": true, "ThrottledCount": true,
+		MetricNameBucketRemaining: true, MetricNameRateLimitUtilization: true,
+	}, names)
+}
+
+func TestCTFileBatcher_AggregateOneRecordPerSecond(t *testing.T) {
+	ff := newFakeFlusher()
+	batcher := NewCTFileBatcher(CTFileBatcherConfig{
+		ParentCtx:     context.Background(),
+		Namespace:     "N",
+		MetricName:    "CallCount",
+		BaseDir:       t.TempDir(),
+		MaxCount:      1_000_000,
+		FlushInterval: time.Hour,
+		EmfFlusher:    ff,
+		Aggregate:     true,
+		Logger:        logger.Get(),
+	})
+
+	// 10,000 AssumeRole calls in one minute
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 10000; i++ {
+		batcher.Add(context.Background(), "us-east-1", sharedtypes.CloudTrailEvent{
+			EventName: "AssumeRole",
+			EventTime: start.Add(time.Duration(i) * 6 * time.Millisecond),
+		})
+	}
+	batcher.Stop()
+
+	ff.mu.Lock()
+	defer ff.mu.Unlock()
+	var records []emf.EMFRecord
+	for _, batch := range ff.calls["us-east-1"] {
+		records = append(records, batch...)
+	}
+	assert.LessOrEqual(t, len(records), 60)
+
+	var total float64
+	for _, rec := range records {
+		var doc map[string]any
+		require.NoError(t, json.Unmarshal(rec.Payload, &doc))
+		total += doc["CallCount"].(float64)
+		assert.Zero(t, rec.TimeStamp.Nanosecond(), "aggregates are timestamped with their second")
+	}
+	assert.Equal(t, 10000.0, total)
+}
+
+func TestCTFileBatcher_AggregateFlushesOnMaxCount(t *testing.T) {
+	ff := newFakeFlusher()
+	batcher := NewCTFileBatcher(CTFileBatcherConfig{
+		ParentCtx:     context.Background(),
+		Namespace:     "N",
+		MetricName:    "CallCount",
+		BaseDir:       t.TempDir(),
+		MaxCount:      2,
+		FlushInterval: time.Hour,
+		EmfFlusher:    ff,
+		Aggregate:     true,
+		Logger:        logger.Get(),
+	})
+
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	batcher.Add(context.Background(), "us-east-1", sharedtypes.CloudTrailEvent{EventName: "A", EventTime: start})
+	batcher.Add(context.Background(), "us-east-1", sharedtypes.CloudTrailEvent{EventName: "A", EventTime: start})
+
+	ff.mu.Lock()
+	assert.Empty(t, ff.calls["us-east-1"], "events of the same second share an aggregate")
+	ff.mu.Unlock()
+
+	batcher.Add(context.Background(), "us-east-1", sharedtypes.CloudTrailEvent{EventName: "A", EventTime: start.Add(time.Second)})
+
+	ff.mu.Lock()
+	defer ff.mu.Unlock()
+	if assert.Len(t, ff.calls["us-east-1"], 1, "a second aggregate reaches MaxCount") {
+		assert.Len(t, ff.calls["us-east-1"][0], 2)
+	}
+}
+
+func TestCTFileBatcher_AggregateKeptWhenWriteFails(t *testing.T) {
+	ff, fs := newFakeFlusher(), &faultyFS{}
+	batcher := NewCTFileBatcher(CTFileBatcherConfig{
+		ParentCtx:       context.Background(),
+		Namespace:       "N",
+		MetricName:      "CallCount",
+		ErrorMetricName: "ErrorCount",
+		BaseDir:         t.TempDir(),
+		MaxCount:        1_000_000,
+		FlushInterval:   time.Hour,
+		EmfFlusher:      ff,
+		Aggregate:       true,
+		FS:              fs,
+		Logger:          logger.Get(),
+	})
+
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	add := func(errorCode string) {
+		batcher.Add(context.Background(), "us-east-1", sharedtypes.CloudTrailEvent{EventName: "A", EventTime: start, ErrorCode: errorCode})
+	}
+	add("")
+	add("AccessDenied")
+	fs.opens = 1
+	assert.ErrorIs(t, batcher.FlushRegion(context.Background(), "us-east-1"), errInjected)
+
+	// the aggregates go back to the aggregator and are merged with the events added since
+	add("")
+	require.NoError(t, batcher.FlushRegion(context.Background(), "us-east-1"))
+
+	ff.mu.Lock()
+	defer ff.mu.Unlock()
+	totals := map[string]float64{}
+	for _, batch := range ff.calls["us-east-1"] {
+		for _, rec := range batch {
+			var doc map[string]any
+			require.NoError(t, json.Unmarshal(rec.Payload, &doc))
+			for _, name := range []string{"CallCount", "ErrorCount"} {
+				if v, ok := doc[name].(float64); ok {
+					totals[name] += v
+				}
+			}
+		}
+	}
+	assert.Equal(t, map[string]float64{"CallCount": 3, "ErrorCount": 1}, totals)
+}
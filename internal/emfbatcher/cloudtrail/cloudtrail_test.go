@@ -50,6 +50,12 @@ func (f *fakeCWClient) CreateLogStream(ctx context.Context, _ *cloudwatchlogs.Cr
 func (f *fakeCWClient) DescribeLogStreams(ctx context.Context, _ *cloudwatchlogs.DescribeLogStreamsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogStreamsOutput, error) {
 	return &cloudwatchlogs.DescribeLogStreamsOutput{}, nil
 }
+func (f *fakeCWClient) DescribeMetricFilters(context.Context, *cloudwatchlogs.DescribeMetricFiltersInput, ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeMetricFiltersOutput, error) {
+	return &cloudwatchlogs.DescribeMetricFiltersOutput{}, nil
+}
+func (f *fakeCWClient) DescribeSubscriptionFilters(context.Context, *cloudwatchlogs.DescribeSubscriptionFiltersInput, ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeSubscriptionFiltersOutput, error) {
+	return &cloudwatchlogs.DescribeSubscriptionFiltersOutput{}, nil
+}
 
 // helper to unmarshal the first event's JSON
 func firstPayload(t *testing.T, call *cloudwatchlogs.PutLogEventsInput) map[string]any {
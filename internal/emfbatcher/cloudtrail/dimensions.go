@@ -0,0 +1,123 @@
+package cloudtrailemfbatcher
+
+import (
+	"fmt"
+	"strings"
+
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+)
+
+// cloudtrail fields that can be used as CallCount dimensions.
+// The field name is also the name of the dimension.
+const (
+	DimensionEventName          = "eventName"
+	DimensionEventSource        = "eventSource"
+	DimensionUserIdentityARN    = "userIdentity.arn"
+	DimensionUserIdentityType   = "userIdentity.type"
+	DimensionUserAgent          = "userAgent"
+	DimensionSourceIPAddress    = "sourceIPAddress"
+	DimensionRecipientAccountID = "recipientAccountId"
+
+	// value of a dimension the event does not have
+	unknownDimensionValue = "unknown"
+	// longest dimension value cloudwatch accepts
+	maxDimensionValueLength = 1024
+)
+
+var ErrInvalidDimension = fmt.Errorf("invalid dimension")
+
+// dimensionFields reads and normalises the value of each supported dimension
+var dimensionFields = map[string]func(ct sharedtypes.CloudTrailEvent) string{
+	DimensionEventName:          func(ct sharedtypes.CloudTrailEvent) string { return ct.EventName },
+	DimensionEventSource:        func(ct sharedtypes.CloudTrailEvent) string { return ct.EventSource },
+	DimensionUserIdentityARN:    func(ct sharedtypes.CloudTrailEvent) string { return NormaliseARN(ct.UserIdentity.ARN) },
+	DimensionUserIdentityType:   func(ct sharedtypes.CloudTrailEvent) string { return ct.UserIdentity.Type },
+	DimensionUserAgent:          func(ct sharedtypes.CloudTrailEvent) string { return UserAgentFamily(ct.UserAgent) },
+	DimensionSourceIPAddress:    func(ct sharedtypes.CloudTrailEvent) string { return ct.SourceIP },
+	DimensionRecipientAccountID: func(ct sharedtypes.CloudTrailEvent) string { return ct.RecipientAccountID },
+}
+
+// BuildDimensionSets returns the CallCount dimension sets for the configured ones.
+// Every set is dimensioned by eventName first and the eventName set is always present,
+// so the per api CallCount does not depend on the config.
+func BuildDimensionSets(configured [][]string) ([][]string, error) {
+	sets := [][]string{{DimensionEventName}}
+	seen := map[string]struct{}{DimensionEventName: {}}
+	for _, fields := range configured {
+		set := []string{DimensionEventName}
+		inSet := map[string]struct{}{DimensionEventName: {}}
+		for _, field := range fields {
+			if _, ok := dimensionFields[field]; !ok {
+				return nil, fmt.Errorf("%w: %s", ErrInvalidDimension, field)
+			}
+			if _, ok := inSet[field]; ok {
+				continue
+			}
+			inSet[field] = struct{}{}
+			set = append(set, field)
+		}
+		key := strings.Join(set, ",")
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		sets = append(sets, set)
+	}
+	return sets, nil
+}
+
+// dimensionValues returns the name / value pairs of every field used by the dimension sets
+func dimensionValues(ct sharedtypes.CloudTrailEvent, sets [][]string) [][]string {
+	var dims [][]string
+	seen := make(map[string]struct{})
+	for _, set := range sets {
+		for _, field := range set {
+			if _, ok := seen[field]; ok {
+				continue
+			}
+			seen[field] = struct{}{}
+			value := dimensionFields[field](ct)
+			if value == "" {
+				value = unknownDimensionValue
+			}
+			if len(value) > maxDimensionValueLength {
+				value = value[:maxDimensionValueLength]
+			}
+			dims = append(dims, []string{field, value})
+		}
+	}
+	return dims
+}
+
+// NormaliseARN strips the session name from assumed-role arns so all the sessions
+// of a role share one dimension value, e.g.
+// arn:aws:sts::123456789012:assumed-role/MyRole/i-0abc becomes arn:aws:sts::123456789012:assumed-role/MyRole
+func NormaliseARN(arn string) string {
+	const assumedRole = ":assumed-role/"
+	i := strings.Index(arn, assumedRole)
+	if i < 0 {
+		return arn
+	}
+	rest := arn[i+len(assumedRole):]
+	if j := strings.Index(rest, "/"); j >= 0 {
+		return arn[:i+len(assumedRole)+j]
+	}
+	return arn
+}
+
+// UserAgentFamily collapses a user agent to the name of its first product, without version, e.g.
+// "aws-cli/2.15.0 Python/3.11.6 Linux/6.1 exe/x86_64" becomes "aws-cli" and
+// "[aws-sdk-go-v2/1.36.3 os/linux lang/go#1.22.1]" becomes "aws-sdk-go-v2".
+// Agents without a version, like "console.amazonaws.com" or "AWS Internal", are kept as is.
+func UserAgentFamily(userAgent string) string {
+	ua := strings.TrimSpace(strings.Trim(userAgent, "[]"))
+	if ua == "" {
+		return ""
+	}
+	if !strings.Contains(ua, "/") {
+		return ua
+	}
+	first, _, _ := strings.Cut(ua, " ")
+	family, _, _ := strings.Cut(first, "/")
+	return family
+}
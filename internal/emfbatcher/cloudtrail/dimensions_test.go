@@ -0,0 +1,101 @@
+package cloudtrailemfbatcher
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+)
+
+func TestBuildDimensionSets(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured [][]string
+		want       [][]string
+		wantErr    error
+	}{
+		{"default", nil, [][]string{{"eventName"}}, nil},
+		{
+			"extra sets",
+			[][]string{{"userIdentity.arn"}, {"eventSource", "userAgent"}},
+			[][]string{{"eventName"}, {"eventName", "userIdentity.arn"}, {"eventName", "eventSource", "userAgent"}},
+			nil,
+		},
+		{
+			"duplicates collapsed",
+			[][]string{{"eventName"}, {"userAgent", "userAgent"}, {"eventName", "userAgent"}},
+			[][]string{{"eventName"}, {"eventName", "userAgent"}},
+			nil,
+		},
+		{"unknown field", [][]string{{"requestParameters.roleArn"}}, nil, ErrInvalidDimension},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BuildDimensionSets(tt.configured)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "expected %v, got %v", tt.wantErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDimensionValues(t *testing.T) {
+	ct := sharedtypes.CloudTrailEvent{
+		EventName:   "AssumeRole",
+		EventSource: "sts.amazonaws.com",
+		UserIdentity: sharedtypes.UserIdentityDetail{
+			Type: "AssumedRole",
+			ARN:  "arn:aws:sts::123456789012:assumed-role/Deployer/session-1",
+		},
+		UserAgent: "aws-cli/2.15.0 Python/3.11.6 Linux/6.1 exe/x86_64",
+		SourceIP:  strings.Repeat("a", 2000),
+	}
+	sets := [][]string{
+		{"eventName"},
+		{"eventName", "userIdentity.arn", "userAgent"},
+		{"eventName", "sourceIPAddress", "recipientAccountId"},
+	}
+
+	got := dimensionValues(ct, sets)
+	assert.Equal(t, [][]string{
+		{"eventName", "AssumeRole"},
+		{"userIdentity.arn", "arn:aws:sts::123456789012:assumed-role/Deployer"},
+		{"userAgent", "aws-cli"},
+		{"sourceIPAddress", strings.Repeat("a", maxDimensionValueLength)},
+		{"recipientAccountId", unknownDimensionValue},
+	}, got)
+}
+
+func TestNormaliseARN(t *testing.T) {
+	tests := map[string]string{
+		"arn:aws:sts::123456789012:assumed-role/Deployer/session-1": "arn:aws:sts::123456789012:assumed-role/Deployer",
+		"arn:aws-cn:sts::123456789012:assumed-role/Deployer/i-0abc": "arn:aws-cn:sts::123456789012:assumed-role/Deployer",
+		"arn:aws:sts::123456789012:assumed-role/Deployer":           "arn:aws:sts::123456789012:assumed-role/Deployer",
+		"arn:aws:iam::123456789012:user/alice":                      "arn:aws:iam::123456789012:user/alice",
+		"arn:aws:iam::123456789012:role/service-role/MyRole":        "arn:aws:iam::123456789012:role/service-role/MyRole",
+		"": "",
+	}
+	for in, want := range tests {
+		assert.Equal(t, want, NormaliseARN(in), in)
+	}
+}
+
+func TestUserAgentFamily(t *testing.T) {
+	tests := map[string]string{
+		"aws-cli/2.15.0 Python/3.11.6 Linux/6.1 exe/x86_64":                  "aws-cli",
+		"[aws-sdk-go-v2/1.36.3 os/linux lang/go#1.22.1 md/GOOS#linux]":       "aws-sdk-go-v2",
+		"Boto3/1.34.0 md/Botocore#1.34.0 ua/2.0 os/linux#6.1 md/arch#x86_64": "Boto3",
+		"console.amazonaws.com": "console.amazonaws.com",
+		"AWS Internal":          "AWS Internal",
+		"":                      "",
+	}
+	for in, want := range tests {
+		assert.Equal(t, want, UserAgentFamily(in), in)
+	}
+}
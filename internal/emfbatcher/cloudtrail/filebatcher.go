@@ -2,35 +2,91 @@
 package cloudtrailemfbatcher
 
 import (
-	"bufio"
 	"context"
-	"encoding/json"
-	"fmt"
-	"os"
-	"path/filepath"
+	"errors"
+	"strings"
 	"sync"
 	"time"
 
-	"slices"
-
 	"github.com/outofoffice3/aws-samples/geras/internal/emf"
 	appLogger "github.com/outofoffice3/aws-samples/geras/internal/logger"
 	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/tokenbucket"
+	"github.com/outofoffice3/aws-samples/geras/internal/wal"
 )
 
+const (
+	// token bucket metric names
+	MetricNameBucketRemaining      = "BucketRemaining"
+	MetricNameRateLimitUtilization = "RateLimitUtilization"
+
+	// peak rate metric names
+	MetricNamePeakRPS = "PeakRPS"
+	MetricNameP99RPS  = "P99RPS"
+)
+
+// bucketDimensionSets are the dimension sets of the token bucket metrics
+var bucketDimensionSets = [][]string{{DimensionEventName}}
+
+// throttlingErrorCodes are the cloudtrail errorCodes of calls rejected by a rate limit
+var throttlingErrorCodes = map[string]struct{}{
+	"Throttling":                             {},
+	"ThrottlingException":                    {},
+	"ThrottledException":                     {},
+	"RequestLimitExceeded":                   {},
+	"RequestThrottled":                       {},
+	"RequestThrottledException":              {},
+	"TooManyRequestsException":               {},
+	"ProvisionedThroughputExceededException": {},
+	"SlowDown":                               {},
+}
+
+// IsThrottlingErrorCode reports whether a cloudtrail errorCode means the call was throttled.
+// Some services prefix their codes with "Client." or "Server.", e.g. Client.RequestLimitExceeded.
+func IsThrottlingErrorCode(errorCode string) bool {
+	code := strings.TrimPrefix(strings.TrimPrefix(errorCode, "Client."), "Server.")
+	_, ok := throttlingErrorCodes[code]
+	return ok
+}
+
+var ErrRegionLogUnavailable = errors.New("write-ahead log unavailable")
+
+// EMFFileBatcher writes the EMF records of CloudTrail events to disk.  Add returns an
+// error when the records of the event could not be written.
 type EMFFileBatcher interface {
-	Add(ctx context.Context, region string, event sharedtypes.CloudTrailEvent)
+	Add(ctx context.Context, region string, event sharedtypes.CloudTrailEvent) error
+}
+
+// FlushingEMFFileBatcher is an EMFFileBatcher that can flush a region on demand, so its
+// caller knows when the records added so far have reached CloudWatch Logs.
+type FlushingEMFFileBatcher interface {
+	EMFFileBatcher
+	FlushRegion(ctx context.Context, region string) error
 }
 
 // CTFileBatcher handles CloudTrail events by:
 // 1) converting each event to an EMFRecord,
-// 2) immediately appending the EMF JSON to per-region files,
-// 3) tracking in-memory counts and byte-sizes of EMFRecords per region,
-// 4) flushing (reading and pushing EMFRecords) when:
+// 2) immediately appending the EMF JSON to a per-region write-ahead log,
+// 3) tracking the counts and byte-sizes of the active segment of every region,
+// 4) flushing when:
 //      - record count >= maxCount,
 //      - total bytes >= maxBytes,
 //      - flushInterval elapses,
 // 5) gracefully stopping and flushing all regions on context cancellation.
+//
+// A flush seals the active segment, so events added meanwhile go to a new one, then
+// pushes every sealed segment oldest first and deletes each only once it is pushed.
+// A segment that fails to push is kept for the next flush while the ones after it are
+// still pushed, and segments left by a previous run are found and pushed by the first flush.
+//
+// Events with an errorCode also write an EMF record dimensioned by eventName and errorCode
+// when the error metric names are set.
+// When top callers are enabled, the heaviest principals and user agents of every api are
+// reported on every flush interval, the other callers are summed under "other".
+// When peak rates are enabled, the highest and p99 calls per second of every api are
+// reported on every flush interval.
+// When a token bucket simulator is set, every event with a known rate limit is replayed
+// through it and its EMF record also carries BucketRemaining and RateLimitUtilization.
 
 type CTFileBatcher struct {
 	namespace  string
@@ -44,11 +100,34 @@ type CTFileBatcher struct {
 	// multi region emf flusher
 	emfFlusher emf.EMFFlusher
 
+	// dimension sets of the call count, see BuildDimensionSets
+	dimensionSets [][]string
+
+	// optional error metrics, not emitted when the names are empty
+	errorMetricName     string
+	throttledMetricName string
+
+	// optional token bucket simulator
+	simulator *tokenbucket.Simulator
+
+	// optional top callers of every api, reported on every flush interval
+	topCallers           *topCallers
+	topCallersMetricName string
+
+	// optional peak calls per second of every api, reported on every flush interval
+	peakRates *peakRates
+
+	// optional per second aggregation of the call and error counts
+	aggregator *aggregator
+
 	logger appLogger.Logger
 
-	mu     sync.Mutex
-	counts map[string]int   // region -> EMF record count
-	sizes  map[string]int64 // region -> EMF byte-size
+	// write-ahead log settings
+	fsync wal.FsyncPolicy
+	fs    wal.FS
+
+	mu         sync.Mutex
+	regionLogs map[string]*regionLog // region -> write-ahead log
 
 	ticker *time.Ticker
 	ctx    context.Context
@@ -59,15 +138,51 @@ type CTFileBatcher struct {
 // and flushes via the provided EMF flushers per region.
 
 type CTFileBatcherConfig struct {
-	ParentCtx     context.Context
-	Namespace     string
-	MetricName    string
+	ParentCtx  context.Context
+	Namespace  string
+	MetricName string
+	// ErrorMetricName counts failed calls per eventName and errorCode.
+	// ThrottledMetricName counts the failed calls with a throttling errorCode.
+	ErrorMetricName     string
+	ThrottledMetricName string
+	// DimensionSets of the call count, built by BuildDimensionSets. Defaults to eventName only.
+	DimensionSets [][]string
 	BaseDir       string
 	MaxCount      int
 	MaxBytes      int64
 	FlushInterval time.Duration
 	EmfFlusher    emf.EMFFlusher
-	Logger        appLogger.Logger
+	Simulator     *tokenbucket.Simulator
+	// TopCallers is the number of principals and user agents reported per api under
+	// TopCallersMetricName on every flush interval. Zero disables the report.
+	TopCallers           int
+	TopCallersMetricName string
+	// PeakRate reports the highest and p99 calls per second of every api as PeakRPS and
+	// P99RPS on every flush interval.
+	PeakRate bool
+	// Aggregate sums the events of every second and dimension values into one record.
+	// MaxCount then limits the aggregated records held in memory per region.
+	Aggregate bool
+	// Fsync decides when the write-ahead log is synced to disk. FS defaults to the os.
+	Fsync  wal.FsyncPolicy
+	FS     wal.FS
+	Logger appLogger.Logger
+}
+
+// regionLog is the write-ahead log of a region.  Flushes of a region run one at a time.
+type regionLog struct {
+	log     *wal.Log
+	flushMu sync.Mutex
+}
+
+// segmentPrefix is the prefix of the write-ahead log segments of a region
+func segmentPrefix(region string) string {
+	return "emf_" + region
+}
+
+// RegionFromSegment returns the region of a write-ahead log segment written by the batcher
+func RegionFromSegment(segment wal.Segment) (string, bool) {
+	return strings.CutPrefix(segment.Prefix, "emf_")
 }
 
 func NewCTFileBatcher(
@@ -75,97 +190,339 @@ func NewCTFileBatcher(
 	if config.Logger == nil {
 		config.Logger = appLogger.Get()
 	}
+	if len(config.DimensionSets) == 0 {
+		config.DimensionSets = [][]string{{DimensionEventName}}
+	}
 	ctx, cancel := context.WithCancel(config.ParentCtx)
 	fb := &CTFileBatcher{
-		namespace:     config.Namespace,
-		metricName:    config.MetricName,
-		baseDir:       config.BaseDir,
-		maxCount:      config.MaxCount,
-		maxBytes:      config.MaxBytes,
-		flushInterval: config.FlushInterval,
-		emfFlusher:    config.EmfFlusher,
-		logger:        config.Logger,
-		counts:        make(map[string]int),
-		sizes:         make(map[string]int64),
-		ticker:        time.NewTicker(config.FlushInterval),
-		ctx:           ctx,
-		cancel:        cancel,
+		namespace:           config.Namespace,
+		metricName:          config.MetricName,
+		dimensionSets:       config.DimensionSets,
+		errorMetricName:     config.ErrorMetricName,
+		throttledMetricName: config.ThrottledMetricName,
+		baseDir:             config.BaseDir,
+		maxCount:            config.MaxCount,
+		maxBytes:            config.MaxBytes,
+		flushInterval:       config.FlushInterval,
+		emfFlusher:          config.EmfFlusher,
+		simulator:           config.Simulator,
+		logger:              config.Logger,
+		fsync:               config.Fsync,
+		fs:                  config.FS,
+		regionLogs:          make(map[string]*regionLog),
+		ticker:              time.NewTicker(config.FlushInterval),
+		ctx:                 ctx,
+		cancel:              cancel,
+	}
+	if config.TopCallers > 0 && config.TopCallersMetricName != "" {
+		fb.topCallers = newTopCallers(config.TopCallers)
+		fb.topCallersMetricName = config.TopCallersMetricName
+	}
+	if fb.fs == nil {
+		fb.fs = wal.OSFS{}
+	}
+
+	// open the logs of the regions with segments left by a previous run, so they are replayed
+	leftovers, err := wal.ListSegments(fb.fs, fb.baseDir)
+	if err != nil {
+		fb.logger.Error("unable to list leftover segments in %s: %v", fb.baseDir, err)
+	}
+	for _, segment := range leftovers {
+		if region, ok := RegionFromSegment(segment); ok && fb.regionLog(region) != nil {
+			fb.logger.Info("found leftover segment %s for region %s", segment.Path, region)
+		}
+	}
+	if config.PeakRate {
+		fb.peakRates = newPeakRates()
+	}
+	if config.Aggregate {
+		fb.aggregator = newAggregator()
 	}
 	// Start periodic flush
 	go fb.startTicker()
 	return fb
 }
 
-// Add converts a CloudTrailEvent to EMFRecord, writes its JSON to disk,
+// Add converts a CloudTrailEvent to EMFRecords, writes their JSON to disk,
 // updates counters, and triggers flushes BEFORE and AFTER writing if thresholds
-// would be or are exceeded.
-func (fb *CTFileBatcher) Add(ctx context.Context, region string, ct sharedtypes.CloudTrailEvent) {
-	// 1) convert to EMF
-	emfRec, err := emf.Build(emf.EMFInput{
-		Namespace:  fb.namespace,
-		MetricName: fb.metricName,
-		Value:      1,
-		Unit:       emf.MetricUnitCount,
-		Dimensions: [][]string{{"eventName", ct.EventName}},
-		Timestamp:  ct.EventTime,
+// would be or are exceeded.  When aggregating, the event is added to the counts of
+// its second instead and the records are written when the region is flushed.
+// It returns an error when a record of the event could not be appended to the region log.
+func (fb *CTFileBatcher) Add(ctx context.Context, region string, ct sharedtypes.CloudTrailEvent) error {
+	// 1) replay the call through its token bucket when the api has a known rate limit
+	var sample *tokenbucket.Sample
+	if fb.simulator != nil {
+		if s, ok := fb.simulator.Observe(region, ct.EventSource, ct.EventName, ct.EventTime); ok {
+			sample = &s
+		}
+	}
+	if fb.topCallers != nil {
+		fb.topCallers.observe(region, ct)
+	}
+	if fb.peakRates != nil {
+		fb.peakRates.observe(region, ct)
+	}
+	dims := dimensionValues(ct, fb.dimensionSets)
+	var errDims [][]string
+	if ct.ErrorCode != "" && fb.errorMetricName != "" {
+		errDims = [][]string{{DimensionEventName, ct.EventName}, {"errorCode", ct.ErrorCode}}
+	}
+	throttled := IsThrottlingErrorCode(ct.ErrorCode)
+
+	// 2) aggregate, flushing the region when it holds too many distinct seconds and dimensions
+	if fb.aggregator != nil {
+		fb.aggregator.addCall(region, ct.EventTime, dims, sample)
+		if errDims != nil {
+			fb.aggregator.addError(region, ct.EventTime, errDims, throttled)
+		}
+		if fb.maxCount > 0 && fb.aggregator.size(region) >= fb.maxCount {
+			fb.logger.Info("aggregate threshold reached for region %s; flushing", region)
+			fb.flushRegion(fb.ctx, region)
+		}
+		return nil
+	}
+
+	// 3) or write one record per event
+	if rec, ok := fb.buildCallRecord(ct.EventTime, dims, 1, sample); ok {
+		if err := fb.write(region, rec.Payload); err != nil {
+			return err
+		}
+	}
+	// failed calls are also counted per errorCode, throttled calls get their own metric
+	if errDims == nil {
+		return nil
+	}
+	var throttledCount float64
+	if throttled {
+		throttledCount = 1
+	}
+	if rec, ok := fb.buildErrorRecord(ct.EventTime, errDims, 1, throttledCount); ok {
+		return fb.write(region, rec.Payload)
+	}
+	return nil
+}
+
+// buildCallRecord builds the call count record of a set of dimension values.
+// The bucket metrics are only published under eventName as the bucket is per api.
+func (fb *CTFileBatcher) buildCallRecord(ts time.Time, dims [][]string, count float64, sample *tokenbucket.Sample) (emf.EMFRecord, bool) {
+	var extraMetrics []emf.EMFMetric
+	if sample != nil {
+		extraMetrics = []emf.EMFMetric{
+			{Name: MetricNameBucketRemaining, Value: sample.Remaining, Unit: emf.MetricUnitCount, DimensionSets: bucketDimensionSets},
+			{Name: MetricNameRateLimitUtilization, Value: sample.Utilization, Unit: emf.MetricUnitPercent, DimensionSets: bucketDimensionSets},
+		}
+	}
+	rec, err := emf.Build(emf.EMFInput{
+		Namespace:     fb.namespace,
+		MetricName:    fb.metricName,
+		Value:         count,
+		Unit:          emf.MetricUnitCount,
+		Dimensions:    dims,
+		DimensionSets: fb.dimensionSets,
+		Timestamp:     ts,
+		ExtraMetrics:  extraMetrics,
+	}, fb.logger)
+	if err != nil {
+		fb.logger.Error("EMF build failed: %v", err)
+		return emf.EMFRecord{}, false
+	}
+	return rec, true
+}
+
+// buildErrorRecord builds the error count record of an eventName and errorCode
+func (fb *CTFileBatcher) buildErrorRecord(ts time.Time, dims [][]string, count, throttled float64) (emf.EMFRecord, bool) {
+	var throttledMetrics []emf.EMFMetric
+	if fb.throttledMetricName != "" && throttled > 0 {
+		throttledMetrics = []emf.EMFMetric{{Name: fb.throttledMetricName, Value: throttled, Unit: emf.MetricUnitCount}}
+	}
+	rec, err := emf.Build(emf.EMFInput{
+		Namespace:    fb.namespace,
+		MetricName:   fb.errorMetricName,
+		Value:        count,
+		Unit:         emf.MetricUnitCount,
+		Dimensions:   dims,
+		Timestamp:    ts,
+		ExtraMetrics: throttledMetrics,
 	}, fb.logger)
 	if err != nil {
 		fb.logger.Error("EMF build failed: %v", err)
-		return
+		return emf.EMFRecord{}, false
+	}
+	return rec, true
+}
+
+// writeAggregates writes the aggregated records of a region to its file.  The aggregates
+// that could not be appended go back to the aggregator for the next flush.
+// It does not check the thresholds as it runs as part of a flush.
+func (fb *CTFileBatcher) writeAggregates(region string) error {
+	if fb.aggregator == nil {
+		return nil
 	}
-	data := emfRec.Payload
+	calls, errs := fb.aggregator.drain(region)
+	for i, a := range calls {
+		var sample *tokenbucket.Sample
+		if a.hasBucket {
+			sample = &tokenbucket.Sample{Remaining: a.minRemaining, Utilization: a.maxUtilization}
+		}
+		if rec, ok := fb.buildCallRecord(a.timestamp, a.dims, a.count, sample); ok {
+			if err := fb.appendLine(region, rec.Payload); err != nil {
+				fb.aggregator.restore(region, aggregateCall, calls[i:])
+				fb.aggregator.restore(region, aggregateError, errs)
+				return err
+			}
+		}
+	}
+	for i, a := range errs {
+		if rec, ok := fb.buildErrorRecord(a.timestamp, a.dims, a.count, a.throttled); ok {
+			if err := fb.appendLine(region, rec.Payload); err != nil {
+				fb.aggregator.restore(region, aggregateError, errs[i:])
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// write appends one EMF JSON line to the region file, flushing before the write
+// when it would overflow the thresholds and after it when they are reached.
+func (fb *CTFileBatcher) write(region string, data []byte) error {
 	recSize := int64(len(data) + 1) // include newline
 
-	// 2) pre-add threshold check: if adding would overflow, flush now
-	fb.mu.Lock()
-	prevCount := fb.counts[region]
-	prevSize := fb.sizes[region]
-	fb.mu.Unlock()
+	rl := fb.regionLog(region)
+	if rl == nil {
+		return ErrRegionLogUnavailable
+	}
+
+	// 1) pre-add threshold check: if adding would overflow, flush now
+	prevCount, prevSize := rl.log.Stats()
 
 	if (fb.maxCount > 0 && prevCount+1 > fb.maxCount) ||
 		(fb.maxBytes > 0 && prevSize+recSize > fb.maxBytes) {
 		fb.logger.Info("threshold reached for region %s before add; flushing", region)
-		fb.flushRegion(region)
+		fb.flushRegion(fb.ctx, region)
 	}
 
-	// 3) write new EMF JSON to file
-	path := filepath.Join(fb.baseDir, fmt.Sprintf("emf_%s.ndjson", region))
-	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
-	if err != nil {
-		fb.logger.Error("unable to open file for region %s: %v", region, err)
-		return
+	// 2) append new EMF JSON to the log
+	if err := fb.appendLine(region, data); err != nil {
+		return err
 	}
-	f.Write(data)
-	f.Write([]byte("\n"))
-	f.Close()
+	newCount, newSize := rl.log.Stats()
 
-	// 4) update counters
-	fb.mu.Lock()
-	fb.counts[region]++
-	fb.sizes[region] += recSize
-	newCount := fb.counts[region]
-	newSize := fb.sizes[region]
-	fb.mu.Unlock()
-
-	// 5) post-add threshold flush if exactly hit
+	// 3) post-add threshold flush if exactly hit
 	if (fb.maxCount > 0 && newCount >= fb.maxCount) ||
 		(fb.maxBytes > 0 && newSize >= fb.maxBytes) {
 		fb.logger.Info("threshold reached for region %s after add; flushing asynchronously", region)
-		fb.flushRegion(region)
+		fb.flushRegion(fb.ctx, region)
 	}
+	return nil
+}
+
+// regionLog returns the write-ahead log of a region, opening it when needed
+func (fb *CTFileBatcher) regionLog(region string) *regionLog {
+	fb.mu.Lock()
+	defer fb.mu.Unlock()
+	if rl, ok := fb.regionLogs[region]; ok {
+		return rl
+	}
+	log, err := wal.Open(wal.Config{
+		Dir:    fb.baseDir,
+		Prefix: segmentPrefix(region),
+		Fsync:  fb.fsync,
+		FS:     fb.fs,
+	})
+	if err != nil {
+		fb.logger.Error("unable to open write-ahead log for region %s: %v", region, err)
+		return nil
+	}
+	rl := &regionLog{log: log}
+	fb.regionLogs[region] = rl
+	return rl
+}
+
+// appendLine appends one EMF JSON line to the region log
+func (fb *CTFileBatcher) appendLine(region string, data []byte) error {
+	rl := fb.regionLog(region)
+	if rl == nil {
+		return ErrRegionLogUnavailable
+	}
+	if err := rl.log.Append(data); err != nil {
+		fb.logger.Error("unable to append to write-ahead log for region %s: %v", region, err)
+		return err
+	}
+	return nil
+}
+
+// writeTopCallers closes the top callers window and writes its report.
+// It returns the errors of the records that could not be written.
+func (fb *CTFileBatcher) writeTopCallers() error {
+	if fb.topCallers == nil {
+		return nil
+	}
+	var errs []error
+	for _, c := range fb.topCallers.rotate() {
+		rec, err := emf.Build(emf.EMFInput{
+			Namespace:  fb.namespace,
+			MetricName: fb.topCallersMetricName,
+			Value:      float64(c.Count),
+			Unit:       emf.MetricUnitCount,
+			Dimensions: [][]string{{DimensionEventName, c.EventName}, {c.Dimension, c.Caller}},
+			Timestamp:  c.Timestamp,
+		}, fb.logger)
+		if err != nil {
+			fb.logger.Error("EMF build failed: %v", err)
+			continue
+		}
+		if err := fb.write(c.Region, rec.Payload); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// writePeakRates closes the peak rate window and writes its report.
+// It returns the errors of the records that could not be written.
+func (fb *CTFileBatcher) writePeakRates() error {
+	if fb.peakRates == nil {
+		return nil
+	}
+	var errs []error
+	for _, r := range fb.peakRates.rotate() {
+		rec, err := emf.Build(emf.EMFInput{
+			Namespace:  fb.namespace,
+			MetricName: MetricNamePeakRPS,
+			Value:      r.Peak,
+			Unit:       emf.MetricUnitCountPerSecond,
+			Dimensions: [][]string{{DimensionEventName, r.EventName}},
+			Timestamp:  r.Timestamp,
+			ExtraMetrics: []emf.EMFMetric{
+				{Name: MetricNameP99RPS, Value: r.P99, Unit: emf.MetricUnitCountPerSecond},
+			},
+		}, fb.logger)
+		if err != nil {
+			fb.logger.Error("EMF build failed: %v", err)
+			continue
+		}
+		if err := fb.write(r.Region, rec.Payload); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// writeWindowReports writes the reports that follow the flush interval.
+// The records of a report that could not be written are lost with its window.
+func (fb *CTFileBatcher) writeWindowReports() error {
+	return errors.Join(fb.writeTopCallers(), fb.writePeakRates())
 }
 
 // Stop cancels periodic flushes and flushes all regions once.
 func (fb *CTFileBatcher) Stop() {
 	fb.ticker.Stop()
-
-	// snapshot regions
-	fb.mu.Lock()
-	regions := make([]string, 0, len(fb.counts))
-	for r := range fb.counts {
-		regions = append(regions, r)
+	if err := fb.writeWindowReports(); err != nil {
+		fb.logger.Error("failed to write the top callers and peak rate reports: %v", err)
 	}
-	fb.mu.Unlock()
+
+	regions := fb.regions()
 
 	// flush in parallel
 	var wg sync.WaitGroup
@@ -173,13 +530,33 @@ func (fb *CTFileBatcher) Stop() {
 		wg.Add(1)
 		go func(r string) {
 			defer wg.Done()
-			fb.flushRegion(r)
+			fb.flushRegion(fb.ctx, r)
 		}(region)
 	}
 	wg.Wait()
 	fb.cancel()
 }
 
+// regions snapshots the regions with written or aggregated records
+func (fb *CTFileBatcher) regions() []string {
+	fb.mu.Lock()
+	seen := make(map[string]struct{}, len(fb.regionLogs))
+	regions := make([]string, 0, len(fb.regionLogs))
+	for r := range fb.regionLogs {
+		seen[r] = struct{}{}
+		regions = append(regions, r)
+	}
+	fb.mu.Unlock()
+	if fb.aggregator != nil {
+		for _, r := range fb.aggregator.regionNames() {
+			if _, ok := seen[r]; !ok {
+				regions = append(regions, r)
+			}
+		}
+	}
+	return regions
+}
+
 // startTicker periodically flushes all regions until context is canceled.
 func (fb *CTFileBatcher) startTicker() {
 	for {
@@ -187,13 +564,12 @@ func (fb *CTFileBatcher) startTicker() {
 		case <-fb.ctx.Done():
 			return
 		case <-fb.ticker.C:
-			// snapshot regions
-			fb.mu.Lock()
-			regions := make([]string, 0, len(fb.counts))
-			for r := range fb.counts {
-				regions = append(regions, r)
+			// the top callers and peak rate windows follow the flush interval
+			if err := fb.writeWindowReports(); err != nil {
+				fb.logger.Error("failed to write the top callers and peak rate reports: %v", err)
 			}
-			fb.mu.Unlock()
+
+			regions := fb.regions()
 
 			// flush in parallel
 			var wg sync.WaitGroup
@@ -201,7 +577,7 @@ func (fb *CTFileBatcher) startTicker() {
 				wg.Add(1)
 				go func(r string) {
 					defer wg.Done()
-					fb.flushRegion(r)
+					fb.flushRegion(fb.ctx, r)
 				}(region)
 			}
 			wg.Wait()
@@ -209,53 +585,85 @@ func (fb *CTFileBatcher) startTicker() {
 	}
 }
 
-// flushRegion reads EMF JSON lines, parses timestamps, invokes the EMF flusher,
-// truncates the file, and resets counters.
-func (fb *CTFileBatcher) flushRegion(region string) {
+// FlushRegion flushes a region now.  It returns nil only when every record added to the
+// region before the call, and left by earlier failed flushes, was accepted by the EMF flusher.
+// The records are pushed under ctx, so its deadline also bounds the calls to CloudWatch Logs.
+func (fb *CTFileBatcher) FlushRegion(ctx context.Context, region string) error {
+	if err := fb.ctx.Err(); err != nil {
+		return err
+	}
+	return fb.flushRegion(ctx, region)
+}
+
+// flushRegion seals the active segment of a region, then pushes its sealed segments
+// oldest first, deleting each once the EMF flusher accepted it.  A segment that fails is
+// kept for the next flush and does not hold back the newer ones.  It returns the errors
+// of the failed segments.
+func (fb *CTFileBatcher) flushRegion(ctx context.Context, region string) error {
 	// respect cancellation
 	select {
-	case <-fb.ctx.Done():
-		return
+	case <-ctx.Done():
+		return ctx.Err()
 	default:
 	}
 
-	path := filepath.Join(fb.baseDir, fmt.Sprintf("emf_%s.ndjson", region))
-	f, err := os.Open(path)
+	rl := fb.regionLog(region)
+	if rl == nil {
+		return ErrRegionLogUnavailable
+	}
+	rl.flushMu.Lock()
+	defer rl.flushMu.Unlock()
+
+	if err := fb.writeAggregates(region); err != nil {
+		return err
+	}
+	if err := rl.log.Rotate(); err != nil {
+		fb.logger.Error("failed to seal segment for region %s: %v", region, err)
+	}
+	segments, err := rl.log.Sealed()
+	if err != nil {
+		fb.logger.Error("cannot list segments for region %s: %v", region, err)
+		return err
+	}
+
+	var errs []error
+	for _, segment := range segments {
+		if err := ctx.Err(); err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+		if err := fb.flushSegment(ctx, rl, region, segment); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// flushSegment pushes the records of a sealed segment and deletes it once they are accepted
+func (fb *CTFileBatcher) flushSegment(ctx context.Context, rl *regionLog, region string, segment wal.Segment) error {
+	lines, err := rl.log.Read(segment)
 	if err != nil {
-		fb.logger.Error("cannot open file for flush for region %s: %v", region, err)
-		return
+		fb.logger.Error("cannot read segment %s for region %s, keeping it for the next flush: %v", segment.Path, region, err)
+		return err
 	}
-	scanner := bufio.NewScanner(f)
 	var batch []emf.EMFRecord
-	for scanner.Scan() {
-		line := scanner.Bytes()
+	for _, line := range lines {
 		fb.logger.Debug("flushing line: %s", string(line))
-		var env struct {
-			AWS struct {
-				Timestamp int64 `json:"Timestamp"`
-			} `json:"_aws"`
-		}
-		if err := json.Unmarshal(line, &env); err != nil {
+		rec, err := emf.ParseRecord(line)
+		if err != nil {
+			fb.logger.Warn("skipping unreadable line in segment %s: %v", segment.Path, err)
 			continue
 		}
-		rec := emf.EMFRecord{Payload: slices.Clone(line), TimeStamp: time.UnixMilli(env.AWS.Timestamp)}
 		batch = append(batch, rec)
 	}
-	f.Close()
-	if err := scanner.Err(); err != nil {
-		fb.logger.Error("error reading file for region %s: %v", region, err)
-	}
-
 	if len(batch) > 0 {
-		fb.emfFlusher.Flush(fb.ctx, region, batch)
+		if err := fb.emfFlusher.Flush(ctx, region, batch); err != nil {
+			fb.logger.Error("failed to flush segment %s for region %s, keeping it for the next flush: %v", segment.Path, region, err)
+			return err
+		}
 	}
-
-	// truncate & reset
-	if err := os.Truncate(path, 0); err != nil {
-		fb.logger.Error("failed to truncate file for region %s: %v", region, err)
+	if err := rl.log.Remove(segment); err != nil {
+		fb.logger.Error("failed to delete flushed segment %s for region %s, it will be flushed again: %v", segment.Path, region, err)
+		return err
 	}
-	fb.mu.Lock()
-	fb.counts[region] = 0
-	fb.sizes[region] = 0
-	fb.mu.Unlock()
+	return nil
 }
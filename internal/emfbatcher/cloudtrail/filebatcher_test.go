@@ -3,7 +3,7 @@ package cloudtrailemfbatcher
 
 import (
 	"context"
-	"os"
+	"encoding/json"
 	"path/filepath"
 	"sync"
 	"testing"
@@ -14,6 +14,7 @@ import (
 	"github.com/outofoffice3/aws-samples/geras/internal/emf"
 	"github.com/outofoffice3/aws-samples/geras/internal/logger"
 	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/tokenbucket"
 )
 
 // fakeFlusher records every Flush call.
@@ -136,7 +137,7 @@ func TestCTFileBatcher_StopFlushAll(t *testing.T) {
 	assert.Len(t, ff1.calls["ap-south-1"], 1, "Stop should flush ap-south-1")
 }
 
-func TestCTFileBatcher_FileWriteAndTruncate(t *testing.T) {
+func TestCTFileBatcher_FileWriteAndDelete(t *testing.T) {
 	temp := t.TempDir()
 	ff := newFakeFlusher()
 
@@ -158,9 +159,353 @@ func TestCTFileBatcher_FileWriteAndTruncate(t *testing.T) {
 	})
 	time.Sleep(10 * time.Millisecond)
 
-	// After flush, file should be truncated to zero length
-	path := filepath.Join(temp, "emf_ca-central-1.ndjson")
-	info, err := os.Stat(path)
+	// After flush, the flushed segment should be deleted
+	ff.mu.Lock()
+	assert.Len(t, ff.calls["ca-central-1"], 1)
+	ff.mu.Unlock()
+	segments, err := filepath.Glob(filepath.Join(temp, "emf_ca-central-1.*.ndjson"))
 	assert.NoError(t, err)
-	assert.Zero(t, info.Size(), "file must be truncated after flush")
+	assert.Empty(t, segments, "segment must be deleted after flush")
+}
+
+func TestCTFileBatcher_TokenBucketMetrics(t *testing.T) {
+	temp := t.TempDir()
+	ff := newFakeFlusher()
+
+	batcher := NewCTFileBatcher(CTFileBatcherConfig{
+		ParentCtx:     context.Background(),
+		Namespace:     "N",
+		MetricName:    "M",
+		BaseDir:       temp,
+		MaxCount:      100,
+		MaxBytes:      0,
+		FlushInterval: time.Hour,
+		EmfFlusher:    ff,
+		Simulator: tokenbucket.NewSimulator(map[string]tokenbucket.Limit{
+			tokenbucket.Key("ec2.amazonaws.com", "DescribeInstances"): {BucketSize: 4, RefillRate: 1},
+		}),
+		Logger: logger.Get(),
+	})
+
+	now := time.Now()
+	batcher.Add(context.Background(), "us-east-1", sharedtypes.CloudTrailEvent{
+		EventSource: "ec2.amazonaws.com", EventName: "DescribeInstances", AWSRegion: "us-east-1", EventTime: now,
+	})
+	batcher.Add(context.Background(), "us-east-1", sharedtypes.CloudTrailEvent{
+		EventSource: "ec2.amazonaws.com", EventName: "DescribeInstances", AWSRegion: "us-east-1", EventTime: now,
+	})
+	batcher.Add(context.Background(), "us-east-1", sharedtypes.CloudTrailEvent{
+		EventSource: "iam.amazonaws.com", EventName: "GetRole", AWSRegion: "us-east-1", EventTime: now,
+	})
+	batcher.Stop()
+
+	ff.mu.Lock()
+	defer ff.mu.Unlock()
+	if !assert.Len(t, ff.calls["us-east-1"], 1) || !assert.Len(t, ff.calls["us-east-1"][0], 3) {
+		return
+	}
+
+	var docs []map[string]any
+	for _, rec := range ff.calls["us-east-1"][0] {
+		var doc map[string]any
+		assert.NoError(t, json.Unmarshal(rec.Payload, &doc))
+		docs = append(docs, doc)
+	}
+	assert.Equal(t, 3.0, docs[0][MetricNameBucketRemaining])
+	assert.Equal(t, 25.0, docs[0][MetricNameRateLimitUtilization])
+	assert.Equal(t, 2.0, docs[1][MetricNameBucketRemaining])
+	assert.Equal(t, 50.0, docs[1][MetricNameRateLimitUtilization])
+	assert.Equal(t, 1.0, docs[2]["M"])
+	assert.NotContains(t, docs[2], MetricNameBucketRemaining, "apis without a limit only emit the call count")
+}
+
+func TestCTFileBatcher_ErrorMetrics(t *testing.T) {
+	temp := t.TempDir()
+	ff := newFakeFlusher()
+
+	batcher := NewCTFileBatcher(CTFileBatcherConfig{
+		ParentCtx:           context.Background(),
+		Namespace:           "N",
+		MetricName:          "M",
+		ErrorMetricName:     "ErrorCount",
+		ThrottledMetricName: "ThrottledCount",
+		BaseDir:             temp,
+		MaxCount:            100,
+		FlushInterval:       time.Hour,
+		EmfFlusher:          ff,
+		Logger:              logger.Get(),
+	})
+
+	now := time.Now()
+	batcher.Add(context.Background(), "us-east-1", sharedtypes.CloudTrailEvent{
+		EventName: "AssumeRole", EventTime: now,
+	})
+	batcher.Add(context.Background(), "us-east-1", sharedtypes.CloudTrailEvent{
+		EventName: "AssumeRole", EventTime: now, ErrorCode: "ThrottlingException", ErrorMessage: "Rate exceeded",
+	})
+	batcher.Add(context.Background(), "us-east-1", sharedtypes.CloudTrailEvent{
+		EventName: "AssumeRole", EventTime: now, ErrorCode: "AccessDenied",
+	})
+	batcher.Stop()
+
+	ff.mu.Lock()
+	defer ff.mu.Unlock()
+	if !assert.Len(t, ff.calls["us-east-1"], 1) {
+		return
+	}
+
+	var callCount, errorCount, throttledCount float64
+	errorCodes := map[string]float64{}
+	for _, rec := range ff.calls["us-east-1"][0] {
+		var doc map[string]any
+		assert.NoError(t, json.Unmarshal(rec.Payload, &doc))
+		if v, ok := doc["M"].(float64); ok {
+			callCount += v
+		}
+		if v, ok := doc["ErrorCount"].(float64); ok {
+			errorCount += v
+			errorCodes[doc["errorCode"].(string)] += v
+			assert.Equal(t, "AssumeRole", doc["eventName"])
+		}
+		if v, ok := doc["ThrottledCount"].(float64); ok {
+			throttledCount += v
+			assert.Equal(t, "ThrottlingException", doc["errorCode"])
+		}
+	}
+	assert.Equal(t, 3.0, callCount, "failed calls are still counted as calls")
+	assert.Equal(t, 2.0, errorCount)
+	assert.Equal(t, 1.0, throttledCount)
+	assert.Equal(t, map[string]float64{"ThrottlingException": 1, "AccessDenied": 1}, errorCodes)
+}
+
+func TestCTFileBatcher_ErrorMetricsDisabled(t *testing.T) {
+	temp := t.TempDir()
+	ff := newFakeFlusher()
+
+	batcher := NewCTFileBatcher(CTFileBatcherConfig{
+		ParentCtx:     context.Background(),
+		Namespace:     "N",
+		MetricName:    "M",
+		BaseDir:       temp,
+		MaxCount:      100,
+		FlushInterval: time.Hour,
+		EmfFlusher:    ff,
+		Logger:        logger.Get(),
+	})
+
+	batcher.Add(context.Background(), "us-east-1", sharedtypes.CloudTrailEvent{
+		EventName: "AssumeRole", EventTime: time.Now(), ErrorCode: "ThrottlingException",
+	})
+	batcher.Stop()
+
+	ff.mu.Lock()
+	defer ff.mu.Unlock()
+	if assert.Len(t, ff.calls["us-east-1"], 1) {
+		assert.Len(t, ff.calls["us-east-1"][0], 1, "only the call count is written")
+	}
+}
+
+func TestIsThrottlingErrorCode(t *testing.T) {
+	tests := []struct {
+		code string
+		want bool
+	}{
+		{"ThrottlingException", true},
+		{"RequestLimitExceeded", true},
+		{"Client.RequestLimitExceeded", true},
+		{"TooManyRequestsException", true},
+		{"AccessDenied", false},
+		{"Client.UnauthorizedOperation", false},
+		{"LimitExceededException", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsThrottlingErrorCode(tt.code))
+		})
+	}
+}
+
+func TestCTFileBatcher_DimensionSets(t *testing.T) {
+	temp := t.TempDir()
+	ff := newFakeFlusher()
+
+	sets, err := BuildDimensionSets([][]string{{DimensionUserIdentityARN}})
+	assert.NoError(t, err)
+	batcher := NewCTFileBatcher(CTFileBatcherConfig{
+		ParentCtx:     context.Background(),
+		Namespace:     "N",
+		MetricName:    "M",
+		DimensionSets: sets,
+		BaseDir:       temp,
+		MaxCount:      1,
+		FlushInterval: time.Hour,
+		EmfFlusher:    ff,
+		Simulator: tokenbucket.NewSimulator(map[string]tokenbucket.Limit{
+			tokenbucket.Key("sts.amazonaws.com", "AssumeRole"): {BucketSize: 10, RefillRate: 10},
+		}),
+		Logger: logger.Get(),
+	})
+
+	batcher.Add(context.Background(), "us-east-1", sharedtypes.CloudTrailEvent{
+		EventSource:  "sts.amazonaws.com",
+		EventName:    "AssumeRole",
+		EventTime:    time.Now(),
+		UserIdentity: sharedtypes.UserIdentityDetail{ARN: "arn:aws:sts::123456789012:assumed-role/Deployer/session-1"},
+	})
+
+	ff.mu.Lock()
+	defer ff.mu.Unlock()
+	if !assert.Len(t, ff.calls["us-east-1"], 1) {
+		return
+	}
+
+	var doc struct {
+		EventName string `json:"eventName"`
+		ARN       string `json:"userIdentity.arn"`
+		AWS       struct {
+			CloudWatchMetrics []struct {
+				Dimensions [][]string `json:"Dimensions"`
+				Metrics    []struct {
+					Name string `json:"Name"`
+				} `json:"Metrics"`
+			} `json:"CloudWatchMetrics"`
+		} `json:"_aws"`
+	}
+	assert.NoError(t, json.Unmarshal(ff.calls["us-east-1"][0][0].Payload, &doc))
+	assert.Equal(t, "AssumeRole", doc.EventName)
+	assert.Equal(t, "arn:aws:sts::123456789012:assumed-role/Deployer", doc.ARN)
+
+	// the call count is published under every set, the bucket metrics under eventName only
+	directives := doc.AWS.CloudWatchMetrics
+	if assert.Len(t, directives, 3) {
+		assert.Equal(t, [][]string{{"eventName"}, {"eventName", "userIdentity.arn"}}, directives[0].Dimensions)
+		assert.Equal(t, "M", directives[0].Metrics[0].Name)
+		assert.Equal(t, [][]string{{"eventName"}}, directives[1].Dimensions)
+		assert.Equal(t, MetricNameBucketRemaining, directives[1].Metrics[0].Name)
+		assert.Equal(t, [][]string{{"eventName"}}, directives[2].Dimensions)
+	}
+}
+
+func TestCTFileBatcher_TopCallers(t *testing.T) {
+	temp := t.TempDir()
+	ff := newFakeFlusher()
+
+	batcher := NewCTFileBatcher(CTFileBatcherConfig{
+		ParentCtx:            context.Background(),
+		Namespace:            "N",
+		MetricName:           "M",
+		TopCallers:           1,
+		TopCallersMetricName: "TopCallerCount",
+		BaseDir:              temp,
+		MaxCount:             100,
+		FlushInterval:        time.Hour,
+		EmfFlusher:           ff,
+		Logger:               logger.Get(),
+	})
+
+	now := time.Now()
+	for _, arn := range []string{"arn:aws:iam::1:user/a", "arn:aws:iam::1:user/a", "arn:aws:iam::1:user/b"} {
+		batcher.Add(context.Background(), "us-east-1", sharedtypes.CloudTrailEvent{
+			EventName: "AssumeRole", EventTime: now, UserIdentity: sharedtypes.UserIdentityDetail{ARN: arn},
+		})
+	}
+	batcher.Stop()
+
+	ff.mu.Lock()
+	defer ff.mu.Unlock()
+	if !assert.Len(t, ff.calls["us-east-1"], 1) {
+		return
+	}
+
+	callers := map[string]float64{}
+	for _, rec := range ff.calls["us-east-1"][0] {
+		var doc map[string]any
+		assert.NoError(t, json.Unmarshal(rec.Payload, &doc))
+		v, ok := doc["TopCallerCount"].(float64)
+		if !ok {
+			continue
+		}
+		if arn, ok := doc[DimensionUserIdentityARN].(string); ok {
+			callers[arn] = v
+		}
+		if ua, ok := doc[DimensionUserAgent].(string); ok {
+			callers["ua:"+ua] = v
+		}
+	}
+	assert.Equal(t, map[string]float64{
+		"arn:aws:iam::1:user/a": 2,
+		"other":                 1,
+		"ua:unknown":            3,
+	}, callers)
+}
+
+func TestCTFileBatcher_PeakRate(t *testing.T) {
+	temp := t.TempDir()
+	ff := newFakeFlusher()
+
+	batcher := NewCTFileBatcher(CTFileBatcherConfig{
+		ParentCtx:     context.Background(),
+		Namespace:     "N",
+		MetricName:    "M",
+		PeakRate:      true,
+		BaseDir:       temp,
+		MaxCount:      100,
+		FlushInterval: time.Hour,
+		EmfFlusher:    ff,
+		Logger:        logger.Get(),
+	})
+
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	for _, offset := range []time.Duration{0, 100 * time.Millisecond, 900 * time.Millisecond, 1500 * time.Millisecond} {
+		batcher.Add(context.Background(), "us-east-1", sharedtypes.CloudTrailEvent{
+			EventName: "AssumeRole", EventTime: start.Add(offset),
+		})
+	}
+	batcher.Stop()
+
+	ff.mu.Lock()
+	defer ff.mu.Unlock()
+	if !assert.Len(t, ff.calls["us-east-1"], 1) {
+		return
+	}
+	var found bool
+	for _, rec := range ff.calls["us-east-1"][0] {
+		var doc map[string]any
+		assert.NoError(t, json.Unmarshal(rec.Payload, &doc))
+		if _, ok := doc[MetricNamePeakRPS]; !ok {
+			continue
+		}
+		found = true
+		assert.Equal(t, "AssumeRole", doc["eventName"])
+		assert.Equal(t, 3.0, doc[MetricNamePeakRPS])
+		assert.Equal(t, 3.0, doc[MetricNameP99RPS])
+		assert.Equal(t, start.Add(1500*time.Millisecond).UnixMilli(), rec.TimeStamp.UnixMilli())
+	}
+	assert.True(t, found, "PeakRPS should be written on stop")
+}
+
+func TestCTFileBatcher_WindowReportsReturnWriteErrors(t *testing.T) {
+	ff, fs := newFakeFlusher(), &faultyFS{}
+	batcher := NewCTFileBatcher(CTFileBatcherConfig{
+		ParentCtx:            context.Background(),
+		Namespace:            "N",
+		MetricName:           "M",
+		TopCallers:           1,
+		TopCallersMetricName: "TopCallerCount",
+		PeakRate:             true,
+		BaseDir:              t.TempDir(),
+		MaxCount:             100,
+		FlushInterval:        time.Hour,
+		EmfFlusher:           ff,
+		FS:                   fs,
+		Logger:               logger.Get(),
+	})
+
+	batcher.Add(context.Background(), "us-east-1", sharedtypes.CloudTrailEvent{EventName: "AssumeRole", EventTime: time.Now()})
+	assert.NoError(t, batcher.FlushRegion(context.Background(), "us-east-1"))
+
+	fs.opens = 1
+	assert.ErrorIs(t, batcher.writeWindowReports(), errInjected)
+	assert.NoError(t, batcher.writeWindowReports(), "the next window has nothing to report")
 }
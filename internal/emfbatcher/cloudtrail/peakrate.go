@@ -0,0 +1,97 @@
+package cloudtrailemfbatcher
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+)
+
+// perSecondCalls counts the calls of one api in one region per second of event time
+type perSecondCalls struct {
+	seconds   map[int64]int64 // unix second -> calls
+	lastEvent time.Time
+}
+
+// peakRate is the peak and p99 per second call rate of an api over a window
+type peakRate struct {
+	Region    string
+	EventName string
+	Peak      float64
+	P99       float64
+	Timestamp time.Time
+}
+
+// peakRates counts the calls of every api per second over one window, so sub minute
+// bursts can be reported without publishing a high resolution metric per event.
+type peakRates struct {
+	mu      sync.Mutex
+	windows map[string]map[string]*perSecondCalls // region -> eventName -> calls
+}
+
+func newPeakRates() *peakRates {
+	return &peakRates{windows: make(map[string]map[string]*perSecondCalls)}
+}
+
+// observe counts one call in the second of its event time
+func (pr *peakRates) observe(region string, ct sharedtypes.CloudTrailEvent) {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+
+	apis, ok := pr.windows[region]
+	if !ok {
+		apis = make(map[string]*perSecondCalls)
+		pr.windows[region] = apis
+	}
+	calls, ok := apis[ct.EventName]
+	if !ok {
+		calls = &perSecondCalls{seconds: make(map[int64]int64)}
+		apis[ct.EventName] = calls
+	}
+	calls.seconds[ct.EventTime.Unix()]++
+	if ct.EventTime.After(calls.lastEvent) {
+		calls.lastEvent = ct.EventTime
+	}
+}
+
+// rotate closes the current window and returns the highest and p99 calls per second of every api.
+// Only the seconds with calls are ranked, so the p99 describes the rate while the api is in use.
+func (pr *peakRates) rotate() []peakRate {
+	pr.mu.Lock()
+	windows := pr.windows
+	pr.windows = make(map[string]map[string]*perSecondCalls)
+	pr.mu.Unlock()
+
+	var out []peakRate
+	for region, apis := range windows {
+		for eventName, calls := range apis {
+			rates := make([]int64, 0, len(calls.seconds))
+			for _, n := range calls.seconds {
+				rates = append(rates, n)
+			}
+			sort.Slice(rates, func(i, j int) bool { return rates[i] < rates[j] })
+			out = append(out, peakRate{
+				Region:    region,
+				EventName: eventName,
+				Peak:      float64(rates[len(rates)-1]),
+				P99:       float64(percentile(rates, 99)),
+				Timestamp: calls.lastEvent,
+			})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Region != out[j].Region {
+			return out[i].Region < out[j].Region
+		}
+		return out[i].EventName < out[j].EventName
+	})
+	return out
+}
+
+// percentile returns the nearest rank percentile of sorted values
+func percentile(sorted []int64, p float64) int64 {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
@@ -0,0 +1,56 @@
+package cloudtrailemfbatcher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+)
+
+func TestPeakRates_Rotate(t *testing.T) {
+	pr := newPeakRates()
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// 100 seconds with 1 to 100 calls each, then a burst of 500 calls in one second
+	for s := 1; s <= 100; s++ {
+		for i := 0; i < s; i++ {
+			pr.observe("us-east-1", sharedtypes.CloudTrailEvent{
+				EventName: "AssumeRole",
+				EventTime: start.Add(time.Duration(s)*time.Second + time.Duration(i)*time.Millisecond),
+			})
+		}
+	}
+	burst := start.Add(200 * time.Second)
+	for i := 0; i < 500; i++ {
+		pr.observe("us-east-1", sharedtypes.CloudTrailEvent{EventName: "AssumeRole", EventTime: burst})
+	}
+	pr.observe("eu-west-1", sharedtypes.CloudTrailEvent{EventName: "Decrypt", EventTime: start})
+
+	got := pr.rotate()
+	assert.Equal(t, []peakRate{
+		{Region: "eu-west-1", EventName: "Decrypt", Peak: 1, P99: 1, Timestamp: start},
+		// 101 ranked seconds, the 100th of them holds 100 calls
+		{Region: "us-east-1", EventName: "AssumeRole", Peak: 500, P99: 100, Timestamp: burst},
+	}, got)
+
+	// the window restarts after a rotate
+	assert.Empty(t, pr.rotate())
+}
+
+func TestPercentile(t *testing.T) {
+	tests := []struct {
+		values []int64
+		p      float64
+		want   int64
+	}{
+		{[]int64{7}, 99, 7},
+		{[]int64{1, 2, 3, 4}, 50, 2},
+		{[]int64{1, 2, 3, 4}, 99, 4},
+		{[]int64{1, 2, 3, 4}, 0, 1},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, percentile(tt.values, tt.p), "p%v of %v", tt.p, tt.values)
+	}
+}
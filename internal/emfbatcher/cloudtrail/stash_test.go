@@ -0,0 +1,329 @@
+package cloudtrailemfbatcher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/outofoffice3/aws-samples/geras/internal/emf"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/wal"
+)
+
+var errInjected = errors.New("injected failure")
+
+// flakyFlusher records the batches it accepts and fails the calls it is told to
+type flakyFlusher struct {
+	mu       sync.Mutex
+	failures int
+	accepted [][]emf.EMFRecord
+	onFlush  func()
+}
+
+func (f *flakyFlusher) Flush(_ context.Context, _ string, batch []emf.EMFRecord) error {
+	if f.onFlush != nil {
+		f.onFlush()
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failures > 0 {
+		f.failures--
+		return errInjected
+	}
+	f.accepted = append(f.accepted, batch)
+	return nil
+}
+
+// eventNames returns the eventName of every accepted record, in order
+func (f *flakyFlusher) eventNames(t *testing.T) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var names []string
+	for _, batch := range f.accepted {
+		for _, rec := range batch {
+			var doc map[string]any
+			require.NoError(t, json.Unmarshal(rec.Payload, &doc))
+			names = append(names, doc["eventName"].(string))
+		}
+	}
+	return names
+}
+
+// faultyFS fails the next opens, reads and removes it is told to
+type faultyFS struct {
+	wal.OSFS
+	mu      sync.Mutex
+	opens   int
+	reads   int
+	removes int
+}
+
+func (f *faultyFS) OpenFile(name string, flag int, perm os.FileMode) (wal.File, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.opens > 0 {
+		f.opens--
+		return nil, errInjected
+	}
+	return f.OSFS.OpenFile(name, flag, perm)
+}
+
+func (f *faultyFS) ReadFile(name string) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.reads > 0 {
+		f.reads--
+		return nil, errInjected
+	}
+	return os.ReadFile(name)
+}
+
+func (f *faultyFS) Remove(name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.removes > 0 {
+		f.removes--
+		return errInjected
+	}
+	return os.Remove(name)
+}
+
+func newStashBatcher(dir string, flusher emf.EMFFlusher, fs wal.FS) *CTFileBatcher {
+	return NewCTFileBatcher(CTFileBatcherConfig{
+		ParentCtx:     context.Background(),
+		Namespace:     "N",
+		MetricName:    "M",
+		BaseDir:       dir,
+		MaxCount:      100,
+		FlushInterval: time.Hour,
+		EmfFlusher:    flusher,
+		FS:            fs,
+		Logger:        logger.Get(),
+	})
+}
+
+func addEvents(b *CTFileBatcher, names ...string) {
+	for _, name := range names {
+		b.Add(context.Background(), "us-east-1", sharedtypes.CloudTrailEvent{EventName: name, EventTime: time.Now()})
+	}
+}
+
+func segmentFiles(t *testing.T, dir string) []string {
+	files, err := filepath.Glob(filepath.Join(dir, "emf_us-east-1.*.ndjson"))
+	require.NoError(t, err)
+	return files
+}
+
+func TestStash_FailedFlushKeepsSegments(t *testing.T) {
+	dir := t.TempDir()
+	ff := &flakyFlusher{failures: 1}
+	b := newStashBatcher(dir, ff, nil)
+
+	addEvents(b, "A", "B")
+	b.flushRegion(context.Background(), "us-east-1")
+	assert.Empty(t, ff.eventNames(t))
+	assert.Len(t, segmentFiles(t, dir), 1, "the failed segment is kept")
+
+	// the next flush retries the kept segment before the new one
+	addEvents(b, "C")
+	b.flushRegion(context.Background(), "us-east-1")
+	assert.Equal(t, []string{"A", "B", "C"}, ff.eventNames(t))
+	assert.Len(t, ff.accepted, 2, "one batch per segment")
+	assert.Empty(t, segmentFiles(t, dir))
+}
+
+func TestStash_FailedSegmentDoesNotBlockNewerOnes(t *testing.T) {
+	dir := t.TempDir()
+	ff := &flakyFlusher{failures: 2}
+	b := newStashBatcher(dir, ff, nil)
+
+	addEvents(b, "A")
+	assert.Error(t, b.FlushRegion(context.Background(), "us-east-1"))
+
+	// the kept segment fails again, the newer one is still pushed
+	addEvents(b, "B")
+	assert.ErrorIs(t, b.FlushRegion(context.Background(), "us-east-1"), errInjected)
+	assert.Equal(t, []string{"B"}, ff.eventNames(t))
+	assert.Len(t, segmentFiles(t, dir), 1)
+
+	require.NoError(t, b.FlushRegion(context.Background(), "us-east-1"))
+	assert.Equal(t, []string{"B", "A"}, ff.eventNames(t))
+	assert.Empty(t, segmentFiles(t, dir))
+}
+
+func TestStash_AddDuringFlushIsKept(t *testing.T) {
+	dir := t.TempDir()
+	ff := &flakyFlusher{}
+	b := newStashBatcher(dir, ff, nil)
+
+	var once sync.Once
+	ff.onFlush = func() {
+		// an event arriving while the sealed segment is being pushed
+		once.Do(func() { addEvents(b, "during") })
+	}
+	addEvents(b, "before")
+	b.flushRegion(context.Background(), "us-east-1")
+	assert.Equal(t, []string{"before"}, ff.eventNames(t))
+	assert.Len(t, segmentFiles(t, dir), 1, "the event added during the flush is in the next segment")
+
+	b.Stop()
+	assert.Equal(t, []string{"before", "during"}, ff.eventNames(t))
+}
+
+func TestStash_ReplayAfterCrash(t *testing.T) {
+	tests := []struct {
+		name     string
+		crash    func(t *testing.T, b *CTFileBatcher, ff *flakyFlusher, fs *faultyFS)
+		first    []string // accepted before the crash
+		replayed []string // accepted by the next run
+	}{
+		{
+			name:     "after append",
+			crash:    func(t *testing.T, b *CTFileBatcher, ff *flakyFlusher, fs *faultyFS) {},
+			replayed: []string{"A", "B"},
+		},
+		{
+			name: "after rotate",
+			crash: func(t *testing.T, b *CTFileBatcher, ff *flakyFlusher, fs *faultyFS) {
+				require.NoError(t, b.regionLog("us-east-1").log.Rotate())
+			},
+			replayed: []string{"A", "B"},
+		},
+		{
+			name: "after a failed read",
+			crash: func(t *testing.T, b *CTFileBatcher, ff *flakyFlusher, fs *faultyFS) {
+				fs.reads = 1
+				b.flushRegion(context.Background(), "us-east-1")
+			},
+			replayed: []string{"A", "B"},
+		},
+		{
+			name: "after a failed flush",
+			crash: func(t *testing.T, b *CTFileBatcher, ff *flakyFlusher, fs *faultyFS) {
+				ff.failures = 1
+				b.flushRegion(context.Background(), "us-east-1")
+			},
+			replayed: []string{"A", "B"},
+		},
+		{
+			// the records were delivered but not deleted, so they are delivered twice
+			name: "after flush before delete",
+			crash: func(t *testing.T, b *CTFileBatcher, ff *flakyFlusher, fs *faultyFS) {
+				fs.removes = 1
+				b.flushRegion(context.Background(), "us-east-1")
+			},
+			first:    []string{"A", "B"},
+			replayed: []string{"A", "B"},
+		},
+		{
+			name: "after delete",
+			crash: func(t *testing.T, b *CTFileBatcher, ff *flakyFlusher, fs *faultyFS) {
+				b.flushRegion(context.Background(), "us-east-1")
+			},
+			first: []string{"A", "B"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			ff, fs := &flakyFlusher{}, &faultyFS{}
+			b := newStashBatcher(dir, ff, fs)
+			addEvents(b, "A", "B")
+			tt.crash(t, b, ff, fs)
+			assert.Equal(t, tt.first, ff.eventNames(t))
+
+			// a new batcher on the same directory replays what is left
+			replayFlusher := &flakyFlusher{}
+			replay := newStashBatcher(dir, replayFlusher, nil)
+			replay.Stop()
+			assert.Equal(t, tt.replayed, replayFlusher.eventNames(t))
+			assert.Empty(t, segmentFiles(t, dir))
+		})
+	}
+}
+
+func TestStash_TornRecordIsSkipped(t *testing.T) {
+	dir := t.TempDir()
+	ff := &flakyFlusher{}
+	b := newStashBatcher(dir, ff, nil)
+	addEvents(b, "A")
+
+	// a crash in the middle of an append leaves half a record at the end of the segment
+	files := segmentFiles(t, dir)
+	require.Len(t, files, 1)
+	f, err := os.OpenFile(files[0], os.O_APPEND|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"eventName":"tor`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	replayFlusher := &flakyFlusher{}
+	replay := newStashBatcher(dir, replayFlusher, nil)
+	replay.Stop()
+	assert.Equal(t, []string{"A"}, replayFlusher.eventNames(t))
+}
+
+func TestStash_FlushRegionReportsFailures(t *testing.T) {
+	dir := t.TempDir()
+	ff, fs := &flakyFlusher{failures: 1}, &faultyFS{}
+	b := newStashBatcher(dir, ff, fs)
+	var _ FlushingEMFFileBatcher = b
+
+	addEvents(b, "A")
+	assert.ErrorIs(t, b.FlushRegion(context.Background(), "us-east-1"), errInjected)
+
+	fs.reads = 1
+	assert.ErrorIs(t, b.FlushRegion(context.Background(), "us-east-1"), errInjected)
+
+	// a failed delete keeps the segment, the next flush pushes it again
+	fs.removes = 1
+	assert.ErrorIs(t, b.FlushRegion(context.Background(), "us-east-1"), errInjected)
+	assert.Equal(t, []string{"A"}, ff.eventNames(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, b.FlushRegion(ctx, "us-east-1"), context.Canceled)
+}
+
+func TestStash_AddReportsFailedWrites(t *testing.T) {
+	dir := t.TempDir()
+	ff, fs := &flakyFlusher{}, &faultyFS{opens: 1}
+	b := newStashBatcher(dir, ff, fs)
+
+	ct := sharedtypes.CloudTrailEvent{EventName: "A", EventTime: time.Now()}
+	assert.ErrorIs(t, b.Add(context.Background(), "us-east-1", ct), errInjected)
+	assert.NoError(t, b.Add(context.Background(), "us-east-1", ct))
+
+	require.NoError(t, b.FlushRegion(context.Background(), "us-east-1"))
+	assert.Equal(t, []string{"A"}, ff.eventNames(t), "only the written record is pushed")
+}
+
+func TestStash_FlushRegionStopsWhenCanceled(t *testing.T) {
+	dir := t.TempDir()
+	ff := &flakyFlusher{failures: 1}
+	b := newStashBatcher(dir, ff, nil)
+
+	// two sealed segments, A left by a failed flush, then B
+	addEvents(b, "A")
+	assert.Error(t, b.FlushRegion(context.Background(), "us-east-1"))
+	addEvents(b, "B")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ff.onFlush = cancel
+	assert.ErrorIs(t, b.FlushRegion(ctx, "us-east-1"), context.Canceled)
+	assert.Equal(t, []string{"A"}, ff.eventNames(t))
+	assert.Len(t, segmentFiles(t, dir), 1, "the segment of B waits for the next flush")
+
+	ff.onFlush = nil
+	require.NoError(t, b.FlushRegion(context.Background(), "us-east-1"))
+	assert.Equal(t, []string{"A", "B"}, ff.eventNames(t))
+}
@@ -0,0 +1,130 @@
+package cloudtrailemfbatcher
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/outofoffice3/aws-samples/geras/internal/heavyhitters"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+)
+
+const (
+	// value of the callers folded out of the top n
+	otherCallers = "other"
+	// tracked callers per top caller, more keeps the estimates closer to the real counts
+	topCallersSketchFactor = 10
+)
+
+// callerDimensions are the dimensions callers are attributed by
+var callerDimensions = []string{DimensionUserIdentityARN, DimensionUserAgent}
+
+// callerSketches tracks the callers of one api in one region
+type callerSketches struct {
+	sketches  map[string]*heavyhitters.SpaceSaving // dimension -> sketch
+	lastEvent time.Time
+}
+
+// callerCount is the number of calls of an api made by one caller in a window
+type callerCount struct {
+	Region    string
+	EventName string
+	Dimension string
+	Caller    string
+	Count     int64
+	Timestamp time.Time
+}
+
+// topCallers keeps an approximate count of the callers of every api over one window,
+// so the heaviest callers can be reported without a dimension per caller.
+type topCallers struct {
+	n int
+
+	mu      sync.Mutex
+	windows map[string]map[string]*callerSketches // region -> eventName -> sketches
+}
+
+func newTopCallers(n int) *topCallers {
+	return &topCallers{
+		n:       n,
+		windows: make(map[string]map[string]*callerSketches),
+	}
+}
+
+// observe counts the caller of one event in the current window
+func (tc *topCallers) observe(region string, ct sharedtypes.CloudTrailEvent) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	apis, ok := tc.windows[region]
+	if !ok {
+		apis = make(map[string]*callerSketches)
+		tc.windows[region] = apis
+	}
+	cs, ok := apis[ct.EventName]
+	if !ok {
+		cs = &callerSketches{sketches: make(map[string]*heavyhitters.SpaceSaving, len(callerDimensions))}
+		for _, dim := range callerDimensions {
+			cs.sketches[dim] = heavyhitters.NewSpaceSaving(tc.n * topCallersSketchFactor)
+		}
+		apis[ct.EventName] = cs
+	}
+	for _, dim := range callerDimensions {
+		caller := dimensionFields[dim](ct)
+		if caller == "" {
+			caller = unknownDimensionValue
+		}
+		cs.sketches[dim].Add(caller)
+	}
+	if ct.EventTime.After(cs.lastEvent) {
+		cs.lastEvent = ct.EventTime
+	}
+}
+
+// rotate closes the current window and returns the top n callers of every api per dimension.
+// The calls of every other caller are summed under "other".  Counts are timestamped with the
+// last event of the window so they line up with the call count.
+func (tc *topCallers) rotate() []callerCount {
+	tc.mu.Lock()
+	windows := tc.windows
+	tc.windows = make(map[string]map[string]*callerSketches)
+	tc.mu.Unlock()
+
+	var out []callerCount
+	for region, apis := range windows {
+		for eventName, cs := range apis {
+			for _, dim := range callerDimensions {
+				sketch := cs.sketches[dim]
+				var top int64
+				for _, c := range sketch.Top(tc.n) {
+					out = append(out, callerCount{
+						Region: region, EventName: eventName, Dimension: dim,
+						Caller: c.Item, Count: c.Count, Timestamp: cs.lastEvent,
+					})
+					top += c.Count
+				}
+				// estimates can over count, so other is never negative
+				if other := sketch.Total() - top; other > 0 {
+					out = append(out, callerCount{
+						Region: region, EventName: eventName, Dimension: dim,
+						Caller: otherCallers, Count: other, Timestamp: cs.lastEvent,
+					})
+				}
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.Region != b.Region {
+			return a.Region < b.Region
+		}
+		if a.EventName != b.EventName {
+			return a.EventName < b.EventName
+		}
+		if a.Dimension != b.Dimension {
+			return a.Dimension < b.Dimension
+		}
+		return a.Count > b.Count
+	})
+	return out
+}
@@ -0,0 +1,75 @@
+package cloudtrailemfbatcher
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+)
+
+func assumeRoleBy(arn, userAgent string, at time.Time) sharedtypes.CloudTrailEvent {
+	return sharedtypes.CloudTrailEvent{
+		EventName:    "AssumeRole",
+		EventTime:    at,
+		UserAgent:    userAgent,
+		UserIdentity: sharedtypes.UserIdentityDetail{ARN: arn},
+	}
+}
+
+func TestTopCallers_Rotate(t *testing.T) {
+	tc := newTopCallers(2)
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	deployer := "arn:aws:sts::123456789012:assumed-role/Deployer"
+
+	// one hot role over many sessions, a warm role and a long tail of single callers
+	for i := 0; i < 50; i++ {
+		tc.observe("us-east-1", assumeRoleBy(fmt.Sprintf("%s/session-%d", deployer, i), "aws-cli/2.15.0 Python/3.11", start))
+	}
+	for i := 0; i < 20; i++ {
+		tc.observe("us-east-1", assumeRoleBy("arn:aws:iam::123456789012:user/ci", "aws-sdk-go-v2/1.36.3", start))
+	}
+	last := start.Add(30 * time.Second)
+	for i := 0; i < 5; i++ {
+		tc.observe("us-east-1", assumeRoleBy(fmt.Sprintf("arn:aws:iam::123456789012:user/dev-%d", i), "", last))
+	}
+
+	got := tc.rotate()
+	byCaller := map[string]map[string]int64{}
+	for _, c := range got {
+		assert.Equal(t, "us-east-1", c.Region)
+		assert.Equal(t, "AssumeRole", c.EventName)
+		assert.Equal(t, last, c.Timestamp, "counts carry the last event time of the window")
+		if byCaller[c.Dimension] == nil {
+			byCaller[c.Dimension] = map[string]int64{}
+		}
+		byCaller[c.Dimension][c.Caller] = c.Count
+	}
+	assert.Equal(t, map[string]int64{
+		deployer:                            50,
+		"arn:aws:iam::123456789012:user/ci": 20,
+		otherCallers:                        5,
+	}, byCaller[DimensionUserIdentityARN])
+	assert.Equal(t, map[string]int64{
+		"aws-cli":       50,
+		"aws-sdk-go-v2": 20,
+		otherCallers:    5,
+	}, byCaller[DimensionUserAgent])
+
+	// the window restarts after a rotate
+	assert.Empty(t, tc.rotate())
+}
+
+func TestTopCallers_NoOtherWhenAllReported(t *testing.T) {
+	tc := newTopCallers(5)
+	tc.observe("eu-west-1", assumeRoleBy("arn:aws:iam::123456789012:user/ci", "aws-cli/2.15.0", time.Now()))
+
+	got := tc.rotate()
+	assert.Len(t, got, 2, "one caller per dimension and no other")
+	for _, c := range got {
+		assert.NotEqual(t, otherCallers, c.Caller)
+		assert.Equal(t, int64(1), c.Count)
+	}
+}
@@ -45,6 +45,12 @@ func (f *fakeCWClient) CreateLogStream(context.Context, *cloudwatchlogs.CreateLo
 func (f *fakeCWClient) DescribeLogStreams(context.Context, *cloudwatchlogs.DescribeLogStreamsInput, ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogStreamsOutput, error) {
 	return &cloudwatchlogs.DescribeLogStreamsOutput{}, nil
 }
+func (f *fakeCWClient) DescribeMetricFilters(context.Context, *cloudwatchlogs.DescribeMetricFiltersInput, ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeMetricFiltersOutput, error) {
+	return &cloudwatchlogs.DescribeMetricFiltersOutput{}, nil
+}
+func (f *fakeCWClient) DescribeSubscriptionFilters(context.Context, *cloudwatchlogs.DescribeSubscriptionFiltersInput, ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeSubscriptionFiltersOutput, error) {
+	return &cloudwatchlogs.DescribeSubscriptionFiltersOutput{}, nil
+}
 
 // fakeLogger satisfies logger.Logger but does nothing.
 type fakeLogger struct{}
@@ -63,6 +63,22 @@ func (m *mockCWL) DescribeLogStreams(
 	return &cloudwatchlogs.DescribeLogStreamsOutput{}, nil
 }
 
+func (m *mockCWL) DescribeMetricFilters(
+	ctx context.Context,
+	params *cloudwatchlogs.DescribeMetricFiltersInput,
+	_ ...func(*cloudwatchlogs.Options),
+) (*cloudwatchlogs.DescribeMetricFiltersOutput, error) {
+	return &cloudwatchlogs.DescribeMetricFiltersOutput{}, nil
+}
+
+func (m *mockCWL) DescribeSubscriptionFilters(
+	ctx context.Context,
+	params *cloudwatchlogs.DescribeSubscriptionFiltersInput,
+	_ ...func(*cloudwatchlogs.Options),
+) (*cloudwatchlogs.DescribeSubscriptionFiltersOutput, error) {
+	return &cloudwatchlogs.DescribeSubscriptionFiltersOutput{}, nil
+}
+
 func TestMetricBatcher_Success(t *testing.T) {
 	ctx := context.Background()
 	mock := &mockCWL{region: "us-west-2"}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+)
+
+const (
+	// eventbridge detail-type of cloudtrail management and data events
+	cloudTrailAPICallDetailType = "AWS API Call via CloudTrail"
+	// sns message type of published messages
+	snsNotificationType = "Notification"
+)
+
+var (
+	ErrMessageNotJSON            = errors.New("message is not a json object")
+	ErrUnrecognisedMessage       = errors.New("message is not a cloudtrail record, eventbridge event or sns notification")
+	ErrUnsupportedDetailType     = errors.New("unsupported eventbridge detail-type")
+	ErrUnsupportedSNSMessageType = errors.New("unsupported sns message type")
+	ErrNestedSNSNotification     = errors.New("sns notification wraps another sns notification")
+	ErrInvalidCloudTrailRecord   = errors.New("invalid cloudtrail record")
+)
+
+// parseCloudTrailMessage extracts the cloudtrail record carried by an SQS message body.
+// The body can be a raw cloudtrail record, an EventBridge "AWS API Call via CloudTrail"
+// event that wraps the record in detail, or an SNS notification wrapping either of them.
+// The returned error explains why a message could not be used.
+func parseCloudTrailMessage(body string) (sharedtypes.CloudTrailEvent, error) {
+	return parseCloudTrailPayload([]byte(body), true)
+}
+
+func parseCloudTrailPayload(payload []byte, allowSNS bool) (sharedtypes.CloudTrailEvent, error) {
+	// encoding/json matches keys case insensitively, so the envelope is detected
+	// from the exact top level keys before decoding into a concrete type
+	var keys map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &keys); err != nil {
+		return sharedtypes.CloudTrailEvent{}, fmt.Errorf("%w: %v", ErrMessageNotJSON, err)
+	}
+
+	_, hasDetail := keys["detail"]
+	_, hasDetailType := keys["detail-type"]
+	_, hasSNSType := keys["Type"]
+	_, hasSNSMessage := keys["Message"]
+	_, hasEventName := keys["eventName"]
+
+	switch {
+	case hasDetail && hasDetailType:
+		return parseEventBridgeEvent(payload)
+	case hasSNSType && hasSNSMessage:
+		if !allowSNS {
+			return sharedtypes.CloudTrailEvent{}, ErrNestedSNSNotification
+		}
+		return parseSNSNotification(payload)
+	case hasEventName:
+		var ctEvent sharedtypes.CloudTrailEvent
+		if err := json.Unmarshal(payload, &ctEvent); err != nil {
+			return sharedtypes.CloudTrailEvent{}, fmt.Errorf("%w: %v", ErrInvalidCloudTrailRecord, err)
+		}
+		return ctEvent, validateCloudTrailEvent(ctEvent)
+	default:
+		return sharedtypes.CloudTrailEvent{}, ErrUnrecognisedMessage
+	}
+}
+
+// parseEventBridgeEvent unwraps the cloudtrail record from the detail of an EventBridge event
+func parseEventBridgeEvent(payload []byte) (sharedtypes.CloudTrailEvent, error) {
+	var envelope sharedtypes.ScheduledEvent
+	if err := json.Unmarshal(payload, &envelope); err != nil {
+		return sharedtypes.CloudTrailEvent{}, fmt.Errorf("%w: %v", ErrUnrecognisedMessage, err)
+	}
+	if envelope.DetailType != cloudTrailAPICallDetailType {
+		return sharedtypes.CloudTrailEvent{}, fmt.Errorf("%w: %q", ErrUnsupportedDetailType, envelope.DetailType)
+	}
+
+	var ctEvent sharedtypes.CloudTrailEvent
+	if err := json.Unmarshal(envelope.Detail, &ctEvent); err != nil {
+		return sharedtypes.CloudTrailEvent{}, fmt.Errorf("%w: detail: %v", ErrInvalidCloudTrailRecord, err)
+	}
+	// the record carries its own region, the envelope region is only a fallback
+	if ctEvent.AWSRegion == "" {
+		ctEvent.AWSRegion = envelope.Region
+	}
+	return ctEvent, validateCloudTrailEvent(ctEvent)
+}
+
+// parseSNSNotification unwraps the message of an SNS notification
+func parseSNSNotification(payload []byte) (sharedtypes.CloudTrailEvent, error) {
+	var notification sharedtypes.SNSNotification
+	if err := json.Unmarshal(payload, &notification); err != nil {
+		return sharedtypes.CloudTrailEvent{}, fmt.Errorf("%w: %v", ErrUnrecognisedMessage, err)
+	}
+	if notification.Type != snsNotificationType {
+		return sharedtypes.CloudTrailEvent{}, fmt.Errorf("%w: %q", ErrUnsupportedSNSMessageType, notification.Type)
+	}
+	ctEvent, err := parseCloudTrailPayload([]byte(notification.Message), false)
+	if err != nil {
+		return sharedtypes.CloudTrailEvent{}, fmt.Errorf("sns message: %w", err)
+	}
+	return ctEvent, nil
+}
+
+// validateCloudTrailEvent makes sure the fields metrics are keyed on are present
+func validateCloudTrailEvent(ctEvent sharedtypes.CloudTrailEvent) error {
+	if ctEvent.EventName == "" {
+		return fmt.Errorf("%w: missing eventName", ErrInvalidCloudTrailRecord)
+	}
+	if ctEvent.AWSRegion == "" {
+		return fmt.Errorf("%w: missing awsRegion", ErrInvalidCloudTrailRecord)
+	}
+	return nil
+}
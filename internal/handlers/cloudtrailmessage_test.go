@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+)
+
+const rawRecord = `{"eventVersion":"1.08","eventSource":"sts.amazonaws.com","eventName":"AssumeRole","awsRegion":"us-west-2","eventID":"e-1","userIdentity":{"type":"AWSService"}}`
+
+// eventBridgeEnvelope wraps a cloudtrail record the way an EventBridge rule delivers it to SQS
+func eventBridgeEnvelope(detailType, detail string) string {
+	return `{"version":"0","id":"1","detail-type":"` + detailType + `","source":"aws.sts","account":"123456789012",` +
+		`"time":"2025-01-01T00:00:00Z","region":"us-east-1","resources":[],"detail":` + detail + `}`
+}
+
+// snsNotification wraps a message the way SNS delivers it to SQS without raw message delivery
+func snsNotification(msgType, message string) string {
+	b, _ := json.Marshal(sharedtypes.SNSNotification{
+		Type:      msgType,
+		MessageID: "sns-1",
+		TopicArn:  "arn:aws:sns:us-east-1:123456789012:trail",
+		Message:   message,
+	})
+	return string(b)
+}
+
+func TestParseCloudTrailMessage(t *testing.T) {
+	cases := []struct {
+		name       string
+		body       string
+		wantName   string
+		wantRegion string
+	}{
+		{"raw record", rawRecord, "AssumeRole", "us-west-2"},
+		{"eventbridge", eventBridgeEnvelope(cloudTrailAPICallDetailType, rawRecord), "AssumeRole", "us-west-2"},
+		{"eventbridge region fallback", eventBridgeEnvelope(cloudTrailAPICallDetailType, `{"eventName":"AssumeRole"}`), "AssumeRole", "us-east-1"},
+		{"sns raw record", snsNotification(snsNotificationType, rawRecord), "AssumeRole", "us-west-2"},
+		{"sns eventbridge", snsNotification(snsNotificationType, eventBridgeEnvelope(cloudTrailAPICallDetailType, rawRecord)), "AssumeRole", "us-west-2"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctEvent, err := parseCloudTrailMessage(tc.body)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantName, ctEvent.EventName)
+			assert.Equal(t, tc.wantRegion, ctEvent.AWSRegion)
+		})
+	}
+}
+
+func TestParseCloudTrailMessage_ErrorCode(t *testing.T) {
+	body := eventBridgeEnvelope(cloudTrailAPICallDetailType,
+		`{"eventSource":"ec2.amazonaws.com","eventName":"DescribeInstances","awsRegion":"us-west-2",`+
+			`"errorCode":"Client.RequestLimitExceeded","errorMessage":"Request limit exceeded."}`)
+	ctEvent, err := parseCloudTrailMessage(body)
+	assert.NoError(t, err)
+	assert.Equal(t, "Client.RequestLimitExceeded", ctEvent.ErrorCode)
+	assert.Equal(t, "Request limit exceeded.", ctEvent.ErrorMessage)
+}
+
+func TestParseCloudTrailMessage_Errors(t *testing.T) {
+	cases := []struct {
+		name    string
+		body    string
+		wantErr error
+	}{
+		{"not json", "not-json", ErrMessageNotJSON},
+		{"json array", `[1,2]`, ErrMessageNotJSON},
+		{"unknown shape", `{"foo":"bar"}`, ErrUnrecognisedMessage},
+		{"detail type", eventBridgeEnvelope("EC2 Instance State-change Notification", `{"state":"running"}`), ErrUnsupportedDetailType},
+		{"detail not a record", eventBridgeEnvelope(cloudTrailAPICallDetailType, `"text"`), ErrInvalidCloudTrailRecord},
+		{"missing event name", eventBridgeEnvelope(cloudTrailAPICallDetailType, `{"awsRegion":"us-east-1"}`), ErrInvalidCloudTrailRecord},
+		{"missing region", `{"eventName":"AssumeRole"}`, ErrInvalidCloudTrailRecord},
+		{"sns subscription confirmation", snsNotification("SubscriptionConfirmation", "confirm"), ErrUnsupportedSNSMessageType},
+		{"sns cloudtrail log file delivery", snsNotification(snsNotificationType, `{"s3Bucket":"b","s3ObjectKey":["k"]}`), ErrUnrecognisedMessage},
+		{"sns in sns", snsNotification(snsNotificationType, snsNotification(snsNotificationType, rawRecord)), ErrNestedSNSNotification},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parseCloudTrailMessage(tc.body)
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestHandleEvent_Envelopes(t *testing.T) {
+	fake := &fakeBatcher{}
+	tl := &testLogger{}
+	h, _ := NewRateLimitHandler(RateLimitHandlerConfig{
+		CloudTrailEmfFileBatcher: fake,
+		Namespace:                "ns",
+		Logger:                   tl,
+	})
+
+	input := events.SQSEvent{Records: []events.SQSMessage{
+		{MessageId: "raw", Body: rawRecord},
+		{MessageId: "eventbridge", Body: eventBridgeEnvelope(cloudTrailAPICallDetailType, rawRecord)},
+		{MessageId: "sns", Body: snsNotification(snsNotificationType, rawRecord)},
+		{MessageId: "other-detail", Body: eventBridgeEnvelope("Scheduled Event", `{}`)},
+	}}
+	failures, err := h.HandleEvent(context.Background(), input)
+	assert.NoError(t, err)
+	assert.Equal(t, []events.SQSBatchItemFailure{{ItemIdentifier: "other-detail"}}, failures)
+
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	assert.Len(t, fake.calls, 3)
+	for _, call := range fake.calls {
+		assert.Equal(t, "us-west-2", call.region)
+		assert.Equal(t, "AssumeRole", call.event.EventName)
+	}
+
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+	if assert.Len(t, tl.errMsgs, 1) {
+		assert.Contains(t, tl.errMsgs[0], "other-detail")
+		assert.Contains(t, tl.errMsgs[0], ErrUnsupportedDetailType.Error())
+	}
+}
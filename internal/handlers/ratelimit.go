@@ -2,14 +2,16 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-lambda-go/events"
 
+	"github.com/outofoffice3/aws-samples/geras/internal/dedup"
 	cloudtrailemfbatcher "github.com/outofoffice3/aws-samples/geras/internal/emfbatcher/cloudtrail"
 	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	"github.com/outofoffice3/aws-samples/geras/internal/serviceconfig"
 	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
 )
 
@@ -19,12 +21,28 @@ const (
 	CloudTrailEmfFileBatcherNillErrMsg = "cloudtrail emf file batcher is nil"
 	NamespaceNotSetErrMsg              = "namespace is not set"
 	StashNilErrMsg                     = "stash is nil"
+	BatcherCannotFlushErrMsg           = "batcher cannot flush on demand, synchronous durability is not available"
 )
 
 // RateLimitHandler handles scheduled events from EventBridge
 // and batches EMF records to CloudWatch.
+// When AllowList is set, only the events enabled by rateLimitAPIs are counted. Other events
+// go to UnlistedEventBatcher when it is set and are dropped otherwise.
+// When SyncDurability is set, the regions written by an SQS event are flushed before it
+// returns, so its messages are acknowledged once their records reached CloudWatch Logs.
+// The records of a region that failed to flush stay in the stash for the next flush and
+// their messages are acknowledged too, so SQS does not deliver them a second time.
+// Messages whose records could not be written to disk are reported as batch item failures
+// in both modes.
+// When Dedup is set, events whose eventID was already counted are acknowledged without
+// being counted again, and go to DuplicateEventBatcher when it is set.
 type RateLimitHandler struct {
 	CloudTrailEmfFileBatcher cloudtrailemfbatcher.EMFFileBatcher
+	UnlistedEventBatcher     cloudtrailemfbatcher.EMFFileBatcher
+	DuplicateEventBatcher    cloudtrailemfbatcher.EMFFileBatcher
+	AllowList                *serviceconfig.RateLimitAllowList
+	Dedup                    dedup.Store
+	SyncDurability           bool
 	Logger                   logger.Logger
 	initialized              bool
 	Namespace                string
@@ -32,8 +50,15 @@ type RateLimitHandler struct {
 
 type RateLimitHandlerConfig struct {
 	CloudTrailEmfFileBatcher cloudtrailemfbatcher.EMFFileBatcher
-	Namespace                string
-	Logger                   logger.Logger
+	UnlistedEventBatcher     cloudtrailemfbatcher.EMFFileBatcher
+	DuplicateEventBatcher    cloudtrailemfbatcher.EMFFileBatcher
+	AllowList                *serviceconfig.RateLimitAllowList
+	// Dedup holds the eventIDs already counted, nil counts every delivery
+	Dedup dedup.Store
+	// SyncDurability requires the batchers to be cloudtrailemfbatcher.FlushingEMFFileBatcher
+	SyncDurability bool
+	Namespace      string
+	Logger         logger.Logger
 }
 
 // NewRateLimitHandler constructs a fully-initialized RateLimitHandler.
@@ -61,9 +86,26 @@ func NewRateLimitHandler(
 		}, config.Logger)
 	}
 
+	// synchronous durability flushes the batchers on every event
+	if config.SyncDurability {
+		for _, b := range []cloudtrailemfbatcher.EMFFileBatcher{config.CloudTrailEmfFileBatcher, config.UnlistedEventBatcher} {
+			if _, ok := b.(cloudtrailemfbatcher.FlushingEMFFileBatcher); b != nil && !ok {
+				return nil, LogAndReturnError(sharedtypes.ErrorRecord{
+					Timestamp: time.Now(),
+					Err:       errors.New(BatcherCannotFlushErrMsg),
+				}, config.Logger)
+			}
+		}
+	}
+
 	// construct handler
 	rlh := &RateLimitHandler{
 		CloudTrailEmfFileBatcher: config.CloudTrailEmfFileBatcher,
+		UnlistedEventBatcher:     config.UnlistedEventBatcher,
+		DuplicateEventBatcher:    config.DuplicateEventBatcher,
+		AllowList:                config.AllowList,
+		Dedup:                    config.Dedup,
+		SyncDurability:           config.SyncDurability,
 		Logger:                   config.Logger,
 		Namespace:                config.Namespace,
 		initialized:              true,
@@ -73,6 +115,7 @@ func NewRateLimitHandler(
 }
 
 // HandleEvent processes an SQS event, batching and flushing EMF records.
+// Messages can be raw CloudTrail records, EventBridge events or SNS notifications.
 // Returns a slice of failed message IDs for partial-batch retry.
 func (rlh *RateLimitHandler) HandleEvent(
 	ctx context.Context,
@@ -84,21 +127,107 @@ func (rlh *RateLimitHandler) HandleEvent(
 	rlh.Logger.Info("Received %d records from SQS event", len(event.Records))
 
 	var failures []events.SQSBatchItemFailure
+	pending := make(map[pendingFlush]int) // batcher and region -> messages
 
 	for _, msg := range event.Records {
-		var ctEvent sharedtypes.CloudTrailEvent
-		if err := json.Unmarshal([]byte(msg.Body), &ctEvent); err != nil {
-			rlh.Logger.Error("failed to unmarshal SQS message %s: %v", msg.MessageId, err)
+		ctEvent, err := parseCloudTrailMessage(msg.Body)
+		if err != nil {
+			rlh.Logger.Error("failed to parse SQS message %s, reporting it as a batch item failure: %v", msg.MessageId, err)
+			failures = append(failures, events.SQSBatchItemFailure{ItemIdentifier: msg.MessageId})
+			continue
+		}
+
+		if rlh.isDuplicate(ctx, ctEvent) {
+			if rlh.DuplicateEventBatcher != nil {
+				if err := rlh.DuplicateEventBatcher.Add(ctx, ctEvent.AWSRegion, ctEvent); err != nil {
+					rlh.Logger.Warn("failed to count duplicate event %s, message %s: %v", ctEvent.EventID, msg.MessageId, err)
+				}
+			}
+			rlh.Logger.Debug("skipping duplicate event %s, message %s", ctEvent.EventID, msg.MessageId)
+			continue
+		}
+
+		batcher := rlh.CloudTrailEmfFileBatcher
+		if rlh.AllowList != nil && !rlh.AllowList.Allowed(ctEvent.EventSource, ctEvent.EventName) {
+			if rlh.UnlistedEventBatcher == nil {
+				rlh.Logger.Debug("dropping unlisted event %s:%s, message %s", ctEvent.EventSource, ctEvent.EventName, msg.MessageId)
+				continue
+			}
+			batcher = rlh.UnlistedEventBatcher
+		}
+		if err := batcher.Add(ctx, ctEvent.AWSRegion, ctEvent); err != nil {
+			rlh.Logger.Error("failed to write SQS message %s, reporting it as a batch item failure: %v", msg.MessageId, err)
+			rlh.forget(ctx, ctEvent)
 			failures = append(failures, events.SQSBatchItemFailure{ItemIdentifier: msg.MessageId})
 			continue
 		}
+		rlh.Logger.Debug("added CloudTrail event %s:%s to file batcher for region %s, message %s", ctEvent.EventSource, ctEvent.EventName, ctEvent.AWSRegion, msg.MessageId)
+
+		if rlh.SyncDurability {
+			key := pendingFlush{batcher: batcher, region: ctEvent.AWSRegion}
+			pending[key]++
+		}
+	}
 
-		rlh.CloudTrailEmfFileBatcher.Add(ctx, ctEvent.AWSRegion, ctEvent)
-		rlh.Logger.Debug("added CloudTrail event to file batcher for region %s, message %s", ctEvent.AWSRegion, msg.MessageId)
+	// messages are acknowledged once their records reached cloudwatch logs or, when the
+	// flush failed, once they are in the stash.  the stash retries them and their eventIDs
+	// stay seen, so they are counted once
+	if len(pending) > 0 {
+		rlh.flushPending(ctx, pending)
 	}
 
 	return failures, nil
+}
+
+// pendingFlush is a region of a batcher holding records of the current SQS event
+type pendingFlush struct {
+	batcher cloudtrailemfbatcher.EMFFileBatcher
+	region  string
+}
+
+// flushPending flushes every region written by the current SQS event, in parallel.
+// The records of a region that could not be flushed stay in the stash.
+func (rlh *RateLimitHandler) flushPending(ctx context.Context, pending map[pendingFlush]int) {
+	var wg sync.WaitGroup
+	for key, msgs := range pending {
+		wg.Add(1)
+		go func(key pendingFlush, msgs int) {
+			defer wg.Done()
+			err := errors.New(BatcherCannotFlushErrMsg)
+			if fb, ok := key.batcher.(cloudtrailemfbatcher.FlushingEMFFileBatcher); ok {
+				err = fb.FlushRegion(ctx, key.region)
+			}
+			if err != nil {
+				rlh.Logger.Error("failed to flush region %s, the records of its %d messages stay in the stash for the next flush: %v", key.region, msgs, err)
+			}
+		}(key, msgs)
+	}
+	wg.Wait()
+}
 
+// isDuplicate reports whether the eventID of an event was already counted.
+// Events without an eventID, or that the store cannot check, are counted.
+func (rlh *RateLimitHandler) isDuplicate(ctx context.Context, ct sharedtypes.CloudTrailEvent) bool {
+	if rlh.Dedup == nil || ct.EventID == "" {
+		return false
+	}
+	dup, err := rlh.Dedup.Seen(ctx, ct.EventID, time.Now())
+	if err != nil {
+		rlh.Logger.Warn("cannot check event %s for duplicates, counting it: %v", ct.EventID, err)
+		return false
+	}
+	return dup
+}
+
+// forget removes the eventID of an event whose records could not be written, so its
+// redelivery is counted
+func (rlh *RateLimitHandler) forget(ctx context.Context, ct sharedtypes.CloudTrailEvent) {
+	if rlh.Dedup == nil || ct.EventID == "" {
+		return
+	}
+	if err := rlh.Dedup.Forget(ctx, ct.EventID); err != nil {
+		rlh.Logger.Warn("cannot forget event %s, its redelivery will be dropped as a duplicate: %v", ct.EventID, err)
+	}
 }
 
 // LogAndReturnError centralizes error logging
@@ -13,27 +13,36 @@ import (
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/outofoffice3/aws-samples/geras/internal/dedup"
+	"github.com/outofoffice3/aws-samples/geras/internal/emf"
+	cloudtrailemfbatcher "github.com/outofoffice3/aws-samples/geras/internal/emfbatcher/cloudtrail"
+	"github.com/outofoffice3/aws-samples/geras/internal/serviceconfig"
 	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
 )
 
 //––– Mocks –––––––––––––––––––––––––––––––––––––––––––––––––––––––––––––––
 
-// fakeBatcher records calls to Add(...)
+// fakeBatcher records calls to Add(...), failing the events named in failing
 type fakeBatcher struct {
 	mu    sync.Mutex
 	calls []struct {
 		region string
 		event  sharedtypes.CloudTrailEvent
 	}
+	failing map[string]bool
 }
 
-func (f *fakeBatcher) Add(_ context.Context, region string, ct sharedtypes.CloudTrailEvent) {
+func (f *fakeBatcher) Add(_ context.Context, region string, ct sharedtypes.CloudTrailEvent) error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
+	if f.failing[ct.EventName] {
+		return errors.New("append failed")
+	}
 	f.calls = append(f.calls, struct {
 		region string
 		event  sharedtypes.CloudTrailEvent
 	}{region, ct})
+	return nil
 }
 
 // testLogger captures Error(...) messages
@@ -182,3 +191,336 @@ func TestLogAndReturnError(t *testing.T) {
 	// should have logged "Handler error: boom"
 	assert.Contains(t, tl.errMsgs[0], "Handler error: boom")
 }
+
+func TestHandleEvent_AllowList(t *testing.T) {
+	allowList, err := serviceconfig.NewRateLimitAllowList(serviceconfig.TopLevelServiceConfig{
+		Services: map[string]serviceconfig.ServiceConfig{
+			"sts": {RateLimitAPIs: []serviceconfig.RateLimitAPIs{{Name: "assumeRole"}}},
+		},
+	})
+	assert.NoError(t, err)
+
+	listed, _ := json.Marshal(sharedtypes.CloudTrailEvent{EventSource: "sts.amazonaws.com", EventName: "AssumeRole", AWSRegion: "r1"})
+	unlisted, _ := json.Marshal(sharedtypes.CloudTrailEvent{EventSource: "sts.amazonaws.com", EventName: "GetSessionToken", AWSRegion: "r1"})
+	input := events.SQSEvent{Records: []events.SQSMessage{
+		{MessageId: "listed", Body: string(listed)},
+		{MessageId: "unlisted", Body: string(unlisted)},
+	}}
+
+	t.Run("drop unlisted", func(t *testing.T) {
+		fake := &fakeBatcher{}
+		h, _ := NewRateLimitHandler(RateLimitHandlerConfig{
+			CloudTrailEmfFileBatcher: fake,
+			AllowList:                allowList,
+			Namespace:                "ns",
+		})
+		failures, err := h.HandleEvent(context.Background(), input)
+		assert.NoError(t, err)
+		assert.Empty(t, failures, "unlisted events are not failures")
+		if assert.Len(t, fake.calls, 1) {
+			assert.Equal(t, "AssumeRole", fake.calls[0].event.EventName)
+		}
+	})
+
+	t.Run("count unlisted separately", func(t *testing.T) {
+		fake, unlistedFake := &fakeBatcher{}, &fakeBatcher{}
+		h, _ := NewRateLimitHandler(RateLimitHandlerConfig{
+			CloudTrailEmfFileBatcher: fake,
+			UnlistedEventBatcher:     unlistedFake,
+			AllowList:                allowList,
+			Namespace:                "ns",
+		})
+		failures, err := h.HandleEvent(context.Background(), input)
+		assert.NoError(t, err)
+		assert.Empty(t, failures)
+		if assert.Len(t, fake.calls, 1) {
+			assert.Equal(t, "AssumeRole", fake.calls[0].event.EventName)
+		}
+		if assert.Len(t, unlistedFake.calls, 1) {
+			assert.Equal(t, "GetSessionToken", unlistedFake.calls[0].event.EventName)
+			assert.Equal(t, "r1", unlistedFake.calls[0].region)
+		}
+	})
+}
+
+// flushingBatcher is a fakeBatcher that can flush on demand, failing the listed regions
+type flushingBatcher struct {
+	fakeBatcher
+	failRegions map[string]bool
+	flushed     []string
+}
+
+func (f *flushingBatcher) FlushRegion(_ context.Context, region string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.flushed = append(f.flushed, region)
+	if f.failRegions[region] {
+		return errors.New("flush failed")
+	}
+	return nil
+}
+
+func TestNewRateLimitHandler_SyncDurabilityNeedsFlushingBatchers(t *testing.T) {
+	_, err := NewRateLimitHandler(RateLimitHandlerConfig{
+		CloudTrailEmfFileBatcher: &fakeBatcher{},
+		SyncDurability:           true,
+		Namespace:                "ns",
+	})
+	assert.Error(t, err)
+
+	_, err = NewRateLimitHandler(RateLimitHandlerConfig{
+		CloudTrailEmfFileBatcher: &flushingBatcher{},
+		UnlistedEventBatcher:     &fakeBatcher{},
+		SyncDurability:           true,
+		Namespace:                "ns",
+	})
+	assert.Error(t, err, "the unlisted batcher must flush too")
+
+	_, err = NewRateLimitHandler(RateLimitHandlerConfig{
+		CloudTrailEmfFileBatcher: &flushingBatcher{},
+		SyncDurability:           true,
+		Namespace:                "ns",
+	})
+	assert.NoError(t, err)
+}
+
+func TestHandleEvent_SyncDurability(t *testing.T) {
+	allowList, err := serviceconfig.NewRateLimitAllowList(serviceconfig.TopLevelServiceConfig{
+		Services: map[string]serviceconfig.ServiceConfig{
+			"sts": {RateLimitAPIs: []serviceconfig.RateLimitAPIs{{Name: "assumeRole"}}},
+		},
+	})
+	assert.NoError(t, err)
+
+	body := func(name, region string) string {
+		b, _ := json.Marshal(sharedtypes.CloudTrailEvent{EventSource: "sts.amazonaws.com", EventName: name, AWSRegion: region})
+		return string(b)
+	}
+	input := events.SQSEvent{Records: []events.SQSMessage{
+		{MessageId: "ok-1", Body: body("AssumeRole", "us-east-1")},
+		{MessageId: "fail-1", Body: body("AssumeRole", "eu-west-1")},
+		{MessageId: "bad", Body: "xxx"},
+		{MessageId: "unlisted", Body: body("GetSessionToken", "eu-west-1")},
+		{MessageId: "ok-2", Body: body("AssumeRole", "us-east-1")},
+		{MessageId: "fail-2", Body: body("AssumeRole", "eu-west-1")},
+	}}
+
+	listed := &flushingBatcher{failRegions: map[string]bool{"eu-west-1": true}}
+	unlisted := &flushingBatcher{}
+	h, err := NewRateLimitHandler(RateLimitHandlerConfig{
+		CloudTrailEmfFileBatcher: listed,
+		UnlistedEventBatcher:     unlisted,
+		AllowList:                allowList,
+		SyncDurability:           true,
+		Namespace:                "ns",
+	})
+	assert.NoError(t, err)
+
+	failures, err := h.HandleEvent(context.Background(), input)
+	assert.NoError(t, err)
+	assert.Equal(t, []events.SQSBatchItemFailure{{ItemIdentifier: "bad"}}, failures,
+		"the messages of the region that failed to flush stay in the stash and are not redelivered")
+	assert.ElementsMatch(t, []string{"us-east-1", "eu-west-1"}, listed.flushed, "each region is flushed once")
+	assert.Equal(t, []string{"eu-west-1"}, unlisted.flushed, "the unlisted batcher flushes its own region")
+}
+
+func TestHandleEvent_AsyncDoesNotFlush(t *testing.T) {
+	fake := &flushingBatcher{failRegions: map[string]bool{"r1": true}}
+	h, _ := NewRateLimitHandler(RateLimitHandlerConfig{
+		CloudTrailEmfFileBatcher: fake,
+		Namespace:                "ns",
+	})
+	payload, _ := json.Marshal(sharedtypes.CloudTrailEvent{EventName: "e1", AWSRegion: "r1"})
+	failures, err := h.HandleEvent(context.Background(), events.SQSEvent{Records: []events.SQSMessage{
+		{MessageId: "msg", Body: string(payload)},
+	}})
+	assert.NoError(t, err)
+	assert.Empty(t, failures)
+	assert.Empty(t, fake.flushed)
+}
+
+func TestHandleEvent_FailedAddIsRetried(t *testing.T) {
+	for _, syncDurability := range []bool{false, true} {
+		t.Run(fmt.Sprint("sync=", syncDurability), func(t *testing.T) {
+			fake := &flushingBatcher{fakeBatcher: fakeBatcher{failing: map[string]bool{"e2": true}}}
+			h, err := NewRateLimitHandler(RateLimitHandlerConfig{
+				CloudTrailEmfFileBatcher: fake,
+				SyncDurability:           syncDurability,
+				Namespace:                "ns",
+			})
+			assert.NoError(t, err)
+
+			var input events.SQSEvent
+			for i, name := range []string{"e1", "e2", "e1"} {
+				payload, _ := json.Marshal(sharedtypes.CloudTrailEvent{EventName: name, AWSRegion: "r1"})
+				input.Records = append(input.Records, events.SQSMessage{MessageId: fmt.Sprint("msg-", i), Body: string(payload)})
+			}
+			failures, err := h.HandleEvent(context.Background(), input)
+			assert.NoError(t, err)
+			assert.Equal(t, []events.SQSBatchItemFailure{{ItemIdentifier: "msg-1"}}, failures, "the message that was not written is retried")
+			assert.Len(t, fake.calls, 2)
+		})
+	}
+}
+
+// failingStore is a dedup store that cannot be reached
+type failingStore struct{}
+
+func (failingStore) Seen(context.Context, string, time.Time) (bool, error) {
+	return false, errors.New("unavailable")
+}
+func (failingStore) Forget(context.Context, string) error { return errors.New("unavailable") }
+
+func TestHandleEvent_Dedup(t *testing.T) {
+	body := func(id, region string) string {
+		b, _ := json.Marshal(sharedtypes.CloudTrailEvent{EventID: id, EventName: "AssumeRole", AWSRegion: region})
+		return string(b)
+	}
+	batch := func(ids ...string) events.SQSEvent {
+		var event events.SQSEvent
+		for i, id := range ids {
+			event.Records = append(event.Records, events.SQSMessage{MessageId: fmt.Sprint("msg-", i), Body: body(id, "r1")})
+		}
+		return event
+	}
+
+	t.Run("duplicates are counted once", func(t *testing.T) {
+		fake, duplicates := &fakeBatcher{}, &fakeBatcher{}
+		h, _ := NewRateLimitHandler(RateLimitHandlerConfig{
+			CloudTrailEmfFileBatcher: fake,
+			DuplicateEventBatcher:    duplicates,
+			Dedup:                    dedup.NewWindow(100, time.Hour),
+			Namespace:                "ns",
+		})
+		// a duplicate within the batch, then a redelivery of the whole batch
+		failures, err := h.HandleEvent(context.Background(), batch("a", "b", "a", ""))
+		assert.NoError(t, err)
+		assert.Empty(t, failures, "duplicates are acknowledged")
+		_, err = h.HandleEvent(context.Background(), batch("a", "b", ""))
+		assert.NoError(t, err)
+
+		assert.Len(t, fake.calls, 4, "a, b and the two events without an eventID")
+		assert.Len(t, duplicates.calls, 3)
+	})
+
+	t.Run("store failures count the event", func(t *testing.T) {
+		fake := &fakeBatcher{}
+		h, _ := NewRateLimitHandler(RateLimitHandlerConfig{
+			CloudTrailEmfFileBatcher: fake,
+			Dedup:                    failingStore{},
+			Namespace:                "ns",
+		})
+		_, err := h.HandleEvent(context.Background(), batch("a", "a"))
+		assert.NoError(t, err)
+		assert.Len(t, fake.calls, 2)
+	})
+
+	t.Run("failed writes are forgotten", func(t *testing.T) {
+		fake := &fakeBatcher{failing: map[string]bool{"AssumeRole": true}}
+		duplicates := &fakeBatcher{}
+		h, _ := NewRateLimitHandler(RateLimitHandlerConfig{
+			CloudTrailEmfFileBatcher: fake,
+			DuplicateEventBatcher:    duplicates,
+			Dedup:                    dedup.NewWindow(100, time.Hour),
+			Namespace:                "ns",
+		})
+		failures, err := h.HandleEvent(context.Background(), batch("a"))
+		assert.NoError(t, err)
+		assert.Len(t, failures, 1)
+
+		// the redelivery of a message that was not written is counted
+		fake.failing = nil
+		failures, err = h.HandleEvent(context.Background(), batch("a"))
+		assert.NoError(t, err)
+		assert.Empty(t, failures)
+		assert.Len(t, fake.calls, 1)
+		assert.Empty(t, duplicates.calls)
+	})
+
+	t.Run("failed flushes stay seen", func(t *testing.T) {
+		fake := &flushingBatcher{failRegions: map[string]bool{"r1": true}}
+		duplicates := &fakeBatcher{}
+		h, _ := NewRateLimitHandler(RateLimitHandlerConfig{
+			CloudTrailEmfFileBatcher: fake,
+			DuplicateEventBatcher:    duplicates,
+			Dedup:                    dedup.NewWindow(100, time.Hour),
+			SyncDurability:           true,
+			Namespace:                "ns",
+		})
+		failures, err := h.HandleEvent(context.Background(), batch("a"))
+		assert.NoError(t, err)
+		assert.Empty(t, failures)
+
+		// the record of a message that failed to flush is still in the stash,
+		// so its redelivery is not counted again
+		fake.failRegions = nil
+		failures, err = h.HandleEvent(context.Background(), batch("a"))
+		assert.NoError(t, err)
+		assert.Empty(t, failures)
+		assert.Len(t, fake.calls, 1)
+		assert.Len(t, duplicates.calls, 1)
+	})
+}
+
+// countingFlusher counts the records it accepts and fails the calls it is told to
+type countingFlusher struct {
+	mu       sync.Mutex
+	failures int
+	records  int
+}
+
+func (f *countingFlusher) Flush(_ context.Context, _ string, batch []emf.EMFRecord) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failures > 0 {
+		f.failures--
+		return errors.New("flush failed")
+	}
+	f.records += len(batch)
+	return nil
+}
+
+func TestHandleEvent_SyncDurabilityRecovers(t *testing.T) {
+	flusher := &countingFlusher{failures: 3}
+	batcher := cloudtrailemfbatcher.NewCTFileBatcher(cloudtrailemfbatcher.CTFileBatcherConfig{
+		ParentCtx:     context.Background(),
+		Namespace:     "ns",
+		MetricName:    "CallCount",
+		BaseDir:       t.TempDir(),
+		FlushInterval: time.Hour,
+		EmfFlusher:    flusher,
+		Logger:        &testLogger{},
+	})
+	defer batcher.Stop()
+	h, err := NewRateLimitHandler(RateLimitHandlerConfig{
+		CloudTrailEmfFileBatcher: batcher,
+		Dedup:                    dedup.NewWindow(100, time.Hour),
+		SyncDurability:           true,
+		Namespace:                "ns",
+	})
+	assert.NoError(t, err)
+
+	batch := func(ids ...string) events.SQSEvent {
+		var event events.SQSEvent
+		for _, id := range ids {
+			b, _ := json.Marshal(sharedtypes.CloudTrailEvent{EventID: id, EventName: "AssumeRole", AWSRegion: "r1", EventTime: time.Now()})
+			event.Records = append(event.Records, events.SQSMessage{MessageId: "msg-" + id, Body: string(b)})
+		}
+		return event
+	}
+
+	// the flushes fail, the messages are acknowledged as their records stay in the stash,
+	// and a redelivery of "a" is not counted again
+	for _, ids := range [][]string{{"a", "b"}, {"a"}, {"c"}} {
+		failures, err := h.HandleEvent(context.Background(), batch(ids...))
+		assert.NoError(t, err)
+		assert.Empty(t, failures)
+	}
+	assert.Zero(t, flusher.records)
+
+	// once cloudwatch logs accepts them again, every record is published once
+	failures, err := h.HandleEvent(context.Background(), batch("d"))
+	assert.NoError(t, err)
+	assert.Empty(t, failures)
+	assert.Equal(t, 4, flusher.records)
+}
@@ -0,0 +1,112 @@
+package heavyhitters
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// Counter is the estimated count of an item.  The real count is between Count-Error and Count.
+type Counter struct {
+	Item  string
+	Count int64
+	Error int64
+}
+
+// SpaceSaving is the Space-Saving heavy hitter sketch of Metwally et al.  It tracks at most
+// capacity items, so memory does not grow with the number of distinct items, and every item
+// seen more than total/capacity times is guaranteed to be tracked.
+// A SpaceSaving is not safe for concurrent use.
+type SpaceSaving struct {
+	capacity int
+	total    int64
+	items    map[string]*entry
+	counters minHeap
+}
+
+type entry struct {
+	Counter
+	index int // position in the heap
+}
+
+// NewSpaceSaving creates a sketch tracking at most capacity items, at least one
+func NewSpaceSaving(capacity int) *SpaceSaving {
+	capacity = max(capacity, 1)
+	return &SpaceSaving{
+		capacity: capacity,
+		items:    make(map[string]*entry, capacity),
+		counters: make(minHeap, 0, capacity),
+	}
+}
+
+// Add counts one occurrence of item
+func (s *SpaceSaving) Add(item string) {
+	s.total++
+	if e, ok := s.items[item]; ok {
+		e.Count++
+		heap.Fix(&s.counters, e.index)
+		return
+	}
+	if len(s.counters) < s.capacity {
+		e := &entry{Counter: Counter{Item: item, Count: 1}}
+		s.items[item] = e
+		heap.Push(&s.counters, e)
+		return
+	}
+
+	// the sketch is full, the least counted item is replaced and its count becomes the error
+	e := s.counters[0]
+	delete(s.items, e.Item)
+	e.Item = item
+	e.Error = e.Count
+	e.Count++
+	s.items[item] = e
+	heap.Fix(&s.counters, e.index)
+}
+
+// Total returns the number of occurrences added
+func (s *SpaceSaving) Total() int64 {
+	return s.total
+}
+
+// Top returns the n items with the highest estimated count, highest first.
+// Items with the same count are ordered by name.
+func (s *SpaceSaving) Top(n int) []Counter {
+	out := make([]Counter, 0, len(s.counters))
+	for _, e := range s.counters {
+		out = append(out, e.Counter)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		return out[i].Item < out[j].Item
+	})
+	if n < len(out) {
+		out = out[:n]
+	}
+	return out
+}
+
+// minHeap orders the tracked items by count, least counted first
+type minHeap []*entry
+
+func (h minHeap) Len() int           { return len(h) }
+func (h minHeap) Less(i, j int) bool { return h[i].Count < h[j].Count }
+func (h minHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *minHeap) Push(x any) {
+	e := x.(*entry)
+	e.index = len(*h)
+	*h = append(*h, e)
+}
+
+func (h *minHeap) Pop() any {
+	old := *h
+	e := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return e
+}
@@ -0,0 +1,66 @@
+package heavyhitters
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpaceSaving_Exact(t *testing.T) {
+	s := NewSpaceSaving(10)
+	for i := 0; i < 5; i++ {
+		s.Add("a")
+	}
+	for i := 0; i < 3; i++ {
+		s.Add("b")
+	}
+	s.Add("c")
+
+	assert.Equal(t, int64(9), s.Total())
+	assert.Equal(t, []Counter{
+		{Item: "a", Count: 5},
+		{Item: "b", Count: 3},
+	}, s.Top(2))
+	assert.Len(t, s.Top(100), 3)
+}
+
+func TestSpaceSaving_Eviction(t *testing.T) {
+	s := NewSpaceSaving(2)
+	s.Add("a")
+	s.Add("a")
+	s.Add("b")
+
+	// c replaces b, the least counted item, and inherits its count as error
+	s.Add("c")
+	assert.Equal(t, []Counter{
+		{Item: "a", Count: 2},
+		{Item: "c", Count: 2, Error: 1},
+	}, s.Top(2))
+}
+
+func TestSpaceSaving_HeavyHittersSurvive(t *testing.T) {
+	s := NewSpaceSaving(20)
+
+	// two heavy hitters hidden in a long tail of distinct items
+	for i := 0; i < 1000; i++ {
+		s.Add(fmt.Sprintf("tail-%d", i))
+		if i%4 == 0 {
+			s.Add("hot-1")
+		}
+		if i%10 == 0 {
+			s.Add("hot-2")
+		}
+	}
+
+	top := s.Top(2)
+	assert.Equal(t, "hot-1", top[0].Item)
+	assert.Equal(t, "hot-2", top[1].Item)
+	for _, c := range top {
+		// the real count is within the error bound
+		want := map[string]int64{"hot-1": 250, "hot-2": 100}[c.Item]
+		assert.GreaterOrEqual(t, c.Count, want)
+		assert.LessOrEqual(t, c.Count-c.Error, want)
+	}
+	assert.Equal(t, int64(1350), s.Total())
+}
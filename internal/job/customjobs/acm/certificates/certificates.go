@@ -0,0 +1,99 @@
+package certificates
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/acm"
+	acmTypes "github.com/aws/aws-sdk-go-v2/service/acm/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/acmclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// CertificatesJob will implement the Job interface
+// It counts the ACM certificates of the region against the certificates quota.
+
+type CertificatesJob struct {
+	ACMClient           acmclient.ACMClient
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	jobName             string
+	region              string
+	Logger              logger.Logger
+}
+
+type CertificatesJobConfig struct {
+	ACMClient           acmclient.ACMClient
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	Logger              logger.Logger
+}
+
+const (
+	certificatesJobPrefix = "acmCertificates"
+	serviceCode           = "acm"
+
+	// cloudwatch metric names
+	certificatesMetricName = "acmCertificates"
+)
+
+var (
+	// ACM certificates per region
+	certificatesQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, QuotaCode: "L-F141DD1D", Default: 2500}
+)
+
+// NewCertificatesJob will create a new CertificatesJob
+func NewCertificatesJob(config CertificatesJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	job := &CertificatesJob{
+		ACMClient:           config.ACMClient,
+		ServiceQuotasClient: config.ServiceQuotasClient,
+		jobName:             certificatesJobPrefix + "-" + config.ACMClient.GetRegion(),
+		region:              config.ACMClient.GetRegion(),
+		Logger:              config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns certificate utilization
+func (j *CertificatesJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	// ListCertificates only returns RSA 2048 certificates unless every key type is asked for
+	input := &acm.ListCertificatesInput{
+		Includes: &acmTypes.Filters{
+			KeyTypes: acmTypes.KeyAlgorithm("").Values(),
+		},
+	}
+	certificates := 0
+	paginator := acm.NewListCertificatesPaginator(j.ACMClient, input)
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		certificates += len(output.CertificateSummaryList)
+	}
+
+	limit, err := servicequotaclient.GetQuotaValue(ctx, j.ServiceQuotasClient, certificatesQuota)
+	if err != nil {
+		return nil, err
+	}
+	j.Logger.Debug("%s certificates : %d/%.0f", j.GetJobName(), certificates, limit)
+
+	return []sharedtypes.CloudWatchMetric{
+		utils.PercentMetric(certificatesMetricName, utils.Utilization(float64(certificates), limit), nil, time.Now()),
+	}, nil
+}
+
+// GetJobName return the name of the job
+func (j *CertificatesJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *CertificatesJob) GetRegion() string {
+	return j.region
+}
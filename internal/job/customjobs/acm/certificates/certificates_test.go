@@ -0,0 +1,57 @@
+package certificates
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/acm"
+	acmTypes "github.com/aws/aws-sdk-go-v2/service/acm/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/acmclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeACMClient builds a fake with 300 certificates over two pages
+func newFakeACMClient() *acmclient.FakeACMClient {
+	return &acmclient.FakeACMClient{
+		Region: "us-east-1",
+		ListCertificatesPageOutputs: []*acm.ListCertificatesOutput{
+			{CertificateSummaryList: make([]acmTypes.CertificateSummary, 200)},
+			{CertificateSummaryList: make([]acmTypes.CertificateSummary, 100)},
+		},
+		ErrOnListCertificatesCall: -1,
+	}
+}
+
+func TestCertificatesJob_Execute(t *testing.T) {
+	acmFake := newFakeACMClient()
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 1000}
+	j, err := NewCertificatesJob(CertificatesJobConfig{ACMClient: acmFake, ServiceQuotasClient: sqFake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, certificatesJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, metrics, 1) {
+		assert.Equal(t, certificatesMetricName, metrics[0].Name)
+		assert.InDelta(t, 30, metrics[0].Value, 0.001)
+	}
+	assert.Equal(t, []string{certificatesQuota.QuotaCode}, sqFake.RequestedQuotaCodes)
+
+	// every key type is requested, not only the RSA 2048 default
+	if assert.NotEmpty(t, acmFake.ListCertificatesInputs) {
+		keyTypes := acmFake.ListCertificatesInputs[0].Includes.KeyTypes
+		assert.Contains(t, keyTypes, acmTypes.KeyAlgorithmEcPrime256v1)
+		assert.Contains(t, keyTypes, acmTypes.KeyAlgorithmRsa4096)
+	}
+}
+
+func TestCertificatesJob_Error(t *testing.T) {
+	acmFake := newFakeACMClient()
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 1000}
+	acmFake.ErrOnListCertificatesCall = 1
+	j, _ := NewCertificatesJob(CertificatesJobConfig{ACMClient: acmFake, ServiceQuotasClient: sqFake})
+	jobtest.AssertExecuteFails(t, j)
+}
@@ -0,0 +1,180 @@
+package accountresources
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/apigateway"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/apigatewayclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/apigatewayv2client"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// AccountResourcesJob will implement the Job interface
+// It compares the custom domain names, REST and HTTP VPC links, usage plans and API keys
+// of the region against their quotas.
+
+type AccountResourcesJob struct {
+	APIGatewayClient    apigatewayclient.APIGatewayClient
+	APIGatewayV2Client  apigatewayv2client.APIGatewayV2Client
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	jobName             string
+	region              string
+	Logger              logger.Logger
+}
+
+type AccountResourcesJobConfig struct {
+	APIGatewayClient    apigatewayclient.APIGatewayClient
+	APIGatewayV2Client  apigatewayv2client.APIGatewayV2Client
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	Logger              logger.Logger
+}
+
+const (
+	accountResourcesJobPrefix = "apigatewayAccountResources"
+	serviceCode               = "apigateway"
+
+	// cloudwatch metric names
+	domainNamesMetricName  = "apigatewayCustomDomainNames"
+	restVpcLinksMetricName = "apigatewayRestVpcLinks"
+	httpVpcLinksMetricName = "apigatewayHttpVpcLinks"
+	usagePlansMetricName   = "apigatewayUsagePlans"
+	apiKeysMetricName      = "apigatewayApiKeys"
+)
+
+var (
+	// Custom domain names per region, shared by REST and HTTP APIs
+	domainNamesQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, Default: 120}
+	// VPC links for REST APIs per region
+	restVpcLinksQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, Default: 20}
+	// VPC links for HTTP APIs per region
+	httpVpcLinksQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, Default: 10}
+	// Usage plans per region
+	usagePlansQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, Default: 300}
+	// API keys per region
+	apiKeysQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, Default: 10000}
+)
+
+// NewAccountResourcesJob will create a new AccountResourcesJob
+func NewAccountResourcesJob(config AccountResourcesJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	job := &AccountResourcesJob{
+		APIGatewayClient:    config.APIGatewayClient,
+		APIGatewayV2Client:  config.APIGatewayV2Client,
+		ServiceQuotasClient: config.ServiceQuotasClient,
+		jobName:             accountResourcesJobPrefix + "-" + config.APIGatewayClient.GetRegion(),
+		region:              config.APIGatewayClient.GetRegion(),
+		Logger:              config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns utilization of the region wide api gateway resources
+func (j *AccountResourcesJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	domainNames := 0
+	domains := apigateway.NewGetDomainNamesPaginator(j.APIGatewayClient, &apigateway.GetDomainNamesInput{})
+	for domains.HasMorePages() {
+		output, err := domains.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		domainNames += len(output.Items)
+	}
+
+	restVpcLinks := 0
+	links := apigateway.NewGetVpcLinksPaginator(j.APIGatewayClient, &apigateway.GetVpcLinksInput{})
+	for links.HasMorePages() {
+		output, err := links.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		restVpcLinks += len(output.Items)
+	}
+
+	httpVpcLinks, err := j.countHttpVpcLinks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	usagePlans := 0
+	plans := apigateway.NewGetUsagePlansPaginator(j.APIGatewayClient, &apigateway.GetUsagePlansInput{})
+	for plans.HasMorePages() {
+		output, err := plans.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		usagePlans += len(output.Items)
+	}
+
+	apiKeys := 0
+	keys := apigateway.NewGetApiKeysPaginator(j.APIGatewayClient, &apigateway.GetApiKeysInput{})
+	for keys.HasMorePages() {
+		output, err := keys.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		apiKeys += len(output.Items)
+	}
+
+	usages := []struct {
+		name  string
+		count int
+		quota servicequotaclient.QuotaSpec
+	}{
+		{domainNamesMetricName, domainNames, domainNamesQuota},
+		{restVpcLinksMetricName, restVpcLinks, restVpcLinksQuota},
+		{httpVpcLinksMetricName, httpVpcLinks, httpVpcLinksQuota},
+		{usagePlansMetricName, usagePlans, usagePlansQuota},
+		{apiKeysMetricName, apiKeys, apiKeysQuota},
+	}
+
+	now := time.Now()
+	out := make([]sharedtypes.CloudWatchMetric, 0, len(usages))
+	for _, u := range usages {
+		limit, err := servicequotaclient.GetQuotaValue(ctx, j.ServiceQuotasClient, u.quota)
+		if err != nil {
+			return nil, err
+		}
+		j.Logger.Debug("%s %s : %d/%.0f", j.GetJobName(), u.name, u.count, limit)
+		out = append(out, utils.PercentMetric(u.name, utils.Utilization(float64(u.count), limit), nil, now))
+	}
+	return out, nil
+}
+
+// countHttpVpcLinks counts the HTTP API VPC links of the region
+func (j *AccountResourcesJob) countHttpVpcLinks(ctx context.Context) (int, error) {
+	var (
+		total     int
+		nextToken *string
+	)
+	for {
+		output, err := j.APIGatewayV2Client.GetVpcLinks(ctx, &apigatewayv2.GetVpcLinksInput{
+			NextToken: nextToken,
+		})
+		if err != nil {
+			return 0, err
+		}
+		total += len(output.Items)
+		if output.NextToken == nil {
+			return total, nil
+		}
+		nextToken = output.NextToken
+	}
+}
+
+// GetJobName return the name of the job
+func (j *AccountResourcesJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *AccountResourcesJob) GetRegion() string {
+	return j.region
+}
@@ -0,0 +1,94 @@
+package accountresources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/apigateway"
+	apigwTypes "github.com/aws/aws-sdk-go-v2/service/apigateway/types"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	apigwv2Types "github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/apigatewayclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/apigatewayv2client"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeClients builds fakes with 30 domain names, 5 REST and 4 HTTP VPC links,
+// 60 usage plans and 2500 API keys, all but the HTTP VPC links over two pages
+func newFakeClients() (*apigatewayclient.FakeAPIGatewayClient, *apigatewayv2client.FakeAPIGatewayV2Client) {
+	v1 := &apigatewayclient.FakeAPIGatewayClient{
+		Region: "us-east-1",
+		GetDomainNamesPageOutputs: []*apigateway.GetDomainNamesOutput{
+			{Items: make([]apigwTypes.DomainName, 20)},
+			{Items: make([]apigwTypes.DomainName, 10)},
+		},
+		GetVpcLinksPageOutputs: []*apigateway.GetVpcLinksOutput{
+			{Items: make([]apigwTypes.VpcLink, 3)},
+			{Items: make([]apigwTypes.VpcLink, 2)},
+		},
+		GetUsagePlansPageOutputs: []*apigateway.GetUsagePlansOutput{
+			{Items: make([]apigwTypes.UsagePlan, 50)},
+			{Items: make([]apigwTypes.UsagePlan, 10)},
+		},
+		GetApiKeysPageOutputs: []*apigateway.GetApiKeysOutput{
+			{Items: make([]apigwTypes.ApiKey, 2000)},
+			{Items: make([]apigwTypes.ApiKey, 500)},
+		},
+		ErrOnGetRestApisCall:    -1,
+		ErrOnGetResourcesCall:   -1,
+		ErrOnGetDomainNamesCall: -1,
+		ErrOnGetVpcLinksCall:    -1,
+		ErrOnGetUsagePlansCall:  -1,
+		ErrOnGetApiKeysCall:     -1,
+	}
+	v2 := &apigatewayv2client.FakeAPIGatewayV2Client{
+		Region: "us-east-1",
+		GetVpcLinksPageOutputs: []*apigatewayv2.GetVpcLinksOutput{
+			{Items: make([]apigwv2Types.VpcLink, 4)},
+		},
+		ErrOnGetApisCall:     -1,
+		ErrOnGetRoutesCall:   -1,
+		ErrOnGetVpcLinksCall: -1,
+	}
+	return v1, v2
+}
+
+func TestAccountResourcesJob_Execute(t *testing.T) {
+	v1, v2 := newFakeClients()
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1"}
+	j, err := NewAccountResourcesJob(AccountResourcesJobConfig{APIGatewayClient: v1, APIGatewayV2Client: v2, ServiceQuotasClient: sqFake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, accountResourcesJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, metrics, 5)
+
+	cases := []struct {
+		name  string
+		value float64
+	}{
+		{domainNamesMetricName, 25},
+		{restVpcLinksMetricName, 25},
+		{httpVpcLinksMetricName, 40},
+		{usagePlansMetricName, 20},
+		{apiKeysMetricName, 25},
+	}
+	for _, tc := range cases {
+		m, ok := jobtest.FindMetric(metrics, tc.name)
+		if assert.True(t, ok, "missing metric %s", tc.name) {
+			assert.InDelta(t, tc.value, m.Value, 0.001, tc.name)
+		}
+	}
+}
+
+func TestAccountResourcesJob_Error(t *testing.T) {
+	v1, v2 := newFakeClients()
+	v1.ErrOnGetDomainNamesCall = 1
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1"}
+	j, _ := NewAccountResourcesJob(AccountResourcesJobConfig{APIGatewayClient: v1, APIGatewayV2Client: v2, ServiceQuotasClient: sqFake})
+	jobtest.AssertExecuteFails(t, j)
+}
@@ -0,0 +1,162 @@
+package httpapis
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/apigatewayv2client"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// HttpApisJob will implement the Job interface
+// It counts the HTTP and WebSocket APIs of the region against the APIs quota and emits the
+// APIs with the most routes against the routes per API quota.
+
+type HttpApisJob struct {
+	APIGatewayV2Client  apigatewayv2client.APIGatewayV2Client
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	jobName             string
+	region              string
+	Logger              logger.Logger
+}
+
+type HttpApisJobConfig struct {
+	APIGatewayV2Client  apigatewayv2client.APIGatewayV2Client
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	Logger              logger.Logger
+}
+
+const (
+	httpApisJobPrefix = "apigatewayHttpApis"
+	serviceCode       = "apigateway"
+	apiIdDimension    = "apiId"
+	// only the APIs with the most routes get a metric
+	topApis = 10
+
+	// cloudwatch metric names
+	httpApisMetricName     = "apigatewayHttpApis"
+	routesPerApiMetricName = "apigatewayRoutesPerHttpApi"
+)
+
+var (
+	// HTTP and WebSocket APIs per region
+	httpApisQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, Default: 600}
+	// Routes per API
+	routesPerApiQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, Default: 300}
+)
+
+// NewHttpApisJob will create a new HttpApisJob
+func NewHttpApisJob(config HttpApisJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	job := &HttpApisJob{
+		APIGatewayV2Client:  config.APIGatewayV2Client,
+		ServiceQuotasClient: config.ServiceQuotasClient,
+		jobName:             httpApisJobPrefix + "-" + config.APIGatewayV2Client.GetRegion(),
+		region:              config.APIGatewayV2Client.GetRegion(),
+		Logger:              config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns API utilization and routes per API utilization for the largest APIs
+func (j *HttpApisJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	apis, err := j.listApis(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make([]utils.ResourceCount, 0, len(apis))
+	for _, apiId := range apis {
+		routes, err := j.countRoutes(ctx, apiId)
+		if err != nil {
+			return nil, err
+		}
+		counts = append(counts, utils.ResourceCount{
+			Name:       apiId,
+			Count:      routes,
+			Dimensions: map[string]string{apiIdDimension: apiId},
+		})
+	}
+
+	apisLimit, err := servicequotaclient.GetQuotaValue(ctx, j.ServiceQuotasClient, httpApisQuota)
+	if err != nil {
+		return nil, err
+	}
+	routesLimit, err := servicequotaclient.GetQuotaValue(ctx, j.ServiceQuotasClient, routesPerApiQuota)
+	if err != nil {
+		return nil, err
+	}
+	j.Logger.Debug("%s apis : %d/%.0f", j.GetJobName(), len(apis), apisLimit)
+
+	now := time.Now()
+	out := []sharedtypes.CloudWatchMetric{
+		utils.PercentMetric(httpApisMetricName, utils.Utilization(float64(len(apis)), apisLimit), nil, now),
+	}
+	for _, rc := range utils.TopResourceCounts(counts, topApis) {
+		out = append(out, utils.PercentMetric(routesPerApiMetricName, utils.Utilization(float64(rc.Count), routesLimit), rc.Dimensions, now))
+	}
+	return out, nil
+}
+
+// listApis returns the ids of every HTTP and WebSocket API in the region
+func (j *HttpApisJob) listApis(ctx context.Context) ([]string, error) {
+	var (
+		apis      []string
+		nextToken *string
+	)
+	for {
+		output, err := j.APIGatewayV2Client.GetApis(ctx, &apigatewayv2.GetApisInput{
+			NextToken: nextToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, api := range output.Items {
+			apis = append(apis, aws.ToString(api.ApiId))
+		}
+		if output.NextToken == nil {
+			return apis, nil
+		}
+		nextToken = output.NextToken
+	}
+}
+
+// countRoutes counts the routes of a single API
+func (j *HttpApisJob) countRoutes(ctx context.Context, apiId string) (int64, error) {
+	var (
+		total     int64
+		nextToken *string
+	)
+	for {
+		output, err := j.APIGatewayV2Client.GetRoutes(ctx, &apigatewayv2.GetRoutesInput{
+			ApiId:     aws.String(apiId),
+			NextToken: nextToken,
+		})
+		if err != nil {
+			return 0, err
+		}
+		total += int64(len(output.Items))
+		if output.NextToken == nil {
+			return total, nil
+		}
+		nextToken = output.NextToken
+	}
+}
+
+// GetJobName return the name of the job
+func (j *HttpApisJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *HttpApisJob) GetRegion() string {
+	return j.region
+}
@@ -0,0 +1,75 @@
+package httpapis
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	apigwv2Types "github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/apigatewayv2client"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeAPIGatewayV2Client builds a fake with n APIs over two pages, api i owning i*6 routes over two pages
+func newFakeAPIGatewayV2Client(n int) *apigatewayv2client.FakeAPIGatewayV2Client {
+	f := &apigatewayv2client.FakeAPIGatewayV2Client{
+		Region:               "us-east-1",
+		GetApisPageOutputs:   []*apigatewayv2.GetApisOutput{{}, {}},
+		GetRoutesPageOutputs: map[string][]*apigatewayv2.GetRoutesOutput{},
+		ErrOnGetApisCall:     -1,
+		ErrOnGetRoutesCall:   -1,
+		ErrOnGetVpcLinksCall: -1,
+	}
+	for i := 1; i <= n; i++ {
+		id := fmt.Sprintf("api%02d", i)
+		f.GetApisPageOutputs[i%2].Items = append(f.GetApisPageOutputs[i%2].Items, apigwv2Types.Api{ApiId: aws.String(id)})
+		f.GetRoutesPageOutputs[id] = []*apigatewayv2.GetRoutesOutput{
+			{Items: make([]apigwv2Types.Route, i*3)},
+			{Items: make([]apigwv2Types.Route, i*3)},
+		}
+	}
+	return f
+}
+
+func TestHttpApisJob_Execute(t *testing.T) {
+	apigwFake := newFakeAPIGatewayV2Client(topApis + 2)
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1"}
+	j, err := NewHttpApisJob(HttpApisJobConfig{APIGatewayV2Client: apigwFake, ServiceQuotasClient: sqFake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, httpApisJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, metrics, 1+topApis)
+
+	cases := []struct {
+		name  string
+		apiId string
+		value float64
+	}{
+		{httpApisMetricName, "", 12 / httpApisQuota.Default * 100},
+		{routesPerApiMetricName, "api12", 72 / routesPerApiQuota.Default * 100},
+		{routesPerApiMetricName, "api03", 18 / routesPerApiQuota.Default * 100},
+	}
+	for _, tc := range cases {
+		m, ok := jobtest.FindMetric(metrics, tc.name, apiIdDimension, tc.apiId)
+		if assert.True(t, ok, "missing metric %s %s", tc.name, tc.apiId) {
+			assert.InDelta(t, tc.value, m.Value, 0.001, "%s %s", tc.name, tc.apiId)
+		}
+	}
+	_, ok := jobtest.FindMetric(metrics, routesPerApiMetricName, apiIdDimension, "api01")
+	assert.False(t, ok, "smallest apis should be dropped")
+}
+
+func TestHttpApisJob_Error(t *testing.T) {
+	apigwFake := newFakeAPIGatewayV2Client(3)
+	apigwFake.ErrOnGetApisCall = 1
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1"}
+	j, _ := NewHttpApisJob(HttpApisJobConfig{APIGatewayV2Client: apigwFake, ServiceQuotasClient: sqFake})
+	jobtest.AssertExecuteFails(t, j)
+}
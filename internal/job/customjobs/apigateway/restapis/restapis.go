@@ -0,0 +1,161 @@
+package restapis
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway"
+	apigwTypes "github.com/aws/aws-sdk-go-v2/service/apigateway/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/apigatewayclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// RestApisJob will implement the Job interface
+// It counts the REST APIs of the region per endpoint type against their quotas and emits
+// the APIs with the most resources against the resources per API quota.
+
+type RestApisJob struct {
+	APIGatewayClient    apigatewayclient.APIGatewayClient
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	jobName             string
+	region              string
+	Logger              logger.Logger
+}
+
+type RestApisJobConfig struct {
+	APIGatewayClient    apigatewayclient.APIGatewayClient
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	Logger              logger.Logger
+}
+
+const (
+	restApisJobPrefix = "apigatewayRestApis"
+	serviceCode       = "apigateway"
+	apiIdDimension    = "apiId"
+	// only the APIs with the most resources get a metric
+	topApis = 10
+
+	// cloudwatch metric names
+	regionalApisMetricName    = "apigatewayRegionalRestApis"
+	edgeApisMetricName        = "apigatewayEdgeRestApis"
+	privateApisMetricName     = "apigatewayPrivateRestApis"
+	resourcesPerApiMetricName = "apigatewayResourcesPerRestApi"
+)
+
+var (
+	// Regional APIs per region
+	regionalApisQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, Default: 600}
+	// Edge-optimized APIs per region
+	edgeApisQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, Default: 120}
+	// Private APIs per region
+	privateApisQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, Default: 600}
+	// Resources per API
+	resourcesPerApiQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, QuotaCode: "L-01C8A9E0", Default: 300}
+)
+
+// NewRestApisJob will create a new RestApisJob
+func NewRestApisJob(config RestApisJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	job := &RestApisJob{
+		APIGatewayClient:    config.APIGatewayClient,
+		ServiceQuotasClient: config.ServiceQuotasClient,
+		jobName:             restApisJobPrefix + "-" + config.APIGatewayClient.GetRegion(),
+		region:              config.APIGatewayClient.GetRegion(),
+		Logger:              config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns REST API utilization per endpoint type and resources per API utilization for the largest APIs
+func (j *RestApisJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	var apis []apigwTypes.RestApi
+	paginator := apigateway.NewGetRestApisPaginator(j.APIGatewayClient, &apigateway.GetRestApisInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		apis = append(apis, output.Items...)
+	}
+
+	perType := make(map[apigwTypes.EndpointType]int)
+	counts := make([]utils.ResourceCount, 0, len(apis))
+	for _, api := range apis {
+		if api.EndpointConfiguration != nil {
+			for _, t := range api.EndpointConfiguration.Types {
+				perType[t]++
+			}
+		}
+		resources, err := j.countResources(ctx, aws.ToString(api.Id))
+		if err != nil {
+			return nil, err
+		}
+		counts = append(counts, utils.ResourceCount{
+			Name:       aws.ToString(api.Id),
+			Count:      resources,
+			Dimensions: map[string]string{apiIdDimension: aws.ToString(api.Id)},
+		})
+	}
+	j.Logger.Debug("%s rest apis : %d", j.GetJobName(), len(apis))
+
+	now := time.Now()
+	var out []sharedtypes.CloudWatchMetric
+	usages := []struct {
+		name  string
+		count int
+		quota servicequotaclient.QuotaSpec
+	}{
+		{regionalApisMetricName, perType[apigwTypes.EndpointTypeRegional], regionalApisQuota},
+		{edgeApisMetricName, perType[apigwTypes.EndpointTypeEdge], edgeApisQuota},
+		{privateApisMetricName, perType[apigwTypes.EndpointTypePrivate], privateApisQuota},
+	}
+	for _, u := range usages {
+		limit, err := servicequotaclient.GetQuotaValue(ctx, j.ServiceQuotasClient, u.quota)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, utils.PercentMetric(u.name, utils.Utilization(float64(u.count), limit), nil, now))
+	}
+
+	resourcesLimit, err := servicequotaclient.GetQuotaValue(ctx, j.ServiceQuotasClient, resourcesPerApiQuota)
+	if err != nil {
+		return nil, err
+	}
+	for _, rc := range utils.TopResourceCounts(counts, topApis) {
+		out = append(out, utils.PercentMetric(resourcesPerApiMetricName, utils.Utilization(float64(rc.Count), resourcesLimit), rc.Dimensions, now))
+	}
+	return out, nil
+}
+
+// countResources counts the resources of a single REST API
+func (j *RestApisJob) countResources(ctx context.Context, apiId string) (int64, error) {
+	var total int64
+	paginator := apigateway.NewGetResourcesPaginator(j.APIGatewayClient, &apigateway.GetResourcesInput{
+		RestApiId: aws.String(apiId),
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return 0, err
+		}
+		total += int64(len(output.Items))
+	}
+	return total, nil
+}
+
+// GetJobName return the name of the job
+func (j *RestApisJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *RestApisJob) GetRegion() string {
+	return j.region
+}
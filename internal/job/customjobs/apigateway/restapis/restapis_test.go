@@ -0,0 +1,94 @@
+package restapis
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway"
+	apigwTypes "github.com/aws/aws-sdk-go-v2/service/apigateway/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/apigatewayclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeAPIGatewayClient builds a fake with n REST APIs over two pages, api i owning i*10 resources
+// over two pages. Every third API is private, every fifth edge-optimized, the rest regional.
+func newFakeAPIGatewayClient(n int) *apigatewayclient.FakeAPIGatewayClient {
+	f := &apigatewayclient.FakeAPIGatewayClient{
+		Region:                  "us-east-1",
+		GetRestApisPageOutputs:  []*apigateway.GetRestApisOutput{{}, {}},
+		GetResourcesPageOutputs: map[string][]*apigateway.GetResourcesOutput{},
+		ErrOnGetRestApisCall:    -1,
+		ErrOnGetResourcesCall:   -1,
+		ErrOnGetDomainNamesCall: -1,
+		ErrOnGetVpcLinksCall:    -1,
+		ErrOnGetUsagePlansCall:  -1,
+		ErrOnGetApiKeysCall:     -1,
+	}
+	for i := 1; i <= n; i++ {
+		id := fmt.Sprintf("api%02d", i)
+		endpoint := apigwTypes.EndpointTypeRegional
+		switch {
+		case i%3 == 0:
+			endpoint = apigwTypes.EndpointTypePrivate
+		case i%5 == 0:
+			endpoint = apigwTypes.EndpointTypeEdge
+		}
+		f.GetRestApisPageOutputs[i%2].Items = append(f.GetRestApisPageOutputs[i%2].Items, apigwTypes.RestApi{
+			Id:                    aws.String(id),
+			EndpointConfiguration: &apigwTypes.EndpointConfiguration{Types: []apigwTypes.EndpointType{endpoint}},
+		})
+		f.GetResourcesPageOutputs[id] = []*apigateway.GetResourcesOutput{
+			{Items: make([]apigwTypes.Resource, i*5)},
+			{Items: make([]apigwTypes.Resource, i*5)},
+		}
+	}
+	return f
+}
+
+func TestRestApisJob_Execute(t *testing.T) {
+	apigwFake := newFakeAPIGatewayClient(topApis + 2)
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 600}
+	j, err := NewRestApisJob(RestApisJobConfig{APIGatewayClient: apigwFake, ServiceQuotasClient: sqFake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, restApisJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, metrics, 3+topApis)
+
+	cases := []struct {
+		name  string
+		apiId string
+		value float64
+	}{
+		// apis 3, 6, 9 and 12 are private, 5 and 10 edge-optimized, the other 6 regional
+		{regionalApisMetricName, "", 6 / regionalApisQuota.Default * 100},
+		{edgeApisMetricName, "", 2 / edgeApisQuota.Default * 100},
+		{privateApisMetricName, "", 4 / privateApisQuota.Default * 100},
+		// resources per api uses the service quota
+		{resourcesPerApiMetricName, "api12", 20},
+		{resourcesPerApiMetricName, "api03", 5},
+	}
+	for _, tc := range cases {
+		m, ok := jobtest.FindMetric(metrics, tc.name, apiIdDimension, tc.apiId)
+		if assert.True(t, ok, "missing metric %s %s", tc.name, tc.apiId) {
+			assert.InDelta(t, tc.value, m.Value, 0.001, "%s %s", tc.name, tc.apiId)
+		}
+	}
+	_, ok := jobtest.FindMetric(metrics, resourcesPerApiMetricName, apiIdDimension, "api01")
+	assert.False(t, ok, "smallest apis should be dropped")
+	assert.Equal(t, []string{resourcesPerApiQuota.QuotaCode}, sqFake.RequestedQuotaCodes)
+}
+
+func TestRestApisJob_Error(t *testing.T) {
+	apigwFake := newFakeAPIGatewayClient(3)
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 600}
+	apigwFake.ErrOnGetRestApisCall = 1
+	j, _ := NewRestApisJob(RestApisJobConfig{APIGatewayClient: apigwFake, ServiceQuotasClient: sqFake})
+	jobtest.AssertExecuteFails(t, j)
+}
@@ -0,0 +1,96 @@
+package throttling
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/apigateway"
+	cwTypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/apigatewayclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+)
+
+// ThrottlingJob will implement the Job interface
+// It emits the account level API Gateway steady state and burst throttle limits of the region.
+// They are published as raw values rather than utilization so they can be graphed next to the
+// call counts produced by the rate limit solution.
+
+type ThrottlingJob struct {
+	APIGatewayClient apigatewayclient.APIGatewayClient
+	jobName          string
+	region           string
+	Logger           logger.Logger
+}
+
+type ThrottlingJobConfig struct {
+	APIGatewayClient apigatewayclient.APIGatewayClient
+	Logger           logger.Logger
+}
+
+const (
+	throttlingJobPrefix = "apigatewayThrottling"
+
+	// cloudwatch metric names
+	rateLimitMetricName  = "apigatewayAccountThrottleRateLimit"
+	burstLimitMetricName = "apigatewayAccountThrottleBurstLimit"
+)
+
+var (
+	ErrNoThrottleSettings = errors.New("apigateway account has no throttle settings")
+)
+
+// NewThrottlingJob will create a new ThrottlingJob
+func NewThrottlingJob(config ThrottlingJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	job := &ThrottlingJob{
+		APIGatewayClient: config.APIGatewayClient,
+		jobName:          throttlingJobPrefix + "-" + config.APIGatewayClient.GetRegion(),
+		region:           config.APIGatewayClient.GetRegion(),
+		Logger:           config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns the account throttle rate and burst limits
+func (j *ThrottlingJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	output, err := j.APIGatewayClient.GetAccount(ctx, &apigateway.GetAccountInput{})
+	if err != nil {
+		return nil, err
+	}
+	if output.ThrottleSettings == nil {
+		return nil, ErrNoThrottleSettings
+	}
+	settings := output.ThrottleSettings
+	j.Logger.Debug("%s rate limit : %.0f, burst limit : %d", j.GetJobName(), settings.RateLimit, settings.BurstLimit)
+
+	now := time.Now()
+	return []sharedtypes.CloudWatchMetric{
+		{
+			Name:      rateLimitMetricName,
+			Value:     settings.RateLimit,
+			Unit:      cwTypes.StandardUnitCountSecond,
+			Timestamp: now,
+		},
+		{
+			Name:      burstLimitMetricName,
+			Value:     float64(settings.BurstLimit),
+			Unit:      cwTypes.StandardUnitCount,
+			Timestamp: now,
+		},
+	}, nil
+}
+
+// GetJobName return the name of the job
+func (j *ThrottlingJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *ThrottlingJob) GetRegion() string {
+	return j.region
+}
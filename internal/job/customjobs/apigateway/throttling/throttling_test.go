@@ -0,0 +1,50 @@
+package throttling
+
+import (
+	"context"
+	"testing"
+
+	apigwTypes "github.com/aws/aws-sdk-go-v2/service/apigateway/types"
+	cwTypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/apigatewayclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThrottlingJob_Execute(t *testing.T) {
+	apigwFake := &apigatewayclient.FakeAPIGatewayClient{
+		Region:           "us-east-1",
+		ThrottleSettings: &apigwTypes.ThrottleSettings{RateLimit: 10000, BurstLimit: 5000},
+	}
+	j, err := NewThrottlingJob(ThrottlingJobConfig{APIGatewayClient: apigwFake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, throttlingJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, metrics, 2) {
+		assert.Equal(t, rateLimitMetricName, metrics[0].Name)
+		assert.Equal(t, 10000.0, metrics[0].Value)
+		assert.Equal(t, cwTypes.StandardUnitCountSecond, metrics[0].Unit)
+		assert.Equal(t, burstLimitMetricName, metrics[1].Name)
+		assert.Equal(t, 5000.0, metrics[1].Value)
+		assert.Equal(t, cwTypes.StandardUnitCount, metrics[1].Unit)
+	}
+}
+
+func TestThrottlingJob_Errors(t *testing.T) {
+	cases := []struct {
+		name string
+		fake *apigatewayclient.FakeAPIGatewayClient
+	}{
+		{"get account", &apigatewayclient.FakeAPIGatewayClient{Region: "us-east-1", ErrGetAccount: true}},
+		{"no throttle settings", &apigatewayclient.FakeAPIGatewayClient{Region: "us-east-1"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			j, _ := NewThrottlingJob(ThrottlingJobConfig{APIGatewayClient: tc.fake})
+			jobtest.AssertExecuteFails(t, j)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package graphqlapis
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/appsync"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/appsyncclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// GraphqlApisJob will implement the Job interface
+// It counts the AppSync GraphQL APIs of the region against the APIs quota.
+
+type GraphqlApisJob struct {
+	AppSyncClient       appsyncclient.AppSyncClient
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	jobName             string
+	region              string
+	Logger              logger.Logger
+}
+
+type GraphqlApisJobConfig struct {
+	AppSyncClient       appsyncclient.AppSyncClient
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	Logger              logger.Logger
+}
+
+const (
+	graphqlApisJobPrefix = "appsyncGraphqlApis"
+	serviceCode          = "appsync"
+
+	// cloudwatch metric names
+	graphqlApisMetricName = "appsyncGraphqlApis"
+)
+
+var (
+	// GraphQL APIs per region
+	graphqlApisQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, Default: 25}
+)
+
+// NewGraphqlApisJob will create a new GraphqlApisJob
+func NewGraphqlApisJob(config GraphqlApisJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	job := &GraphqlApisJob{
+		AppSyncClient:       config.AppSyncClient,
+		ServiceQuotasClient: config.ServiceQuotasClient,
+		jobName:             graphqlApisJobPrefix + "-" + config.AppSyncClient.GetRegion(),
+		region:              config.AppSyncClient.GetRegion(),
+		Logger:              config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns GraphQL API utilization
+func (j *GraphqlApisJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	apis := 0
+	paginator := appsync.NewListGraphqlApisPaginator(j.AppSyncClient, &appsync.ListGraphqlApisInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		apis += len(output.GraphqlApis)
+	}
+
+	limit, err := servicequotaclient.GetQuotaValue(ctx, j.ServiceQuotasClient, graphqlApisQuota)
+	if err != nil {
+		return nil, err
+	}
+	j.Logger.Debug("%s graphql apis : %d/%.0f", j.GetJobName(), apis, limit)
+
+	return []sharedtypes.CloudWatchMetric{
+		utils.PercentMetric(graphqlApisMetricName, utils.Utilization(float64(apis), limit), nil, time.Now()),
+	}, nil
+}
+
+// GetJobName return the name of the job
+func (j *GraphqlApisJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *GraphqlApisJob) GetRegion() string {
+	return j.region
+}
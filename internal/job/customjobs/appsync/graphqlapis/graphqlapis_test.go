@@ -0,0 +1,49 @@
+package graphqlapis
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/appsync"
+	appsyncTypes "github.com/aws/aws-sdk-go-v2/service/appsync/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/appsyncclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeAppSyncClient builds a fake with 10 GraphQL APIs over two pages
+func newFakeAppSyncClient() *appsyncclient.FakeAppSyncClient {
+	return &appsyncclient.FakeAppSyncClient{
+		Region: "us-east-1",
+		ListGraphqlApisPageOutputs: []*appsync.ListGraphqlApisOutput{
+			{GraphqlApis: make([]appsyncTypes.GraphqlApi, 6)},
+			{GraphqlApis: make([]appsyncTypes.GraphqlApi, 4)},
+		},
+		ErrOnListGraphqlApisCall: -1,
+	}
+}
+
+func TestGraphqlApisJob_Execute(t *testing.T) {
+	appsyncFake := newFakeAppSyncClient()
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1"}
+	j, err := NewGraphqlApisJob(GraphqlApisJobConfig{AppSyncClient: appsyncFake, ServiceQuotasClient: sqFake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, graphqlApisJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, metrics, 1) {
+		assert.Equal(t, graphqlApisMetricName, metrics[0].Name)
+		assert.InDelta(t, 40, metrics[0].Value, 0.001)
+	}
+}
+
+func TestGraphqlApisJob_Error(t *testing.T) {
+	appsyncFake := newFakeAppSyncClient()
+	appsyncFake.ErrOnListGraphqlApisCall = 1
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1"}
+	j, _ := NewGraphqlApisJob(GraphqlApisJobConfig{AppSyncClient: appsyncFake, ServiceQuotasClient: sqFake})
+	jobtest.AssertExecuteFails(t, j)
+}
@@ -0,0 +1,92 @@
+package workgroups
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/athena"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/athenaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// WorkGroupsJob will implement the Job interface
+// It counts the Athena workgroups of the region against the workgroups quota.
+
+type WorkGroupsJob struct {
+	AthenaClient        athenaclient.AthenaClient
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	jobName             string
+	region              string
+	Logger              logger.Logger
+}
+
+type WorkGroupsJobConfig struct {
+	AthenaClient        athenaclient.AthenaClient
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	Logger              logger.Logger
+}
+
+const (
+	workGroupsJobPrefix = "athenaWorkGroups"
+	serviceCode         = "athena"
+
+	// cloudwatch metric names
+	workGroupsMetricName = "athenaWorkGroups"
+)
+
+var (
+	// Workgroups per region
+	workGroupsQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, Default: 1000}
+)
+
+// NewWorkGroupsJob will create a new WorkGroupsJob
+func NewWorkGroupsJob(config WorkGroupsJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	job := &WorkGroupsJob{
+		AthenaClient:        config.AthenaClient,
+		ServiceQuotasClient: config.ServiceQuotasClient,
+		jobName:             workGroupsJobPrefix + "-" + config.AthenaClient.GetRegion(),
+		region:              config.AthenaClient.GetRegion(),
+		Logger:              config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns workgroup utilization
+func (j *WorkGroupsJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	workGroups := 0
+	paginator := athena.NewListWorkGroupsPaginator(j.AthenaClient, &athena.ListWorkGroupsInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		workGroups += len(output.WorkGroups)
+	}
+
+	limit, err := servicequotaclient.GetQuotaValue(ctx, j.ServiceQuotasClient, workGroupsQuota)
+	if err != nil {
+		return nil, err
+	}
+	j.Logger.Debug("%s workgroups : %d/%.0f", j.GetJobName(), workGroups, limit)
+
+	return []sharedtypes.CloudWatchMetric{
+		utils.PercentMetric(workGroupsMetricName, utils.Utilization(float64(workGroups), limit), nil, time.Now()),
+	}, nil
+}
+
+// GetJobName return the name of the job
+func (j *WorkGroupsJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *WorkGroupsJob) GetRegion() string {
+	return j.region
+}
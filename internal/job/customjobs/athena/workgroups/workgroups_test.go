@@ -0,0 +1,49 @@
+package workgroups
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/athena"
+	athenaTypes "github.com/aws/aws-sdk-go-v2/service/athena/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/athenaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeAthenaClient builds a fake with 10 workgroups over two pages
+func newFakeAthenaClient() *athenaclient.FakeAthenaClient {
+	return &athenaclient.FakeAthenaClient{
+		Region: "us-east-1",
+		ListWorkGroupsPageOutputs: []*athena.ListWorkGroupsOutput{
+			{WorkGroups: make([]athenaTypes.WorkGroupSummary, 6)},
+			{WorkGroups: make([]athenaTypes.WorkGroupSummary, 4)},
+		},
+		ErrOnListWorkGroupsCall: -1,
+	}
+}
+
+func TestWorkGroupsJob_Execute(t *testing.T) {
+	athenaFake := newFakeAthenaClient()
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1"}
+	j, err := NewWorkGroupsJob(WorkGroupsJobConfig{AthenaClient: athenaFake, ServiceQuotasClient: sqFake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, workGroupsJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, metrics, 1) {
+		assert.Equal(t, workGroupsMetricName, metrics[0].Name)
+		assert.InDelta(t, 1, metrics[0].Value, 0.001)
+	}
+}
+
+func TestWorkGroupsJob_Error(t *testing.T) {
+	athenaFake := newFakeAthenaClient()
+	athenaFake.ErrOnListWorkGroupsCall = 1
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1"}
+	j, _ := NewWorkGroupsJob(WorkGroupsJobConfig{AthenaClient: athenaFake, ServiceQuotasClient: sqFake})
+	jobtest.AssertExecuteFails(t, j)
+}
@@ -0,0 +1,240 @@
+package stacks
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	cfnTypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/cloudformationclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// StacksJob will implement the Job interface
+// It counts active stacks against the StackLimit reported by DescribeAccountLimits,
+// active stack sets against the stack set quota, and emits the resource count of the
+// largest stacks against the resources per template maximum.
+// ListStackResources takes one paged call per stack, so at most MaxStacks stacks are
+// inspected and counting stops near the job deadline.  The stack and stack set metrics
+// are emitted even when resource counting is cut short.
+
+type StacksJob struct {
+	CloudFormationClient cloudformationclient.CloudFormationClient
+	ServiceQuotasClient  servicequotaclient.ServiceQuotasClient
+	MaxStacks            int
+	jobName              string
+	region               string
+	Logger               logger.Logger
+}
+
+type StacksJobConfig struct {
+	CloudFormationClient cloudformationclient.CloudFormationClient
+	ServiceQuotasClient  servicequotaclient.ServiceQuotasClient
+	// MaxStacks bounds the stacks whose resources are counted, defaults to defaultMaxStacks
+	MaxStacks int
+	Logger    logger.Logger
+}
+
+const (
+	stacksJobPrefix = "cloudformationStacks"
+	serviceCode     = "cloudformation"
+	stackDimension  = "stack"
+	stackLimitName  = "StackLimit"
+	// only the stacks with the most resources get a metric
+	topStacks = 10
+	// stacks whose resources are counted at most per run
+	defaultMaxStacks = 100
+	// resource counting stops this long before the job deadline
+	deadlineMargin = 10 * time.Second
+
+	// cloudwatch metric names
+	stacksMetricName            = "cloudformationStacks"
+	stackSetsMetricName         = "cloudformationStackSets"
+	resourcesPerStackMetricName = "cloudformationResourcesPerStack"
+)
+
+var (
+	// Stack count, used only when DescribeAccountLimits does not report StackLimit
+	stacksQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, Default: 2000}
+	// Stack sets per administrator account
+	stackSetsQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, QuotaCode: "L-31709F13", Default: 1000}
+	// Resources declared in a single template is a fixed maximum
+	resourcesPerStackQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, Default: 500}
+)
+
+// NewStacksJob will create a new StacksJob
+func NewStacksJob(config StacksJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	if config.MaxStacks <= 0 {
+		config.MaxStacks = defaultMaxStacks
+	}
+	job := &StacksJob{
+		CloudFormationClient: config.CloudFormationClient,
+		ServiceQuotasClient:  config.ServiceQuotasClient,
+		MaxStacks:            config.MaxStacks,
+		jobName:              stacksJobPrefix + "-" + config.CloudFormationClient.GetRegion(),
+		region:               config.CloudFormationClient.GetRegion(),
+		Logger:               config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns stack, stack set and resources per stack utilization
+func (j *StacksJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	stackLimit, err := j.getStackLimit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stacks, err := j.listActiveStacks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	j.Logger.Debug("%s active stacks : %d/%.0f", j.GetJobName(), len(stacks), stackLimit)
+
+	stackSets := 0
+	stackSetPaginator := cloudformation.NewListStackSetsPaginator(j.CloudFormationClient, &cloudformation.ListStackSetsInput{
+		Status: cfnTypes.StackSetStatusActive,
+	})
+	for stackSetPaginator.HasMorePages() {
+		output, err := stackSetPaginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		stackSets += len(output.Summaries)
+	}
+	stackSetsLimit, err := servicequotaclient.GetQuotaValue(ctx, j.ServiceQuotasClient, stackSetsQuota)
+	if err != nil {
+		return nil, err
+	}
+	j.Logger.Debug("%s active stack sets : %d/%.0f", j.GetJobName(), stackSets, stackSetsLimit)
+
+	now := time.Now()
+	out := []sharedtypes.CloudWatchMetric{
+		utils.PercentMetric(stacksMetricName, utils.Utilization(float64(len(stacks)), stackLimit), nil, now),
+		utils.PercentMetric(stackSetsMetricName, utils.Utilization(float64(stackSets), stackSetsLimit), nil, now),
+	}
+	for _, rc := range utils.TopResourceCounts(j.countStackResources(ctx, stacks), topStacks) {
+		out = append(out, utils.PercentMetric(resourcesPerStackMetricName, utils.Utilization(float64(rc.Count), resourcesPerStackQuota.Default), rc.Dimensions, now))
+	}
+	return out, nil
+}
+
+// getStackLimit reads StackLimit from DescribeAccountLimits, falling back to the documented default
+func (j *StacksJob) getStackLimit(ctx context.Context) (float64, error) {
+	paginator := cloudformation.NewDescribeAccountLimitsPaginator(j.CloudFormationClient, &cloudformation.DescribeAccountLimitsInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return 0, err
+		}
+		for _, limit := range output.AccountLimits {
+			if aws.ToString(limit.Name) == stackLimitName && aws.ToInt32(limit.Value) > 0 {
+				return float64(aws.ToInt32(limit.Value)), nil
+			}
+		}
+	}
+	j.Logger.Warn("%s %s not reported, using default %.0f", j.GetJobName(), stackLimitName, stacksQuota.Default)
+	return stacksQuota.Default, nil
+}
+
+// countStackResources counts the resources of up to MaxStacks stacks that can still be
+// updated.  It stops early near the job deadline or on the first failed call, keeping the
+// counts collected so far.
+func (j *StacksJob) countStackResources(ctx context.Context, stacks []cfnTypes.StackSummary) []utils.ResourceCount {
+	var resources []utils.ResourceCount
+	for _, stack := range stacks {
+		if !updatable(stack.StackStatus) {
+			continue
+		}
+		if len(resources) == j.MaxStacks {
+			j.Logger.Warn("%s more than %d stacks, only the first %d have their resources counted", j.GetJobName(), j.MaxStacks, j.MaxStacks)
+			break
+		}
+		if utils.NearDeadline(ctx, deadlineMargin) {
+			j.Logger.Warn("%s job deadline near, resources counted for %d stacks only", j.GetJobName(), len(resources))
+			break
+		}
+		name := aws.ToString(stack.StackName)
+		count, err := j.countResources(ctx, name)
+		if err != nil {
+			j.Logger.Warn("%s counting resources of stack %s failed, resources counted for %d stacks only : %v", j.GetJobName(), name, len(resources), err)
+			break
+		}
+		resources = append(resources, utils.ResourceCount{
+			Name:       name,
+			Count:      count,
+			Dimensions: map[string]string{stackDimension: name},
+		})
+	}
+	return resources
+}
+
+// updatable reports whether a stack in the given status can still change its resources.
+// Stacks that failed to create or delete keep counting against StackLimit, but they can
+// only be deleted, so their resources are not counted.
+func updatable(status cfnTypes.StackStatus) bool {
+	switch status {
+	case cfnTypes.StackStatusRollbackComplete, cfnTypes.StackStatusRollbackFailed,
+		cfnTypes.StackStatusDeleteInProgress, cfnTypes.StackStatusDeleteFailed:
+		return false
+	}
+	return true
+}
+
+// listActiveStacks returns every stack that is not deleted.  StackLimit counts all of
+// them, including stacks left in ROLLBACK_COMPLETE or DELETE_FAILED, until they are deleted.
+func (j *StacksJob) listActiveStacks(ctx context.Context) ([]cfnTypes.StackSummary, error) {
+	var statuses []cfnTypes.StackStatus
+	for _, status := range cfnTypes.StackStatus("").Values() {
+		if status != cfnTypes.StackStatusDeleteComplete {
+			statuses = append(statuses, status)
+		}
+	}
+
+	var stacks []cfnTypes.StackSummary
+	paginator := cloudformation.NewListStacksPaginator(j.CloudFormationClient, &cloudformation.ListStacksInput{
+		StackStatusFilter: statuses,
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		stacks = append(stacks, output.StackSummaries...)
+	}
+	return stacks, nil
+}
+
+// countResources counts the resources of a single stack
+func (j *StacksJob) countResources(ctx context.Context, stack string) (int64, error) {
+	var total int64
+	paginator := cloudformation.NewListStackResourcesPaginator(j.CloudFormationClient, &cloudformation.ListStackResourcesInput{
+		StackName: aws.String(stack),
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return 0, err
+		}
+		total += int64(len(output.StackResourceSummaries))
+	}
+	return total, nil
+}
+
+// GetJobName return the name of the job
+func (j *StacksJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *StacksJob) GetRegion() string {
+	return j.region
+}
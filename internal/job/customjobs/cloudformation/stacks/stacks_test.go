@@ -0,0 +1,175 @@
+package stacks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	cfnTypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/cloudformationclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeCloudFormationClient builds a fake with n active stacks, stack i owning i*10 resources
+// over two pages, plus one deleted stack and one deleted stack set that must be ignored
+func newFakeCloudFormationClient(n int) *cloudformationclient.FakeCloudFormationClient {
+	f := &cloudformationclient.FakeCloudFormationClient{
+		Region: "us-east-1",
+		AccountLimits: []cfnTypes.AccountLimit{
+			{Name: aws.String("StackOutputsLimit"), Value: aws.Int32(200)},
+			{Name: aws.String(stackLimitName), Value: aws.Int32(20)},
+		},
+		ListStackSetsPageOutputs: []*cloudformation.ListStackSetsOutput{
+			{Summaries: []cfnTypes.StackSetSummary{{Status: cfnTypes.StackSetStatusActive}, {Status: cfnTypes.StackSetStatusDeleted}}},
+			{Summaries: []cfnTypes.StackSetSummary{{Status: cfnTypes.StackSetStatusActive}}},
+		},
+		ListStackResourcesPageOutputs: map[string][]*cloudformation.ListStackResourcesOutput{},
+		ErrOnListStacksCall:           -1,
+		ErrOnListStackSetsCall:        -1,
+		ErrOnListStackResourcesCall:   -1,
+	}
+	pages := []*cloudformation.ListStacksOutput{
+		{StackSummaries: []cfnTypes.StackSummary{{StackName: aws.String("deleted"), StackStatus: cfnTypes.StackStatusDeleteComplete}}},
+		{},
+	}
+	for i := 1; i <= n; i++ {
+		name := fmt.Sprintf("stack%02d", i)
+		pages[i%2].StackSummaries = append(pages[i%2].StackSummaries, cfnTypes.StackSummary{
+			StackName:   aws.String(name),
+			StackStatus: cfnTypes.StackStatusUpdateComplete,
+		})
+		f.ListStackResourcesPageOutputs[name] = []*cloudformation.ListStackResourcesOutput{
+			{StackResourceSummaries: make([]cfnTypes.StackResourceSummary, i*5)},
+			{StackResourceSummaries: make([]cfnTypes.StackResourceSummary, i*5)},
+		}
+	}
+	f.ListStacksPageOutputs = pages
+	return f
+}
+
+func TestStacksJob_Execute(t *testing.T) {
+	cfnFake := newFakeCloudFormationClient(topStacks + 2)
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 8}
+	j, err := NewStacksJob(StacksJobConfig{CloudFormationClient: cfnFake, ServiceQuotasClient: sqFake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, stacksJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, metrics, 2+topStacks)
+
+	cases := []struct {
+		name  string
+		stack string
+		value float64
+	}{
+		// 12 active stacks of a 20 stack limit, the deleted stack is filtered out
+		{stacksMetricName, "", 60},
+		// 2 active stack sets of 8
+		{stackSetsMetricName, "", 25},
+		{resourcesPerStackMetricName, "stack12", 120 / resourcesPerStackQuota.Default * 100},
+		{resourcesPerStackMetricName, "stack03", 30 / resourcesPerStackQuota.Default * 100},
+	}
+	for _, tc := range cases {
+		m, ok := jobtest.FindMetric(metrics, tc.name, stackDimension, tc.stack)
+		if assert.True(t, ok, "missing metric %s %s", tc.name, tc.stack) {
+			assert.InDelta(t, tc.value, m.Value, 0.001, "%s %s", tc.name, tc.stack)
+		}
+	}
+	_, ok := jobtest.FindMetric(metrics, resourcesPerStackMetricName, stackDimension, "stack01")
+	assert.False(t, ok, "smallest stacks should be dropped")
+
+	// the stack limit comes from DescribeAccountLimits, only stack sets use service quotas
+	assert.Equal(t, []string{stackSetsQuota.QuotaCode}, sqFake.RequestedQuotaCodes)
+}
+
+func TestStacksJob_DefaultStackLimit(t *testing.T) {
+	cfnFake := newFakeCloudFormationClient(4)
+	cfnFake.AccountLimits = nil
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 8}
+	j, _ := NewStacksJob(StacksJobConfig{CloudFormationClient: cfnFake, ServiceQuotasClient: sqFake})
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	m, ok := jobtest.FindMetric(metrics, stacksMetricName, stackDimension, "")
+	if assert.True(t, ok) {
+		assert.InDelta(t, 4/stacksQuota.Default*100, m.Value, 0.001)
+	}
+}
+
+func TestStacksJob_MaxStacks(t *testing.T) {
+	cfnFake := newFakeCloudFormationClient(topStacks + 2)
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 8}
+	j, _ := NewStacksJob(StacksJobConfig{CloudFormationClient: cfnFake, ServiceQuotasClient: sqFake, MaxStacks: 3})
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, metrics, 2+3)
+	m, ok := jobtest.FindMetric(metrics, stacksMetricName, stackDimension, "")
+	if assert.True(t, ok) {
+		assert.InDelta(t, 60, m.Value, 0.001, "every stack is still counted against the limit")
+	}
+}
+
+func TestStacksJob_ResourceCountingCutShort(t *testing.T) {
+	cases := []struct {
+		name      string
+		mutate    func(*cloudformationclient.FakeCloudFormationClient)
+		timeout   time.Duration
+		resources int
+	}{
+		// the first page lists the even stacks, stack04 fails after stack02 was counted
+		{"list stack resources", func(f *cloudformationclient.FakeCloudFormationClient) { f.ErrOnListStackResourcesCall = 2 }, time.Minute, 1},
+		{"deadline near", func(*cloudformationclient.FakeCloudFormationClient) {}, deadlineMargin / 2, 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfnFake := newFakeCloudFormationClient(4)
+			tc.mutate(cfnFake)
+			sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 8}
+			j, _ := NewStacksJob(StacksJobConfig{CloudFormationClient: cfnFake, ServiceQuotasClient: sqFake})
+			ctx, cancel := context.WithTimeout(context.Background(), tc.timeout)
+			defer cancel()
+
+			metrics, err := j.Execute(ctx)
+			assert.NoError(t, err)
+			assert.Len(t, metrics, 2+tc.resources)
+			_, ok := jobtest.FindMetric(metrics, stackSetsMetricName)
+			assert.True(t, ok, "account metrics are emitted when resource counting is cut short")
+		})
+	}
+}
+
+func TestStacksJob_FailedStacks(t *testing.T) {
+	cfnFake := newFakeCloudFormationClient(4)
+	cfnFake.ListStacksPageOutputs[1].StackSummaries = append(cfnFake.ListStacksPageOutputs[1].StackSummaries,
+		cfnTypes.StackSummary{StackName: aws.String("rolledback"), StackStatus: cfnTypes.StackStatusRollbackComplete},
+		cfnTypes.StackSummary{StackName: aws.String("deletefailed"), StackStatus: cfnTypes.StackStatusDeleteFailed},
+	)
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 8}
+	j, _ := NewStacksJob(StacksJobConfig{CloudFormationClient: cfnFake, ServiceQuotasClient: sqFake})
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, metrics, 2+4)
+	m, ok := jobtest.FindMetric(metrics, stacksMetricName, stackDimension, "")
+	if assert.True(t, ok) {
+		assert.InDelta(t, 30, m.Value, 0.001, "failed stacks count against the limit until deleted")
+	}
+	_, ok = jobtest.FindMetric(metrics, resourcesPerStackMetricName, stackDimension, "rolledback")
+	assert.False(t, ok, "stacks that can only be deleted have no resources counted")
+}
+
+func TestStacksJob_Error(t *testing.T) {
+	cfnFake := newFakeCloudFormationClient(3)
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 8}
+	cfnFake.ErrDescribeAccountLimits = true
+	j, _ := NewStacksJob(StacksJobConfig{CloudFormationClient: cfnFake, ServiceQuotasClient: sqFake})
+	jobtest.AssertExecuteFails(t, j)
+}
@@ -0,0 +1,83 @@
+package alarms
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwTypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/cwclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// AlarmsJob will implement the Job interface
+// It counts the metric and composite alarms of the region against the alarms per region maximum.
+
+type AlarmsJob struct {
+	CloudWatchClient cwclient.CloudWatchClient
+	jobName          string
+	region           string
+	Logger           logger.Logger
+}
+
+type AlarmsJobConfig struct {
+	CloudWatchClient cwclient.CloudWatchClient
+	Logger           logger.Logger
+}
+
+const (
+	alarmsJobPrefix = "cloudwatchAlarms"
+	// maximum number of metric and composite alarms per region
+	maxAlarms = 5000
+
+	// cloudwatch metric names
+	alarmsMetricName = "cloudwatchAlarms"
+)
+
+// NewAlarmsJob will create a new AlarmsJob
+func NewAlarmsJob(config AlarmsJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	job := &AlarmsJob{
+		CloudWatchClient: config.CloudWatchClient,
+		jobName:          alarmsJobPrefix + "-" + config.CloudWatchClient.GetRegion(),
+		region:           config.CloudWatchClient.GetRegion(),
+		Logger:           config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns alarm utilization
+func (j *AlarmsJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	alarms := 0
+	// DescribeAlarms only returns metric alarms unless the alarm types are listed
+	paginator := cloudwatch.NewDescribeAlarmsPaginator(j.CloudWatchClient, &cloudwatch.DescribeAlarmsInput{
+		AlarmTypes: []cwTypes.AlarmType{cwTypes.AlarmTypeMetricAlarm, cwTypes.AlarmTypeCompositeAlarm},
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		alarms += len(output.MetricAlarms) + len(output.CompositeAlarms)
+	}
+	j.Logger.Debug("%s alarms : %d/%d", j.GetJobName(), alarms, maxAlarms)
+
+	return []sharedtypes.CloudWatchMetric{
+		utils.PercentMetric(alarmsMetricName, utils.Utilization(float64(alarms), maxAlarms), nil, time.Now()),
+	}, nil
+}
+
+// GetJobName return the name of the job
+func (j *AlarmsJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *AlarmsJob) GetRegion() string {
+	return j.region
+}
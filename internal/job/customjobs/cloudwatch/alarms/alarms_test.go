@@ -0,0 +1,53 @@
+package alarms
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwTypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/cwclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeCloudWatchClient builds a fake with 400 metric alarms and 100 composite alarms over two pages
+func newFakeCloudWatchClient() *cwclient.FakeCloudWatchClient {
+	return &cwclient.FakeCloudWatchClient{
+		Region: "us-east-1",
+		DescribeAlarmsPageOutputs: []*cloudwatch.DescribeAlarmsOutput{
+			{MetricAlarms: make([]cwTypes.MetricAlarm, 300)},
+			{MetricAlarms: make([]cwTypes.MetricAlarm, 100), CompositeAlarms: make([]cwTypes.CompositeAlarm, 100)},
+		},
+		ErrOnDescribeAlarmsCall: -1,
+		ErrOnListDashboardsCall: -1,
+	}
+}
+
+func TestAlarmsJob_Execute(t *testing.T) {
+	cwFake := newFakeCloudWatchClient()
+	j, err := NewAlarmsJob(AlarmsJobConfig{CloudWatchClient: cwFake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, alarmsJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, metrics, 1) {
+		assert.Equal(t, alarmsMetricName, metrics[0].Name)
+		assert.InDelta(t, 10, metrics[0].Value, 0.001)
+	}
+	// composite alarms are only returned when asked for
+	if assert.NotEmpty(t, cwFake.DescribeAlarmsInputs) {
+		assert.ElementsMatch(t,
+			[]cwTypes.AlarmType{cwTypes.AlarmTypeMetricAlarm, cwTypes.AlarmTypeCompositeAlarm},
+			cwFake.DescribeAlarmsInputs[0].AlarmTypes)
+	}
+}
+
+func TestAlarmsJob_Error(t *testing.T) {
+	cwFake := newFakeCloudWatchClient()
+	cwFake.ErrOnDescribeAlarmsCall = 1
+	j, _ := NewAlarmsJob(AlarmsJobConfig{CloudWatchClient: cwFake})
+	jobtest.AssertExecuteFails(t, j)
+}
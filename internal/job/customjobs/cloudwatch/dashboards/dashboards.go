@@ -0,0 +1,80 @@
+package dashboards
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/cwclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// DashboardsJob will implement the Job interface
+// It counts the dashboards of the account against the dashboards per account maximum.
+// Dashboards are global, so the job only needs to run in a single region.
+
+type DashboardsJob struct {
+	CloudWatchClient cwclient.CloudWatchClient
+	jobName          string
+	region           string
+	Logger           logger.Logger
+}
+
+type DashboardsJobConfig struct {
+	CloudWatchClient cwclient.CloudWatchClient
+	Logger           logger.Logger
+}
+
+const (
+	dashboardsJobPrefix = "cloudwatchDashboards"
+	// maximum number of dashboards per account
+	maxDashboards = 5000
+
+	// cloudwatch metric names
+	dashboardsMetricName = "cloudwatchDashboards"
+)
+
+// NewDashboardsJob will create a new DashboardsJob
+func NewDashboardsJob(config DashboardsJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	job := &DashboardsJob{
+		CloudWatchClient: config.CloudWatchClient,
+		jobName:          dashboardsJobPrefix + "-" + config.CloudWatchClient.GetRegion(),
+		region:           config.CloudWatchClient.GetRegion(),
+		Logger:           config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns dashboard utilization
+func (j *DashboardsJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	dashboards := 0
+	paginator := cloudwatch.NewListDashboardsPaginator(j.CloudWatchClient, &cloudwatch.ListDashboardsInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		dashboards += len(output.DashboardEntries)
+	}
+	j.Logger.Debug("%s dashboards : %d/%d", j.GetJobName(), dashboards, maxDashboards)
+
+	return []sharedtypes.CloudWatchMetric{
+		utils.PercentMetric(dashboardsMetricName, utils.Utilization(float64(dashboards), maxDashboards), nil, time.Now()),
+	}, nil
+}
+
+// GetJobName return the name of the job
+func (j *DashboardsJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *DashboardsJob) GetRegion() string {
+	return j.region
+}
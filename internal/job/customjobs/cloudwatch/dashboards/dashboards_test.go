@@ -0,0 +1,47 @@
+package dashboards
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwTypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/cwclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeCloudWatchClient builds a fake with 1250 dashboards over two pages
+func newFakeCloudWatchClient() *cwclient.FakeCloudWatchClient {
+	return &cwclient.FakeCloudWatchClient{
+		Region: "us-east-1",
+		ListDashboardsPageOutputs: []*cloudwatch.ListDashboardsOutput{
+			{DashboardEntries: make([]cwTypes.DashboardEntry, 1000)},
+			{DashboardEntries: make([]cwTypes.DashboardEntry, 250)},
+		},
+		ErrOnDescribeAlarmsCall: -1,
+		ErrOnListDashboardsCall: -1,
+	}
+}
+
+func TestDashboardsJob_Execute(t *testing.T) {
+	cwFake := newFakeCloudWatchClient()
+	j, err := NewDashboardsJob(DashboardsJobConfig{CloudWatchClient: cwFake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, dashboardsJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, metrics, 1) {
+		assert.Equal(t, dashboardsMetricName, metrics[0].Name)
+		assert.InDelta(t, 25, metrics[0].Value, 0.001)
+	}
+}
+
+func TestDashboardsJob_Error(t *testing.T) {
+	cwFake := newFakeCloudWatchClient()
+	cwFake.ErrOnListDashboardsCall = 1
+	j, _ := NewDashboardsJob(DashboardsJobConfig{CloudWatchClient: cwFake})
+	jobtest.AssertExecuteFails(t, j)
+}
@@ -0,0 +1,99 @@
+package metricfilters
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/cwlclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// MetricFiltersJob will implement the Job interface
+// It lists every metric filter in the region in a single pass, groups them by log group and
+// emits the log groups with the most filters against the fixed metric filters per log group maximum.
+
+type MetricFiltersJob struct {
+	CloudWatchLogsClient cwlclient.CloudWatchLogsClient
+	jobName              string
+	region               string
+	Logger               logger.Logger
+}
+
+type MetricFiltersJobConfig struct {
+	CloudWatchLogsClient cwlclient.CloudWatchLogsClient
+	Logger               logger.Logger
+}
+
+const (
+	metricFiltersJobPrefix = "cloudwatchLogsMetricFilters"
+	logGroupDimension      = "logGroup"
+	// maximum number of metric filters per log group
+	maxMetricFiltersPerLogGroup = 100
+	// only the log groups with the most filters get a metric
+	topLogGroups = 10
+
+	// cloudwatch metric names
+	metricFiltersPerLogGroupMetricName = "cloudwatchLogsMetricFiltersPerLogGroup"
+)
+
+// NewMetricFiltersJob will create a new MetricFiltersJob
+func NewMetricFiltersJob(config MetricFiltersJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	job := &MetricFiltersJob{
+		CloudWatchLogsClient: config.CloudWatchLogsClient,
+		jobName:              metricFiltersJobPrefix + "-" + config.CloudWatchLogsClient.GetRegion(),
+		region:               config.CloudWatchLogsClient.GetRegion(),
+		Logger:               config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns metric filters per log group utilization for the fullest log groups
+func (j *MetricFiltersJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	// without a log group name DescribeMetricFilters returns the filters of every log group
+	perLogGroup := make(map[string]int64)
+	paginator := cloudwatchlogs.NewDescribeMetricFiltersPaginator(j.CloudWatchLogsClient, &cloudwatchlogs.DescribeMetricFiltersInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, filter := range output.MetricFilters {
+			perLogGroup[aws.ToString(filter.LogGroupName)]++
+		}
+	}
+
+	counts := make([]utils.ResourceCount, 0, len(perLogGroup))
+	for logGroup, count := range perLogGroup {
+		counts = append(counts, utils.ResourceCount{
+			Name:       logGroup,
+			Count:      count,
+			Dimensions: map[string]string{logGroupDimension: logGroup},
+		})
+	}
+	j.Logger.Debug("%s log groups with metric filters : %d", j.GetJobName(), len(counts))
+
+	now := time.Now()
+	var out []sharedtypes.CloudWatchMetric
+	for _, rc := range utils.TopResourceCounts(counts, topLogGroups) {
+		out = append(out, utils.PercentMetric(metricFiltersPerLogGroupMetricName, utils.Utilization(float64(rc.Count), maxMetricFiltersPerLogGroup), rc.Dimensions, now))
+	}
+	return out, nil
+}
+
+// GetJobName return the name of the job
+func (j *MetricFiltersJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *MetricFiltersJob) GetRegion() string {
+	return j.region
+}
@@ -0,0 +1,82 @@
+package metricfilters
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	cwlTypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/cwlclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeCloudWatchLogsClient builds a fake with n log groups, log group i owning i metric filters.
+// The filters of every log group are split across two pages, as DescribeMetricFilters does not
+// group its results.
+func newFakeCloudWatchLogsClient(n int) *cwlclient.FakeCloudWatchLogsClient {
+	pages := []*cloudwatchlogs.DescribeMetricFiltersOutput{{}, {}}
+	for i := 1; i <= n; i++ {
+		name := fmt.Sprintf("/aws/lambda/fn%02d", i)
+		for k := 0; k < i; k++ {
+			pages[k%2].MetricFilters = append(pages[k%2].MetricFilters, cwlTypes.MetricFilter{
+				LogGroupName: aws.String(name),
+				FilterName:   aws.String(fmt.Sprintf("filter%d", k)),
+			})
+		}
+	}
+	return &cwlclient.FakeCloudWatchLogsClient{
+		Region:                               "us-east-1",
+		DescribeMetricFiltersPageOutputs:     pages,
+		ErrOnDescribeLogGroupsCall:           -1,
+		ErrOnDescribeMetricFiltersCall:       -1,
+		ErrOnDescribeSubscriptionFiltersCall: -1,
+	}
+}
+
+func TestMetricFiltersJob_Execute(t *testing.T) {
+	cwlFake := newFakeCloudWatchLogsClient(topLogGroups + 2)
+	j, err := NewMetricFiltersJob(MetricFiltersJobConfig{CloudWatchLogsClient: cwlFake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, metricFiltersJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, metrics, topLogGroups)
+
+	cases := []struct {
+		logGroup string
+		value    float64
+	}{
+		{"/aws/lambda/fn12", 12},
+		{"/aws/lambda/fn05", 5},
+		{"/aws/lambda/fn03", 3},
+	}
+	for _, tc := range cases {
+		m, ok := jobtest.FindMetric(metrics, metricFiltersPerLogGroupMetricName, logGroupDimension, tc.logGroup)
+		if assert.True(t, ok, "missing metric for %s", tc.logGroup) {
+			assert.InDelta(t, tc.value, m.Value, 0.001, tc.logGroup)
+		}
+	}
+	_, ok := jobtest.FindMetric(metrics, metricFiltersPerLogGroupMetricName, logGroupDimension, "/aws/lambda/fn01")
+	assert.False(t, ok, "log groups with the fewest filters should be dropped")
+}
+
+func TestMetricFiltersJob_NoFilters(t *testing.T) {
+	cwlFake := newFakeCloudWatchLogsClient(0)
+	j, _ := NewMetricFiltersJob(MetricFiltersJobConfig{CloudWatchLogsClient: cwlFake})
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, metrics)
+}
+
+func TestMetricFiltersJob_Error(t *testing.T) {
+	cwlFake := newFakeCloudWatchLogsClient(3)
+	cwlFake.ErrOnDescribeMetricFiltersCall = 1
+	j, _ := NewMetricFiltersJob(MetricFiltersJobConfig{CloudWatchLogsClient: cwlFake})
+	jobtest.AssertExecuteFails(t, j)
+}
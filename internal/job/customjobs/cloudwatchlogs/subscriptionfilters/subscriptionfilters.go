@@ -0,0 +1,143 @@
+package subscriptionfilters
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/cwlclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// SubscriptionFiltersJob will implement the Job interface
+// It lists the subscription filters of the log groups in the region and emits the log groups
+// with the most filters against the fixed subscription filters per log group maximum.
+// DescribeSubscriptionFilters only accepts a single log group and is limited to 5 requests per
+// second, so only the first MaxLogGroups log groups are scanned to finish within the job timeout.
+
+type SubscriptionFiltersJob struct {
+	CloudWatchLogsClient cwlclient.CloudWatchLogsClient
+	MaxLogGroups         int
+	jobName              string
+	region               string
+	Logger               logger.Logger
+}
+
+type SubscriptionFiltersJobConfig struct {
+	CloudWatchLogsClient cwlclient.CloudWatchLogsClient
+	// MaxLogGroups bounds the log groups scanned, defaults to defaultMaxLogGroups
+	MaxLogGroups int
+	Logger       logger.Logger
+}
+
+const (
+	subscriptionFiltersJobPrefix = "cloudwatchLogsSubscriptionFilters"
+	logGroupDimension            = "logGroup"
+	// maximum number of subscription filters per log group
+	maxSubscriptionFiltersPerLogGroup = 2
+	// only the log groups with the most filters get a metric
+	topLogGroups = 10
+	// one DescribeSubscriptionFilters call per log group at 5 requests per second
+	// takes a minute, half of the job timeout
+	defaultMaxLogGroups = 300
+
+	// cloudwatch metric names
+	subscriptionFiltersPerLogGroupMetricName = "cloudwatchLogsSubscriptionFiltersPerLogGroup"
+)
+
+// NewSubscriptionFiltersJob will create a new SubscriptionFiltersJob
+func NewSubscriptionFiltersJob(config SubscriptionFiltersJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	if config.MaxLogGroups <= 0 {
+		config.MaxLogGroups = defaultMaxLogGroups
+	}
+	job := &SubscriptionFiltersJob{
+		CloudWatchLogsClient: config.CloudWatchLogsClient,
+		MaxLogGroups:         config.MaxLogGroups,
+		jobName:              subscriptionFiltersJobPrefix + "-" + config.CloudWatchLogsClient.GetRegion(),
+		region:               config.CloudWatchLogsClient.GetRegion(),
+		Logger:               config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns subscription filters per log group utilization for the fullest log groups
+func (j *SubscriptionFiltersJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	var (
+		logGroups []string
+		truncated bool
+	)
+	paginator := cloudwatchlogs.NewDescribeLogGroupsPaginator(j.CloudWatchLogsClient, &cloudwatchlogs.DescribeLogGroupsInput{})
+	for paginator.HasMorePages() && !truncated {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, lg := range output.LogGroups {
+			if len(logGroups) == j.MaxLogGroups {
+				truncated = true
+				break
+			}
+			logGroups = append(logGroups, aws.ToString(lg.LogGroupName))
+		}
+	}
+	if truncated {
+		j.Logger.Warn("%s more than %d log groups, only the first %d are scanned", j.GetJobName(), j.MaxLogGroups, j.MaxLogGroups)
+	}
+
+	var counts []utils.ResourceCount
+	for _, logGroup := range logGroups {
+		count, err := j.countSubscriptionFilters(ctx, logGroup)
+		if err != nil {
+			return nil, err
+		}
+		if count == 0 {
+			continue
+		}
+		counts = append(counts, utils.ResourceCount{
+			Name:       logGroup,
+			Count:      count,
+			Dimensions: map[string]string{logGroupDimension: logGroup},
+		})
+	}
+	j.Logger.Debug("%s log groups : %d, with subscription filters : %d", j.GetJobName(), len(logGroups), len(counts))
+
+	now := time.Now()
+	var out []sharedtypes.CloudWatchMetric
+	for _, rc := range utils.TopResourceCounts(counts, topLogGroups) {
+		out = append(out, utils.PercentMetric(subscriptionFiltersPerLogGroupMetricName, utils.Utilization(float64(rc.Count), maxSubscriptionFiltersPerLogGroup), rc.Dimensions, now))
+	}
+	return out, nil
+}
+
+// countSubscriptionFilters counts the subscription filters of a single log group
+func (j *SubscriptionFiltersJob) countSubscriptionFilters(ctx context.Context, logGroup string) (int64, error) {
+	var total int64
+	paginator := cloudwatchlogs.NewDescribeSubscriptionFiltersPaginator(j.CloudWatchLogsClient, &cloudwatchlogs.DescribeSubscriptionFiltersInput{
+		LogGroupName: aws.String(logGroup),
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return 0, err
+		}
+		total += int64(len(output.SubscriptionFilters))
+	}
+	return total, nil
+}
+
+// GetJobName return the name of the job
+func (j *SubscriptionFiltersJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *SubscriptionFiltersJob) GetRegion() string {
+	return j.region
+}
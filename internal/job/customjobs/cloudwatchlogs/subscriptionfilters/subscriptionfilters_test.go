@@ -0,0 +1,87 @@
+package subscriptionfilters
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	cwlTypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/cwlclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeCloudWatchLogsClient builds a fake with n log groups over two pages. Every third log group
+// has no subscription filters, the rest have one, and the last log group has two.
+func newFakeCloudWatchLogsClient(n int) *cwlclient.FakeCloudWatchLogsClient {
+	f := &cwlclient.FakeCloudWatchLogsClient{
+		Region:                                 "us-east-1",
+		DescribeLogGroupsPageOutputs:           []*cloudwatchlogs.DescribeLogGroupsOutput{{}, {}},
+		DescribeSubscriptionFiltersPageOutputs: map[string][]*cloudwatchlogs.DescribeSubscriptionFiltersOutput{},
+		ErrOnDescribeLogGroupsCall:             -1,
+		ErrOnDescribeMetricFiltersCall:         -1,
+		ErrOnDescribeSubscriptionFiltersCall:   -1,
+	}
+	for i := 1; i <= n; i++ {
+		name := fmt.Sprintf("/app/group%02d", i)
+		f.DescribeLogGroupsPageOutputs[i%2].LogGroups = append(f.DescribeLogGroupsPageOutputs[i%2].LogGroups,
+			cwlTypes.LogGroup{LogGroupName: aws.String(name)})
+		switch {
+		case i == n:
+			f.DescribeSubscriptionFiltersPageOutputs[name] = []*cloudwatchlogs.DescribeSubscriptionFiltersOutput{
+				{SubscriptionFilters: make([]cwlTypes.SubscriptionFilter, 1)},
+				{SubscriptionFilters: make([]cwlTypes.SubscriptionFilter, 1)},
+			}
+		case i%3 != 0:
+			f.DescribeSubscriptionFiltersPageOutputs[name] = []*cloudwatchlogs.DescribeSubscriptionFiltersOutput{
+				{SubscriptionFilters: make([]cwlTypes.SubscriptionFilter, 1)},
+			}
+		}
+	}
+	return f
+}
+
+func TestSubscriptionFiltersJob_Execute(t *testing.T) {
+	cwlFake := newFakeCloudWatchLogsClient(20)
+	j, err := NewSubscriptionFiltersJob(SubscriptionFiltersJobConfig{CloudWatchLogsClient: cwlFake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, subscriptionFiltersJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, metrics, topLogGroups)
+
+	// the log group with both filters in use comes first
+	m, ok := jobtest.FindMetric(metrics, subscriptionFiltersPerLogGroupMetricName, logGroupDimension, "/app/group20")
+	if assert.True(t, ok) {
+		assert.InDelta(t, 100, m.Value, 0.001)
+	}
+	m, ok = jobtest.FindMetric(metrics, subscriptionFiltersPerLogGroupMetricName, logGroupDimension, "/app/group01")
+	if assert.True(t, ok) {
+		assert.InDelta(t, 50, m.Value, 0.001)
+	}
+	_, ok = jobtest.FindMetric(metrics, subscriptionFiltersPerLogGroupMetricName, logGroupDimension, "/app/group03")
+	assert.False(t, ok, "log groups without subscription filters should be skipped")
+}
+
+func TestSubscriptionFiltersJob_MaxLogGroups(t *testing.T) {
+	cwlFake := newFakeCloudWatchLogsClient(20)
+	j, _ := NewSubscriptionFiltersJob(SubscriptionFiltersJobConfig{CloudWatchLogsClient: cwlFake, MaxLogGroups: 4})
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	// the first page holds the even log groups, group06 has no filters
+	assert.Len(t, metrics, 3)
+	_, ok := jobtest.FindMetric(metrics, subscriptionFiltersPerLogGroupMetricName, logGroupDimension, "/app/group20")
+	assert.False(t, ok, "log groups past the bound should not be scanned")
+}
+
+func TestSubscriptionFiltersJob_Error(t *testing.T) {
+	cwlFake := newFakeCloudWatchLogsClient(5)
+	cwlFake.ErrOnDescribeLogGroupsCall = 1
+	j, _ := NewSubscriptionFiltersJob(SubscriptionFiltersJobConfig{CloudWatchLogsClient: cwlFake})
+	jobtest.AssertExecuteFails(t, j)
+}
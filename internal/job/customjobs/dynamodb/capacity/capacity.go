@@ -0,0 +1,150 @@
+package capacity
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	dynamodbTypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/dynamodbclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// CapacityJob will implement the Job interface
+// It compares provisioned read and write capacity against the maximums
+// reported by DescribeLimits, for the account as a whole and for the
+// tables with the most provisioned capacity. On-demand tables have no
+// provisioned capacity and do not count towards these maximums.
+
+type CapacityJob struct {
+	DynamoDBClient dynamodbclient.DynamoDBClient
+	jobName        string
+	region         string
+	Logger         logger.Logger
+}
+
+type CapacityJobConfig struct {
+	DynamoDBClient dynamodbclient.DynamoDBClient
+	Logger         logger.Logger
+}
+
+const (
+	capacityJobPrefix = "dynamodbCapacity"
+	tableDimension    = "table"
+	// only the tables with the most provisioned capacity get per-table metrics
+	topTables = 10
+
+	// cloudwatch metric names
+	accountReadMetricName  = "dynamodbAccountReadCapacity"
+	accountWriteMetricName = "dynamodbAccountWriteCapacity"
+	tableReadMetricName    = "dynamodbTableReadCapacity"
+	tableWriteMetricName   = "dynamodbTableWriteCapacity"
+)
+
+// NewCapacityJob will create a new CapacityJob
+func NewCapacityJob(config CapacityJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	job := &CapacityJob{
+		DynamoDBClient: config.DynamoDBClient,
+		jobName:        capacityJobPrefix + "-" + config.DynamoDBClient.GetRegion(),
+		region:         config.DynamoDBClient.GetRegion(),
+		Logger:         config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns account and per-table provisioned capacity utilization
+func (j *CapacityJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	limits, err := j.DynamoDBClient.DescribeLimits(ctx, &dynamodb.DescribeLimitsInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	var tables []string
+	paginator := dynamodb.NewListTablesPaginator(j.DynamoDBClient, &dynamodb.ListTablesInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, output.TableNames...)
+	}
+	j.Logger.Debug("%s total tables : %d", j.GetJobName(), len(tables))
+
+	var accountRead, accountWrite int64
+	var tableReads, tableWrites []utils.ResourceCount
+	for _, table := range tables {
+		output, err := j.DynamoDBClient.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(table)})
+		if err != nil {
+			return nil, err
+		}
+		if output.Table == nil {
+			continue
+		}
+		read, write, maxRead, maxWrite := provisionedCapacity(output.Table)
+		accountRead += read
+		accountWrite += write
+		dims := map[string]string{tableDimension: table}
+		if maxRead > 0 {
+			tableReads = append(tableReads, utils.ResourceCount{Name: table, Count: maxRead, Dimensions: dims})
+		}
+		if maxWrite > 0 {
+			tableWrites = append(tableWrites, utils.ResourceCount{Name: table, Count: maxWrite, Dimensions: dims})
+		}
+	}
+	j.Logger.Debug("%s provisioned read %d/%d write %d/%d", j.GetJobName(),
+		accountRead, aws.ToInt64(limits.AccountMaxReadCapacityUnits), accountWrite, aws.ToInt64(limits.AccountMaxWriteCapacityUnits))
+
+	now := time.Now()
+	out := []sharedtypes.CloudWatchMetric{
+		utils.PercentMetric(accountReadMetricName, utils.Utilization(float64(accountRead), float64(aws.ToInt64(limits.AccountMaxReadCapacityUnits))), nil, now),
+		utils.PercentMetric(accountWriteMetricName, utils.Utilization(float64(accountWrite), float64(aws.ToInt64(limits.AccountMaxWriteCapacityUnits))), nil, now),
+	}
+	tableMaxRead := float64(aws.ToInt64(limits.TableMaxReadCapacityUnits))
+	for _, rc := range utils.TopResourceCounts(tableReads, topTables) {
+		out = append(out, utils.PercentMetric(tableReadMetricName, utils.Utilization(float64(rc.Count), tableMaxRead), rc.Dimensions, now))
+	}
+	tableMaxWrite := float64(aws.ToInt64(limits.TableMaxWriteCapacityUnits))
+	for _, rc := range utils.TopResourceCounts(tableWrites, topTables) {
+		out = append(out, utils.PercentMetric(tableWriteMetricName, utils.Utilization(float64(rc.Count), tableMaxWrite), rc.Dimensions, now))
+	}
+	return out, nil
+}
+
+// provisionedCapacity returns the total read and write capacity of a table and its
+// global secondary indexes, which count towards the account maximum, and the largest
+// read and write capacity of the table or any single index, which is what the
+// table level maximum applies to.
+func provisionedCapacity(table *dynamodbTypes.TableDescription) (read, write, maxRead, maxWrite int64) {
+	add := func(pt *dynamodbTypes.ProvisionedThroughputDescription) {
+		if pt == nil {
+			return
+		}
+		r, w := aws.ToInt64(pt.ReadCapacityUnits), aws.ToInt64(pt.WriteCapacityUnits)
+		read += r
+		write += w
+		maxRead = max(maxRead, r)
+		maxWrite = max(maxWrite, w)
+	}
+	add(table.ProvisionedThroughput)
+	for _, index := range table.GlobalSecondaryIndexes {
+		add(index.ProvisionedThroughput)
+	}
+	return read, write, maxRead, maxWrite
+}
+
+// GetJobName return the name of the job
+func (j *CapacityJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *CapacityJob) GetRegion() string {
+	return j.region
+}
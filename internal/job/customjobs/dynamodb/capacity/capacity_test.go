@@ -0,0 +1,89 @@
+package capacity
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	dynamodbTypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/dynamodbclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func throughput(read, write int64) *dynamodbTypes.ProvisionedThroughputDescription {
+	return &dynamodbTypes.ProvisionedThroughputDescription{
+		ReadCapacityUnits:  aws.Int64(read),
+		WriteCapacityUnits: aws.Int64(write),
+	}
+}
+
+// newFakeDynamoDBClient builds a fake with a provisioned table, a table with a
+// larger index and an on-demand table
+func newFakeDynamoDBClient() *dynamodbclient.FakeDynamoDBClient {
+	return &dynamodbclient.FakeDynamoDBClient{
+		Region: "us-east-1",
+		Limits: dynamodb.DescribeLimitsOutput{
+			AccountMaxReadCapacityUnits:  aws.Int64(1000),
+			AccountMaxWriteCapacityUnits: aws.Int64(1000),
+			TableMaxReadCapacityUnits:    aws.Int64(400),
+			TableMaxWriteCapacityUnits:   aws.Int64(400),
+		},
+		ListTablesPageOutputs: []*dynamodb.ListTablesOutput{
+			{TableNames: []string{"orders", "users"}},
+			{TableNames: []string{"events"}},
+		},
+		Tables: map[string]dynamodbTypes.TableDescription{
+			"orders": {ProvisionedThroughput: throughput(200, 100)},
+			"users": {
+				ProvisionedThroughput: throughput(40, 20),
+				GlobalSecondaryIndexes: []dynamodbTypes.GlobalSecondaryIndexDescription{
+					{ProvisionedThroughput: throughput(160, 80)},
+				},
+			},
+			"events": {ProvisionedThroughput: throughput(0, 0)},
+		},
+		ErrOnListTablesCall:    -1,
+		ErrOnDescribeTableCall: -1,
+	}
+}
+
+func TestCapacityJob_Execute(t *testing.T) {
+	fake := newFakeDynamoDBClient()
+	j, err := NewCapacityJob(CapacityJobConfig{DynamoDBClient: fake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, capacityJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, metrics, 6, "on-demand tables should not get per-table metrics")
+
+	cases := []struct {
+		name  string
+		table string
+		value float64
+	}{
+		{accountReadMetricName, "", 40},
+		{accountWriteMetricName, "", 20},
+		{tableReadMetricName, "orders", 50},
+		{tableWriteMetricName, "orders", 25},
+		// the index has more capacity than its table
+		{tableReadMetricName, "users", 40},
+		{tableWriteMetricName, "users", 20},
+	}
+	for _, tc := range cases {
+		m, ok := jobtest.FindMetric(metrics, tc.name, tableDimension, tc.table)
+		if assert.True(t, ok, "missing metric %s %s", tc.name, tc.table) {
+			assert.InDelta(t, tc.value, m.Value, 0.001, "%s %s", tc.name, tc.table)
+		}
+	}
+}
+
+func TestCapacityJob_Error(t *testing.T) {
+	fake := newFakeDynamoDBClient()
+	fake.ErrDescribeLimits = true
+	j, _ := NewCapacityJob(CapacityJobConfig{DynamoDBClient: fake})
+	jobtest.AssertExecuteFails(t, j)
+}
@@ -0,0 +1,91 @@
+package tables
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/dynamodbclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// TablesJob will implement the Job interface
+// It counts the dynamodb tables in the region against the table quota.
+// DescribeLimits does not report a table limit, so service quotas is used here.
+
+type TablesJob struct {
+	DynamoDBClient      dynamodbclient.DynamoDBClient
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	jobName             string
+	region              string
+	Logger              logger.Logger
+}
+
+type TablesJobConfig struct {
+	DynamoDBClient      dynamodbclient.DynamoDBClient
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	Logger              logger.Logger
+}
+
+const (
+	tablesJobPrefix      = "dynamodbTables"
+	serviceCode          = "dynamodb"
+	cloudwatchMetricName = "dynamodbTables"
+)
+
+var (
+	// Maximum number of tables per region
+	tablesQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, QuotaCode: "L-F98FE922", Default: 2500}
+)
+
+// NewTablesJob will create a new TablesJob
+func NewTablesJob(config TablesJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	job := &TablesJob{
+		DynamoDBClient:      config.DynamoDBClient,
+		ServiceQuotasClient: config.ServiceQuotasClient,
+		jobName:             tablesJobPrefix + "-" + config.DynamoDBClient.GetRegion(),
+		region:              config.DynamoDBClient.GetRegion(),
+		Logger:              config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns the table count as a percent of the quota
+func (j *TablesJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	// use aws sdk paginator to count all tables
+	total := 0
+	paginator := dynamodb.NewListTablesPaginator(j.DynamoDBClient, &dynamodb.ListTablesInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		total += len(output.TableNames)
+	}
+	j.Logger.Debug("%s total tables : %d", j.GetJobName(), total)
+
+	limit, err := servicequotaclient.GetQuotaValue(ctx, j.ServiceQuotasClient, tablesQuota)
+	if err != nil {
+		return nil, err
+	}
+	return []sharedtypes.CloudWatchMetric{
+		utils.PercentMetric(cloudwatchMetricName, utils.Utilization(float64(total), limit), nil, time.Now()),
+	}, nil
+}
+
+// GetJobName return the name of the job
+func (j *TablesJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *TablesJob) GetRegion() string {
+	return j.region
+}
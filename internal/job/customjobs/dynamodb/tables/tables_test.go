@@ -0,0 +1,49 @@
+package tables
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/dynamodbclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func newFakeDynamoDBClient() *dynamodbclient.FakeDynamoDBClient {
+	return &dynamodbclient.FakeDynamoDBClient{
+		Region: "us-east-1",
+		ListTablesPageOutputs: []*dynamodb.ListTablesOutput{
+			{TableNames: []string{"a", "b"}},
+			{TableNames: []string{"c"}},
+		},
+		ErrOnListTablesCall:    -1,
+		ErrOnDescribeTableCall: -1,
+	}
+}
+
+func TestTablesJob_Execute(t *testing.T) {
+	ddbFake := newFakeDynamoDBClient()
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 12}
+	j, err := NewTablesJob(TablesJobConfig{DynamoDBClient: ddbFake, ServiceQuotasClient: sqFake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, tablesJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, metrics, 1) {
+		assert.Equal(t, cloudwatchMetricName, metrics[0].Name)
+		assert.InDelta(t, 25, metrics[0].Value, 0.001)
+	}
+	assert.Equal(t, []string{tablesQuota.QuotaCode}, sqFake.RequestedQuotaCodes)
+}
+
+func TestTablesJob_Error(t *testing.T) {
+	ddbFake := newFakeDynamoDBClient()
+	ddbFake.ErrOnListTablesCall = 1
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 12}
+	j, _ := NewTablesJob(TablesJobConfig{DynamoDBClient: ddbFake, ServiceQuotasClient: sqFake})
+	jobtest.AssertExecuteFails(t, j)
+}
@@ -0,0 +1,91 @@
+package autoscalinglimits
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/autoscalingclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// AutoScalingLimitsJob will implement the Job interface
+// Auto Scaling reports usage and maximum together in DescribeAccountLimits,
+// so utilization is computed without calling service quotas.
+
+type AutoScalingLimitsJob struct {
+	AutoScalingClient autoscalingclient.AutoScalingClient
+	jobName           string
+	region            string
+	Logger            logger.Logger
+}
+
+type AutoScalingLimitsJobConfig struct {
+	AutoScalingClient autoscalingclient.AutoScalingClient
+	Logger            logger.Logger
+}
+
+const (
+	autoScalingLimitsJobPrefix = "autoscalingLimits"
+
+	// cloudwatch metric names
+	groupsMetricName               = "autoscalingGroups"
+	launchConfigurationsMetricName = "autoscalingLaunchConfigurations"
+)
+
+// NewAutoScalingLimitsJob will create a new AutoScalingLimitsJob
+func NewAutoScalingLimitsJob(config AutoScalingLimitsJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	job := &AutoScalingLimitsJob{
+		AutoScalingClient: config.AutoScalingClient,
+		jobName:           autoScalingLimitsJobPrefix + "-" + config.AutoScalingClient.GetRegion(),
+		region:            config.AutoScalingClient.GetRegion(),
+		Logger:            config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns auto scaling group and launch configuration utilization
+func (j *AutoScalingLimitsJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	output, err := j.AutoScalingClient.DescribeAccountLimits(ctx, &autoscaling.DescribeAccountLimitsInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	limits := []struct {
+		name      string
+		used, max *int32
+	}{
+		{groupsMetricName, output.NumberOfAutoScalingGroups, output.MaxNumberOfAutoScalingGroups},
+		{launchConfigurationsMetricName, output.NumberOfLaunchConfigurations, output.MaxNumberOfLaunchConfigurations},
+	}
+
+	now := time.Now()
+	var out []sharedtypes.CloudWatchMetric
+	for _, l := range limits {
+		if l.max == nil {
+			j.Logger.Warn("%s account limits did not report a maximum for %s", j.GetJobName(), l.name)
+			continue
+		}
+		used, limit := aws.ToInt32(l.used), aws.ToInt32(l.max)
+		j.Logger.Debug("%s %s : %d/%d", j.GetJobName(), l.name, used, limit)
+		out = append(out, utils.PercentMetric(l.name, utils.Utilization(float64(used), float64(limit)), nil, now))
+	}
+	return out, nil
+}
+
+// GetJobName return the name of the job
+func (j *AutoScalingLimitsJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *AutoScalingLimitsJob) GetRegion() string {
+	return j.region
+}
@@ -0,0 +1,54 @@
+package autoscalinglimits
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/autoscalingclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAutoScalingLimitsJob_Execute(t *testing.T) {
+	fake := &autoscalingclient.FakeAutoScalingClient{
+		Region: "us-east-1",
+		AccountLimits: &autoscaling.DescribeAccountLimitsOutput{
+			NumberOfAutoScalingGroups:       aws.Int32(50),
+			MaxNumberOfAutoScalingGroups:    aws.Int32(500),
+			NumberOfLaunchConfigurations:    aws.Int32(100),
+			MaxNumberOfLaunchConfigurations: aws.Int32(200),
+		},
+	}
+	j, err := NewAutoScalingLimitsJob(AutoScalingLimitsJobConfig{AutoScalingClient: fake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, autoScalingLimitsJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, metrics, 2)
+
+	got := map[string]float64{}
+	for _, m := range metrics {
+		got[m.Name] = m.Value
+	}
+	assert.InDelta(t, 10, got[groupsMetricName], 0.001)
+	assert.InDelta(t, 50, got[launchConfigurationsMetricName], 0.001)
+}
+
+func TestAutoScalingLimitsJob_MissingLimits(t *testing.T) {
+	fake := &autoscalingclient.FakeAutoScalingClient{Region: "us-east-1"}
+	j, _ := NewAutoScalingLimitsJob(AutoScalingLimitsJobConfig{AutoScalingClient: fake})
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, metrics)
+}
+
+func TestAutoScalingLimitsJob_Error(t *testing.T) {
+	fake := &autoscalingclient.FakeAutoScalingClient{Region: "us-east-1", ErrDescribeAccountLimits: true}
+	j, _ := NewAutoScalingLimitsJob(AutoScalingLimitsJobConfig{AutoScalingClient: fake})
+	jobtest.AssertExecuteFails(t, j)
+}
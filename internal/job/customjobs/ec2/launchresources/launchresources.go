@@ -0,0 +1,165 @@
+package launchresources
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/ec2client"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// LaunchResourcesJob will implement the Job interface
+// It compares the launch templates, active spot fleet requests, active EC2 Fleets,
+// placement groups and key pairs of the region against their quotas.
+
+type LaunchResourcesJob struct {
+	Ec2Client           ec2client.Ec2Client
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	jobName             string
+	region              string
+	Logger              logger.Logger
+}
+
+type LaunchResourcesJobConfig struct {
+	Ec2Client           ec2client.Ec2Client
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	Logger              logger.Logger
+}
+
+const (
+	launchResourcesJobPrefix = "ec2LaunchResources"
+	serviceCode              = "ec2"
+
+	// cloudwatch metric names
+	launchTemplatesMetricName   = "ec2LaunchTemplates"
+	spotFleetRequestsMetricName = "ec2SpotFleetRequests"
+	fleetsMetricName            = "ec2Fleets"
+	placementGroupsMetricName   = "ec2PlacementGroups"
+	keyPairsMetricName          = "ec2KeyPairs"
+)
+
+var (
+	// Launch templates per region
+	launchTemplatesQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, Default: 5000}
+	// Active spot fleet requests per region
+	spotFleetRequestsQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, Default: 1000}
+	// Active EC2 Fleets of type maintain or request per region
+	fleetsQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, Default: 1000}
+	// Placement groups per region
+	placementGroupsQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, Default: 500}
+	// Key pairs per region
+	keyPairsQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, Default: 5000}
+)
+
+// NewLaunchResourcesJob will create a new LaunchResourcesJob
+func NewLaunchResourcesJob(config LaunchResourcesJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	job := &LaunchResourcesJob{
+		Ec2Client:           config.Ec2Client,
+		ServiceQuotasClient: config.ServiceQuotasClient,
+		jobName:             launchResourcesJobPrefix + "-" + config.Ec2Client.GetRegion(),
+		region:              config.Ec2Client.GetRegion(),
+		Logger:              config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns utilization of the region wide ec2 launch resources
+func (j *LaunchResourcesJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	launchTemplates := 0
+	templates := ec2.NewDescribeLaunchTemplatesPaginator(j.Ec2Client, &ec2.DescribeLaunchTemplatesInput{})
+	for templates.HasMorePages() {
+		output, err := templates.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		launchTemplates += len(output.LaunchTemplates)
+	}
+
+	// cancelled and failed requests stay visible for a while but do not count towards the quota
+	spotFleetRequests := 0
+	requests := ec2.NewDescribeSpotFleetRequestsPaginator(j.Ec2Client, &ec2.DescribeSpotFleetRequestsInput{})
+	for requests.HasMorePages() {
+		output, err := requests.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, cfg := range output.SpotFleetRequestConfigs {
+			switch cfg.SpotFleetRequestState {
+			case ec2Types.BatchStateSubmitted, ec2Types.BatchStateActive, ec2Types.BatchStateModifying:
+				spotFleetRequests++
+			}
+		}
+	}
+
+	// instant fleets are not persisted and deleted fleets are not counted
+	fleets := 0
+	fleetPages := ec2.NewDescribeFleetsPaginator(j.Ec2Client, &ec2.DescribeFleetsInput{})
+	for fleetPages.HasMorePages() {
+		output, err := fleetPages.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, fleet := range output.Fleets {
+			if fleet.Type == ec2Types.FleetTypeInstant {
+				continue
+			}
+			switch fleet.FleetState {
+			case ec2Types.FleetStateCodeSubmitted, ec2Types.FleetStateCodeActive, ec2Types.FleetStateCodeModifying:
+				fleets++
+			}
+		}
+	}
+
+	placementGroups, err := j.Ec2Client.DescribePlacementGroups(ctx, &ec2.DescribePlacementGroupsInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	keyPairs, err := j.Ec2Client.DescribeKeyPairs(ctx, &ec2.DescribeKeyPairsInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	usages := []struct {
+		name  string
+		count int
+		quota servicequotaclient.QuotaSpec
+	}{
+		{launchTemplatesMetricName, launchTemplates, launchTemplatesQuota},
+		{spotFleetRequestsMetricName, spotFleetRequests, spotFleetRequestsQuota},
+		{fleetsMetricName, fleets, fleetsQuota},
+		{placementGroupsMetricName, len(placementGroups.PlacementGroups), placementGroupsQuota},
+		{keyPairsMetricName, len(keyPairs.KeyPairs), keyPairsQuota},
+	}
+
+	now := time.Now()
+	out := make([]sharedtypes.CloudWatchMetric, 0, len(usages))
+	for _, u := range usages {
+		limit, err := servicequotaclient.GetQuotaValue(ctx, j.ServiceQuotasClient, u.quota)
+		if err != nil {
+			return nil, err
+		}
+		j.Logger.Debug("%s %s : %d/%.0f", j.GetJobName(), u.name, u.count, limit)
+		out = append(out, utils.PercentMetric(u.name, utils.Utilization(float64(u.count), limit), nil, now))
+	}
+	return out, nil
+}
+
+// GetJobName return the name of the job
+func (j *LaunchResourcesJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *LaunchResourcesJob) GetRegion() string {
+	return j.region
+}
@@ -0,0 +1,90 @@
+package launchresources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/ec2client"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeEC2Client builds a fake with 500 launch templates over two pages, 4 active of 6 spot fleet
+// requests, 2 counted of 5 EC2 Fleets, 50 placement groups and 250 key pairs
+func newFakeEC2Client() *ec2client.FakeEC2Client {
+	return &ec2client.FakeEC2Client{
+		Region: "us-east-1",
+		DescribeLaunchTemplatesPages: []*ec2.DescribeLaunchTemplatesOutput{
+			{LaunchTemplates: make([]ec2Types.LaunchTemplate, 400)},
+			{LaunchTemplates: make([]ec2Types.LaunchTemplate, 100)},
+		},
+		DescribeSpotFleetRequestsPages: []*ec2.DescribeSpotFleetRequestsOutput{
+			{SpotFleetRequestConfigs: []ec2Types.SpotFleetRequestConfig{
+				{SpotFleetRequestState: ec2Types.BatchStateActive},
+				{SpotFleetRequestState: ec2Types.BatchStateSubmitted},
+				{SpotFleetRequestState: ec2Types.BatchStateCancelled},
+			}},
+			{SpotFleetRequestConfigs: []ec2Types.SpotFleetRequestConfig{
+				{SpotFleetRequestState: ec2Types.BatchStateModifying},
+				{SpotFleetRequestState: ec2Types.BatchStateActive},
+				{SpotFleetRequestState: ec2Types.BatchStateFailed},
+			}},
+		},
+		DescribeFleetsPages: []*ec2.DescribeFleetsOutput{
+			{Fleets: []ec2Types.FleetData{
+				{Type: ec2Types.FleetTypeMaintain, FleetState: ec2Types.FleetStateCodeActive},
+				{Type: ec2Types.FleetTypeRequest, FleetState: ec2Types.FleetStateCodeSubmitted},
+				{Type: ec2Types.FleetTypeInstant, FleetState: ec2Types.FleetStateCodeActive},
+				{Type: ec2Types.FleetTypeMaintain, FleetState: ec2Types.FleetStateCodeDeleted},
+				{Type: ec2Types.FleetTypeRequest, FleetState: ec2Types.FleetStateCodeFailed},
+			}},
+		},
+		PlacementGroups:                    make([]ec2Types.PlacementGroup, 50),
+		KeyPairs:                           make([]ec2Types.KeyPairInfo, 250),
+		ErrOnDescribeVpcsCall:              -1,
+		ErrOnDescribeENICall:               -1,
+		ErrOnDescribeSubnetCall:            -1,
+		ErrOnDescribeTGWVpcAttachCall:      -1,
+		ErrOnDescribeLaunchTemplatesCall:   -1,
+		ErrOnDescribeSpotFleetRequestsCall: -1,
+		ErrOnDescribeFleetsCall:            -1,
+	}
+}
+
+func TestLaunchResourcesJob_Execute(t *testing.T) {
+	ec2Fake := newFakeEC2Client()
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1"}
+	j, err := NewLaunchResourcesJob(LaunchResourcesJobConfig{Ec2Client: ec2Fake, ServiceQuotasClient: sqFake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, launchResourcesJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, metrics, 5)
+
+	want := map[string]float64{
+		launchTemplatesMetricName:   10,
+		spotFleetRequestsMetricName: 0.4,
+		fleetsMetricName:            0.2,
+		placementGroupsMetricName:   10,
+		keyPairsMetricName:          5,
+	}
+	for name, value := range want {
+		m, ok := jobtest.FindMetric(metrics, name)
+		if assert.True(t, ok, name) {
+			assert.InDelta(t, value, m.Value, 0.001, name)
+		}
+	}
+}
+
+func TestLaunchResourcesJob_Error(t *testing.T) {
+	ec2Fake := newFakeEC2Client()
+	ec2Fake.ErrOnDescribeLaunchTemplatesCall = 1
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1"}
+	j, _ := NewLaunchResourcesJob(LaunchResourcesJobConfig{Ec2Client: ec2Fake, ServiceQuotasClient: sqFake})
+	jobtest.AssertExecuteFails(t, j)
+}
@@ -0,0 +1,131 @@
+package images
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/ecrclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// ImagesJob will implement the Job interface
+// It counts the images in every ecr repository and emits images per
+// repository utilization for the fullest repositories.
+
+type ImagesJob struct {
+	EcrClient           ecrclient.ECRClient
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	jobName             string
+	region              string
+	Logger              logger.Logger
+}
+
+type ImagesJobConfig struct {
+	EcrClient           ecrclient.ECRClient
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	Logger              logger.Logger
+}
+
+const (
+	imagesJobPrefix     = "ecrImages"
+	serviceCode         = "ecr"
+	repositoryDimension = "repository"
+	// only the repositories with the most images get a metric
+	topRepositories = 10
+
+	// cloudwatch metric names
+	cloudwatchMetricName = "ecrImagesPerRepository"
+)
+
+var (
+	// Images per repository
+	imagesQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, QuotaCode: "L-03A36CE1", Default: 20000}
+)
+
+// NewImagesJob will create a new ImagesJob
+func NewImagesJob(config ImagesJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	job := &ImagesJob{
+		EcrClient:           config.EcrClient,
+		ServiceQuotasClient: config.ServiceQuotasClient,
+		jobName:             imagesJobPrefix + "-" + config.EcrClient.GetRegion(),
+		region:              config.EcrClient.GetRegion(),
+		Logger:              config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns images per repository utilization for the top repositories
+func (j *ImagesJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	var repositories []string
+	paginator := ecr.NewDescribeRepositoriesPaginator(j.EcrClient, &ecr.DescribeRepositoriesInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, repository := range output.Repositories {
+			repositories = append(repositories, aws.ToString(repository.RepositoryName))
+		}
+	}
+	j.Logger.Debug("%s total repositories : %d", j.GetJobName(), len(repositories))
+	if len(repositories) == 0 {
+		return nil, nil
+	}
+
+	counts := make([]utils.ResourceCount, 0, len(repositories))
+	for _, repository := range repositories {
+		total, err := j.countImages(ctx, repository)
+		if err != nil {
+			return nil, err
+		}
+		counts = append(counts, utils.ResourceCount{
+			Name:       repository,
+			Count:      total,
+			Dimensions: map[string]string{repositoryDimension: repository},
+		})
+	}
+
+	limit, err := servicequotaclient.GetQuotaValue(ctx, j.ServiceQuotasClient, imagesQuota)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	var out []sharedtypes.CloudWatchMetric
+	for _, rc := range utils.TopResourceCounts(counts, topRepositories) {
+		out = append(out, utils.PercentMetric(cloudwatchMetricName, utils.Utilization(float64(rc.Count), limit), rc.Dimensions, now))
+	}
+	return out, nil
+}
+
+// countImages counts the images in a repository, one per image digest
+func (j *ImagesJob) countImages(ctx context.Context, repository string) (int64, error) {
+	var total int64
+	paginator := ecr.NewDescribeImagesPaginator(j.EcrClient, &ecr.DescribeImagesInput{RepositoryName: aws.String(repository)})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return 0, err
+		}
+		total += int64(len(output.ImageDetails))
+	}
+	return total, nil
+}
+
+// GetJobName return the name of the job
+func (j *ImagesJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *ImagesJob) GetRegion() string {
+	return j.region
+}
@@ -0,0 +1,76 @@
+package images
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	ecrTypes "github.com/aws/aws-sdk-go-v2/service/ecr/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/ecrclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeECRClient builds a fake with n repositories, repository i holding i images over two pages
+func newFakeECRClient(n int) *ecrclient.FakeECRClient {
+	f := &ecrclient.FakeECRClient{
+		Region:                        "us-east-1",
+		DescribeImagesPageOutputs:     map[string][]*ecr.DescribeImagesOutput{},
+		ErrOnDescribeRepositoriesCall: -1,
+		ErrOnDescribeImagesCall:       -1,
+	}
+	page := &ecr.DescribeRepositoriesOutput{}
+	for i := 1; i <= n; i++ {
+		name := fmt.Sprintf("repo%02d", i)
+		page.Repositories = append(page.Repositories, ecrTypes.Repository{RepositoryName: aws.String(name)})
+		f.DescribeImagesPageOutputs[name] = []*ecr.DescribeImagesOutput{
+			{ImageDetails: make([]ecrTypes.ImageDetail, i/2)},
+			{ImageDetails: make([]ecrTypes.ImageDetail, i-i/2)},
+		}
+	}
+	f.DescribeRepositoriesPageOutputs = []*ecr.DescribeRepositoriesOutput{page}
+	return f
+}
+
+func TestImagesJob_Execute(t *testing.T) {
+	ecrFake := newFakeECRClient(topRepositories + 2)
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 100}
+	j, err := NewImagesJob(ImagesJobConfig{EcrClient: ecrFake, ServiceQuotasClient: sqFake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, imagesJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, metrics, topRepositories)
+
+	m, ok := jobtest.FindMetric(metrics, cloudwatchMetricName, repositoryDimension, fmt.Sprintf("repo%02d", topRepositories+2))
+	if assert.True(t, ok, "largest repository should be reported") {
+		assert.Equal(t, cloudwatchMetricName, m.Name)
+		assert.InDelta(t, float64(topRepositories+2), m.Value, 0.001)
+	}
+	_, ok = jobtest.FindMetric(metrics, cloudwatchMetricName, repositoryDimension, "repo01")
+	assert.False(t, ok, "smallest repository should be dropped")
+}
+
+func TestImagesJob_NoRepositories(t *testing.T) {
+	ecrFake := newFakeECRClient(0)
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 100}
+	j, _ := NewImagesJob(ImagesJobConfig{EcrClient: ecrFake, ServiceQuotasClient: sqFake})
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, metrics)
+	assert.Empty(t, sqFake.RequestedQuotaCodes)
+}
+
+func TestImagesJob_Error(t *testing.T) {
+	ecrFake := newFakeECRClient(3)
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 100}
+	ecrFake.ErrOnDescribeRepositoriesCall = 0
+	j, _ := NewImagesJob(ImagesJobConfig{EcrClient: ecrFake, ServiceQuotasClient: sqFake})
+	jobtest.AssertExecuteFails(t, j)
+}
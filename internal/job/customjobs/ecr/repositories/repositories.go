@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/ecrclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// RepositoriesJob will implement the Job interface
+// It counts the ecr repositories in the region against the repositories per registry quota.
+
+type RepositoriesJob struct {
+	EcrClient           ecrclient.ECRClient
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	jobName             string
+	region              string
+	Logger              logger.Logger
+}
+
+type RepositoriesJobConfig struct {
+	EcrClient           ecrclient.ECRClient
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	Logger              logger.Logger
+}
+
+const (
+	repositoriesJobPrefix = "ecrRepositories"
+	serviceCode           = "ecr"
+	cloudwatchMetricName  = "ecrRepositories"
+)
+
+var (
+	// Registered repositories per region
+	repositoriesQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, QuotaCode: "L-CFEB8E8D", Default: 100000}
+)
+
+// NewRepositoriesJob will create a new RepositoriesJob
+func NewRepositoriesJob(config RepositoriesJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	job := &RepositoriesJob{
+		EcrClient:           config.EcrClient,
+		ServiceQuotasClient: config.ServiceQuotasClient,
+		jobName:             repositoriesJobPrefix + "-" + config.EcrClient.GetRegion(),
+		region:              config.EcrClient.GetRegion(),
+		Logger:              config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns the repository count as a percent of the quota
+func (j *RepositoriesJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	// use aws sdk paginator to count all repositories
+	total := 0
+	paginator := ecr.NewDescribeRepositoriesPaginator(j.EcrClient, &ecr.DescribeRepositoriesInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		total += len(output.Repositories)
+	}
+	j.Logger.Debug("%s total repositories : %d", j.GetJobName(), total)
+
+	limit, err := servicequotaclient.GetQuotaValue(ctx, j.ServiceQuotasClient, repositoriesQuota)
+	if err != nil {
+		return nil, err
+	}
+	return []sharedtypes.CloudWatchMetric{
+		utils.PercentMetric(cloudwatchMetricName, utils.Utilization(float64(total), limit), nil, time.Now()),
+	}, nil
+}
+
+// GetJobName return the name of the job
+func (j *RepositoriesJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *RepositoriesJob) GetRegion() string {
+	return j.region
+}
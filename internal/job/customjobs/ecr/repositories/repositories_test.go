@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	ecrTypes "github.com/aws/aws-sdk-go-v2/service/ecr/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/ecrclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func newFakeECRClient() *ecrclient.FakeECRClient {
+	return &ecrclient.FakeECRClient{
+		Region: "us-east-1",
+		DescribeRepositoriesPageOutputs: []*ecr.DescribeRepositoriesOutput{
+			{Repositories: make([]ecrTypes.Repository, 3)},
+			{Repositories: make([]ecrTypes.Repository, 2)},
+		},
+		ErrOnDescribeRepositoriesCall: -1,
+		ErrOnDescribeImagesCall:       -1,
+	}
+}
+
+func TestRepositoriesJob_Execute(t *testing.T) {
+	ecrFake := newFakeECRClient()
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 20}
+	j, err := NewRepositoriesJob(RepositoriesJobConfig{EcrClient: ecrFake, ServiceQuotasClient: sqFake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, repositoriesJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, metrics, 1) {
+		assert.Equal(t, cloudwatchMetricName, metrics[0].Name)
+		assert.InDelta(t, 25, metrics[0].Value, 0.001)
+	}
+	assert.Equal(t, []string{repositoriesQuota.QuotaCode}, sqFake.RequestedQuotaCodes)
+}
+
+func TestRepositoriesJob_Error(t *testing.T) {
+	ecrFake := newFakeECRClient()
+	ecrFake.ErrOnDescribeRepositoriesCall = 1
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 20}
+	j, _ := NewRepositoriesJob(RepositoriesJobConfig{EcrClient: ecrFake, ServiceQuotasClient: sqFake})
+	jobtest.AssertExecuteFails(t, j)
+}
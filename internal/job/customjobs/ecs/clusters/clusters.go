@@ -0,0 +1,141 @@
+package clusters
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/ecsclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// ClustersJob will implement the Job interface
+// It counts ecs clusters against the clusters per account quota and emits
+// services and container instances per cluster for the fullest clusters.
+
+type ClustersJob struct {
+	EcsClient           ecsclient.ECSClient
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	jobName             string
+	region              string
+	Logger              logger.Logger
+}
+
+type ClustersJobConfig struct {
+	EcsClient           ecsclient.ECSClient
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	Logger              logger.Logger
+}
+
+const (
+	clustersJobPrefix = "ecsClusters"
+	serviceCode       = "ecs"
+	clusterDimension  = "cluster"
+	// only the clusters with the highest usage get per-cluster metrics
+	topClusters = 10
+	// DescribeClusters accepts at most 100 clusters per call
+	describeBatchSize = 100
+
+	// cloudwatch metric names
+	clustersMetricName           = "ecsClusters"
+	servicesMetricName           = "ecsServicesPerCluster"
+	containerInstancesMetricName = "ecsContainerInstancesPerCluster"
+)
+
+var (
+	// Clusters per account
+	clustersQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, QuotaCode: "L-21C621EB", Default: 10000}
+	// Services per cluster
+	servicesQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, QuotaCode: "L-9EF96962", Default: 5000}
+	// Container instances per cluster uses the published Amazon ECS default
+	containerInstancesQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, Default: 5000}
+)
+
+// NewClustersJob will create a new ClustersJob
+func NewClustersJob(config ClustersJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	job := &ClustersJob{
+		EcsClient:           config.EcsClient,
+		ServiceQuotasClient: config.ServiceQuotasClient,
+		jobName:             clustersJobPrefix + "-" + config.EcsClient.GetRegion(),
+		region:              config.EcsClient.GetRegion(),
+		Logger:              config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns the cluster count utilization and per-cluster utilization for the top clusters
+func (j *ClustersJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	// use aws sdk paginator to retrieve all ecs cluster arns
+	var arns []string
+	paginator := ecs.NewListClustersPaginator(j.EcsClient, &ecs.ListClustersInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		arns = append(arns, output.ClusterArns...)
+	}
+	j.Logger.Debug("%s total clusters : %d", j.GetJobName(), len(arns))
+
+	clustersLimit, err := servicequotaclient.GetQuotaValue(ctx, j.ServiceQuotasClient, clustersQuota)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	out := []sharedtypes.CloudWatchMetric{
+		utils.PercentMetric(clustersMetricName, utils.Utilization(float64(len(arns)), clustersLimit), nil, now),
+	}
+	if len(arns) == 0 {
+		return out, nil
+	}
+
+	// describe clusters in batches to read the service and container instance counts
+	var services, instances []utils.ResourceCount
+	for start := 0; start < len(arns); start += describeBatchSize {
+		end := min(start+describeBatchSize, len(arns))
+		output, err := j.EcsClient.DescribeClusters(ctx, &ecs.DescribeClustersInput{Clusters: arns[start:end]})
+		if err != nil {
+			return nil, err
+		}
+		for _, cluster := range output.Clusters {
+			name := aws.ToString(cluster.ClusterName)
+			dims := map[string]string{clusterDimension: name}
+			services = append(services, utils.ResourceCount{Name: name, Count: int64(cluster.ActiveServicesCount), Dimensions: dims})
+			instances = append(instances, utils.ResourceCount{Name: name, Count: int64(cluster.RegisteredContainerInstancesCount), Dimensions: dims})
+		}
+	}
+
+	servicesLimit, err := servicequotaclient.GetQuotaValue(ctx, j.ServiceQuotasClient, servicesQuota)
+	if err != nil {
+		return nil, err
+	}
+	instancesLimit, err := servicequotaclient.GetQuotaValue(ctx, j.ServiceQuotasClient, containerInstancesQuota)
+	if err != nil {
+		return nil, err
+	}
+	for _, rc := range utils.TopResourceCounts(services, topClusters) {
+		out = append(out, utils.PercentMetric(servicesMetricName, utils.Utilization(float64(rc.Count), servicesLimit), rc.Dimensions, now))
+	}
+	for _, rc := range utils.TopResourceCounts(instances, topClusters) {
+		out = append(out, utils.PercentMetric(containerInstancesMetricName, utils.Utilization(float64(rc.Count), instancesLimit), rc.Dimensions, now))
+	}
+	return out, nil
+}
+
+// GetJobName return the name of the job
+func (j *ClustersJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *ClustersJob) GetRegion() string {
+	return j.region
+}
@@ -0,0 +1,114 @@
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	ecsTypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/ecsclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeECSClient builds a fake with n clusters split over two pages
+// cluster i has i*10 services and i container instances
+func newFakeECSClient(n int) *ecsclient.FakeECSClient {
+	f := &ecsclient.FakeECSClient{
+		Region:                    "us-east-1",
+		Clusters:                  map[string]ecsTypes.Cluster{},
+		ErrOnListClustersCall:     -1,
+		ErrOnDescribeClustersCall: -1,
+		ErrOnListServicesCall:     -1,
+		ErrOnDescribeServicesCall: -1,
+	}
+	pages := []*ecs.ListClustersOutput{{}, {}}
+	for i := 1; i <= n; i++ {
+		name := fmt.Sprintf("c%03d", i)
+		arn := "arn:aws:ecs:us-east-1:123456789012:cluster/" + name
+		pages[i%2].ClusterArns = append(pages[i%2].ClusterArns, arn)
+		f.Clusters[arn] = ecsTypes.Cluster{
+			ClusterArn:                        aws.String(arn),
+			ClusterName:                       aws.String(name),
+			ActiveServicesCount:               int32(i * 10),
+			RegisteredContainerInstancesCount: int32(i),
+		}
+	}
+	f.ListClustersPageOutputs = pages
+	return f
+}
+
+func TestClustersJob_Execute(t *testing.T) {
+	ecsFake := newFakeECSClient(3)
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{
+		Region: "us-east-1",
+		QuotaValues: map[string]float64{
+			clustersQuota.QuotaCode: 30,
+			servicesQuota.QuotaCode: 100,
+		},
+	}
+	j, err := NewClustersJob(ClustersJobConfig{EcsClient: ecsFake, ServiceQuotasClient: sqFake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, clustersJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, metrics, 7, "expected one account metric and two metrics per cluster")
+
+	cases := []struct {
+		name    string
+		cluster string
+		value   float64
+	}{
+		{clustersMetricName, "", 10},
+		{servicesMetricName, "c001", 10},
+		{servicesMetricName, "c003", 30},
+		{containerInstancesMetricName, "c002", 2 / containerInstancesQuota.Default * 100},
+	}
+	for _, tc := range cases {
+		m, ok := jobtest.FindMetric(metrics, tc.name, clusterDimension, tc.cluster)
+		if assert.True(t, ok, "missing metric %s for cluster %s", tc.name, tc.cluster) {
+			assert.InDelta(t, tc.value, m.Value, 0.001, "%s cluster %s", tc.name, tc.cluster)
+		}
+	}
+}
+
+func TestClustersJob_TopClusters(t *testing.T) {
+	// more clusters than a single DescribeClusters call and the top-N bound
+	ecsFake := newFakeECSClient(describeBatchSize + 20)
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 10000}
+	j, _ := NewClustersJob(ClustersJobConfig{EcsClient: ecsFake, ServiceQuotasClient: sqFake})
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, metrics, 1+2*topClusters)
+	_, ok := jobtest.FindMetric(metrics, servicesMetricName, clusterDimension, fmt.Sprintf("c%03d", describeBatchSize+20))
+	assert.True(t, ok, "largest cluster should be reported")
+	_, ok = jobtest.FindMetric(metrics, servicesMetricName, clusterDimension, "c001")
+	assert.False(t, ok, "smallest cluster should be dropped")
+}
+
+func TestClustersJob_NoClusters(t *testing.T) {
+	ecsFake := newFakeECSClient(0)
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 10000}
+	j, _ := NewClustersJob(ClustersJobConfig{EcsClient: ecsFake, ServiceQuotasClient: sqFake})
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, metrics, 1) {
+		assert.Equal(t, clustersMetricName, metrics[0].Name)
+		assert.Equal(t, 0.0, metrics[0].Value)
+	}
+}
+
+func TestClustersJob_Error(t *testing.T) {
+	ecsFake := newFakeECSClient(3)
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 100}
+	ecsFake.ErrOnListClustersCall = 1
+	j, _ := NewClustersJob(ClustersJobConfig{EcsClient: ecsFake, ServiceQuotasClient: sqFake})
+	jobtest.AssertExecuteFails(t, j)
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/ecsclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// ServicesJob will implement the Job interface
+// It walks every service of every ecs cluster and emits the desired task
+// count against the tasks per service quota for the largest services.
+
+type ServicesJob struct {
+	EcsClient           ecsclient.ECSClient
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	jobName             string
+	region              string
+	Logger              logger.Logger
+}
+
+type ServicesJobConfig struct {
+	EcsClient           ecsclient.ECSClient
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	Logger              logger.Logger
+}
+
+const (
+	servicesJobPrefix = "ecsServices"
+	serviceCode       = "ecs"
+	clusterDimension  = "cluster"
+	serviceDimension  = "service"
+	// only the services with the highest desired count get a metric
+	topServices = 10
+	// DescribeServices accepts at most 10 services per call
+	describeBatchSize = 10
+
+	// cloudwatch metric names
+	tasksMetricName = "ecsTasksPerService"
+)
+
+var (
+	// Tasks per service uses the published Amazon ECS default
+	tasksQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, Default: 5000}
+)
+
+// NewServicesJob will create a new ServicesJob
+func NewServicesJob(config ServicesJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	job := &ServicesJob{
+		EcsClient:           config.EcsClient,
+		ServiceQuotasClient: config.ServiceQuotasClient,
+		jobName:             servicesJobPrefix + "-" + config.EcsClient.GetRegion(),
+		region:              config.EcsClient.GetRegion(),
+		Logger:              config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns desired tasks per service utilization for the top services in the region
+func (j *ServicesJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	var clusters []string
+	paginator := ecs.NewListClustersPaginator(j.EcsClient, &ecs.ListClustersInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		clusters = append(clusters, output.ClusterArns...)
+	}
+	j.Logger.Debug("%s total clusters : %d", j.GetJobName(), len(clusters))
+	if len(clusters) == 0 {
+		return nil, nil
+	}
+
+	var tasks []utils.ResourceCount
+	for _, cluster := range clusters {
+		counts, err := j.collectDesiredCounts(ctx, cluster)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, counts...)
+	}
+	if len(tasks) == 0 {
+		return nil, nil
+	}
+
+	tasksLimit, err := servicequotaclient.GetQuotaValue(ctx, j.ServiceQuotasClient, tasksQuota)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	var out []sharedtypes.CloudWatchMetric
+	for _, rc := range utils.TopResourceCounts(tasks, topServices) {
+		out = append(out, utils.PercentMetric(tasksMetricName, utils.Utilization(float64(rc.Count), tasksLimit), rc.Dimensions, now))
+	}
+	return out, nil
+}
+
+// collectDesiredCounts returns the desired task count of every service in a cluster
+func (j *ServicesJob) collectDesiredCounts(ctx context.Context, cluster string) ([]utils.ResourceCount, error) {
+	var arns []string
+	paginator := ecs.NewListServicesPaginator(j.EcsClient, &ecs.ListServicesInput{Cluster: aws.String(cluster)})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		arns = append(arns, output.ServiceArns...)
+	}
+
+	clusterName := utils.ResourceNameFromArn(cluster)
+	var counts []utils.ResourceCount
+	for start := 0; start < len(arns); start += describeBatchSize {
+		end := min(start+describeBatchSize, len(arns))
+		output, err := j.EcsClient.DescribeServices(ctx, &ecs.DescribeServicesInput{
+			Cluster:  aws.String(cluster),
+			Services: arns[start:end],
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, service := range output.Services {
+			serviceName := aws.ToString(service.ServiceName)
+			counts = append(counts, utils.ResourceCount{
+				Name:       clusterName + "/" + serviceName,
+				Count:      int64(service.DesiredCount),
+				Dimensions: map[string]string{clusterDimension: clusterName, serviceDimension: serviceName},
+			})
+		}
+	}
+	return counts, nil
+}
+
+// GetJobName return the name of the job
+func (j *ServicesJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *ServicesJob) GetRegion() string {
+	return j.region
+}
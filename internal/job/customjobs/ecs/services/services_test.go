@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	ecsTypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/ecsclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+const clusterArnPrefix = "arn:aws:ecs:us-east-1:123456789012:cluster/"
+
+// newFakeECSClient builds a fake with clusters "a" and "b"
+// "a" has 12 services spread over two pages with desired counts 1..12, "b" has one service
+func newFakeECSClient() *ecsclient.FakeECSClient {
+	f := &ecsclient.FakeECSClient{
+		Region: "us-east-1",
+		ListClustersPageOutputs: []*ecs.ListClustersOutput{
+			{ClusterArns: []string{clusterArnPrefix + "a", clusterArnPrefix + "b"}},
+		},
+		ListServicesPageOutputs:   map[string][]*ecs.ListServicesOutput{},
+		Services:                  map[string]ecsTypes.Service{},
+		ErrOnListClustersCall:     -1,
+		ErrOnDescribeClustersCall: -1,
+		ErrOnListServicesCall:     -1,
+		ErrOnDescribeServicesCall: -1,
+	}
+	addService := func(cluster, name string, desired int32, page int) {
+		arn := "arn:aws:ecs:us-east-1:123456789012:service/" + cluster + "/" + name
+		pages := f.ListServicesPageOutputs[clusterArnPrefix+cluster]
+		for len(pages) <= page {
+			pages = append(pages, &ecs.ListServicesOutput{})
+		}
+		pages[page].ServiceArns = append(pages[page].ServiceArns, arn)
+		f.ListServicesPageOutputs[clusterArnPrefix+cluster] = pages
+		f.Services[arn] = ecsTypes.Service{ServiceName: aws.String(name), DesiredCount: desired}
+	}
+	for i := 1; i <= 12; i++ {
+		addService("a", fmt.Sprintf("svc%02d", i), int32(i), i%2)
+	}
+	addService("b", "web", 2500, 0)
+	return f
+}
+
+func TestServicesJob_Execute(t *testing.T) {
+	ecsFake := newFakeECSClient()
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1"}
+	j, err := NewServicesJob(ServicesJobConfig{EcsClient: ecsFake, ServiceQuotasClient: sqFake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, servicesJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, metrics, topServices, "expected only the top services")
+
+	m, ok := jobtest.FindMetric(metrics, tasksMetricName, clusterDimension, "b", serviceDimension, "web")
+	if assert.True(t, ok, "largest service should be reported") {
+		assert.Equal(t, tasksMetricName, m.Name)
+		assert.InDelta(t, 2500/tasksQuota.Default*100, m.Value, 0.001)
+	}
+	_, ok = jobtest.FindMetric(metrics, tasksMetricName, clusterDimension, "a", serviceDimension, "svc12")
+	assert.True(t, ok)
+	_, ok = jobtest.FindMetric(metrics, tasksMetricName, clusterDimension, "a", serviceDimension, "svc01")
+	assert.False(t, ok, "smallest services should be dropped")
+	assert.Empty(t, sqFake.RequestedQuotaCodes, "tasks per service uses the documented default")
+}
+
+func TestServicesJob_NoServices(t *testing.T) {
+	ecsFake := newFakeECSClient()
+	ecsFake.ListServicesPageOutputs = nil
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1"}
+	j, _ := NewServicesJob(ServicesJobConfig{EcsClient: ecsFake, ServiceQuotasClient: sqFake})
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, metrics)
+}
+
+func TestServicesJob_Error(t *testing.T) {
+	ecsFake := newFakeECSClient()
+	ecsFake.ErrOnListClustersCall = 0
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1"}
+	j, _ := NewServicesJob(ServicesJobConfig{EcsClient: ecsFake, ServiceQuotasClient: sqFake})
+	jobtest.AssertExecuteFails(t, j)
+}
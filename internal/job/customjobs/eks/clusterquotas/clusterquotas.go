@@ -0,0 +1,215 @@
+package clusterquotas
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cwTypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	eksTypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/eksclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// ClusterQuotaJob will implement the Job interface
+// For every cluster in a region it counts managed node groups, fargate
+// profiles, selectors per fargate profile and access entries, and emits
+// one metric per cluster with a "cluster" dimension.  Clusters using the
+// CONFIG_MAP authentication mode have no access entries metric.
+
+type ClusterQuotaJob struct {
+	EksClient           eksclient.EKSClient
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	jobName             string
+	region              string
+	Logger              logger.Logger
+}
+
+type ClusterQuotaJobConfig struct {
+	EksClient           eksclient.EKSClient
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	Logger              logger.Logger
+}
+
+const (
+	clusterQuotaJobPrefix = "eksClusterQuotas"
+	serviceCode           = "eks"
+	clusterDimension      = "cluster"
+
+	// cloudwatch metric names
+	nodeGroupsMetricName       = "eksManagedNodeGroups"
+	fargateProfilesMetricName  = "eksFargateProfiles"
+	fargateSelectorsMetricName = "eksFargateSelectorsPerProfile"
+	accessEntriesMetricName    = "eksAccessEntries"
+)
+
+var (
+	// Managed node groups per cluster
+	nodeGroupsQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, QuotaCode: "L-6D54EA21", Default: 30}
+	// Fargate profiles per cluster and selectors per fargate profile
+	// use the published Amazon EKS defaults
+	fargateProfilesQuota  = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, Default: 10}
+	fargateSelectorsQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, Default: 5}
+)
+
+// clusterUsage holds the raw counts for a single cluster
+type clusterUsage struct {
+	nodeGroups      int64
+	fargateProfiles int64
+	maxSelectors    int64
+	accessEntries   int64
+	// false when the cluster does not use access entries
+	hasAccessEntries bool
+}
+
+// NewClusterQuotaJob will create a new ClusterQuotaJob
+func NewClusterQuotaJob(config ClusterQuotaJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	job := &ClusterQuotaJob{
+		EksClient:           config.EksClient,
+		ServiceQuotasClient: config.ServiceQuotasClient,
+		jobName:             clusterQuotaJobPrefix + "-" + config.EksClient.GetRegion(),
+		region:              config.EksClient.GetRegion(),
+		Logger:              config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns per-cluster utilization metrics for every cluster in the region
+func (j *ClusterQuotaJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	// use aws sdk paginator to retrieve all eks clusters
+	var clusters []string
+	paginator := eks.NewListClustersPaginator(j.EksClient, &eks.ListClustersInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		clusters = append(clusters, output.Clusters...)
+	}
+	sort.Strings(clusters)
+	j.Logger.Debug("%s total clusters : %d", j.GetJobName(), len(clusters))
+	if len(clusters) == 0 {
+		return nil, nil
+	}
+
+	// get quota values once for all clusters
+	nodeGroupsLimit, err := servicequotaclient.GetQuotaValue(ctx, j.ServiceQuotasClient, nodeGroupsQuota)
+	if err != nil {
+		return nil, err
+	}
+	fargateProfilesLimit, err := servicequotaclient.GetQuotaValue(ctx, j.ServiceQuotasClient, fargateProfilesQuota)
+	if err != nil {
+		return nil, err
+	}
+	fargateSelectorsLimit, err := servicequotaclient.GetQuotaValue(ctx, j.ServiceQuotasClient, fargateSelectorsQuota)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	out := make([]sharedtypes.CloudWatchMetric, 0, len(clusters)*4)
+	for _, cluster := range clusters {
+		usage, err := j.collectClusterUsage(ctx, cluster)
+		if err != nil {
+			return nil, err
+		}
+		j.Logger.Debug("%s cluster %s : %+v", j.GetJobName(), cluster, usage)
+
+		dims := map[string]string{clusterDimension: cluster}
+		out = append(out,
+			utils.PercentMetric(nodeGroupsMetricName, utils.Utilization(float64(usage.nodeGroups), nodeGroupsLimit), dims, now),
+			utils.PercentMetric(fargateProfilesMetricName, utils.Utilization(float64(usage.fargateProfiles), fargateProfilesLimit), dims, now),
+			utils.PercentMetric(fargateSelectorsMetricName, utils.Utilization(float64(usage.maxSelectors), fargateSelectorsLimit), dims, now),
+		)
+		// access entries have no published per-cluster quota, so emit the raw count
+		if usage.hasAccessEntries {
+			out = append(out, sharedtypes.CloudWatchMetric{
+				Name:      accessEntriesMetricName,
+				Value:     float64(usage.accessEntries),
+				Unit:      cwTypes.StandardUnitCount,
+				Metadata:  dims,
+				Timestamp: now,
+			})
+		}
+	}
+	return out, nil
+}
+
+// collectClusterUsage counts node groups, fargate profiles, selectors and access entries for a cluster
+func (j *ClusterQuotaJob) collectClusterUsage(ctx context.Context, cluster string) (clusterUsage, error) {
+	var usage clusterUsage
+
+	nodeGroups := eks.NewListNodegroupsPaginator(j.EksClient, &eks.ListNodegroupsInput{ClusterName: aws.String(cluster)})
+	for nodeGroups.HasMorePages() {
+		output, err := nodeGroups.NextPage(ctx)
+		if err != nil {
+			return usage, err
+		}
+		usage.nodeGroups += int64(len(output.Nodegroups))
+	}
+
+	var profiles []string
+	fargateProfiles := eks.NewListFargateProfilesPaginator(j.EksClient, &eks.ListFargateProfilesInput{ClusterName: aws.String(cluster)})
+	for fargateProfiles.HasMorePages() {
+		output, err := fargateProfiles.NextPage(ctx)
+		if err != nil {
+			return usage, err
+		}
+		profiles = append(profiles, output.FargateProfileNames...)
+	}
+	usage.fargateProfiles = int64(len(profiles))
+
+	// selectors are a per-profile quota, report the fullest profile of the cluster
+	for _, profile := range profiles {
+		output, err := j.EksClient.DescribeFargateProfile(ctx, &eks.DescribeFargateProfileInput{
+			ClusterName:        aws.String(cluster),
+			FargateProfileName: aws.String(profile),
+		})
+		if err != nil {
+			return usage, err
+		}
+		if output.FargateProfile == nil {
+			continue
+		}
+		if selectors := int64(len(output.FargateProfile.Selectors)); selectors > usage.maxSelectors {
+			usage.maxSelectors = selectors
+		}
+	}
+
+	// clusters using the CONFIG_MAP authentication mode reject the access entries apis
+	accessEntries := eks.NewListAccessEntriesPaginator(j.EksClient, &eks.ListAccessEntriesInput{ClusterName: aws.String(cluster)})
+	for accessEntries.HasMorePages() {
+		output, err := accessEntries.NextPage(ctx)
+		var invalidRequest *eksTypes.InvalidRequestException
+		if errors.As(err, &invalidRequest) {
+			j.Logger.Warn("%s cluster %s does not use access entries, skipping them : %v", j.GetJobName(), cluster, err)
+			return usage, nil
+		}
+		if err != nil {
+			return usage, err
+		}
+		usage.accessEntries += int64(len(output.AccessEntries))
+	}
+	usage.hasAccessEntries = true
+	return usage, nil
+}
+
+// GetJobName return the name of the job
+func (j *ClusterQuotaJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *ClusterQuotaJob) GetRegion() string {
+	return j.region
+}
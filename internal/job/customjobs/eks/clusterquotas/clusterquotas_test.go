@@ -0,0 +1,138 @@
+package clusterquotas
+
+import (
+	"context"
+	"testing"
+
+	cwTypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	eksTypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/eksclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeEKSClient builds a fake with two clusters, "a" spanning two pages
+func newFakeEKSClient() *eksclient.FakeEKSClient {
+	return &eksclient.FakeEKSClient{
+		Region: "us-east-1",
+		ListClustersPageOutputs: []*eks.ListClustersOutput{
+			{Clusters: []string{"b"}},
+			{Clusters: []string{"a"}},
+		},
+		ListNodegroupsPageOutputs: map[string][]*eks.ListNodegroupsOutput{
+			"a": {{Nodegroups: []string{"ng1", "ng2"}}, {Nodegroups: []string{"ng3"}}},
+			"b": {{Nodegroups: []string{"ng1"}}},
+		},
+		ListFargateProfilesPageOutputs: map[string][]*eks.ListFargateProfilesOutput{
+			"a": {{FargateProfileNames: []string{"fp1", "fp2"}}},
+		},
+		FargateProfiles: map[string]*eksTypes.FargateProfile{
+			"fp1": {Selectors: []eksTypes.FargateProfileSelector{{}}},
+			"fp2": {Selectors: []eksTypes.FargateProfileSelector{{}, {}, {}, {}}},
+		},
+		ListAccessEntriesPageOutputs: map[string][]*eks.ListAccessEntriesOutput{
+			"a": {{AccessEntries: []string{"arn1", "arn2"}}},
+			"b": {{AccessEntries: []string{"arn1"}}},
+		},
+		ErrOnListClustersCall:           -1,
+		ErrOnListNodegroupsCall:         -1,
+		ErrOnListFargateProfilesCall:    -1,
+		ErrOnDescribeFargateProfileCall: -1,
+		ErrOnListAccessEntriesCall:      -1,
+	}
+}
+
+func TestClusterQuotaJob_Execute(t *testing.T) {
+	eksFake := newFakeEKSClient()
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{
+		Region:      "us-east-1",
+		QuotaValues: map[string]float64{nodeGroupsQuota.QuotaCode: 10},
+	}
+	j, err := NewClusterQuotaJob(ClusterQuotaJobConfig{
+		EksClient:           eksFake,
+		ServiceQuotasClient: sqFake,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, clusterQuotaJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, metrics, 8, "expected four metrics per cluster")
+
+	cases := []struct {
+		name    string
+		cluster string
+		value   float64
+		unit    cwTypes.StandardUnit
+	}{
+		{nodeGroupsMetricName, "a", 30, cwTypes.StandardUnitPercent},
+		{nodeGroupsMetricName, "b", 10, cwTypes.StandardUnitPercent},
+		{fargateProfilesMetricName, "a", 20, cwTypes.StandardUnitPercent},
+		{fargateProfilesMetricName, "b", 0, cwTypes.StandardUnitPercent},
+		{fargateSelectorsMetricName, "a", 80, cwTypes.StandardUnitPercent},
+		{accessEntriesMetricName, "a", 2, cwTypes.StandardUnitCount},
+		{accessEntriesMetricName, "b", 1, cwTypes.StandardUnitCount},
+	}
+	for _, tc := range cases {
+		m, ok := jobtest.FindMetric(metrics, tc.name, clusterDimension, tc.cluster)
+		if assert.True(t, ok, "missing metric %s for cluster %s", tc.name, tc.cluster) {
+			assert.InDelta(t, tc.value, m.Value, 0.001, "%s cluster %s", tc.name, tc.cluster)
+			assert.Equal(t, tc.unit, m.Unit)
+		}
+	}
+
+	// only the node group quota is looked up in service quotas
+	assert.Equal(t, []string{nodeGroupsQuota.QuotaCode}, sqFake.RequestedQuotaCodes)
+}
+
+func TestClusterQuotaJob_ConfigMapCluster(t *testing.T) {
+	eksFake := newFakeEKSClient()
+	eksFake.ConfigMapClusters = map[string]bool{"b": true}
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 30}
+	j, _ := NewClusterQuotaJob(ClusterQuotaJobConfig{EksClient: eksFake, ServiceQuotasClient: sqFake})
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err, "a cluster without access entries does not fail the job")
+	assert.Len(t, metrics, 7)
+	_, ok := jobtest.FindMetric(metrics, accessEntriesMetricName, clusterDimension, "b")
+	assert.False(t, ok)
+	_, ok = jobtest.FindMetric(metrics, nodeGroupsMetricName, clusterDimension, "b")
+	assert.True(t, ok)
+}
+
+func TestClusterQuotaJob_NoClusters(t *testing.T) {
+	eksFake := &eksclient.FakeEKSClient{Region: "us-east-1", ErrOnListClustersCall: -1}
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 30}
+	j, _ := NewClusterQuotaJob(ClusterQuotaJobConfig{EksClient: eksFake, ServiceQuotasClient: sqFake})
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, metrics)
+	assert.Empty(t, sqFake.RequestedQuotaCodes, "should not look up quotas without clusters")
+}
+
+func TestClusterQuotaJob_Error(t *testing.T) {
+	eksFake := newFakeEKSClient()
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 30}
+	eksFake.ErrOnListClustersCall = 1
+	j, _ := NewClusterQuotaJob(ClusterQuotaJobConfig{EksClient: eksFake, ServiceQuotasClient: sqFake})
+	jobtest.AssertExecuteFails(t, j)
+}
+
+func TestClusterQuotaJob_MissingQuotaUsesDefault(t *testing.T) {
+	eksFake := newFakeEKSClient()
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{
+		Region:            "us-east-1",
+		MissingQuotaCodes: map[string]bool{nodeGroupsQuota.QuotaCode: true},
+	}
+	j, _ := NewClusterQuotaJob(ClusterQuotaJobConfig{EksClient: eksFake, ServiceQuotasClient: sqFake})
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	m, ok := jobtest.FindMetric(metrics, nodeGroupsMetricName, clusterDimension, "a")
+	assert.True(t, ok)
+	assert.InDelta(t, 3/nodeGroupsQuota.Default*100, m.Value, 0.001)
+}
@@ -0,0 +1,94 @@
+package nodes
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/elasticache"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/elasticacheclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// NodesJob will implement the Job interface
+// It sums the cache nodes of every elasticache cluster in the region
+// and compares it against the nodes per region quota.
+
+type NodesJob struct {
+	ElastiCacheClient   elasticacheclient.ElastiCacheClient
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	jobName             string
+	region              string
+	Logger              logger.Logger
+}
+
+type NodesJobConfig struct {
+	ElastiCacheClient   elasticacheclient.ElastiCacheClient
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	Logger              logger.Logger
+}
+
+const (
+	nodesJobPrefix       = "elasticacheNodes"
+	serviceCode          = "elasticache"
+	cloudwatchMetricName = "elasticacheNodes"
+)
+
+var (
+	// Nodes per Region
+	nodesQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, QuotaCode: "L-8C334AD1", Default: 300}
+)
+
+// NewNodesJob will create a new NodesJob
+func NewNodesJob(config NodesJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	job := &NodesJob{
+		ElastiCacheClient:   config.ElastiCacheClient,
+		ServiceQuotasClient: config.ServiceQuotasClient,
+		jobName:             nodesJobPrefix + "-" + config.ElastiCacheClient.GetRegion(),
+		region:              config.ElastiCacheClient.GetRegion(),
+		Logger:              config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns the cache node count as a percent of the quota
+func (j *NodesJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	// use aws sdk paginator to sum the nodes of all cache clusters
+	var total int64
+	paginator := elasticache.NewDescribeCacheClustersPaginator(j.ElastiCacheClient, &elasticache.DescribeCacheClustersInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, cluster := range output.CacheClusters {
+			total += int64(aws.ToInt32(cluster.NumCacheNodes))
+		}
+	}
+	j.Logger.Debug("%s total cache nodes : %d", j.GetJobName(), total)
+
+	limit, err := servicequotaclient.GetQuotaValue(ctx, j.ServiceQuotasClient, nodesQuota)
+	if err != nil {
+		return nil, err
+	}
+	return []sharedtypes.CloudWatchMetric{
+		utils.PercentMetric(cloudwatchMetricName, utils.Utilization(float64(total), limit), nil, time.Now()),
+	}, nil
+}
+
+// GetJobName return the name of the job
+func (j *NodesJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *NodesJob) GetRegion() string {
+	return j.region
+}
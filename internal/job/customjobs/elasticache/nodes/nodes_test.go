@@ -0,0 +1,50 @@
+package nodes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/elasticache"
+	elasticacheTypes "github.com/aws/aws-sdk-go-v2/service/elasticache/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/elasticacheclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func newFakeElastiCacheClient() *elasticacheclient.FakeElastiCacheClient {
+	return &elasticacheclient.FakeElastiCacheClient{
+		Region: "us-east-1",
+		DescribeCacheClustersPageOutputs: []*elasticache.DescribeCacheClustersOutput{
+			{CacheClusters: []elasticacheTypes.CacheCluster{{NumCacheNodes: aws.Int32(3)}, {NumCacheNodes: aws.Int32(2)}}},
+			{CacheClusters: []elasticacheTypes.CacheCluster{{NumCacheNodes: aws.Int32(10)}, {}}},
+		},
+		ErrOnDescribeCacheClustersCall: -1,
+	}
+}
+
+func TestNodesJob_Execute(t *testing.T) {
+	ecFake := newFakeElastiCacheClient()
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 60}
+	j, err := NewNodesJob(NodesJobConfig{ElastiCacheClient: ecFake, ServiceQuotasClient: sqFake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, nodesJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, metrics, 1) {
+		assert.Equal(t, cloudwatchMetricName, metrics[0].Name)
+		assert.InDelta(t, 25, metrics[0].Value, 0.001)
+	}
+	assert.Equal(t, []string{nodesQuota.QuotaCode}, sqFake.RequestedQuotaCodes)
+}
+
+func TestNodesJob_Error(t *testing.T) {
+	ecFake := newFakeElastiCacheClient()
+	ecFake.ErrOnDescribeCacheClustersCall = 1
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 60}
+	j, _ := NewNodesJob(NodesJobConfig{ElastiCacheClient: ecFake, ServiceQuotasClient: sqFake})
+	jobtest.AssertExecuteFails(t, j)
+}
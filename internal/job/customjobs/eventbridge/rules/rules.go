@@ -0,0 +1,153 @@
+package rules
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/eventbridgeclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// RulesJob will implement the Job interface
+// The rules quota applies to each event bus, so the rules of every bus are counted
+// and the fullest buses are emitted.
+
+type RulesJob struct {
+	EventBridgeClient   eventbridgeclient.EventBridgeClient
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	jobName             string
+	region              string
+	Logger              logger.Logger
+}
+
+type RulesJobConfig struct {
+	EventBridgeClient   eventbridgeclient.EventBridgeClient
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	Logger              logger.Logger
+}
+
+const (
+	rulesJobPrefix    = "eventbridgeRules"
+	serviceCode       = "events"
+	eventBusDimension = "eventBus"
+	// only the buses with the most rules get a metric
+	topEventBuses = 10
+
+	// cloudwatch metric names
+	rulesPerBusMetricName = "eventbridgeRulesPerBus"
+)
+
+var (
+	// Rules per event bus
+	rulesQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, QuotaCode: "L-244521F2", Default: 300}
+)
+
+// NewRulesJob will create a new RulesJob
+func NewRulesJob(config RulesJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	job := &RulesJob{
+		EventBridgeClient:   config.EventBridgeClient,
+		ServiceQuotasClient: config.ServiceQuotasClient,
+		jobName:             rulesJobPrefix + "-" + config.EventBridgeClient.GetRegion(),
+		region:              config.EventBridgeClient.GetRegion(),
+		Logger:              config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns rules per event bus utilization for the fullest buses
+func (j *RulesJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	buses, err := j.listEventBuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make([]utils.ResourceCount, 0, len(buses))
+	for _, bus := range buses {
+		count, err := j.countRules(ctx, bus)
+		if err != nil {
+			return nil, err
+		}
+		counts = append(counts, utils.ResourceCount{
+			Name:       bus,
+			Count:      count,
+			Dimensions: map[string]string{eventBusDimension: bus},
+		})
+	}
+
+	limit, err := servicequotaclient.GetQuotaValue(ctx, j.ServiceQuotasClient, rulesQuota)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var out []sharedtypes.CloudWatchMetric
+	for _, rc := range utils.TopResourceCounts(counts, topEventBuses) {
+		j.Logger.Debug("%s %s rules : %d/%.0f", j.GetJobName(), rc.Name, rc.Count, limit)
+		out = append(out, utils.PercentMetric(rulesPerBusMetricName, utils.Utilization(float64(rc.Count), limit), rc.Dimensions, now))
+	}
+	return out, nil
+}
+
+// listEventBuses returns the names of every event bus in the region
+func (j *RulesJob) listEventBuses(ctx context.Context) ([]string, error) {
+	var (
+		buses     []string
+		nextToken *string
+	)
+	for {
+		output, err := j.EventBridgeClient.ListEventBuses(ctx, &eventbridge.ListEventBusesInput{
+			NextToken: nextToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, bus := range output.EventBuses {
+			buses = append(buses, aws.ToString(bus.Name))
+		}
+		if output.NextToken == nil {
+			return buses, nil
+		}
+		nextToken = output.NextToken
+	}
+}
+
+// countRules counts the rules of a single event bus
+func (j *RulesJob) countRules(ctx context.Context, bus string) (int64, error) {
+	var (
+		total     int64
+		nextToken *string
+	)
+	for {
+		output, err := j.EventBridgeClient.ListRules(ctx, &eventbridge.ListRulesInput{
+			EventBusName: aws.String(bus),
+			NextToken:    nextToken,
+		})
+		if err != nil {
+			return 0, err
+		}
+		total += int64(len(output.Rules))
+		if output.NextToken == nil {
+			return total, nil
+		}
+		nextToken = output.NextToken
+	}
+}
+
+// GetJobName return the name of the job
+func (j *RulesJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *RulesJob) GetRegion() string {
+	return j.region
+}
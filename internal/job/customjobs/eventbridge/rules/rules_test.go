@@ -0,0 +1,76 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	ebTypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/eventbridgeclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeEventBridgeClient builds a fake with n buses over two pages, bus i owning
+// i*10 rules over two pages
+func newFakeEventBridgeClient(n int) *eventbridgeclient.FakeEventBridgeClient {
+	f := &eventbridgeclient.FakeEventBridgeClient{
+		Region:                     "us-east-1",
+		ListEventBusesPageOutputs:  []*eventbridge.ListEventBusesOutput{{}, {}},
+		ListRulesPageOutputs:       map[string][]*eventbridge.ListRulesOutput{},
+		ErrOnListEventBusesCall:    -1,
+		ErrOnListRulesCall:         -1,
+		ErrOnListTargetsByRuleCall: -1,
+	}
+	for i := 1; i <= n; i++ {
+		bus := fmt.Sprintf("bus%02d", i)
+		page := f.ListEventBusesPageOutputs[i%2]
+		page.EventBuses = append(page.EventBuses, ebTypes.EventBus{Name: aws.String(bus)})
+		f.ListRulesPageOutputs[bus] = []*eventbridge.ListRulesOutput{
+			{Rules: make([]ebTypes.Rule, i*5)},
+			{Rules: make([]ebTypes.Rule, i*5)},
+		}
+	}
+	return f
+}
+
+func TestRulesJob_Execute(t *testing.T) {
+	ebFake := newFakeEventBridgeClient(topEventBuses + 2)
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 200}
+	j, err := NewRulesJob(RulesJobConfig{EventBridgeClient: ebFake, ServiceQuotasClient: sqFake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, rulesJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, metrics, topEventBuses)
+
+	cases := []struct {
+		bus   string
+		value float64
+	}{
+		{"bus12", 60},
+		{"bus03", 15},
+	}
+	for _, tc := range cases {
+		m, ok := jobtest.FindMetric(metrics, rulesPerBusMetricName, eventBusDimension, tc.bus)
+		if assert.True(t, ok, "missing metric for %s", tc.bus) {
+			assert.InDelta(t, tc.value, m.Value, 0.001, tc.bus)
+		}
+	}
+	_, ok := jobtest.FindMetric(metrics, rulesPerBusMetricName, eventBusDimension, "bus01")
+	assert.False(t, ok, "buses with the fewest rules should be dropped")
+	assert.Equal(t, []string{rulesQuota.QuotaCode}, sqFake.RequestedQuotaCodes)
+}
+
+func TestRulesJob_Error(t *testing.T) {
+	ebFake := newFakeEventBridgeClient(3)
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 200}
+	ebFake.ErrOnListEventBusesCall = 1
+	j, _ := NewRulesJob(RulesJobConfig{EventBridgeClient: ebFake, ServiceQuotasClient: sqFake})
+	jobtest.AssertExecuteFails(t, j)
+}
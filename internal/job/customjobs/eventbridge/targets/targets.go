@@ -0,0 +1,172 @@
+package targets
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/eventbridgeclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// TargetsJob will implement the Job interface
+// It lists the targets of every rule on every event bus and emits the rules with the
+// most targets against the fixed targets per rule maximum.
+
+type TargetsJob struct {
+	EventBridgeClient eventbridgeclient.EventBridgeClient
+	jobName           string
+	region            string
+	Logger            logger.Logger
+}
+
+type TargetsJobConfig struct {
+	EventBridgeClient eventbridgeclient.EventBridgeClient
+	Logger            logger.Logger
+}
+
+const (
+	targetsJobPrefix  = "eventbridgeTargets"
+	eventBusDimension = "eventBus"
+	ruleDimension     = "rule"
+	// maximum number of targets per rule
+	maxTargetsPerRule = 5
+	// only the rules with the most targets get a metric
+	topRules = 10
+
+	// cloudwatch metric names
+	targetsPerRuleMetricName = "eventbridgeTargetsPerRule"
+)
+
+// NewTargetsJob will create a new TargetsJob
+func NewTargetsJob(config TargetsJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	job := &TargetsJob{
+		EventBridgeClient: config.EventBridgeClient,
+		jobName:           targetsJobPrefix + "-" + config.EventBridgeClient.GetRegion(),
+		region:            config.EventBridgeClient.GetRegion(),
+		Logger:            config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns targets per rule utilization for the fullest rules
+func (j *TargetsJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	buses, err := j.listEventBuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var counts []utils.ResourceCount
+	for _, bus := range buses {
+		rules, err := j.listRules(ctx, bus)
+		if err != nil {
+			return nil, err
+		}
+		for _, rule := range rules {
+			count, err := j.countTargets(ctx, bus, rule)
+			if err != nil {
+				return nil, err
+			}
+			counts = append(counts, utils.ResourceCount{
+				Name:       bus + "/" + rule,
+				Count:      count,
+				Dimensions: map[string]string{eventBusDimension: bus, ruleDimension: rule},
+			})
+		}
+	}
+	j.Logger.Debug("%s rules : %d", j.GetJobName(), len(counts))
+
+	now := time.Now()
+	var out []sharedtypes.CloudWatchMetric
+	for _, rc := range utils.TopResourceCounts(counts, topRules) {
+		out = append(out, utils.PercentMetric(targetsPerRuleMetricName, utils.Utilization(float64(rc.Count), maxTargetsPerRule), rc.Dimensions, now))
+	}
+	return out, nil
+}
+
+// listEventBuses returns the names of every event bus in the region
+func (j *TargetsJob) listEventBuses(ctx context.Context) ([]string, error) {
+	var (
+		buses     []string
+		nextToken *string
+	)
+	for {
+		output, err := j.EventBridgeClient.ListEventBuses(ctx, &eventbridge.ListEventBusesInput{
+			NextToken: nextToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, bus := range output.EventBuses {
+			buses = append(buses, aws.ToString(bus.Name))
+		}
+		if output.NextToken == nil {
+			return buses, nil
+		}
+		nextToken = output.NextToken
+	}
+}
+
+// listRules returns the names of the rules of a single event bus
+func (j *TargetsJob) listRules(ctx context.Context, bus string) ([]string, error) {
+	var (
+		rules     []string
+		nextToken *string
+	)
+	for {
+		output, err := j.EventBridgeClient.ListRules(ctx, &eventbridge.ListRulesInput{
+			EventBusName: aws.String(bus),
+			NextToken:    nextToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, rule := range output.Rules {
+			rules = append(rules, aws.ToString(rule.Name))
+		}
+		if output.NextToken == nil {
+			return rules, nil
+		}
+		nextToken = output.NextToken
+	}
+}
+
+// countTargets counts the targets of a single rule
+func (j *TargetsJob) countTargets(ctx context.Context, bus, rule string) (int64, error) {
+	var (
+		total     int64
+		nextToken *string
+	)
+	for {
+		output, err := j.EventBridgeClient.ListTargetsByRule(ctx, &eventbridge.ListTargetsByRuleInput{
+			EventBusName: aws.String(bus),
+			Rule:         aws.String(rule),
+			NextToken:    nextToken,
+		})
+		if err != nil {
+			return 0, err
+		}
+		total += int64(len(output.Targets))
+		if output.NextToken == nil {
+			return total, nil
+		}
+		nextToken = output.NextToken
+	}
+}
+
+// GetJobName return the name of the job
+func (j *TargetsJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *TargetsJob) GetRegion() string {
+	return j.region
+}
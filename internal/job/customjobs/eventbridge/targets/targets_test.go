@@ -0,0 +1,81 @@
+package targets
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	ebTypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/eventbridgeclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeEventBridgeClient builds a fake with two buses of six rules each, rule i
+// having i%6 targets, with the rules of each bus split over two pages
+func newFakeEventBridgeClient() *eventbridgeclient.FakeEventBridgeClient {
+	f := &eventbridgeclient.FakeEventBridgeClient{
+		Region: "us-east-1",
+		ListEventBusesPageOutputs: []*eventbridge.ListEventBusesOutput{
+			{EventBuses: []ebTypes.EventBus{{Name: aws.String("default")}}},
+			{EventBuses: []ebTypes.EventBus{{Name: aws.String("orders")}}},
+		},
+		ListRulesPageOutputs:         map[string][]*eventbridge.ListRulesOutput{},
+		ListTargetsByRulePageOutputs: map[string]map[string][]*eventbridge.ListTargetsByRuleOutput{},
+		ErrOnListEventBusesCall:      -1,
+		ErrOnListRulesCall:           -1,
+		ErrOnListTargetsByRuleCall:   -1,
+	}
+	for _, bus := range []string{"default", "orders"} {
+		f.ListRulesPageOutputs[bus] = []*eventbridge.ListRulesOutput{{}, {}}
+		f.ListTargetsByRulePageOutputs[bus] = map[string][]*eventbridge.ListTargetsByRuleOutput{}
+		for i := 0; i < 6; i++ {
+			rule := fmt.Sprintf("rule%d", i)
+			page := f.ListRulesPageOutputs[bus][i%2]
+			page.Rules = append(page.Rules, ebTypes.Rule{Name: aws.String(rule)})
+			f.ListTargetsByRulePageOutputs[bus][rule] = []*eventbridge.ListTargetsByRuleOutput{
+				{Targets: make([]ebTypes.Target, i%6)},
+			}
+		}
+	}
+	return f
+}
+
+func TestTargetsJob_Execute(t *testing.T) {
+	ebFake := newFakeEventBridgeClient()
+	j, err := NewTargetsJob(TargetsJobConfig{EventBridgeClient: ebFake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, targetsJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, metrics, topRules)
+
+	cases := []struct {
+		bus   string
+		rule  string
+		value float64
+	}{
+		{"default", "rule5", 100},
+		{"orders", "rule5", 100},
+		{"orders", "rule1", 20},
+	}
+	for _, tc := range cases {
+		m, ok := jobtest.FindMetric(metrics, targetsPerRuleMetricName, eventBusDimension, tc.bus, ruleDimension, tc.rule)
+		if assert.True(t, ok, "missing metric for %s/%s", tc.bus, tc.rule) {
+			assert.InDelta(t, tc.value, m.Value, 0.001, "%s/%s", tc.bus, tc.rule)
+		}
+	}
+	_, ok := jobtest.FindMetric(metrics, targetsPerRuleMetricName, eventBusDimension, "default", ruleDimension, "rule0")
+	assert.False(t, ok, "rules without targets should be dropped")
+}
+
+func TestTargetsJob_Error(t *testing.T) {
+	ebFake := newFakeEventBridgeClient()
+	ebFake.ErrOnListEventBusesCall = 0
+	j, _ := NewTargetsJob(TargetsJobConfig{EventBridgeClient: ebFake})
+	jobtest.AssertExecuteFails(t, j)
+}
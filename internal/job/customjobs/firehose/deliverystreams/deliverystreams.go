@@ -0,0 +1,110 @@
+package deliverystreams
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/firehose"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/firehoseclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// DeliveryStreamsJob will implement the Job interface
+// It counts the Firehose delivery streams of the region against the delivery streams quota.
+
+type DeliveryStreamsJob struct {
+	FirehoseClient      firehoseclient.FirehoseClient
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	jobName             string
+	region              string
+	Logger              logger.Logger
+}
+
+type DeliveryStreamsJobConfig struct {
+	FirehoseClient      firehoseclient.FirehoseClient
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	Logger              logger.Logger
+}
+
+const (
+	deliveryStreamsJobPrefix = "firehoseDeliveryStreams"
+	serviceCode              = "firehose"
+
+	// cloudwatch metric names
+	deliveryStreamsMetricName = "firehoseDeliveryStreams"
+)
+
+var (
+	// Delivery streams per region
+	deliveryStreamsQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, Default: 5000}
+)
+
+// NewDeliveryStreamsJob will create a new DeliveryStreamsJob
+func NewDeliveryStreamsJob(config DeliveryStreamsJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	job := &DeliveryStreamsJob{
+		FirehoseClient:      config.FirehoseClient,
+		ServiceQuotasClient: config.ServiceQuotasClient,
+		jobName:             deliveryStreamsJobPrefix + "-" + config.FirehoseClient.GetRegion(),
+		region:              config.FirehoseClient.GetRegion(),
+		Logger:              config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns delivery stream utilization
+func (j *DeliveryStreamsJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	streams, err := j.countDeliveryStreams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := servicequotaclient.GetQuotaValue(ctx, j.ServiceQuotasClient, deliveryStreamsQuota)
+	if err != nil {
+		return nil, err
+	}
+	j.Logger.Debug("%s delivery streams : %d/%.0f", j.GetJobName(), streams, limit)
+
+	return []sharedtypes.CloudWatchMetric{
+		utils.PercentMetric(deliveryStreamsMetricName, utils.Utilization(float64(streams), limit), nil, time.Now()),
+	}, nil
+}
+
+// countDeliveryStreams counts the delivery streams of the region.
+// firehose has no paginator, each page continues after the last stream name of the previous one.
+func (j *DeliveryStreamsJob) countDeliveryStreams(ctx context.Context) (int, error) {
+	var (
+		total int
+		start *string
+	)
+	for {
+		output, err := j.FirehoseClient.ListDeliveryStreams(ctx, &firehose.ListDeliveryStreamsInput{
+			ExclusiveStartDeliveryStreamName: start,
+		})
+		if err != nil {
+			return 0, err
+		}
+		total += len(output.DeliveryStreamNames)
+		if !aws.ToBool(output.HasMoreDeliveryStreams) || len(output.DeliveryStreamNames) == 0 {
+			return total, nil
+		}
+		start = aws.String(output.DeliveryStreamNames[len(output.DeliveryStreamNames)-1])
+	}
+}
+
+// GetJobName return the name of the job
+func (j *DeliveryStreamsJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *DeliveryStreamsJob) GetRegion() string {
+	return j.region
+}
@@ -0,0 +1,48 @@
+package deliverystreams
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/firehose"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/firehoseclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeFirehoseClient builds a fake with 5 delivery streams over two pages
+func newFakeFirehoseClient() *firehoseclient.FakeFirehoseClient {
+	return &firehoseclient.FakeFirehoseClient{
+		Region: "us-east-1",
+		ListDeliveryStreamsPageOutputs: []*firehose.ListDeliveryStreamsOutput{
+			{DeliveryStreamNames: []string{"a", "b", "c"}},
+			{DeliveryStreamNames: []string{"d", "e"}},
+		},
+		ErrOnListDeliveryStreamsCall: -1,
+	}
+}
+
+func TestDeliveryStreamsJob_Execute(t *testing.T) {
+	firehoseFake := newFakeFirehoseClient()
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1"}
+	j, err := NewDeliveryStreamsJob(DeliveryStreamsJobConfig{FirehoseClient: firehoseFake, ServiceQuotasClient: sqFake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, deliveryStreamsJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, metrics, 1) {
+		assert.Equal(t, deliveryStreamsMetricName, metrics[0].Name)
+		assert.InDelta(t, 0.1, metrics[0].Value, 0.001)
+	}
+}
+
+func TestDeliveryStreamsJob_Error(t *testing.T) {
+	firehoseFake := newFakeFirehoseClient()
+	firehoseFake.ErrOnListDeliveryStreamsCall = 1
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1"}
+	j, _ := NewDeliveryStreamsJob(DeliveryStreamsJobConfig{FirehoseClient: firehoseFake, ServiceQuotasClient: sqFake})
+	jobtest.AssertExecuteFails(t, j)
+}
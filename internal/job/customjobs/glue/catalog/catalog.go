@@ -0,0 +1,124 @@
+package catalog
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/glue"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/glueclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// CatalogJob will implement the Job interface
+// It compares the data catalog databases and tables of the region against their account quotas.
+// Tables are listed per database, so this job is kept apart from the glue jobs count.
+
+type CatalogJob struct {
+	GlueClient          glueclient.GlueClient
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	jobName             string
+	region              string
+	Logger              logger.Logger
+}
+
+type CatalogJobConfig struct {
+	GlueClient          glueclient.GlueClient
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	Logger              logger.Logger
+}
+
+const (
+	catalogJobPrefix = "glueCatalog"
+	serviceCode      = "glue"
+
+	// cloudwatch metric names
+	databasesMetricName = "glueDatabases"
+	tablesMetricName    = "glueTables"
+)
+
+var (
+	// Databases per account
+	databasesQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, Default: 10000}
+	// Tables per account
+	tablesQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, Default: 1000000}
+)
+
+// NewCatalogJob will create a new CatalogJob
+func NewCatalogJob(config CatalogJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	job := &CatalogJob{
+		GlueClient:          config.GlueClient,
+		ServiceQuotasClient: config.ServiceQuotasClient,
+		jobName:             catalogJobPrefix + "-" + config.GlueClient.GetRegion(),
+		region:              config.GlueClient.GetRegion(),
+		Logger:              config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns database and table utilization
+func (j *CatalogJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	var names []string
+	databases := glue.NewGetDatabasesPaginator(j.GlueClient, &glue.GetDatabasesInput{})
+	for databases.HasMorePages() {
+		output, err := databases.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, db := range output.DatabaseList {
+			names = append(names, aws.ToString(db.Name))
+		}
+	}
+
+	tables := 0
+	for _, name := range names {
+		paginator := glue.NewGetTablesPaginator(j.GlueClient, &glue.GetTablesInput{
+			DatabaseName: aws.String(name),
+		})
+		for paginator.HasMorePages() {
+			output, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, err
+			}
+			tables += len(output.TableList)
+		}
+	}
+
+	usages := []struct {
+		name  string
+		count int
+		quota servicequotaclient.QuotaSpec
+	}{
+		{databasesMetricName, len(names), databasesQuota},
+		{tablesMetricName, tables, tablesQuota},
+	}
+
+	now := time.Now()
+	out := make([]sharedtypes.CloudWatchMetric, 0, len(usages))
+	for _, u := range usages {
+		limit, err := servicequotaclient.GetQuotaValue(ctx, j.ServiceQuotasClient, u.quota)
+		if err != nil {
+			return nil, err
+		}
+		j.Logger.Debug("%s %s : %d/%.0f", j.GetJobName(), u.name, u.count, limit)
+		out = append(out, utils.PercentMetric(u.name, utils.Utilization(float64(u.count), limit), nil, now))
+	}
+	return out, nil
+}
+
+// GetJobName return the name of the job
+func (j *CatalogJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *CatalogJob) GetRegion() string {
+	return j.region
+}
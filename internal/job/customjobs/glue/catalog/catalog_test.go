@@ -0,0 +1,68 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/glue"
+	glueTypes "github.com/aws/aws-sdk-go-v2/service/glue/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/glueclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeGlueClient builds a fake with 3 databases over two pages holding 10000 tables,
+// the tables of sales spread over two pages
+func newFakeGlueClient() *glueclient.FakeGlueClient {
+	return &glueclient.FakeGlueClient{
+		Region: "us-east-1",
+		GetDatabasesPageOutputs: []*glue.GetDatabasesOutput{
+			{DatabaseList: []glueTypes.Database{{Name: aws.String("sales")}, {Name: aws.String("ops")}}},
+			{DatabaseList: []glueTypes.Database{{Name: aws.String("empty")}}},
+		},
+		GetTablesPageOutputs: map[string][]*glue.GetTablesOutput{
+			"sales": {
+				{TableList: make([]glueTypes.Table, 6000)},
+				{TableList: make([]glueTypes.Table, 3000)},
+			},
+			"ops": {
+				{TableList: make([]glueTypes.Table, 1000)},
+			},
+		},
+		ErrOnGetDatabasesCall: -1,
+		ErrOnGetTablesCall:    -1,
+		ErrOnListJobsCall:     -1,
+	}
+}
+
+func TestCatalogJob_Execute(t *testing.T) {
+	glueFake := newFakeGlueClient()
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1"}
+	j, err := NewCatalogJob(CatalogJobConfig{GlueClient: glueFake, ServiceQuotasClient: sqFake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, catalogJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, metrics, 2)
+
+	m, ok := jobtest.FindMetric(metrics, databasesMetricName)
+	if assert.True(t, ok) {
+		assert.InDelta(t, 0.03, m.Value, 0.0001)
+	}
+	m, ok = jobtest.FindMetric(metrics, tablesMetricName)
+	if assert.True(t, ok) {
+		assert.InDelta(t, 1, m.Value, 0.0001)
+	}
+}
+
+func TestCatalogJob_Error(t *testing.T) {
+	glueFake := newFakeGlueClient()
+	glueFake.ErrOnGetDatabasesCall = 0
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1"}
+	j, _ := NewCatalogJob(CatalogJobConfig{GlueClient: glueFake, ServiceQuotasClient: sqFake})
+	jobtest.AssertExecuteFails(t, j)
+}
@@ -0,0 +1,92 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/glue"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/glueclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// JobsJob will implement the Job interface
+// It counts the Glue jobs of the region against the jobs quota.
+
+type JobsJob struct {
+	GlueClient          glueclient.GlueClient
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	jobName             string
+	region              string
+	Logger              logger.Logger
+}
+
+type JobsJobConfig struct {
+	GlueClient          glueclient.GlueClient
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	Logger              logger.Logger
+}
+
+const (
+	jobsJobPrefix = "glueJobs"
+	serviceCode   = "glue"
+
+	// cloudwatch metric names
+	jobsMetricName = "glueJobs"
+)
+
+var (
+	// Jobs per account
+	jobsQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, Default: 1000}
+)
+
+// NewJobsJob will create a new JobsJob
+func NewJobsJob(config JobsJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	job := &JobsJob{
+		GlueClient:          config.GlueClient,
+		ServiceQuotasClient: config.ServiceQuotasClient,
+		jobName:             jobsJobPrefix + "-" + config.GlueClient.GetRegion(),
+		region:              config.GlueClient.GetRegion(),
+		Logger:              config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns job utilization
+func (j *JobsJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	jobs := 0
+	paginator := glue.NewListJobsPaginator(j.GlueClient, &glue.ListJobsInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		jobs += len(output.JobNames)
+	}
+
+	limit, err := servicequotaclient.GetQuotaValue(ctx, j.ServiceQuotasClient, jobsQuota)
+	if err != nil {
+		return nil, err
+	}
+	j.Logger.Debug("%s jobs : %d/%.0f", j.GetJobName(), jobs, limit)
+
+	return []sharedtypes.CloudWatchMetric{
+		utils.PercentMetric(jobsMetricName, utils.Utilization(float64(jobs), limit), nil, time.Now()),
+	}, nil
+}
+
+// GetJobName return the name of the job
+func (j *JobsJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *JobsJob) GetRegion() string {
+	return j.region
+}
@@ -0,0 +1,50 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/glue"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/glueclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeGlueClient builds a fake with 10 jobs over two pages
+func newFakeGlueClient() *glueclient.FakeGlueClient {
+	return &glueclient.FakeGlueClient{
+		Region: "us-east-1",
+		ListJobsPageOutputs: []*glue.ListJobsOutput{
+			{JobNames: make([]string, 6)},
+			{JobNames: make([]string, 4)},
+		},
+		ErrOnGetDatabasesCall: -1,
+		ErrOnGetTablesCall:    -1,
+		ErrOnListJobsCall:     -1,
+	}
+}
+
+func TestJobsJob_Execute(t *testing.T) {
+	glueFake := newFakeGlueClient()
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1"}
+	j, err := NewJobsJob(JobsJobConfig{GlueClient: glueFake, ServiceQuotasClient: sqFake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, jobsJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, metrics, 1) {
+		assert.Equal(t, jobsMetricName, metrics[0].Name)
+		assert.InDelta(t, 1, metrics[0].Value, 0.001)
+	}
+}
+
+func TestJobsJob_Error(t *testing.T) {
+	glueFake := newFakeGlueClient()
+	glueFake.ErrOnListJobsCall = 1
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1"}
+	j, _ := NewJobsJob(JobsJobConfig{GlueClient: glueFake, ServiceQuotasClient: sqFake})
+	jobtest.AssertExecuteFails(t, j)
+}
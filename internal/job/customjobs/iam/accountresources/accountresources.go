@@ -0,0 +1,116 @@
+package accountresources
+
+import (
+	"context"
+	"time"
+
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/iamclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/iam/inventory"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// AccountResourcesJob will implement the Job interface
+// It compares the account wide IAM customer managed policies, instance profiles, SAML providers
+// and server certificates against their quotas. The counts come from the shared IAM inventory.
+
+type AccountResourcesJob struct {
+	Inventory           *inventory.Inventory
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	jobName             string
+	region              string
+	Logger              logger.Logger
+}
+
+type AccountResourcesJobConfig struct {
+	IamClient iamclient.IamClient
+	// Inventory is shared with the other IAM jobs. When nil the job lists on its own.
+	Inventory           *inventory.Inventory
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	Logger              logger.Logger
+}
+
+const (
+	accountResourcesJobPrefix = "iamAccountResources"
+	serviceCode               = "iam"
+
+	// cloudwatch metric names
+	policiesMetricName           = "iamCustomerManagedPolicies"
+	instanceProfilesMetricName   = "iamInstanceProfiles"
+	samlProvidersMetricName      = "iamSAMLProviders"
+	serverCertificatesMetricName = "iamServerCertificates"
+)
+
+var (
+	// Customer managed policies per account
+	policiesQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, QuotaCode: "L-E95E4862", Default: 1500}
+	// Instance profiles per account
+	instanceProfilesQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, QuotaCode: "L-6E65F664", Default: 1000}
+	// SAML providers per account
+	samlProvidersQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, QuotaCode: "L-DB618D39", Default: 100}
+	// Server certificates per account
+	serverCertificatesQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, QuotaCode: "L-BF35879D", Default: 20}
+)
+
+// NewAccountResourcesJob will create a new AccountResourcesJob
+func NewAccountResourcesJob(config AccountResourcesJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	if config.Inventory == nil {
+		config.Inventory = inventory.NewInventory(config.IamClient)
+	}
+	// IAM quotas are read from us-east-1, the metrics are published in the region of the iam client
+	job := &AccountResourcesJob{
+		Inventory:           config.Inventory,
+		ServiceQuotasClient: config.ServiceQuotasClient,
+		jobName:             accountResourcesJobPrefix + "-" + config.IamClient.GetRegion(),
+		region:              config.IamClient.GetRegion(),
+		Logger:              config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns utilization of the account wide iam resources
+func (j *AccountResourcesJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	summary, err := j.Inventory.Summary(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	usages := []struct {
+		name  string
+		count int64
+		quota servicequotaclient.QuotaSpec
+	}{
+		{policiesMetricName, summary.CustomerManagedPolicies, policiesQuota},
+		{instanceProfilesMetricName, summary.InstanceProfiles, instanceProfilesQuota},
+		{samlProvidersMetricName, summary.SAMLProviders, samlProvidersQuota},
+		{serverCertificatesMetricName, summary.ServerCertificates, serverCertificatesQuota},
+	}
+
+	now := time.Now()
+	out := make([]sharedtypes.CloudWatchMetric, 0, len(usages))
+	for _, u := range usages {
+		limit, err := servicequotaclient.GetQuotaValue(ctx, j.ServiceQuotasClient, u.quota)
+		if err != nil {
+			return nil, err
+		}
+		j.Logger.Debug("%s %s : %d/%.0f", j.GetJobName(), u.name, u.count, limit)
+		out = append(out, utils.PercentMetric(u.name, utils.Utilization(float64(u.count), limit), nil, now))
+	}
+	return out, nil
+}
+
+// GetJobName return the name of the job
+func (j *AccountResourcesJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *AccountResourcesJob) GetRegion() string {
+	return j.region
+}
@@ -0,0 +1,94 @@
+package accountresources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamTypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/iamclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/iam/inventory"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeIamClient builds a fake with 20 policies, 10 instance profiles, 5 SAML providers and 2 server certificates
+func newFakeIamClient() *iamclient.FakeIamClient {
+	return &iamclient.FakeIamClient{
+		Region:        "eu-west-1",
+		SAMLProviders: make([]iamTypes.SAMLProviderListEntry, 5),
+		ListServerCertificatesPageOutputs: []*iam.ListServerCertificatesOutput{
+			{ServerCertificateMetadataList: make([]iamTypes.ServerCertificateMetadata, 2)},
+		},
+		ListInstanceProfilesPageOutputs: []*iam.ListInstanceProfilesOutput{
+			{InstanceProfiles: make([]iamTypes.InstanceProfile, 10)},
+		},
+		ListPoliciesPageOutputs: []*iam.ListPoliciesOutput{
+			{Policies: make([]iamTypes.Policy, 15)},
+			{Policies: make([]iamTypes.Policy, 5)},
+		},
+		ErrOnListRolesCall:                -1,
+		ErrOnListPoliciesCall:             -1,
+		ErrOnListInstanceProfilesCall:     -1,
+		ErrOnListServerCertificatesCall:   -1,
+		ErrOnListAttachedRolePoliciesCall: -1,
+	}
+}
+
+func TestAccountResourcesJob_Execute(t *testing.T) {
+	iamFake := newFakeIamClient()
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 40}
+	j, err := NewAccountResourcesJob(AccountResourcesJobConfig{IamClient: iamFake, ServiceQuotasClient: sqFake})
+	assert.NoError(t, err)
+	// the metrics land in the region of the iam client, not the us-east-1 quota region
+	assert.Equal(t, "eu-west-1", j.GetRegion())
+	assert.Equal(t, accountResourcesJobPrefix+"-eu-west-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, metrics, 4)
+
+	cases := []struct {
+		name  string
+		value float64
+	}{
+		{policiesMetricName, 50},
+		{instanceProfilesMetricName, 25},
+		{samlProvidersMetricName, 12.5},
+		{serverCertificatesMetricName, 5},
+	}
+	for _, tc := range cases {
+		m, ok := jobtest.FindMetric(metrics, tc.name)
+		if assert.True(t, ok, "missing metric %s", tc.name) {
+			assert.InDelta(t, tc.value, m.Value, 0.001, tc.name)
+		}
+	}
+	assert.Equal(t, []string{
+		policiesQuota.QuotaCode,
+		instanceProfilesQuota.QuotaCode,
+		samlProvidersQuota.QuotaCode,
+		serverCertificatesQuota.QuotaCode,
+	}, sqFake.RequestedQuotaCodes)
+}
+
+func TestAccountResourcesJob_SharedInventory(t *testing.T) {
+	iamFake := newFakeIamClient()
+	inv := inventory.NewInventory(iamFake)
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 40}
+
+	for range 2 {
+		j, _ := NewAccountResourcesJob(AccountResourcesJobConfig{IamClient: iamFake, Inventory: inv, ServiceQuotasClient: sqFake})
+		_, err := j.Execute(context.Background())
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 1, iamFake.ListOpenIDConnectProvidersCalls(), "jobs sharing an inventory list iam once")
+}
+
+func TestAccountResourcesJob_Error(t *testing.T) {
+	iamFake := newFakeIamClient()
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 40}
+	iamFake.ErrListOpenIDConnectProviders = true
+	j, _ := NewAccountResourcesJob(AccountResourcesJobConfig{IamClient: iamFake, ServiceQuotasClient: sqFake})
+	jobtest.AssertExecuteFails(t, j)
+}
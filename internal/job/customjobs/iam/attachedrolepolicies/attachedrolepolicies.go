@@ -0,0 +1,99 @@
+package attachedrolepolicies
+
+import (
+	"context"
+	"time"
+
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/iamclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/iam/inventory"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// AttachedRolePoliciesJob will implement the Job interface
+// It emits the roles with the most attached managed policies against the managed policies per role quota.
+
+type AttachedRolePoliciesJob struct {
+	Inventory           *inventory.Inventory
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	jobName             string
+	region              string
+	Logger              logger.Logger
+}
+
+type AttachedRolePoliciesJobConfig struct {
+	IamClient iamclient.IamClient
+	// Inventory is shared with the other IAM jobs. When nil the job lists on its own.
+	Inventory           *inventory.Inventory
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	Logger              logger.Logger
+}
+
+const (
+	attachedRolePoliciesJobPrefix = "iamAttachedRolePolicies"
+	serviceCode                   = "iam"
+	roleDimension                 = "role"
+	// only the roles with the most attached policies get a metric
+	topRoles = 10
+
+	// cloudwatch metric names
+	managedPoliciesPerRoleMetricName = "iamManagedPoliciesPerRole"
+)
+
+var (
+	// Managed policies attached per role
+	managedPoliciesPerRoleQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, QuotaCode: "L-0DA4ABF3", Default: 10}
+)
+
+// NewAttachedRolePoliciesJob will create a new AttachedRolePoliciesJob
+func NewAttachedRolePoliciesJob(config AttachedRolePoliciesJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	if config.Inventory == nil {
+		config.Inventory = inventory.NewInventory(config.IamClient)
+	}
+	// IAM quotas are read from us-east-1, the metrics are published in the region of the iam client
+	job := &AttachedRolePoliciesJob{
+		Inventory:           config.Inventory,
+		ServiceQuotasClient: config.ServiceQuotasClient,
+		jobName:             attachedRolePoliciesJobPrefix + "-" + config.IamClient.GetRegion(),
+		region:              config.IamClient.GetRegion(),
+		Logger:              config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns managed policies per role utilization for the fullest roles
+func (j *AttachedRolePoliciesJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	counts, err := j.Inventory.AttachedPoliciesPerRole(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := servicequotaclient.GetQuotaValue(ctx, j.ServiceQuotasClient, managedPoliciesPerRoleQuota)
+	if err != nil {
+		return nil, err
+	}
+	j.Logger.Debug("%s roles : %d, managed policies per role quota : %.0f", j.GetJobName(), len(counts), limit)
+
+	now := time.Now()
+	var out []sharedtypes.CloudWatchMetric
+	for _, rc := range utils.TopResourceCounts(counts, topRoles) {
+		out = append(out, utils.PercentMetric(managedPoliciesPerRoleMetricName, utils.Utilization(float64(rc.Count), limit), map[string]string{roleDimension: rc.Name}, now))
+	}
+	return out, nil
+}
+
+// GetJobName return the name of the job
+func (j *AttachedRolePoliciesJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *AttachedRolePoliciesJob) GetRegion() string {
+	return j.region
+}
@@ -0,0 +1,70 @@
+package attachedrolepolicies
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamTypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/iamclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeIamClient builds a fake with n roles over two pages, role i having i attached policies
+func newFakeIamClient(n int) *iamclient.FakeIamClient {
+	f := &iamclient.FakeIamClient{
+		Region:                              "us-east-1",
+		ListRolesPageOutputs:                []*iam.ListRolesOutput{{}, {}},
+		ListAttachedRolePoliciesPageOutputs: map[string][]*iam.ListAttachedRolePoliciesOutput{},
+		ErrOnListRolesCall:                  -1,
+		ErrOnListPoliciesCall:               -1,
+		ErrOnListInstanceProfilesCall:       -1,
+		ErrOnListServerCertificatesCall:     -1,
+		ErrOnListAttachedRolePoliciesCall:   -1,
+	}
+	for i := 1; i <= n; i++ {
+		name := fmt.Sprintf("role%02d", i)
+		f.ListRolesPageOutputs[i%2].Roles = append(f.ListRolesPageOutputs[i%2].Roles, iamTypes.Role{RoleName: aws.String(name)})
+		f.ListAttachedRolePoliciesPageOutputs[name] = []*iam.ListAttachedRolePoliciesOutput{
+			{AttachedPolicies: make([]iamTypes.AttachedPolicy, i)},
+		}
+	}
+	return f
+}
+
+func TestAttachedRolePoliciesJob_Execute(t *testing.T) {
+	iamFake := newFakeIamClient(topRoles + 2)
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 20}
+	j, err := NewAttachedRolePoliciesJob(AttachedRolePoliciesJobConfig{IamClient: iamFake, ServiceQuotasClient: sqFake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, attachedRolePoliciesJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, metrics, topRoles)
+
+	m, ok := jobtest.FindMetric(metrics, managedPoliciesPerRoleMetricName, roleDimension, "role12")
+	if assert.True(t, ok) {
+		assert.InDelta(t, 60, m.Value, 0.001)
+	}
+	m, ok = jobtest.FindMetric(metrics, managedPoliciesPerRoleMetricName, roleDimension, "role03")
+	if assert.True(t, ok) {
+		assert.InDelta(t, 15, m.Value, 0.001)
+	}
+	_, ok = jobtest.FindMetric(metrics, managedPoliciesPerRoleMetricName, roleDimension, "role01")
+	assert.False(t, ok, "roles with the fewest policies should be dropped")
+	assert.Equal(t, []string{managedPoliciesPerRoleQuota.QuotaCode}, sqFake.RequestedQuotaCodes)
+}
+
+func TestAttachedRolePoliciesJob_Error(t *testing.T) {
+	iamFake := newFakeIamClient(4)
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 20}
+	iamFake.ErrOnListRolesCall = 1
+	j, _ := NewAttachedRolePoliciesJob(AttachedRolePoliciesJobConfig{IamClient: iamFake, ServiceQuotasClient: sqFake})
+	jobtest.AssertExecuteFails(t, j)
+}
@@ -0,0 +1,134 @@
+package inventory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamTypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/iamclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// Inventory lists the account wide IAM resources once per invocation and shares the
+// result between every IAM job built from it. The first job to ask does the listing,
+// the others wait for it and reuse the counts, including any error.
+type Inventory struct {
+	IamClient iamclient.IamClient
+
+	summaryOnce sync.Once
+	summary     Summary
+	summaryErr  error
+
+	rolesOnce sync.Once
+	roles     []utils.ResourceCount
+	rolesErr  error
+}
+
+// Summary holds the number of each account wide IAM resource
+type Summary struct {
+	OIDCProviders           int64
+	SAMLProviders           int64
+	ServerCertificates      int64
+	InstanceProfiles        int64
+	CustomerManagedPolicies int64
+}
+
+// NewInventory returns an Inventory backed by the given client
+func NewInventory(client iamclient.IamClient) *Inventory {
+	return &Inventory{IamClient: client}
+}
+
+// Summary returns the account wide resource counts, listing them on the first call
+func (i *Inventory) Summary(ctx context.Context) (Summary, error) {
+	i.summaryOnce.Do(func() {
+		i.summary, i.summaryErr = i.listSummary(ctx)
+	})
+	return i.summary, i.summaryErr
+}
+
+// AttachedPoliciesPerRole returns the number of managed policies attached to every role.
+// It needs one call per role so it is listed separately from Summary, and only when asked for.
+func (i *Inventory) AttachedPoliciesPerRole(ctx context.Context) ([]utils.ResourceCount, error) {
+	i.rolesOnce.Do(func() {
+		i.roles, i.rolesErr = i.listAttachedPoliciesPerRole(ctx)
+	})
+	return i.roles, i.rolesErr
+}
+
+func (i *Inventory) listSummary(ctx context.Context) (Summary, error) {
+	var s Summary
+
+	oidc, err := i.IamClient.ListOpenIDConnectProviders(ctx, &iam.ListOpenIDConnectProvidersInput{})
+	if err != nil {
+		return Summary{}, err
+	}
+	s.OIDCProviders = int64(len(oidc.OpenIDConnectProviderList))
+
+	saml, err := i.IamClient.ListSAMLProviders(ctx, &iam.ListSAMLProvidersInput{})
+	if err != nil {
+		return Summary{}, err
+	}
+	s.SAMLProviders = int64(len(saml.SAMLProviderList))
+
+	certs := iam.NewListServerCertificatesPaginator(i.IamClient, &iam.ListServerCertificatesInput{})
+	for certs.HasMorePages() {
+		output, err := certs.NextPage(ctx)
+		if err != nil {
+			return Summary{}, err
+		}
+		s.ServerCertificates += int64(len(output.ServerCertificateMetadataList))
+	}
+
+	profiles := iam.NewListInstanceProfilesPaginator(i.IamClient, &iam.ListInstanceProfilesInput{})
+	for profiles.HasMorePages() {
+		output, err := profiles.NextPage(ctx)
+		if err != nil {
+			return Summary{}, err
+		}
+		s.InstanceProfiles += int64(len(output.InstanceProfiles))
+	}
+
+	// local scope limits the listing to customer managed policies
+	policies := iam.NewListPoliciesPaginator(i.IamClient, &iam.ListPoliciesInput{Scope: iamTypes.PolicyScopeTypeLocal})
+	for policies.HasMorePages() {
+		output, err := policies.NextPage(ctx)
+		if err != nil {
+			return Summary{}, err
+		}
+		s.CustomerManagedPolicies += int64(len(output.Policies))
+	}
+	return s, nil
+}
+
+func (i *Inventory) listAttachedPoliciesPerRole(ctx context.Context) ([]utils.ResourceCount, error) {
+	var names []string
+	roles := iam.NewListRolesPaginator(i.IamClient, &iam.ListRolesInput{})
+	for roles.HasMorePages() {
+		output, err := roles.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, role := range output.Roles {
+			names = append(names, aws.ToString(role.RoleName))
+		}
+	}
+
+	counts := make([]utils.ResourceCount, 0, len(names))
+	for _, name := range names {
+		var attached int64
+		paginator := iam.NewListAttachedRolePoliciesPaginator(i.IamClient, &iam.ListAttachedRolePoliciesInput{
+			RoleName: aws.String(name),
+		})
+		for paginator.HasMorePages() {
+			output, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, err
+			}
+			attached += int64(len(output.AttachedPolicies))
+		}
+		counts = append(counts, utils.ResourceCount{Name: name, Count: attached})
+	}
+	return counts, nil
+}
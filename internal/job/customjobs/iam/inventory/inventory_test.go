@@ -0,0 +1,107 @@
+package inventory
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamTypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/iamclient"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeIamClient builds a fake with a few of every account wide resource and two roles
+func newFakeIamClient() *iamclient.FakeIamClient {
+	return &iamclient.FakeIamClient{
+		Region:        "us-east-1",
+		OIDCProviders: make([]iamTypes.OpenIDConnectProviderListEntry, 2),
+		SAMLProviders: make([]iamTypes.SAMLProviderListEntry, 3),
+		ListServerCertificatesPageOutputs: []*iam.ListServerCertificatesOutput{
+			{ServerCertificateMetadataList: make([]iamTypes.ServerCertificateMetadata, 1)},
+		},
+		ListInstanceProfilesPageOutputs: []*iam.ListInstanceProfilesOutput{
+			{InstanceProfiles: make([]iamTypes.InstanceProfile, 4)},
+			{InstanceProfiles: make([]iamTypes.InstanceProfile, 1)},
+		},
+		ListPoliciesPageOutputs: []*iam.ListPoliciesOutput{
+			{Policies: make([]iamTypes.Policy, 6)},
+			{Policies: make([]iamTypes.Policy, 4)},
+		},
+		ListRolesPageOutputs: []*iam.ListRolesOutput{
+			{Roles: []iamTypes.Role{{RoleName: aws.String("admin")}}},
+			{Roles: []iamTypes.Role{{RoleName: aws.String("reader")}}},
+		},
+		ListAttachedRolePoliciesPageOutputs: map[string][]*iam.ListAttachedRolePoliciesOutput{
+			"admin": {
+				{AttachedPolicies: make([]iamTypes.AttachedPolicy, 5)},
+				{AttachedPolicies: make([]iamTypes.AttachedPolicy, 2)},
+			},
+		},
+		ErrOnListRolesCall:                -1,
+		ErrOnListPoliciesCall:             -1,
+		ErrOnListInstanceProfilesCall:     -1,
+		ErrOnListServerCertificatesCall:   -1,
+		ErrOnListAttachedRolePoliciesCall: -1,
+	}
+}
+
+func TestInventory_Summary(t *testing.T) {
+	fake := newFakeIamClient()
+	inv := NewInventory(fake)
+
+	// every job asks at the same time, only one listing pass is made
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s, err := inv.Summary(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, Summary{
+				OIDCProviders:           2,
+				SAMLProviders:           3,
+				ServerCertificates:      1,
+				InstanceProfiles:        5,
+				CustomerManagedPolicies: 10,
+			}, s)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 1, fake.ListOpenIDConnectProvidersCalls())
+}
+
+func TestInventory_SummaryError(t *testing.T) {
+	fake := newFakeIamClient()
+	fake.ErrOnListPoliciesCall = 1
+	inv := NewInventory(fake)
+
+	_, err := inv.Summary(context.Background())
+	assert.Error(t, err)
+	// the error is shared too, the listing is not retried
+	_, err = inv.Summary(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, 1, fake.ListOpenIDConnectProvidersCalls())
+}
+
+func TestInventory_AttachedPoliciesPerRole(t *testing.T) {
+	fake := newFakeIamClient()
+	inv := NewInventory(fake)
+
+	counts, err := inv.AttachedPoliciesPerRole(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, counts, 2) {
+		assert.Equal(t, "admin", counts[0].Name)
+		assert.Equal(t, int64(7), counts[0].Count)
+		assert.Equal(t, "reader", counts[1].Name)
+		assert.Equal(t, int64(0), counts[1].Count)
+	}
+	// roles are listed independently of the summary
+	assert.Equal(t, 0, fake.ListOpenIDConnectProvidersCalls())
+
+	fake = newFakeIamClient()
+	fake.ErrOnListAttachedRolePoliciesCall = 1
+	_, err = NewInventory(fake).AttachedPoliciesPerRole(context.Background())
+	assert.Error(t, err)
+}
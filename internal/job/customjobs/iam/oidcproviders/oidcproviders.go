@@ -6,21 +6,23 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	cwTypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
-	"github.com/aws/aws-sdk-go-v2/service/iam"
 
 	"github.com/aws/aws-sdk-go-v2/service/servicequotas"
 	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/iamclient"
 	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
 	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/customjobs/iam/inventory"
 	"github.com/outofoffice3/aws-samples/geras/internal/logger"
 	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
 )
 
 // OIDCProvider will implement the Job interface
-// It will calculate the total number of OIDC providers in a given region
+// It will calculate the total number of OIDC providers in the account.
+// The providers are counted by the shared IAM inventory so the other IAM jobs reuse the same listing.
 
 type OIDCProviderJob struct {
 	IamClient           iamclient.IamClient
+	Inventory           *inventory.Inventory
 	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
 	jobName             string
 	region              string
@@ -28,7 +30,9 @@ type OIDCProviderJob struct {
 }
 
 type OIDCProviderJobConfig struct {
-	IamClient          iamclient.IamClient
+	IamClient iamclient.IamClient
+	// Inventory is shared with the other IAM jobs. When nil the job lists on its own.
+	Inventory          *inventory.Inventory
 	ServiceQuotasCliet servicequotaclient.ServiceQuotasClient
 	Logger             logger.Logger
 }
@@ -45,29 +49,33 @@ func NewOIDCProviderJob(config OIDCProviderJobConfig) (job.Job, error) {
 	if config.Logger == nil {
 		config.Logger = &logger.NoopLogger{}
 	}
+	if config.Inventory == nil {
+		config.Inventory = inventory.NewInventory(config.IamClient)
+	}
+	// IAM quotas are read from us-east-1, the metric is published in the region of the iam client
 	job := &OIDCProviderJob{
 		IamClient:           config.IamClient,
+		Inventory:           config.Inventory,
 		ServiceQuotasClient: config.ServiceQuotasCliet,
 		jobName:             oidcProvidersJobPrefix + "-" + config.IamClient.GetRegion(),
-		region:              config.ServiceQuotasCliet.GetRegion(),
+		region:              config.IamClient.GetRegion(),
 		Logger:              config.Logger,
 	}
 
 	return job, nil
 }
 
-// Execute will return the total number of OIDC providers in a given region
+// Execute will return the total number of OIDC providers in the account
 func (j *OIDCProviderJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
 
-	input := &iam.ListOpenIDConnectProvidersInput{}
 	var totalCouunt int64 = 0
-	// make call to list oidc providers and calculate total amount
-	oidcProvidersOutput, err := j.IamClient.ListOpenIDConnectProviders(ctx, input)
+	// the shared inventory lists oidc providers along with the other account wide iam resources
+	summary, err := j.Inventory.Summary(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	totalCouunt = int64(len(oidcProvidersOutput.OpenIDConnectProviderList))
+	totalCouunt = summary.OIDCProviders
 	j.Logger.Debug("%s total : %d", j.GetJobName(), totalCouunt)
 
 	// get the quota for oidc providers
@@ -48,6 +48,46 @@ func (f *fakeIAMClient) ListRoles(
 	return &iam.ListRolesOutput{}, nil
 }
 
+func (f *fakeIAMClient) ListPolicies(
+	ctx context.Context,
+	in *iam.ListPoliciesInput,
+	opts ...func(*iam.Options),
+) (*iam.ListPoliciesOutput, error) {
+	return &iam.ListPoliciesOutput{}, nil
+}
+
+func (f *fakeIAMClient) ListInstanceProfiles(
+	ctx context.Context,
+	in *iam.ListInstanceProfilesInput,
+	opts ...func(*iam.Options),
+) (*iam.ListInstanceProfilesOutput, error) {
+	return &iam.ListInstanceProfilesOutput{}, nil
+}
+
+func (f *fakeIAMClient) ListSAMLProviders(
+	ctx context.Context,
+	in *iam.ListSAMLProvidersInput,
+	opts ...func(*iam.Options),
+) (*iam.ListSAMLProvidersOutput, error) {
+	return &iam.ListSAMLProvidersOutput{}, nil
+}
+
+func (f *fakeIAMClient) ListServerCertificates(
+	ctx context.Context,
+	in *iam.ListServerCertificatesInput,
+	opts ...func(*iam.Options),
+) (*iam.ListServerCertificatesOutput, error) {
+	return &iam.ListServerCertificatesOutput{}, nil
+}
+
+func (f *fakeIAMClient) ListAttachedRolePolicies(
+	ctx context.Context,
+	in *iam.ListAttachedRolePoliciesInput,
+	opts ...func(*iam.Options),
+) (*iam.ListAttachedRolePoliciesOutput, error) {
+	return &iam.ListAttachedRolePoliciesOutput{}, nil
+}
+
 func (f *fakeIAMClient) GetRegion() string { return f.Region }
 
 // fakeQuotaClient implements servicequotaclient.ServiceQuotasClient
@@ -0,0 +1,91 @@
+package streamlimits
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/kinesisclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// StreamLimitsJob will implement the Job interface
+// Kinesis Data Streams reports usage and maximum together in DescribeLimits,
+// so utilization is computed without calling service quotas.
+
+type StreamLimitsJob struct {
+	KinesisClient kinesisclient.KinesisClient
+	jobName       string
+	region        string
+	Logger        logger.Logger
+}
+
+type StreamLimitsJobConfig struct {
+	KinesisClient kinesisclient.KinesisClient
+	Logger        logger.Logger
+}
+
+const (
+	streamLimitsJobPrefix = "kinesisStreamLimits"
+
+	// cloudwatch metric names
+	shardsMetricName          = "kinesisShards"
+	onDemandStreamsMetricName = "kinesisOnDemandStreams"
+)
+
+// NewStreamLimitsJob will create a new StreamLimitsJob
+func NewStreamLimitsJob(config StreamLimitsJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	job := &StreamLimitsJob{
+		KinesisClient: config.KinesisClient,
+		jobName:       streamLimitsJobPrefix + "-" + config.KinesisClient.GetRegion(),
+		region:        config.KinesisClient.GetRegion(),
+		Logger:        config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns open shard and on-demand stream utilization
+func (j *StreamLimitsJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	output, err := j.KinesisClient.DescribeLimits(ctx, &kinesis.DescribeLimitsInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	limits := []struct {
+		name      string
+		used, max *int32
+	}{
+		{shardsMetricName, output.OpenShardCount, output.ShardLimit},
+		{onDemandStreamsMetricName, output.OnDemandStreamCount, output.OnDemandStreamCountLimit},
+	}
+
+	now := time.Now()
+	var out []sharedtypes.CloudWatchMetric
+	for _, l := range limits {
+		if l.max == nil {
+			j.Logger.Warn("%s limits did not report a maximum for %s", j.GetJobName(), l.name)
+			continue
+		}
+		used, limit := aws.ToInt32(l.used), aws.ToInt32(l.max)
+		j.Logger.Debug("%s %s : %d/%d", j.GetJobName(), l.name, used, limit)
+		out = append(out, utils.PercentMetric(l.name, utils.Utilization(float64(used), float64(limit)), nil, now))
+	}
+	return out, nil
+}
+
+// GetJobName return the name of the job
+func (j *StreamLimitsJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *StreamLimitsJob) GetRegion() string {
+	return j.region
+}
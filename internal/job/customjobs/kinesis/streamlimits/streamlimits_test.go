@@ -0,0 +1,54 @@
+package streamlimits
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/kinesisclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamLimitsJob_Execute(t *testing.T) {
+	fake := &kinesisclient.FakeKinesisClient{
+		Region: "us-east-1",
+		Limits: &kinesis.DescribeLimitsOutput{
+			OpenShardCount:           aws.Int32(150),
+			ShardLimit:               aws.Int32(500),
+			OnDemandStreamCount:      aws.Int32(5),
+			OnDemandStreamCountLimit: aws.Int32(50),
+		},
+	}
+	j, err := NewStreamLimitsJob(StreamLimitsJobConfig{KinesisClient: fake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, streamLimitsJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, metrics, 2)
+
+	got := map[string]float64{}
+	for _, m := range metrics {
+		got[m.Name] = m.Value
+	}
+	assert.InDelta(t, 30, got[shardsMetricName], 0.001)
+	assert.InDelta(t, 10, got[onDemandStreamsMetricName], 0.001)
+}
+
+func TestStreamLimitsJob_MissingLimits(t *testing.T) {
+	fake := &kinesisclient.FakeKinesisClient{Region: "us-east-1"}
+	j, _ := NewStreamLimitsJob(StreamLimitsJobConfig{KinesisClient: fake})
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, metrics)
+}
+
+func TestStreamLimitsJob_Error(t *testing.T) {
+	fake := &kinesisclient.FakeKinesisClient{Region: "us-east-1", ErrDescribeLimits: true}
+	j, _ := NewStreamLimitsJob(StreamLimitsJobConfig{KinesisClient: fake})
+	jobtest.AssertExecuteFails(t, j)
+}
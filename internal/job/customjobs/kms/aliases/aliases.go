@@ -0,0 +1,105 @@
+package aliases
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/kmsclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// AliasesJob will implement the Job interface
+// It groups the customer aliases of the region by the key they point at and emits the keys
+// with the most aliases against the fixed aliases per key maximum.
+
+type AliasesJob struct {
+	KMSClient kmsclient.KMSClient
+	jobName   string
+	region    string
+	Logger    logger.Logger
+}
+
+type AliasesJobConfig struct {
+	KMSClient kmsclient.KMSClient
+	Logger    logger.Logger
+}
+
+const (
+	aliasesJobPrefix = "kmsAliases"
+	keyIdDimension   = "keyId"
+	// aliases of AWS managed keys start with this prefix
+	awsManagedAliasPrefix = "alias/aws/"
+	// maximum number of aliases per key
+	maxAliasesPerKey = 50
+	// only the keys with the most aliases get a metric
+	topKeys = 10
+
+	// cloudwatch metric names
+	aliasesPerKeyMetricName = "kmsAliasesPerKey"
+)
+
+// NewAliasesJob will create a new AliasesJob
+func NewAliasesJob(config AliasesJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	job := &AliasesJob{
+		KMSClient: config.KMSClient,
+		jobName:   aliasesJobPrefix + "-" + config.KMSClient.GetRegion(),
+		region:    config.KMSClient.GetRegion(),
+		Logger:    config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns aliases per key utilization for the keys with the most aliases
+func (j *AliasesJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	perKey := make(map[string]int64)
+	paginator := kms.NewListAliasesPaginator(j.KMSClient, &kms.ListAliasesInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, alias := range output.Aliases {
+			// skip AWS managed aliases and aliases not associated with a key
+			if strings.HasPrefix(aws.ToString(alias.AliasName), awsManagedAliasPrefix) || alias.TargetKeyId == nil {
+				continue
+			}
+			perKey[aws.ToString(alias.TargetKeyId)]++
+		}
+	}
+
+	counts := make([]utils.ResourceCount, 0, len(perKey))
+	for keyId, count := range perKey {
+		counts = append(counts, utils.ResourceCount{
+			Name:       keyId,
+			Count:      count,
+			Dimensions: map[string]string{keyIdDimension: keyId},
+		})
+	}
+	j.Logger.Debug("%s keys with aliases : %d", j.GetJobName(), len(counts))
+
+	now := time.Now()
+	var out []sharedtypes.CloudWatchMetric
+	for _, rc := range utils.TopResourceCounts(counts, topKeys) {
+		out = append(out, utils.PercentMetric(aliasesPerKeyMetricName, utils.Utilization(float64(rc.Count), maxAliasesPerKey), rc.Dimensions, now))
+	}
+	return out, nil
+}
+
+// GetJobName return the name of the job
+func (j *AliasesJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *AliasesJob) GetRegion() string {
+	return j.region
+}
@@ -0,0 +1,69 @@
+package aliases
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	kmsTypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/kmsclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeKMSClient builds a fake with n keys, key i having i aliases spread over two pages,
+// plus AWS managed and unassociated aliases that must be ignored
+func newFakeKMSClient(n int) *kmsclient.FakeKMSClient {
+	pages := []*kms.ListAliasesOutput{
+		{Aliases: []kmsTypes.AliasListEntry{
+			{AliasName: aws.String("alias/aws/s3"), TargetKeyId: aws.String("aws-key")},
+			{AliasName: aws.String("alias/aws/unused")},
+		}},
+		{},
+	}
+	for i := 1; i <= n; i++ {
+		id := fmt.Sprintf("key-%02d", i)
+		for k := 0; k < i; k++ {
+			pages[k%2].Aliases = append(pages[k%2].Aliases, kmsTypes.AliasListEntry{
+				AliasName:   aws.String(fmt.Sprintf("alias/%s-%d", id, k)),
+				TargetKeyId: aws.String(id),
+			})
+		}
+	}
+	return &kmsclient.FakeKMSClient{
+		Region:                 "us-east-1",
+		ListAliasesPageOutputs: pages,
+		ErrOnListKeysCall:      -1,
+		ErrOnListAliasesCall:   -1,
+	}
+}
+
+func TestAliasesJob_Execute(t *testing.T) {
+	kmsFake := newFakeKMSClient(topKeys + 2)
+	j, err := NewAliasesJob(AliasesJobConfig{KMSClient: kmsFake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, aliasesJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, metrics, topKeys)
+
+	m, ok := jobtest.FindMetric(metrics, aliasesPerKeyMetricName, keyIdDimension, "key-12")
+	if assert.True(t, ok) {
+		assert.InDelta(t, 24, m.Value, 0.001)
+	}
+	_, ok = jobtest.FindMetric(metrics, aliasesPerKeyMetricName, keyIdDimension, "aws-key")
+	assert.False(t, ok, "AWS managed aliases should be skipped")
+	_, ok = jobtest.FindMetric(metrics, aliasesPerKeyMetricName, keyIdDimension, "key-01")
+	assert.False(t, ok, "keys with the fewest aliases should be dropped")
+}
+
+func TestAliasesJob_Error(t *testing.T) {
+	kmsFake := newFakeKMSClient(3)
+	kmsFake.ErrOnListAliasesCall = 1
+	j, _ := NewAliasesJob(AliasesJobConfig{KMSClient: kmsFake})
+	jobtest.AssertExecuteFails(t, j)
+}
@@ -0,0 +1,116 @@
+package keys
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/kmsclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// KeysJob will implement the Job interface
+// It counts the customer managed keys of the region against the customer managed keys quota.
+// ListKeys also returns AWS managed keys, which do not count towards the quota. Those are
+// recognised by their alias/aws/ alias so no per key DescribeKey call is needed.
+
+type KeysJob struct {
+	KMSClient           kmsclient.KMSClient
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	jobName             string
+	region              string
+	Logger              logger.Logger
+}
+
+type KeysJobConfig struct {
+	KMSClient           kmsclient.KMSClient
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	Logger              logger.Logger
+}
+
+const (
+	keysJobPrefix = "kmsKeys"
+	serviceCode   = "kms"
+	// aliases of AWS managed keys start with this prefix
+	awsManagedAliasPrefix = "alias/aws/"
+
+	// cloudwatch metric names
+	keysMetricName = "kmsCustomerManagedKeys"
+)
+
+var (
+	// Customer managed keys per region
+	keysQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, QuotaCode: "L-C2F1777E", Default: 100000}
+)
+
+// NewKeysJob will create a new KeysJob
+func NewKeysJob(config KeysJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	job := &KeysJob{
+		KMSClient:           config.KMSClient,
+		ServiceQuotasClient: config.ServiceQuotasClient,
+		jobName:             keysJobPrefix + "-" + config.KMSClient.GetRegion(),
+		region:              config.KMSClient.GetRegion(),
+		Logger:              config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns customer managed key utilization
+func (j *KeysJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	awsManaged := make(map[string]struct{})
+	aliases := kms.NewListAliasesPaginator(j.KMSClient, &kms.ListAliasesInput{})
+	for aliases.HasMorePages() {
+		output, err := aliases.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, alias := range output.Aliases {
+			if strings.HasPrefix(aws.ToString(alias.AliasName), awsManagedAliasPrefix) && alias.TargetKeyId != nil {
+				awsManaged[aws.ToString(alias.TargetKeyId)] = struct{}{}
+			}
+		}
+	}
+
+	customerManaged := 0
+	keys := kms.NewListKeysPaginator(j.KMSClient, &kms.ListKeysInput{})
+	for keys.HasMorePages() {
+		output, err := keys.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range output.Keys {
+			if _, ok := awsManaged[aws.ToString(key.KeyId)]; !ok {
+				customerManaged++
+			}
+		}
+	}
+
+	limit, err := servicequotaclient.GetQuotaValue(ctx, j.ServiceQuotasClient, keysQuota)
+	if err != nil {
+		return nil, err
+	}
+	j.Logger.Debug("%s customer managed keys : %d/%.0f", j.GetJobName(), customerManaged, limit)
+
+	return []sharedtypes.CloudWatchMetric{
+		utils.PercentMetric(keysMetricName, utils.Utilization(float64(customerManaged), limit), nil, time.Now()),
+	}, nil
+}
+
+// GetJobName return the name of the job
+func (j *KeysJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *KeysJob) GetRegion() string {
+	return j.region
+}
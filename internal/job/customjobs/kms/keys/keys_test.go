@@ -0,0 +1,69 @@
+package keys
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	kmsTypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/kmsclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeKMSClient builds a fake with 30 keys over two pages, 5 of them AWS managed
+func newFakeKMSClient() *kmsclient.FakeKMSClient {
+	f := &kmsclient.FakeKMSClient{
+		Region:                 "us-east-1",
+		ListKeysPageOutputs:    []*kms.ListKeysOutput{{}, {}},
+		ListAliasesPageOutputs: []*kms.ListAliasesOutput{{}, {}},
+		ErrOnListKeysCall:      -1,
+		ErrOnListAliasesCall:   -1,
+	}
+	for i := 0; i < 30; i++ {
+		id := fmt.Sprintf("key-%02d", i)
+		f.ListKeysPageOutputs[i%2].Keys = append(f.ListKeysPageOutputs[i%2].Keys, kmsTypes.KeyListEntry{KeyId: aws.String(id)})
+		alias := fmt.Sprintf("alias/app-%02d", i)
+		if i < 5 {
+			alias = fmt.Sprintf("alias/aws/service-%02d", i)
+		}
+		f.ListAliasesPageOutputs[i%2].Aliases = append(f.ListAliasesPageOutputs[i%2].Aliases, kmsTypes.AliasListEntry{
+			AliasName:   aws.String(alias),
+			TargetKeyId: aws.String(id),
+		})
+	}
+	// an AWS managed alias that has not been created yet has no target key
+	f.ListAliasesPageOutputs[1].Aliases = append(f.ListAliasesPageOutputs[1].Aliases, kmsTypes.AliasListEntry{
+		AliasName: aws.String("alias/aws/unused"),
+	})
+	return f
+}
+
+func TestKeysJob_Execute(t *testing.T) {
+	kmsFake := newFakeKMSClient()
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 100}
+	j, err := NewKeysJob(KeysJobConfig{KMSClient: kmsFake, ServiceQuotasClient: sqFake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, keysJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, metrics, 1) {
+		assert.Equal(t, keysMetricName, metrics[0].Name)
+		// AWS managed keys are not counted
+		assert.InDelta(t, 25, metrics[0].Value, 0.001)
+	}
+	assert.Equal(t, []string{keysQuota.QuotaCode}, sqFake.RequestedQuotaCodes)
+}
+
+func TestKeysJob_Error(t *testing.T) {
+	kmsFake := newFakeKMSClient()
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 100}
+	kmsFake.ErrOnListAliasesCall = 1
+	j, _ := NewKeysJob(KeysJobConfig{KMSClient: kmsFake, ServiceQuotasClient: sqFake})
+	jobtest.AssertExecuteFails(t, j)
+}
@@ -0,0 +1,164 @@
+package accountsettings
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/lambdaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// AccountSettingsJob will implement the Job interface
+// It reads the lambda account limits and usage from GetAccountSettings and
+// emits code storage and reserved concurrency utilization.  The reserved
+// concurrency is what the account limit leaves out of the unreserved pool.
+// The functions with the most reserved concurrency also get their own metric,
+// which takes one GetFunctionConcurrency call per function, so at most
+// MaxFunctions functions are inspected and the scan stops once every reserved
+// unit is accounted for.
+// Lambda reports its own limits, so service quotas is not called.
+
+type AccountSettingsJob struct {
+	LambdaClient lambdaclient.LambdaClient
+	MaxFunctions int
+	jobName      string
+	region       string
+	Logger       logger.Logger
+}
+
+type AccountSettingsJobConfig struct {
+	LambdaClient lambdaclient.LambdaClient
+	// MaxFunctions bounds the functions inspected, defaults to defaultMaxFunctions
+	MaxFunctions int
+	Logger       logger.Logger
+}
+
+const (
+	accountSettingsJobPrefix = "lambdaAccountSettings"
+	functionDimension        = "function"
+	// only the functions with the most reserved concurrency get their own metric
+	topFunctions = 10
+	// GetFunctionConcurrency calls made at most per run
+	defaultMaxFunctions = 500
+
+	// cloudwatch metric names
+	codeStorageMetricName                 = "lambdaCodeStorage"
+	reservedConcurrencyMetricName         = "lambdaReservedConcurrency"
+	functionReservedConcurrencyMetricName = "lambdaFunctionReservedConcurrency"
+)
+
+// NewAccountSettingsJob will create a new AccountSettingsJob
+func NewAccountSettingsJob(config AccountSettingsJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	if config.MaxFunctions <= 0 {
+		config.MaxFunctions = defaultMaxFunctions
+	}
+	job := &AccountSettingsJob{
+		LambdaClient: config.LambdaClient,
+		MaxFunctions: config.MaxFunctions,
+		jobName:      accountSettingsJobPrefix + "-" + config.LambdaClient.GetRegion(),
+		region:       config.LambdaClient.GetRegion(),
+		Logger:       config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns code storage, reserved concurrency and per-function reserved concurrency metrics
+func (j *AccountSettingsJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	settings, err := j.LambdaClient.GetAccountSettings(ctx, &lambda.GetAccountSettingsInput{})
+	if err != nil {
+		return nil, err
+	}
+	if settings.AccountLimit == nil || settings.AccountUsage == nil {
+		return nil, errors.New("lambda account settings missing account limit or usage")
+	}
+	limit := settings.AccountLimit
+	usage := settings.AccountUsage
+
+	// the unreserved pool shrinks as functions reserve concurrency
+	concurrencyLimit := float64(limit.ConcurrentExecutions)
+	reserved := 0.0
+	if limit.UnreservedConcurrentExecutions != nil {
+		reserved = concurrencyLimit - float64(aws.ToInt32(limit.UnreservedConcurrentExecutions))
+	}
+	j.Logger.Debug("%s code storage %d/%d bytes, reserved concurrency %.0f/%.0f", j.GetJobName(),
+		usage.TotalCodeSize, limit.TotalCodeSize, reserved, concurrencyLimit)
+
+	reservations, err := j.listReservations(ctx, int64(reserved))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	out := []sharedtypes.CloudWatchMetric{
+		utils.PercentMetric(codeStorageMetricName, utils.Utilization(float64(usage.TotalCodeSize), float64(limit.TotalCodeSize)), nil, now),
+		utils.PercentMetric(reservedConcurrencyMetricName, utils.Utilization(reserved, concurrencyLimit), nil, now),
+	}
+	for _, rc := range utils.TopResourceCounts(reservations, topFunctions) {
+		out = append(out, utils.PercentMetric(functionReservedConcurrencyMetricName, utils.Utilization(float64(rc.Count), concurrencyLimit), rc.Dimensions, now))
+	}
+	return out, nil
+}
+
+// listReservations returns the reserved concurrency of functions until the reserved total is
+// found or MaxFunctions functions were inspected
+func (j *AccountSettingsJob) listReservations(ctx context.Context, reserved int64) ([]utils.ResourceCount, error) {
+	var (
+		reservations []utils.ResourceCount
+		found        int64
+		inspected    int
+	)
+	paginator := lambda.NewListFunctionsPaginator(j.LambdaClient, &lambda.ListFunctionsInput{})
+	for found < reserved && paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, fn := range output.Functions {
+			if found >= reserved {
+				break
+			}
+			if inspected == j.MaxFunctions {
+				j.Logger.Warn("%s only the first %d functions are inspected for reserved concurrency", j.GetJobName(), j.MaxFunctions)
+				return reservations, nil
+			}
+			inspected++
+			concurrency, err := j.LambdaClient.GetFunctionConcurrency(ctx, &lambda.GetFunctionConcurrencyInput{
+				FunctionName: fn.FunctionName,
+			})
+			if err != nil {
+				return nil, err
+			}
+			if concurrency.ReservedConcurrentExecutions == nil {
+				continue
+			}
+			name := aws.ToString(fn.FunctionName)
+			count := int64(aws.ToInt32(concurrency.ReservedConcurrentExecutions))
+			found += count
+			reservations = append(reservations, utils.ResourceCount{
+				Name:       name,
+				Count:      count,
+				Dimensions: map[string]string{functionDimension: name},
+			})
+		}
+	}
+	return reservations, nil
+}
+
+// GetJobName return the name of the job
+func (j *AccountSettingsJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *AccountSettingsJob) GetRegion() string {
+	return j.region
+}
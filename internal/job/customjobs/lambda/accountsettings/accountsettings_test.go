@@ -0,0 +1,125 @@
+package accountsettings
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdaTypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/lambdaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeLambdaClient builds a fake with 1000 concurrency, 300 reserved across two functions
+func newFakeLambdaClient() *lambdaclient.FakeLambdaClient {
+	return &lambdaclient.FakeLambdaClient{
+		Region: "us-east-1",
+		AccountLimit: lambdaTypes.AccountLimit{
+			ConcurrentExecutions:           1000,
+			UnreservedConcurrentExecutions: aws.Int32(700),
+			TotalCodeSize:                  80,
+		},
+		AccountUsage: lambdaTypes.AccountUsage{TotalCodeSize: 20},
+		ListFunctionsPages: []*lambda.ListFunctionsOutput{
+			{Functions: []lambdaTypes.FunctionConfiguration{{FunctionName: aws.String("a")}, {FunctionName: aws.String("b")}}},
+			{Functions: []lambdaTypes.FunctionConfiguration{{FunctionName: aws.String("c")}}},
+		},
+		FunctionConcurrency:    map[string]int32{"a": 100, "c": 200},
+		ErrOnListFunctionsCall: -1,
+	}
+}
+
+func TestAccountSettingsJob_Execute(t *testing.T) {
+	fake := newFakeLambdaClient()
+	j, err := NewAccountSettingsJob(AccountSettingsJobConfig{LambdaClient: fake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, accountSettingsJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, metrics, 4) {
+		assert.Equal(t, codeStorageMetricName, metrics[0].Name)
+		assert.InDelta(t, 25, metrics[0].Value, 0.001)
+		assert.Equal(t, reservedConcurrencyMetricName, metrics[1].Name)
+		assert.InDelta(t, 30, metrics[1].Value, 0.001)
+	}
+	// largest reservation is emitted first
+	m, ok := jobtest.FindMetric(metrics, functionReservedConcurrencyMetricName, functionDimension, "c")
+	if assert.True(t, ok) {
+		assert.InDelta(t, 20, m.Value, 0.001)
+		assert.Equal(t, m, metrics[2])
+	}
+	m, ok = jobtest.FindMetric(metrics, functionReservedConcurrencyMetricName, functionDimension, "a")
+	if assert.True(t, ok) {
+		assert.InDelta(t, 10, m.Value, 0.001)
+	}
+}
+
+func TestAccountSettingsJob_TopFunctions(t *testing.T) {
+	fake := newFakeLambdaClient()
+	fake.FunctionConcurrency = map[string]int32{}
+	var functions []lambdaTypes.FunctionConfiguration
+	total := int32(0)
+	for i := 0; i < topFunctions+5; i++ {
+		name := fmt.Sprintf("fn-%02d", i)
+		functions = append(functions, lambdaTypes.FunctionConfiguration{FunctionName: aws.String(name)})
+		fake.FunctionConcurrency[name] = int32(i + 1)
+		total += int32(i + 1)
+	}
+	fake.ListFunctionsPages = []*lambda.ListFunctionsOutput{{Functions: functions}}
+	fake.AccountLimit.UnreservedConcurrentExecutions = aws.Int32(1000 - total)
+	j, _ := NewAccountSettingsJob(AccountSettingsJobConfig{LambdaClient: fake})
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, metrics, 2+topFunctions)
+	_, ok := jobtest.FindMetric(metrics, functionReservedConcurrencyMetricName, functionDimension, "fn-00")
+	assert.False(t, ok, "smallest reservation should be dropped")
+}
+
+func TestAccountSettingsJob_NoReservations(t *testing.T) {
+	fake := newFakeLambdaClient()
+	fake.AccountLimit.UnreservedConcurrentExecutions = aws.Int32(1000)
+	// functions are not listed when nothing is reserved
+	fake.ErrOnListFunctionsCall = 0
+	j, _ := NewAccountSettingsJob(AccountSettingsJobConfig{LambdaClient: fake})
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, metrics, 2)
+}
+
+func TestAccountSettingsJob_StopsOnceReservedFound(t *testing.T) {
+	fake := newFakeLambdaClient()
+	fake.AccountLimit.UnreservedConcurrentExecutions = aws.Int32(900)
+	fake.FunctionConcurrency = map[string]int32{"a": 100}
+	// the second page is never read once "a" holds all reserved concurrency
+	fake.ErrOnListFunctionsCall = 1
+	j, _ := NewAccountSettingsJob(AccountSettingsJobConfig{LambdaClient: fake})
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, metrics, 3)
+}
+
+func TestAccountSettingsJob_MaxFunctions(t *testing.T) {
+	fake := newFakeLambdaClient()
+	j, _ := NewAccountSettingsJob(AccountSettingsJobConfig{LambdaClient: fake, MaxFunctions: 2})
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, metrics, 3)
+	_, ok := jobtest.FindMetric(metrics, functionReservedConcurrencyMetricName, functionDimension, "c")
+	assert.False(t, ok, "functions past the bound should not be inspected")
+}
+
+func TestAccountSettingsJob_Error(t *testing.T) {
+	fake := newFakeLambdaClient()
+	fake.ErrAccountSettings = true
+	j, _ := NewAccountSettingsJob(AccountSettingsJobConfig{LambdaClient: fake})
+	jobtest.AssertExecuteFails(t, j)
+}
@@ -0,0 +1,122 @@
+package peakconcurrency
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwTypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/cwclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/lambdaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// PeakConcurrencyJob will implement the Job interface
+// It reads the maximum of the AWS/Lambda ConcurrentExecutions metric over a
+// recent lookback window and emits it as a share of the account concurrency limit.
+
+type PeakConcurrencyJob struct {
+	LambdaClient     lambdaclient.LambdaClient
+	CloudWatchClient cwclient.CloudWatchClient
+	jobName          string
+	region           string
+	Logger           logger.Logger
+}
+
+type PeakConcurrencyJobConfig struct {
+	LambdaClient     lambdaclient.LambdaClient
+	CloudWatchClient cwclient.CloudWatchClient
+	Logger           logger.Logger
+}
+
+const (
+	peakConcurrencyJobPrefix = "lambdaPeakConcurrency"
+	cloudwatchMetricName     = "lambdaPeakConcurrency"
+
+	// source metric
+	lambdaNamespace         = "AWS/Lambda"
+	concurrentExecutionsKey = "ConcurrentExecutions"
+	queryID                 = "peak"
+	period                  = 60
+	lookback                = 5 * time.Minute
+)
+
+// NewPeakConcurrencyJob will create a new PeakConcurrencyJob
+func NewPeakConcurrencyJob(config PeakConcurrencyJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	job := &PeakConcurrencyJob{
+		LambdaClient:     config.LambdaClient,
+		CloudWatchClient: config.CloudWatchClient,
+		jobName:          peakConcurrencyJobPrefix + "-" + config.LambdaClient.GetRegion(),
+		region:           config.LambdaClient.GetRegion(),
+		Logger:           config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns the peak concurrent executions as a percent of the account limit
+func (j *PeakConcurrencyJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	settings, err := j.LambdaClient.GetAccountSettings(ctx, &lambda.GetAccountSettingsInput{})
+	if err != nil {
+		return nil, err
+	}
+	if settings.AccountLimit == nil {
+		return nil, errors.New("lambda account settings missing account limit")
+	}
+
+	now := time.Now()
+	output, err := j.CloudWatchClient.GetMetricData(ctx, &cloudwatch.GetMetricDataInput{
+		StartTime: aws.Time(now.Add(-lookback)),
+		EndTime:   aws.Time(now),
+		MetricDataQueries: []cwTypes.MetricDataQuery{
+			{
+				Id: aws.String(queryID),
+				MetricStat: &cwTypes.MetricStat{
+					Metric: &cwTypes.Metric{
+						Namespace:  aws.String(lambdaNamespace),
+						MetricName: aws.String(concurrentExecutionsKey),
+					},
+					Period: aws.Int32(period),
+					Stat:   aws.String(string(cwTypes.StatisticMaximum)),
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// no datapoints means nothing ran in the window
+	peak := 0.0
+	for _, result := range output.MetricDataResults {
+		for _, v := range result.Values {
+			if v > peak {
+				peak = v
+			}
+		}
+	}
+	limit := float64(settings.AccountLimit.ConcurrentExecutions)
+	j.Logger.Debug("%s peak concurrency %.0f/%.0f", j.GetJobName(), peak, limit)
+
+	return []sharedtypes.CloudWatchMetric{
+		utils.PercentMetric(cloudwatchMetricName, utils.Utilization(peak, limit), nil, now),
+	}, nil
+}
+
+// GetJobName return the name of the job
+func (j *PeakConcurrencyJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *PeakConcurrencyJob) GetRegion() string {
+	return j.region
+}
@@ -0,0 +1,70 @@
+package peakconcurrency
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cwTypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	lambdaTypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/cwclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/lambdaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func newFakes() (*lambdaclient.FakeLambdaClient, *cwclient.FakeCloudWatchClient) {
+	lambdaFake := &lambdaclient.FakeLambdaClient{
+		Region:       "us-east-1",
+		AccountLimit: lambdaTypes.AccountLimit{ConcurrentExecutions: 1000},
+	}
+	cwFake := &cwclient.FakeCloudWatchClient{
+		Region:           "us-east-1",
+		MetricDataValues: map[string][]float64{queryID: {120, 450, 300}},
+	}
+	return lambdaFake, cwFake
+}
+
+func TestPeakConcurrencyJob_Execute(t *testing.T) {
+	lambdaFake, cwFake := newFakes()
+	j, err := NewPeakConcurrencyJob(PeakConcurrencyJobConfig{LambdaClient: lambdaFake, CloudWatchClient: cwFake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, peakConcurrencyJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, metrics, 1) {
+		assert.Equal(t, cloudwatchMetricName, metrics[0].Name)
+		assert.InDelta(t, 45, metrics[0].Value, 0.001)
+		assert.Equal(t, cwTypes.StandardUnitPercent, metrics[0].Unit)
+	}
+
+	// query the account wide maximum of ConcurrentExecutions
+	if assert.Len(t, cwFake.GetMetricDataInputs, 1) {
+		stat := cwFake.GetMetricDataInputs[0].MetricDataQueries[0].MetricStat
+		assert.Equal(t, lambdaNamespace, aws.ToString(stat.Metric.Namespace))
+		assert.Equal(t, concurrentExecutionsKey, aws.ToString(stat.Metric.MetricName))
+		assert.Equal(t, string(cwTypes.StatisticMaximum), aws.ToString(stat.Stat))
+		assert.Empty(t, stat.Metric.Dimensions)
+	}
+}
+
+func TestPeakConcurrencyJob_NoDatapoints(t *testing.T) {
+	lambdaFake, cwFake := newFakes()
+	cwFake.MetricDataValues = nil
+	j, _ := NewPeakConcurrencyJob(PeakConcurrencyJobConfig{LambdaClient: lambdaFake, CloudWatchClient: cwFake})
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, metrics, 1) {
+		assert.Equal(t, 0.0, metrics[0].Value)
+	}
+}
+
+func TestPeakConcurrencyJob_Error(t *testing.T) {
+	lambdaFake, cwFake := newFakes()
+	lambdaFake.ErrAccountSettings = true
+	j, _ := NewPeakConcurrencyJob(PeakConcurrencyJobConfig{LambdaClient: lambdaFake, CloudWatchClient: cwFake})
+	jobtest.AssertExecuteFails(t, j)
+}
@@ -0,0 +1,98 @@
+package accounts
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/organizationsclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// AccountsJob will implement the Job interface
+// It counts the accounts of the organization, which is only possible from the
+// management or a delegated administrator account.
+
+type AccountsJob struct {
+	OrganizationsClient organizationsclient.OrganizationsClient
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	jobName             string
+	region              string
+	Logger              logger.Logger
+}
+
+type AccountsJobConfig struct {
+	OrganizationsClient organizationsclient.OrganizationsClient
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	Logger              logger.Logger
+}
+
+const (
+	accountsJobPrefix = "organizationsAccounts"
+	serviceCode       = "organizations"
+
+	// cloudwatch metric names
+	accountsMetricName = "organizationsAccounts"
+)
+
+var (
+	// Accounts per organization
+	accountsQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, QuotaCode: "L-29A0C5DF", Default: 10}
+)
+
+// NewAccountsJob will create a new AccountsJob
+func NewAccountsJob(config AccountsJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	job := &AccountsJob{
+		OrganizationsClient: config.OrganizationsClient,
+		ServiceQuotasClient: config.ServiceQuotasClient,
+		jobName:             accountsJobPrefix + "-" + config.OrganizationsClient.GetRegion(),
+		region:              config.OrganizationsClient.GetRegion(),
+		Logger:              config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns the account utilization of the organization.  The job is skipped
+// when the organization cannot be read from this account.
+func (j *AccountsJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	accounts := 0
+	paginator := organizations.NewListAccountsPaginator(j.OrganizationsClient, &organizations.ListAccountsInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			if organizationsclient.IsOrganizationUnavailable(err) {
+				j.Logger.Warn("%s skipped, organization not readable from this account (requires the management account and organizations:ListAccounts) : %v", j.GetJobName(), err)
+				return nil, nil
+			}
+			return nil, err
+		}
+		accounts += len(output.Accounts)
+	}
+
+	limit, err := servicequotaclient.GetQuotaValue(ctx, j.ServiceQuotasClient, accountsQuota)
+	if err != nil {
+		return nil, err
+	}
+	j.Logger.Debug("%s accounts : %d/%.0f", j.GetJobName(), accounts, limit)
+
+	return []sharedtypes.CloudWatchMetric{
+		utils.PercentMetric(accountsMetricName, utils.Utilization(float64(accounts), limit), nil, time.Now()),
+	}, nil
+}
+
+// GetJobName return the name of the job
+func (j *AccountsJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *AccountsJob) GetRegion() string {
+	return j.region
+}
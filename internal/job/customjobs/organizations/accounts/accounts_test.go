@@ -0,0 +1,76 @@
+package accounts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+	orgTypes "github.com/aws/aws-sdk-go-v2/service/organizations/types"
+	"github.com/aws/smithy-go"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/organizationsclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeOrganizationsClient builds a fake with 12 accounts over two pages
+func newFakeOrganizationsClient() *organizationsclient.FakeOrganizationsClient {
+	return &organizationsclient.FakeOrganizationsClient{
+		Region: "us-east-1",
+		ListAccountsPageOutputs: []*organizations.ListAccountsOutput{
+			{Accounts: make([]orgTypes.Account, 8)},
+			{Accounts: make([]orgTypes.Account, 4)},
+		},
+		ErrOnListAccountsCall: -1,
+	}
+}
+
+func TestAccountsJob_Execute(t *testing.T) {
+	orgFake := newFakeOrganizationsClient()
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 48}
+	j, err := NewAccountsJob(AccountsJobConfig{OrganizationsClient: orgFake, ServiceQuotasClient: sqFake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, accountsJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, metrics, 1) {
+		assert.Equal(t, accountsMetricName, metrics[0].Name)
+		assert.InDelta(t, 25, metrics[0].Value, 0.001)
+	}
+	assert.Equal(t, []string{accountsQuota.QuotaCode}, sqFake.RequestedQuotaCodes)
+}
+
+func TestAccountsJob_Skipped(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+	}{
+		{"member account", &orgTypes.AccessDeniedException{Message: aws.String("not the management account")}},
+		{"missing permission", &smithy.GenericAPIError{Code: "AccessDeniedException"}},
+		{"not in an organization", &orgTypes.AWSOrganizationsNotInUseException{Message: aws.String("not in use")}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			orgFake := newFakeOrganizationsClient()
+			orgFake.CallError = tc.err
+			sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 48}
+			j, _ := NewAccountsJob(AccountsJobConfig{OrganizationsClient: orgFake, ServiceQuotasClient: sqFake})
+
+			metrics, err := j.Execute(context.Background())
+			assert.NoError(t, err)
+			assert.Empty(t, metrics)
+			assert.Empty(t, sqFake.RequestedQuotaCodes)
+		})
+	}
+}
+
+func TestAccountsJob_Error(t *testing.T) {
+	orgFake := newFakeOrganizationsClient()
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 48}
+	orgFake.ErrOnListAccountsCall = 1
+	j, _ := NewAccountsJob(AccountsJobConfig{OrganizationsClient: orgFake, ServiceQuotasClient: sqFake})
+	jobtest.AssertExecuteFails(t, j)
+}
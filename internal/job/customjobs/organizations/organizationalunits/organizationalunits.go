@@ -0,0 +1,116 @@
+package organizationalunits
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/organizationsclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// OrganizationalUnitsJob will implement the Job interface
+// Organizations has no api that lists every OU, so the tree is walked from each root.
+// The OU maximum is fixed and not available in service quotas.
+
+type OrganizationalUnitsJob struct {
+	OrganizationsClient organizationsclient.OrganizationsClient
+	jobName             string
+	region              string
+	Logger              logger.Logger
+}
+
+type OrganizationalUnitsJobConfig struct {
+	OrganizationsClient organizationsclient.OrganizationsClient
+	Logger              logger.Logger
+}
+
+const (
+	organizationalUnitsJobPrefix = "organizationsOrganizationalUnits"
+	// maximum number of OUs in an organization
+	maxOrganizationalUnits = 1000
+
+	// cloudwatch metric names
+	organizationalUnitsMetricName = "organizationsOrganizationalUnits"
+)
+
+// NewOrganizationalUnitsJob will create a new OrganizationalUnitsJob
+func NewOrganizationalUnitsJob(config OrganizationalUnitsJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	job := &OrganizationalUnitsJob{
+		OrganizationsClient: config.OrganizationsClient,
+		jobName:             organizationalUnitsJobPrefix + "-" + config.OrganizationsClient.GetRegion(),
+		region:              config.OrganizationsClient.GetRegion(),
+		Logger:              config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns the OU utilization of the organization.  The job is skipped
+// when the organization cannot be read from this account.
+func (j *OrganizationalUnitsJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	ous, err := j.countOrganizationalUnits(ctx)
+	if err != nil {
+		if organizationsclient.IsOrganizationUnavailable(err) {
+			j.Logger.Warn("%s skipped, organization not readable from this account (requires the management account and organizations:ListRoots, organizations:ListOrganizationalUnitsForParent) : %v", j.GetJobName(), err)
+			return nil, nil
+		}
+		return nil, err
+	}
+	j.Logger.Debug("%s organizational units : %d/%d", j.GetJobName(), ous, maxOrganizationalUnits)
+
+	return []sharedtypes.CloudWatchMetric{
+		utils.PercentMetric(organizationalUnitsMetricName, utils.Utilization(float64(ous), maxOrganizationalUnits), nil, time.Now()),
+	}, nil
+}
+
+// countOrganizationalUnits walks the OU tree breadth first from every root
+func (j *OrganizationalUnitsJob) countOrganizationalUnits(ctx context.Context) (int, error) {
+	var parents []string
+	rootPaginator := organizations.NewListRootsPaginator(j.OrganizationsClient, &organizations.ListRootsInput{})
+	for rootPaginator.HasMorePages() {
+		output, err := rootPaginator.NextPage(ctx)
+		if err != nil {
+			return 0, err
+		}
+		for _, root := range output.Roots {
+			parents = append(parents, aws.ToString(root.Id))
+		}
+	}
+
+	count := 0
+	for len(parents) > 0 {
+		parent := parents[0]
+		parents = parents[1:]
+		paginator := organizations.NewListOrganizationalUnitsForParentPaginator(j.OrganizationsClient, &organizations.ListOrganizationalUnitsForParentInput{
+			ParentId: aws.String(parent),
+		})
+		for paginator.HasMorePages() {
+			output, err := paginator.NextPage(ctx)
+			if err != nil {
+				return 0, err
+			}
+			for _, ou := range output.OrganizationalUnits {
+				count++
+				parents = append(parents, aws.ToString(ou.Id))
+			}
+		}
+	}
+	return count, nil
+}
+
+// GetJobName return the name of the job
+func (j *OrganizationalUnitsJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *OrganizationalUnitsJob) GetRegion() string {
+	return j.region
+}
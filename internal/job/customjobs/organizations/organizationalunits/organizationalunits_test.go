@@ -0,0 +1,74 @@
+package organizationalunits
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+	orgTypes "github.com/aws/aws-sdk-go-v2/service/organizations/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/organizationsclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// ous builds an OU page with the given ids
+func ous(ids ...string) *organizations.ListOrganizationalUnitsForParentOutput {
+	out := &organizations.ListOrganizationalUnitsForParentOutput{}
+	for _, id := range ids {
+		out.OrganizationalUnits = append(out.OrganizationalUnits, orgTypes.OrganizationalUnit{Id: aws.String(id)})
+	}
+	return out
+}
+
+// newFakeOrganizationsClient builds a fake with a three level tree of 10 OUs under one root
+func newFakeOrganizationsClient() *organizationsclient.FakeOrganizationsClient {
+	return &organizationsclient.FakeOrganizationsClient{
+		Region: "us-east-1",
+		ListRootsPageOutputs: []*organizations.ListRootsOutput{
+			{Roots: []orgTypes.Root{{Id: aws.String("r-1")}}},
+		},
+		ListOrganizationalUnitsForParentPageOutputs: map[string][]*organizations.ListOrganizationalUnitsForParentOutput{
+			"r-1":    {ous("ou-a", "ou-b"), ous("ou-c")},
+			"ou-a":   {ous("ou-a1", "ou-a2", "ou-a3")},
+			"ou-c":   {ous("ou-c1")},
+			"ou-a1":  {ous("ou-a1x", "ou-a1y")},
+			"ou-c1":  {ous("ou-c1x")},
+			"ou-a1x": {},
+		},
+		ErrOnListRootsCall:                        -1,
+		ErrOnListOrganizationalUnitsForParentCall: -1,
+	}
+}
+
+func TestOrganizationalUnitsJob_Execute(t *testing.T) {
+	orgFake := newFakeOrganizationsClient()
+	j, err := NewOrganizationalUnitsJob(OrganizationalUnitsJobConfig{OrganizationsClient: orgFake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, organizationalUnitsJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, metrics, 1) {
+		assert.Equal(t, organizationalUnitsMetricName, metrics[0].Name)
+		assert.InDelta(t, 10.0/maxOrganizationalUnits*100, metrics[0].Value, 0.001)
+	}
+}
+
+func TestOrganizationalUnitsJob_Skipped(t *testing.T) {
+	orgFake := newFakeOrganizationsClient()
+	orgFake.CallError = &orgTypes.AWSOrganizationsNotInUseException{Message: aws.String("not in use")}
+	j, _ := NewOrganizationalUnitsJob(OrganizationalUnitsJobConfig{OrganizationsClient: orgFake})
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, metrics)
+}
+
+func TestOrganizationalUnitsJob_Error(t *testing.T) {
+	orgFake := newFakeOrganizationsClient()
+	orgFake.ErrOnListRootsCall = 0
+	j, _ := NewOrganizationalUnitsJob(OrganizationalUnitsJobConfig{OrganizationsClient: orgFake})
+	jobtest.AssertExecuteFails(t, j)
+}
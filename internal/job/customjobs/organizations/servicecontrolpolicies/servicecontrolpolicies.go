@@ -0,0 +1,132 @@
+package servicecontrolpolicies
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+	orgTypes "github.com/aws/aws-sdk-go-v2/service/organizations/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/organizationsclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// ServiceControlPoliciesJob will implement the Job interface
+// It lists every SCP and the targets it is attached to, then emits the number of
+// SCPs attached to the busiest roots, OUs and accounts against the fixed maximum.
+// The default FullAWSAccess policy counts towards the maximum like any other SCP.
+
+type ServiceControlPoliciesJob struct {
+	OrganizationsClient organizationsclient.OrganizationsClient
+	jobName             string
+	region              string
+	Logger              logger.Logger
+}
+
+type ServiceControlPoliciesJobConfig struct {
+	OrganizationsClient organizationsclient.OrganizationsClient
+	Logger              logger.Logger
+}
+
+const (
+	serviceControlPoliciesJobPrefix = "organizationsSCPsPerTarget"
+	targetDimension                 = "targetId"
+	// maximum number of SCPs attached to a root, OU or account
+	maxPoliciesPerTarget = 5
+	// only the targets with the most policies get a metric
+	topTargets = 10
+
+	// cloudwatch metric names
+	scpsPerTargetMetricName = "organizationsSCPsPerTarget"
+)
+
+// NewServiceControlPoliciesJob will create a new ServiceControlPoliciesJob
+func NewServiceControlPoliciesJob(config ServiceControlPoliciesJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	job := &ServiceControlPoliciesJob{
+		OrganizationsClient: config.OrganizationsClient,
+		jobName:             serviceControlPoliciesJobPrefix + "-" + config.OrganizationsClient.GetRegion(),
+		region:              config.OrganizationsClient.GetRegion(),
+		Logger:              config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns SCPs per target utilization for the top targets.  The job is
+// skipped when the organization cannot be read from this account.
+func (j *ServiceControlPoliciesJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	counts, err := j.countPoliciesPerTarget(ctx)
+	if err != nil {
+		if organizationsclient.IsOrganizationUnavailable(err) {
+			j.Logger.Warn("%s skipped, organization not readable from this account (requires the management account and organizations:ListPolicies, organizations:ListTargetsForPolicy) : %v", j.GetJobName(), err)
+			return nil, nil
+		}
+		return nil, err
+	}
+	j.Logger.Debug("%s targets with scps attached : %d", j.GetJobName(), len(counts))
+
+	now := time.Now()
+	var out []sharedtypes.CloudWatchMetric
+	for _, rc := range utils.TopResourceCounts(counts, topTargets) {
+		out = append(out, utils.PercentMetric(scpsPerTargetMetricName, utils.Utilization(float64(rc.Count), maxPoliciesPerTarget), rc.Dimensions, now))
+	}
+	return out, nil
+}
+
+// countPoliciesPerTarget returns the number of SCPs attached to each target
+func (j *ServiceControlPoliciesJob) countPoliciesPerTarget(ctx context.Context) ([]utils.ResourceCount, error) {
+	var policyIds []string
+	policyPaginator := organizations.NewListPoliciesPaginator(j.OrganizationsClient, &organizations.ListPoliciesInput{
+		Filter: orgTypes.PolicyTypeServiceControlPolicy,
+	})
+	for policyPaginator.HasMorePages() {
+		output, err := policyPaginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, policy := range output.Policies {
+			policyIds = append(policyIds, aws.ToString(policy.Id))
+		}
+	}
+
+	perTarget := map[string]int64{}
+	for _, policyId := range policyIds {
+		paginator := organizations.NewListTargetsForPolicyPaginator(j.OrganizationsClient, &organizations.ListTargetsForPolicyInput{
+			PolicyId: aws.String(policyId),
+		})
+		for paginator.HasMorePages() {
+			output, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, err
+			}
+			for _, target := range output.Targets {
+				perTarget[aws.ToString(target.TargetId)]++
+			}
+		}
+	}
+
+	counts := make([]utils.ResourceCount, 0, len(perTarget))
+	for targetId, count := range perTarget {
+		counts = append(counts, utils.ResourceCount{
+			Name:       targetId,
+			Count:      count,
+			Dimensions: map[string]string{targetDimension: targetId},
+		})
+	}
+	return counts, nil
+}
+
+// GetJobName return the name of the job
+func (j *ServiceControlPoliciesJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *ServiceControlPoliciesJob) GetRegion() string {
+	return j.region
+}
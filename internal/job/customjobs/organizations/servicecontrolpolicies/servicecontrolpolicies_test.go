@@ -0,0 +1,105 @@
+package servicecontrolpolicies
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+	orgTypes "github.com/aws/aws-sdk-go-v2/service/organizations/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/organizationsclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// targets builds a ListTargetsForPolicy page with the given target ids
+func targets(ids ...string) *organizations.ListTargetsForPolicyOutput {
+	out := &organizations.ListTargetsForPolicyOutput{}
+	for _, id := range ids {
+		out.Targets = append(out.Targets, orgTypes.PolicyTargetSummary{TargetId: aws.String(id)})
+	}
+	return out
+}
+
+// newFakeOrganizationsClient builds a fake where FullAWSAccess is attached to the root
+// and 12 accounts, and account i has i%4 extra SCPs. A tag policy must be ignored.
+func newFakeOrganizationsClient() *organizationsclient.FakeOrganizationsClient {
+	f := &organizationsclient.FakeOrganizationsClient{
+		Region: "us-east-1",
+		ListPoliciesPageOutputs: []*organizations.ListPoliciesOutput{
+			{Policies: []orgTypes.PolicySummary{
+				{Id: aws.String("p-full"), Type: orgTypes.PolicyTypeServiceControlPolicy},
+				{Id: aws.String("p-tag"), Type: orgTypes.PolicyTypeTagPolicy},
+			}},
+			{Policies: []orgTypes.PolicySummary{
+				{Id: aws.String("p-1"), Type: orgTypes.PolicyTypeServiceControlPolicy},
+				{Id: aws.String("p-2"), Type: orgTypes.PolicyTypeServiceControlPolicy},
+				{Id: aws.String("p-3"), Type: orgTypes.PolicyTypeServiceControlPolicy},
+			}},
+		},
+		ListTargetsForPolicyPageOutputs: map[string][]*organizations.ListTargetsForPolicyOutput{
+			"p-full": {targets("r-1")},
+			"p-tag":  {targets("r-1", "a-01", "a-02")},
+		},
+		ErrOnListPoliciesCall:         -1,
+		ErrOnListTargetsForPolicyCall: -1,
+	}
+	for i := 1; i <= 12; i++ {
+		account := fmt.Sprintf("a-%02d", i)
+		f.ListTargetsForPolicyPageOutputs["p-full"] = append(f.ListTargetsForPolicyPageOutputs["p-full"], targets(account))
+		for p := 1; p <= i%4; p++ {
+			policy := fmt.Sprintf("p-%d", p)
+			f.ListTargetsForPolicyPageOutputs[policy] = append(f.ListTargetsForPolicyPageOutputs[policy], targets(account))
+		}
+	}
+	return f
+}
+
+func TestServiceControlPoliciesJob_Execute(t *testing.T) {
+	orgFake := newFakeOrganizationsClient()
+	j, err := NewServiceControlPoliciesJob(ServiceControlPoliciesJobConfig{OrganizationsClient: orgFake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, serviceControlPoliciesJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, metrics, topTargets)
+
+	cases := []struct {
+		target string
+		value  float64
+	}{
+		// FullAWSAccess plus three extra SCPs, the tag policy is not counted
+		{"a-03", 80},
+		{"a-11", 80},
+		{"a-02", 60},
+		{"a-05", 40},
+	}
+	for _, tc := range cases {
+		m, ok := jobtest.FindMetric(metrics, scpsPerTargetMetricName, targetDimension, tc.target)
+		if assert.True(t, ok, "missing metric for %s", tc.target) {
+			assert.InDelta(t, tc.value, m.Value, 0.001, tc.target)
+		}
+	}
+	_, ok := jobtest.FindMetric(metrics, scpsPerTargetMetricName, targetDimension, "r-1")
+	assert.False(t, ok, "targets with the fewest policies should be dropped")
+}
+
+func TestServiceControlPoliciesJob_Skipped(t *testing.T) {
+	orgFake := newFakeOrganizationsClient()
+	orgFake.CallError = &orgTypes.AccessDeniedException{Message: aws.String("not the management account")}
+	j, _ := NewServiceControlPoliciesJob(ServiceControlPoliciesJobConfig{OrganizationsClient: orgFake})
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, metrics)
+}
+
+func TestServiceControlPoliciesJob_Error(t *testing.T) {
+	orgFake := newFakeOrganizationsClient()
+	orgFake.ErrOnListPoliciesCall = 1
+	j, _ := NewServiceControlPoliciesJob(ServiceControlPoliciesJobConfig{OrganizationsClient: orgFake})
+	jobtest.AssertExecuteFails(t, j)
+}
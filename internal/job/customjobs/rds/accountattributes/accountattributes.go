@@ -0,0 +1,90 @@
+package accountattributes
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/rdsclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// AccountAttributesJob will implement the Job interface
+// RDS reports usage and maximum together in DescribeAccountAttributes,
+// so utilization is computed without calling service quotas.
+
+type AccountAttributesJob struct {
+	RdsClient rdsclient.RDSClient
+	jobName   string
+	region    string
+	Logger    logger.Logger
+}
+
+type AccountAttributesJobConfig struct {
+	RdsClient rdsclient.RDSClient
+	Logger    logger.Logger
+}
+
+const (
+	accountAttributesJobPrefix = "rdsAccountAttributes"
+)
+
+// accountQuotaMetrics maps the rds account quota names to cloudwatch metric names
+var accountQuotaMetrics = map[string]string{
+	"DBInstances":       "rdsDBInstances",
+	"ManualSnapshots":   "rdsManualSnapshots",
+	"DBParameterGroups": "rdsDBParameterGroups",
+}
+
+// NewAccountAttributesJob will create a new AccountAttributesJob
+func NewAccountAttributesJob(config AccountAttributesJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	job := &AccountAttributesJob{
+		RdsClient: config.RdsClient,
+		jobName:   accountAttributesJobPrefix + "-" + config.RdsClient.GetRegion(),
+		region:    config.RdsClient.GetRegion(),
+		Logger:    config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns db instance, manual snapshot and parameter group utilization
+func (j *AccountAttributesJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	output, err := j.RdsClient.DescribeAccountAttributes(ctx, &rds.DescribeAccountAttributesInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var out []sharedtypes.CloudWatchMetric
+	for _, quota := range output.AccountQuotas {
+		name := aws.ToString(quota.AccountQuotaName)
+		metricName, ok := accountQuotaMetrics[name]
+		if !ok {
+			continue
+		}
+		used, limit := aws.ToInt64(quota.Used), aws.ToInt64(quota.Max)
+		j.Logger.Debug("%s %s : %d/%d", j.GetJobName(), name, used, limit)
+		out = append(out, utils.PercentMetric(metricName, utils.Utilization(float64(used), float64(limit)), nil, now))
+	}
+	if len(out) < len(accountQuotaMetrics) {
+		j.Logger.Warn("%s account attributes reported %d of %d expected quotas", j.GetJobName(), len(out), len(accountQuotaMetrics))
+	}
+	return out, nil
+}
+
+// GetJobName return the name of the job
+func (j *AccountAttributesJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *AccountAttributesJob) GetRegion() string {
+	return j.region
+}
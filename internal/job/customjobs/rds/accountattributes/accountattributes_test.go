@@ -0,0 +1,55 @@
+package accountattributes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	rdsTypes "github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/rdsclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountAttributesJob_Execute(t *testing.T) {
+	fake := &rdsclient.FakeRDSClient{
+		Region: "us-east-1",
+		AccountQuotas: []rdsTypes.AccountQuota{
+			{AccountQuotaName: aws.String("DBInstances"), Used: aws.Int64(10), Max: aws.Int64(40)},
+			{AccountQuotaName: aws.String("ManualSnapshots"), Used: aws.Int64(50), Max: aws.Int64(100)},
+			{AccountQuotaName: aws.String("DBParameterGroups"), Used: aws.Int64(0), Max: aws.Int64(50)},
+			{AccountQuotaName: aws.String("AllocatedStorage"), Used: aws.Int64(1), Max: aws.Int64(2)},
+		},
+	}
+	j, err := NewAccountAttributesJob(AccountAttributesJobConfig{RdsClient: fake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, accountAttributesJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, metrics, 3, "unmapped quotas should be ignored")
+
+	got := map[string]float64{}
+	for _, m := range metrics {
+		got[m.Name] = m.Value
+	}
+	assert.InDelta(t, 25, got["rdsDBInstances"], 0.001)
+	assert.InDelta(t, 50, got["rdsManualSnapshots"], 0.001)
+	assert.InDelta(t, 0, got["rdsDBParameterGroups"], 0.001)
+}
+
+func TestAccountAttributesJob_MissingQuotas(t *testing.T) {
+	fake := &rdsclient.FakeRDSClient{Region: "us-east-1"}
+	j, _ := NewAccountAttributesJob(AccountAttributesJobConfig{RdsClient: fake})
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, metrics)
+}
+
+func TestAccountAttributesJob_Error(t *testing.T) {
+	fake := &rdsclient.FakeRDSClient{Region: "us-east-1", ErrDescribeAccountAttributes: true}
+	j, _ := NewAccountAttributesJob(AccountAttributesJobConfig{RdsClient: fake})
+	jobtest.AssertExecuteFails(t, j)
+}
@@ -0,0 +1,98 @@
+package accountlimits
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	r53Types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/route53client"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// AccountLimitsJob will implement the Job interface
+// Route 53 is a global service and GetAccountLimit reports both the usage and the
+// limit of each account level resource, so no service quotas lookup is needed.
+
+type AccountLimitsJob struct {
+	Route53Client route53client.Route53Client
+	jobName       string
+	region        string
+	Logger        logger.Logger
+}
+
+type AccountLimitsJobConfig struct {
+	Route53Client route53client.Route53Client
+	Logger        logger.Logger
+}
+
+const (
+	accountLimitsJobPrefix = "route53AccountLimits"
+
+	// cloudwatch metric names
+	hostedZonesMetricName            = "route53HostedZones"
+	healthChecksMetricName           = "route53HealthChecks"
+	trafficPoliciesMetricName        = "route53TrafficPolicies"
+	trafficPolicyInstancesMetricName = "route53TrafficPolicyInstances"
+)
+
+// accountLimits maps each account limit type to the metric it produces
+var accountLimits = []struct {
+	limitType  r53Types.AccountLimitType
+	metricName string
+}{
+	{r53Types.AccountLimitTypeMaxHostedZonesByOwner, hostedZonesMetricName},
+	{r53Types.AccountLimitTypeMaxHealthChecksByOwner, healthChecksMetricName},
+	{r53Types.AccountLimitTypeMaxTrafficPoliciesByOwner, trafficPoliciesMetricName},
+	{r53Types.AccountLimitTypeMaxTrafficPolicyInstancesByOwner, trafficPolicyInstancesMetricName},
+}
+
+// NewAccountLimitsJob will create a new AccountLimitsJob
+func NewAccountLimitsJob(config AccountLimitsJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	job := &AccountLimitsJob{
+		Route53Client: config.Route53Client,
+		jobName:       accountLimitsJobPrefix + "-" + config.Route53Client.GetRegion(),
+		region:        config.Route53Client.GetRegion(),
+		Logger:        config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns hosted zone, health check, traffic policy and traffic policy instance utilization
+func (j *AccountLimitsJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	now := time.Now()
+	out := make([]sharedtypes.CloudWatchMetric, 0, len(accountLimits))
+	for _, al := range accountLimits {
+		output, err := j.Route53Client.GetAccountLimit(ctx, &route53.GetAccountLimitInput{
+			Type: al.limitType,
+		})
+		if err != nil {
+			return nil, err
+		}
+		var limit float64
+		if output.Limit != nil {
+			limit = float64(aws.ToInt64(output.Limit.Value))
+		}
+		j.Logger.Debug("%s %s : %d/%.0f", j.GetJobName(), al.limitType, output.Count, limit)
+
+		out = append(out, utils.PercentMetric(al.metricName, utils.Utilization(float64(output.Count), limit), nil, now))
+	}
+	return out, nil
+}
+
+// GetJobName return the name of the job
+func (j *AccountLimitsJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *AccountLimitsJob) GetRegion() string {
+	return j.region
+}
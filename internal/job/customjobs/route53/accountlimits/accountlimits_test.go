@@ -0,0 +1,69 @@
+package accountlimits
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	r53Types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/route53client"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newAccountLimit builds a GetAccountLimit response
+func newAccountLimit(limitType r53Types.AccountLimitType, count, limit int64) *route53.GetAccountLimitOutput {
+	return &route53.GetAccountLimitOutput{
+		Count: count,
+		Limit: &r53Types.AccountLimit{Type: limitType, Value: aws.Int64(limit)},
+	}
+}
+
+// newFakeRoute53Client builds a fake reporting every account limit the job reads
+func newFakeRoute53Client() *route53client.FakeRoute53Client {
+	return &route53client.FakeRoute53Client{
+		Region: "us-east-1",
+		AccountLimits: map[r53Types.AccountLimitType]*route53.GetAccountLimitOutput{
+			r53Types.AccountLimitTypeMaxHostedZonesByOwner:            newAccountLimit(r53Types.AccountLimitTypeMaxHostedZonesByOwner, 250, 500),
+			r53Types.AccountLimitTypeMaxHealthChecksByOwner:           newAccountLimit(r53Types.AccountLimitTypeMaxHealthChecksByOwner, 50, 200),
+			r53Types.AccountLimitTypeMaxTrafficPoliciesByOwner:        newAccountLimit(r53Types.AccountLimitTypeMaxTrafficPoliciesByOwner, 0, 50),
+			r53Types.AccountLimitTypeMaxTrafficPolicyInstancesByOwner: newAccountLimit(r53Types.AccountLimitTypeMaxTrafficPolicyInstancesByOwner, 4, 5),
+		},
+	}
+}
+
+func TestAccountLimitsJob_Execute(t *testing.T) {
+	r53Fake := newFakeRoute53Client()
+	j, err := NewAccountLimitsJob(AccountLimitsJobConfig{Route53Client: r53Fake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, accountLimitsJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, metrics, len(accountLimits))
+
+	cases := []struct {
+		name  string
+		value float64
+	}{
+		{hostedZonesMetricName, 50},
+		{healthChecksMetricName, 25},
+		{trafficPoliciesMetricName, 0},
+		{trafficPolicyInstancesMetricName, 80},
+	}
+	for _, tc := range cases {
+		m, ok := jobtest.FindMetric(metrics, tc.name)
+		if assert.True(t, ok, "missing metric %s", tc.name) {
+			assert.InDelta(t, tc.value, m.Value, 0.001, tc.name)
+		}
+	}
+}
+
+func TestAccountLimitsJob_Error(t *testing.T) {
+	r53Fake := newFakeRoute53Client()
+	r53Fake.ErrGetAccountLimit = true
+	j, _ := NewAccountLimitsJob(AccountLimitsJobConfig{Route53Client: r53Fake})
+	jobtest.AssertExecuteFails(t, j)
+}
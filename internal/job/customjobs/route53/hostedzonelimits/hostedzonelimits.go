@@ -0,0 +1,199 @@
+package hostedzonelimits
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	r53Types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/route53client"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// HostedZoneLimitsJob will implement the Job interface
+// ListHostedZones already reports the record count of every zone, so only the zones
+// with the most records are looked up with GetHostedZoneLimit. VPC associations are
+// not listed anywhere else, so the private zones are looked up and the fullest are kept.
+// Route 53 allows 5 requests per second per account, so GetHostedZoneLimit calls are
+// spaced by CallInterval.  At most maxPrivateZones private zones are looked up, fewer
+// when the context deadline leaves less time, and lookups stop near the deadline so
+// the metrics collected so far are still emitted.
+
+type HostedZoneLimitsJob struct {
+	Route53Client route53client.Route53Client
+	CallInterval  time.Duration
+	jobName       string
+	region        string
+	Logger        logger.Logger
+}
+
+type HostedZoneLimitsJobConfig struct {
+	Route53Client route53client.Route53Client
+	// CallInterval spaces GetHostedZoneLimit calls, defaults to defaultCallInterval
+	CallInterval time.Duration
+	Logger       logger.Logger
+}
+
+const (
+	hostedZoneLimitsJobPrefix = "route53HostedZoneLimits"
+	hostedZoneDimension       = "hostedZoneId"
+	hostedZoneIdPrefix        = "/hostedzone/"
+	// only the fullest zones get a metric
+	topZones = 10
+	// 4 requests per second leaves room for the other route 53 jobs of the account
+	defaultCallInterval = 250 * time.Millisecond
+	// private zones looked up at most per run
+	maxPrivateZones = 300
+	// lookups stop this long before the job deadline
+	deadlineMargin = 5 * time.Second
+
+	// cloudwatch metric names
+	recordsPerZoneMetricName         = "route53RecordsPerZone"
+	vpcAssociationsPerZoneMetricName = "route53VPCAssociationsPerZone"
+)
+
+// zoneUsage is the usage and limit of a single hosted zone limit type
+type zoneUsage struct {
+	count int64
+	limit float64
+}
+
+// NewHostedZoneLimitsJob will create a new HostedZoneLimitsJob
+func NewHostedZoneLimitsJob(config HostedZoneLimitsJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	if config.CallInterval <= 0 {
+		config.CallInterval = defaultCallInterval
+	}
+	job := &HostedZoneLimitsJob{
+		Route53Client: config.Route53Client,
+		CallInterval:  config.CallInterval,
+		jobName:       hostedZoneLimitsJobPrefix + "-" + config.Route53Client.GetRegion(),
+		region:        config.Route53Client.GetRegion(),
+		Logger:        config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns records per zone and vpc associations per private zone utilization
+func (j *HostedZoneLimitsJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	var (
+		records      []utils.ResourceCount
+		privateZones []utils.ResourceCount
+	)
+	paginator := route53.NewListHostedZonesPaginator(j.Route53Client, &route53.ListHostedZonesInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, zone := range output.HostedZones {
+			zoneId := strings.TrimPrefix(aws.ToString(zone.Id), hostedZoneIdPrefix)
+			rc := utils.ResourceCount{
+				Name:       zoneId,
+				Count:      aws.ToInt64(zone.ResourceRecordSetCount),
+				Dimensions: map[string]string{hostedZoneDimension: zoneId},
+			}
+			records = append(records, rc)
+			if zone.Config != nil && zone.Config.PrivateZone {
+				privateZones = append(privateZones, rc)
+			}
+		}
+	}
+	j.Logger.Debug("%s hosted zones : %d, private : %d", j.GetJobName(), len(records), len(privateZones))
+	topRecords := utils.TopResourceCounts(records, topZones)
+	if n := j.privateZoneBudget(ctx, len(topRecords)); len(privateZones) > n {
+		// zones with more records are more likely to be shared with more vpcs
+		j.Logger.Warn("%s %d private zones, only the %d with the most records are looked up", j.GetJobName(), len(privateZones), n)
+		privateZones = utils.TopResourceCounts(privateZones, n)
+	}
+
+	ticker := time.NewTicker(j.CallInterval)
+	defer ticker.Stop()
+
+	now := time.Now()
+	var out []sharedtypes.CloudWatchMetric
+	for _, rc := range topRecords {
+		if utils.NearDeadline(ctx, deadlineMargin) {
+			j.Logger.Warn("%s job deadline near, skipping the remaining hosted zones", j.GetJobName())
+			return out, nil
+		}
+		usage, err := j.getZoneUsage(ctx, ticker, rc.Name, r53Types.HostedZoneLimitTypeMaxRrsetsByZone)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, utils.PercentMetric(recordsPerZoneMetricName, utils.Utilization(float64(usage.count), usage.limit), rc.Dimensions, now))
+	}
+
+	vpcAssociations := make([]utils.ResourceCount, 0, len(privateZones))
+	limits := make(map[string]float64, len(privateZones))
+	for _, rc := range privateZones {
+		if utils.NearDeadline(ctx, deadlineMargin) {
+			j.Logger.Warn("%s job deadline near, vpc associations looked up for %d private zones only", j.GetJobName(), len(vpcAssociations))
+			break
+		}
+		usage, err := j.getZoneUsage(ctx, ticker, rc.Name, r53Types.HostedZoneLimitTypeMaxVpcsAssociatedByZone)
+		if err != nil {
+			return nil, err
+		}
+		limits[rc.Name] = usage.limit
+		vpcAssociations = append(vpcAssociations, utils.ResourceCount{
+			Name:       rc.Name,
+			Count:      usage.count,
+			Dimensions: rc.Dimensions,
+		})
+	}
+	for _, rc := range utils.TopResourceCounts(vpcAssociations, topZones) {
+		out = append(out, utils.PercentMetric(vpcAssociationsPerZoneMetricName, utils.Utilization(float64(rc.Count), limits[rc.Name]), rc.Dimensions, now))
+	}
+	return out, nil
+}
+
+// privateZoneBudget returns how many private zones can be looked up after the record
+// lookups, given the calls left before the context deadline
+func (j *HostedZoneLimitsJob) privateZoneBudget(ctx context.Context, recordLookups int) int {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return maxPrivateZones
+	}
+	calls := int((time.Until(deadline)-deadlineMargin)/j.CallInterval) - recordLookups
+	return max(0, min(calls, maxPrivateZones))
+}
+
+// getZoneUsage waits for the next tick, then reads the usage and limit of one limit type for a hosted zone
+func (j *HostedZoneLimitsJob) getZoneUsage(ctx context.Context, ticker *time.Ticker, zoneId string, limitType r53Types.HostedZoneLimitType) (zoneUsage, error) {
+	select {
+	case <-ctx.Done():
+		return zoneUsage{}, ctx.Err()
+	case <-ticker.C:
+	}
+	output, err := j.Route53Client.GetHostedZoneLimit(ctx, &route53.GetHostedZoneLimitInput{
+		HostedZoneId: aws.String(zoneId),
+		Type:         limitType,
+	})
+	if err != nil {
+		return zoneUsage{}, err
+	}
+	usage := zoneUsage{count: output.Count}
+	if output.Limit != nil {
+		usage.limit = float64(aws.ToInt64(output.Limit.Value))
+	}
+	j.Logger.Debug("%s %s %s : %d/%.0f", j.GetJobName(), zoneId, limitType, usage.count, usage.limit)
+	return usage, nil
+}
+
+// GetJobName return the name of the job
+func (j *HostedZoneLimitsJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *HostedZoneLimitsJob) GetRegion() string {
+	return j.region
+}
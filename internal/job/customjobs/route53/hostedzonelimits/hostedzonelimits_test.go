@@ -0,0 +1,149 @@
+package hostedzonelimits
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	r53Types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/route53client"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newZoneLimit builds a GetHostedZoneLimit response
+func newZoneLimit(limitType r53Types.HostedZoneLimitType, count, limit int64) *route53.GetHostedZoneLimitOutput {
+	return &route53.GetHostedZoneLimitOutput{
+		Count: count,
+		Limit: &r53Types.HostedZoneLimit{Type: limitType, Value: aws.Int64(limit)},
+	}
+}
+
+// newFakeRoute53Client builds a fake with n zones over two pages, zone i holding i*100
+// records against a 10000 record limit. Even zones are private with i VPC associations of 300.
+func newFakeRoute53Client(n int) *route53client.FakeRoute53Client {
+	f := &route53client.FakeRoute53Client{
+		Region:                      "us-east-1",
+		HostedZoneLimits:            map[string]map[r53Types.HostedZoneLimitType]*route53.GetHostedZoneLimitOutput{},
+		ListHostedZonesPageOutputs:  []*route53.ListHostedZonesOutput{{}, {}},
+		ErrOnListHostedZonesCall:    -1,
+		ErrOnGetHostedZoneLimitCall: -1,
+	}
+	for i := 1; i <= n; i++ {
+		zoneId := fmt.Sprintf("Z%02d", i)
+		private := i%2 == 0
+		page := f.ListHostedZonesPageOutputs[i%2]
+		page.HostedZones = append(page.HostedZones, r53Types.HostedZone{
+			Id:                     aws.String(hostedZoneIdPrefix + zoneId),
+			Name:                   aws.String(zoneId + ".example.com."),
+			Config:                 &r53Types.HostedZoneConfig{PrivateZone: private},
+			ResourceRecordSetCount: aws.Int64(int64(i * 100)),
+		})
+		f.HostedZoneLimits[zoneId] = map[r53Types.HostedZoneLimitType]*route53.GetHostedZoneLimitOutput{
+			r53Types.HostedZoneLimitTypeMaxRrsetsByZone: newZoneLimit(r53Types.HostedZoneLimitTypeMaxRrsetsByZone, int64(i*100), 10000),
+		}
+		if private {
+			f.HostedZoneLimits[zoneId][r53Types.HostedZoneLimitTypeMaxVpcsAssociatedByZone] = newZoneLimit(r53Types.HostedZoneLimitTypeMaxVpcsAssociatedByZone, int64(i), 300)
+		}
+	}
+	return f
+}
+
+func TestHostedZoneLimitsJob_Execute(t *testing.T) {
+	// 24 zones, 12 of them private
+	r53Fake := newFakeRoute53Client(24)
+	j, err := NewHostedZoneLimitsJob(HostedZoneLimitsJobConfig{Route53Client: r53Fake, CallInterval: time.Millisecond})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, hostedZoneLimitsJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, topZones, jobtest.CountMetrics(metrics, recordsPerZoneMetricName))
+	assert.Equal(t, topZones, jobtest.CountMetrics(metrics, vpcAssociationsPerZoneMetricName))
+
+	cases := []struct {
+		name   string
+		zoneId string
+		value  float64
+	}{
+		{recordsPerZoneMetricName, "Z24", 24},
+		{recordsPerZoneMetricName, "Z15", 15},
+		{vpcAssociationsPerZoneMetricName, "Z24", 8},
+		{vpcAssociationsPerZoneMetricName, "Z06", 2},
+	}
+	for _, tc := range cases {
+		m, ok := jobtest.FindMetric(metrics, tc.name, hostedZoneDimension, tc.zoneId)
+		if assert.True(t, ok, "missing metric %s %s", tc.name, tc.zoneId) {
+			assert.InDelta(t, tc.value, m.Value, 0.001, "%s %s", tc.name, tc.zoneId)
+		}
+	}
+	_, ok := jobtest.FindMetric(metrics, recordsPerZoneMetricName, hostedZoneDimension, "Z14")
+	assert.False(t, ok, "zones with the fewest records should be dropped")
+	_, ok = jobtest.FindMetric(metrics, vpcAssociationsPerZoneMetricName, hostedZoneDimension, "Z04")
+	assert.False(t, ok, "zones with the fewest vpc associations should be dropped")
+
+	// record limits are only looked up for the top zones, vpc limits for every private zone
+	assert.Len(t, r53Fake.RequestedHostedZoneIds, topZones+12)
+	assert.NotContains(t, r53Fake.RequestedHostedZoneIds, hostedZoneIdPrefix+"Z24", "ids should be stripped of their prefix")
+}
+
+func TestHostedZoneLimitsJob_CallInterval(t *testing.T) {
+	// 4 record limit and 2 vpc association limit calls
+	r53Fake := newFakeRoute53Client(4)
+	j, _ := NewHostedZoneLimitsJob(HostedZoneLimitsJobConfig{Route53Client: r53Fake, CallInterval: 20 * time.Millisecond})
+
+	start := time.Now()
+	_, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 6*20*time.Millisecond, "calls should be spaced by the interval")
+}
+
+func TestHostedZoneLimitsJob_Deadline(t *testing.T) {
+	// 10 record limit calls leave time for about 6 of the 12 private zones
+	r53Fake := newFakeRoute53Client(24)
+	j, _ := NewHostedZoneLimitsJob(HostedZoneLimitsJobConfig{Route53Client: r53Fake, CallInterval: 10 * time.Millisecond})
+	ctx, cancel := context.WithTimeout(context.Background(), deadlineMargin+160*time.Millisecond)
+	defer cancel()
+
+	metrics, err := j.Execute(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, topZones, jobtest.CountMetrics(metrics, recordsPerZoneMetricName))
+	vpcMetrics := jobtest.CountMetrics(metrics, vpcAssociationsPerZoneMetricName)
+	assert.Greater(t, vpcMetrics, 0)
+	assert.Less(t, vpcMetrics, 12, "private zones past the deadline budget should not be looked up")
+	_, ok := jobtest.FindMetric(metrics, vpcAssociationsPerZoneMetricName, hostedZoneDimension, "Z24")
+	assert.True(t, ok, "private zones with the most records are looked up first")
+}
+
+func TestHostedZoneLimitsJob_DeadlineNear(t *testing.T) {
+	r53Fake := newFakeRoute53Client(4)
+	j, _ := NewHostedZoneLimitsJob(HostedZoneLimitsJobConfig{Route53Client: r53Fake, CallInterval: time.Millisecond})
+	ctx, cancel := context.WithTimeout(context.Background(), deadlineMargin/2)
+	defer cancel()
+
+	metrics, err := j.Execute(ctx)
+	assert.NoError(t, err, "running out of time is not an error")
+	assert.Empty(t, metrics)
+	assert.Empty(t, r53Fake.RequestedHostedZoneIds)
+}
+
+func TestHostedZoneLimitsJob_NoZones(t *testing.T) {
+	r53Fake := newFakeRoute53Client(0)
+	j, _ := NewHostedZoneLimitsJob(HostedZoneLimitsJobConfig{Route53Client: r53Fake, CallInterval: time.Millisecond})
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, metrics)
+	assert.Empty(t, r53Fake.RequestedHostedZoneIds)
+}
+
+func TestHostedZoneLimitsJob_Error(t *testing.T) {
+	r53Fake := newFakeRoute53Client(4)
+	r53Fake.ErrOnListHostedZonesCall = 1
+	j, _ := NewHostedZoneLimitsJob(HostedZoneLimitsJobConfig{Route53Client: r53Fake, CallInterval: time.Millisecond})
+	jobtest.AssertExecuteFails(t, j)
+}
@@ -0,0 +1,103 @@
+package buckets
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/s3client"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// BucketsJob will implement the Job interface
+// ListBuckets returns every bucket owned by the account regardless of the region
+// it is called from, so the bucket quota is an account level quota.
+
+type BucketsJob struct {
+	S3Client            s3client.S3Client
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	jobName             string
+	region              string
+	Logger              logger.Logger
+}
+
+type BucketsJobConfig struct {
+	S3Client            s3client.S3Client
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	Logger              logger.Logger
+}
+
+const (
+	bucketsJobPrefix = "s3Buckets"
+	serviceCode      = "s3"
+	// page size for ListBuckets, without it the api caps the response at 10000 buckets
+	maxBucketsPerPage = 1000
+
+	// cloudwatch metric names
+	bucketsMetricName = "s3Buckets"
+)
+
+var (
+	// General purpose buckets per account
+	bucketsQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, QuotaCode: "L-DC2B2D3D", Default: 10000}
+)
+
+// NewBucketsJob will create a new BucketsJob
+func NewBucketsJob(config BucketsJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	job := &BucketsJob{
+		S3Client:            config.S3Client,
+		ServiceQuotasClient: config.ServiceQuotasClient,
+		jobName:             bucketsJobPrefix + "-" + config.S3Client.GetRegion(),
+		region:              config.S3Client.GetRegion(),
+		Logger:              config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns the bucket utilization of the account.  Missing permissions
+// skip the job instead of failing it.
+func (j *BucketsJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	buckets := 0
+	paginator := s3.NewListBucketsPaginator(j.S3Client, &s3.ListBucketsInput{
+		MaxBuckets: aws.Int32(maxBucketsPerPage),
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			if utils.IsAccessDenied(err) {
+				j.Logger.Warn("%s skipped, missing permission s3:ListAllMyBuckets : %v", j.GetJobName(), err)
+				return nil, nil
+			}
+			return nil, err
+		}
+		buckets += len(output.Buckets)
+	}
+
+	limit, err := servicequotaclient.GetQuotaValue(ctx, j.ServiceQuotasClient, bucketsQuota)
+	if err != nil {
+		return nil, err
+	}
+	j.Logger.Debug("%s buckets : %d/%.0f", j.GetJobName(), buckets, limit)
+
+	return []sharedtypes.CloudWatchMetric{
+		utils.PercentMetric(bucketsMetricName, utils.Utilization(float64(buckets), limit), nil, time.Now()),
+	}, nil
+}
+
+// GetJobName return the name of the job
+func (j *BucketsJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *BucketsJob) GetRegion() string {
+	return j.region
+}
@@ -0,0 +1,63 @@
+package buckets
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3Types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/s3client"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeS3Client builds a fake with 30 buckets over two pages
+func newFakeS3Client() *s3client.FakeS3Client {
+	return &s3client.FakeS3Client{
+		Region: "us-east-1",
+		ListBucketsPageOutputs: []*s3.ListBucketsOutput{
+			{Buckets: make([]s3Types.Bucket, 20)},
+			{Buckets: make([]s3Types.Bucket, 10)},
+		},
+		ErrOnListBucketsCall: -1,
+	}
+}
+
+func TestBucketsJob_Execute(t *testing.T) {
+	s3Fake := newFakeS3Client()
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 120}
+	j, err := NewBucketsJob(BucketsJobConfig{S3Client: s3Fake, ServiceQuotasClient: sqFake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, bucketsJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, metrics, 1) {
+		assert.Equal(t, bucketsMetricName, metrics[0].Name)
+		assert.InDelta(t, 25, metrics[0].Value, 0.001)
+	}
+	assert.Equal(t, []string{bucketsQuota.QuotaCode}, sqFake.RequestedQuotaCodes)
+}
+
+func TestBucketsJob_AccessDeniedSkips(t *testing.T) {
+	s3Fake := newFakeS3Client()
+	s3Fake.ListBucketsError = &smithy.GenericAPIError{Code: "AccessDenied", Message: "Access Denied"}
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 120}
+	j, _ := NewBucketsJob(BucketsJobConfig{S3Client: s3Fake, ServiceQuotasClient: sqFake})
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, metrics)
+	assert.Empty(t, sqFake.RequestedQuotaCodes, "should not look up quotas when skipped")
+}
+
+func TestBucketsJob_Error(t *testing.T) {
+	s3Fake := newFakeS3Client()
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 120}
+	s3Fake.ErrOnListBucketsCall = 1
+	j, _ := NewBucketsJob(BucketsJobConfig{S3Client: s3Fake, ServiceQuotasClient: sqFake})
+	jobtest.AssertExecuteFails(t, j)
+}
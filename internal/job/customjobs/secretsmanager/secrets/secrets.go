@@ -0,0 +1,79 @@
+package secrets
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/secretsmanagerclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// SecretsJob will implement the Job interface
+// It counts the secrets of the region against the fixed secrets per region maximum.
+
+type SecretsJob struct {
+	SecretsManagerClient secretsmanagerclient.SecretsManagerClient
+	jobName              string
+	region               string
+	Logger               logger.Logger
+}
+
+type SecretsJobConfig struct {
+	SecretsManagerClient secretsmanagerclient.SecretsManagerClient
+	Logger               logger.Logger
+}
+
+const (
+	secretsJobPrefix = "secretsManagerSecrets"
+	// maximum number of secrets per region
+	maxSecrets = 500000
+
+	// cloudwatch metric names
+	secretsMetricName = "secretsManagerSecrets"
+)
+
+// NewSecretsJob will create a new SecretsJob
+func NewSecretsJob(config SecretsJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	job := &SecretsJob{
+		SecretsManagerClient: config.SecretsManagerClient,
+		jobName:              secretsJobPrefix + "-" + config.SecretsManagerClient.GetRegion(),
+		region:               config.SecretsManagerClient.GetRegion(),
+		Logger:               config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns secret utilization
+func (j *SecretsJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	secrets := 0
+	paginator := secretsmanager.NewListSecretsPaginator(j.SecretsManagerClient, &secretsmanager.ListSecretsInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		secrets += len(output.SecretList)
+	}
+	j.Logger.Debug("%s secrets : %d/%d", j.GetJobName(), secrets, maxSecrets)
+
+	return []sharedtypes.CloudWatchMetric{
+		utils.PercentMetric(secretsMetricName, utils.Utilization(float64(secrets), maxSecrets), nil, time.Now()),
+	}, nil
+}
+
+// GetJobName return the name of the job
+func (j *SecretsJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *SecretsJob) GetRegion() string {
+	return j.region
+}
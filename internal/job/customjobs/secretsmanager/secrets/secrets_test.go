@@ -0,0 +1,46 @@
+package secrets
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	smTypes "github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/secretsmanagerclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeSecretsManagerClient builds a fake with 5000 secrets over two pages
+func newFakeSecretsManagerClient() *secretsmanagerclient.FakeSecretsManagerClient {
+	return &secretsmanagerclient.FakeSecretsManagerClient{
+		Region: "us-east-1",
+		ListSecretsPageOutputs: []*secretsmanager.ListSecretsOutput{
+			{SecretList: make([]smTypes.SecretListEntry, 4000)},
+			{SecretList: make([]smTypes.SecretListEntry, 1000)},
+		},
+		ErrOnListSecretsCall: -1,
+	}
+}
+
+func TestSecretsJob_Execute(t *testing.T) {
+	smFake := newFakeSecretsManagerClient()
+	j, err := NewSecretsJob(SecretsJobConfig{SecretsManagerClient: smFake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, secretsJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, metrics, 1) {
+		assert.Equal(t, secretsMetricName, metrics[0].Name)
+		assert.InDelta(t, 1, metrics[0].Value, 0.001)
+	}
+}
+
+func TestSecretsJob_Error(t *testing.T) {
+	smFake := newFakeSecretsManagerClient()
+	smFake.ErrOnListSecretsCall = 1
+	j, _ := NewSecretsJob(SecretsJobConfig{SecretsManagerClient: smFake})
+	jobtest.AssertExecuteFails(t, j)
+}
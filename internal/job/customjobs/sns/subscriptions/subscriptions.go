@@ -0,0 +1,111 @@
+package subscriptions
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/snsclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// SubscriptionsJob will implement the Job interface
+// ListSubscriptions returns the subscriptions of every topic in the region, so one
+// listing is grouped by topic instead of listing each topic. FIFO topics have a far
+// lower maximum than standard topics, so the fullest topics of each kind are emitted.
+
+type SubscriptionsJob struct {
+	SNSClient snsclient.SNSClient
+	jobName   string
+	region    string
+	Logger    logger.Logger
+}
+
+type SubscriptionsJobConfig struct {
+	SNSClient snsclient.SNSClient
+	Logger    logger.Logger
+}
+
+const (
+	subscriptionsJobPrefix = "snsSubscriptions"
+	topicDimension         = "topic"
+	fifoTopicSuffix        = ".fifo"
+	// maximum number of subscriptions per topic
+	maxSubscriptionsPerStandardTopic = 12500000
+	maxSubscriptionsPerFifoTopic     = 100
+	// only the topics with the most subscriptions get a metric, per topic kind
+	topTopics = 10
+
+	// cloudwatch metric names
+	subscriptionsPerTopicMetricName = "snsSubscriptionsPerTopic"
+)
+
+// NewSubscriptionsJob will create a new SubscriptionsJob
+func NewSubscriptionsJob(config SubscriptionsJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	job := &SubscriptionsJob{
+		SNSClient: config.SNSClient,
+		jobName:   subscriptionsJobPrefix + "-" + config.SNSClient.GetRegion(),
+		region:    config.SNSClient.GetRegion(),
+		Logger:    config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns subscriptions per topic utilization for the fullest standard and FIFO topics
+func (j *SubscriptionsJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	perTopic := map[string]int64{}
+	paginator := sns.NewListSubscriptionsPaginator(j.SNSClient, &sns.ListSubscriptionsInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, subscription := range output.Subscriptions {
+			perTopic[aws.ToString(subscription.TopicArn)]++
+		}
+	}
+
+	var standard, fifo []utils.ResourceCount
+	for topicArn, count := range perTopic {
+		topic := topicArn[strings.LastIndex(topicArn, ":")+1:]
+		rc := utils.ResourceCount{
+			Name:       topic,
+			Count:      count,
+			Dimensions: map[string]string{topicDimension: topic},
+		}
+		if strings.HasSuffix(topic, fifoTopicSuffix) {
+			fifo = append(fifo, rc)
+		} else {
+			standard = append(standard, rc)
+		}
+	}
+	j.Logger.Debug("%s topics with subscriptions : %d standard, %d fifo", j.GetJobName(), len(standard), len(fifo))
+
+	now := time.Now()
+	var out []sharedtypes.CloudWatchMetric
+	for _, rc := range utils.TopResourceCounts(standard, topTopics) {
+		out = append(out, utils.PercentMetric(subscriptionsPerTopicMetricName, utils.Utilization(float64(rc.Count), maxSubscriptionsPerStandardTopic), rc.Dimensions, now))
+	}
+	for _, rc := range utils.TopResourceCounts(fifo, topTopics) {
+		out = append(out, utils.PercentMetric(subscriptionsPerTopicMetricName, utils.Utilization(float64(rc.Count), maxSubscriptionsPerFifoTopic), rc.Dimensions, now))
+	}
+	return out, nil
+}
+
+// GetJobName return the name of the job
+func (j *SubscriptionsJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *SubscriptionsJob) GetRegion() string {
+	return j.region
+}
@@ -0,0 +1,83 @@
+package subscriptions
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	snsTypes "github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/snsclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// subscriptions builds n subscriptions to the given topic
+func subscriptions(topic string, n int) []snsTypes.Subscription {
+	out := make([]snsTypes.Subscription, n)
+	for i := range out {
+		out[i].TopicArn = aws.String("arn:aws:sns:us-east-1:123456789012:" + topic)
+	}
+	return out
+}
+
+// newFakeSNSClient builds a fake with 12 standard topics holding i*1000 subscriptions
+// and two FIFO topics, with subscriptions spread over two pages
+func newFakeSNSClient() *snsclient.FakeSNSClient {
+	f := &snsclient.FakeSNSClient{
+		Region:                       "us-east-1",
+		ListSubscriptionsPageOutputs: []*sns.ListSubscriptionsOutput{{}, {}},
+		ErrOnListTopicsCall:          -1,
+		ErrOnListSubscriptionsCall:   -1,
+	}
+	for i := 1; i <= 12; i++ {
+		topic := fmt.Sprintf("topic%02d", i)
+		for p := range f.ListSubscriptionsPageOutputs {
+			page := f.ListSubscriptionsPageOutputs[p]
+			page.Subscriptions = append(page.Subscriptions, subscriptions(topic, i*500)...)
+		}
+	}
+	f.ListSubscriptionsPageOutputs[0].Subscriptions = append(f.ListSubscriptionsPageOutputs[0].Subscriptions, subscriptions("orders.fifo", 60)...)
+	f.ListSubscriptionsPageOutputs[1].Subscriptions = append(f.ListSubscriptionsPageOutputs[1].Subscriptions, subscriptions("orders.fifo", 30)...)
+	f.ListSubscriptionsPageOutputs[1].Subscriptions = append(f.ListSubscriptionsPageOutputs[1].Subscriptions, subscriptions("audit.fifo", 5)...)
+	return f
+}
+
+func TestSubscriptionsJob_Execute(t *testing.T) {
+	snsFake := newFakeSNSClient()
+	j, err := NewSubscriptionsJob(SubscriptionsJobConfig{SNSClient: snsFake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, subscriptionsJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, metrics, topTopics+2)
+
+	cases := []struct {
+		topic string
+		value float64
+	}{
+		{"topic12", 12000.0 / maxSubscriptionsPerStandardTopic * 100},
+		{"topic03", 3000.0 / maxSubscriptionsPerStandardTopic * 100},
+		// fifo topics are measured against their own maximum and are never crowded out
+		{"orders.fifo", 90},
+		{"audit.fifo", 5},
+	}
+	for _, tc := range cases {
+		m, ok := jobtest.FindMetric(metrics, subscriptionsPerTopicMetricName, topicDimension, tc.topic)
+		if assert.True(t, ok, "missing metric for %s", tc.topic) {
+			assert.InDelta(t, tc.value, m.Value, 0.0001, tc.topic)
+		}
+	}
+	_, ok := jobtest.FindMetric(metrics, subscriptionsPerTopicMetricName, topicDimension, "topic01")
+	assert.False(t, ok, "standard topics with the fewest subscriptions should be dropped")
+}
+
+func TestSubscriptionsJob_Error(t *testing.T) {
+	snsFake := newFakeSNSClient()
+	snsFake.ErrOnListSubscriptionsCall = 1
+	j, _ := NewSubscriptionsJob(SubscriptionsJobConfig{SNSClient: snsFake})
+	jobtest.AssertExecuteFails(t, j)
+}
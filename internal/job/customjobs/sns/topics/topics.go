@@ -0,0 +1,92 @@
+package topics
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/snsclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// TopicsJob will implement the Job interface
+// It counts the standard and FIFO topics of the region against the topics quota.
+
+type TopicsJob struct {
+	SNSClient           snsclient.SNSClient
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	jobName             string
+	region              string
+	Logger              logger.Logger
+}
+
+type TopicsJobConfig struct {
+	SNSClient           snsclient.SNSClient
+	ServiceQuotasClient servicequotaclient.ServiceQuotasClient
+	Logger              logger.Logger
+}
+
+const (
+	topicsJobPrefix = "snsTopics"
+	serviceCode     = "sns"
+
+	// cloudwatch metric names
+	topicsMetricName = "snsTopics"
+)
+
+var (
+	// Topics per account per region
+	topicsQuota = servicequotaclient.QuotaSpec{ServiceCode: serviceCode, QuotaCode: "L-61103206", Default: 100000}
+)
+
+// NewTopicsJob will create a new TopicsJob
+func NewTopicsJob(config TopicsJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	job := &TopicsJob{
+		SNSClient:           config.SNSClient,
+		ServiceQuotasClient: config.ServiceQuotasClient,
+		jobName:             topicsJobPrefix + "-" + config.SNSClient.GetRegion(),
+		region:              config.SNSClient.GetRegion(),
+		Logger:              config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns topic utilization
+func (j *TopicsJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	topics := 0
+	paginator := sns.NewListTopicsPaginator(j.SNSClient, &sns.ListTopicsInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		topics += len(output.Topics)
+	}
+
+	limit, err := servicequotaclient.GetQuotaValue(ctx, j.ServiceQuotasClient, topicsQuota)
+	if err != nil {
+		return nil, err
+	}
+	j.Logger.Debug("%s topics : %d/%.0f", j.GetJobName(), topics, limit)
+
+	return []sharedtypes.CloudWatchMetric{
+		utils.PercentMetric(topicsMetricName, utils.Utilization(float64(topics), limit), nil, time.Now()),
+	}, nil
+}
+
+// GetJobName return the name of the job
+func (j *TopicsJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *TopicsJob) GetRegion() string {
+	return j.region
+}
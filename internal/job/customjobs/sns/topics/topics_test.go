@@ -0,0 +1,51 @@
+package topics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	snsTypes "github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/servicequotaclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/snsclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeSNSClient builds a fake with 150 topics over two pages
+func newFakeSNSClient() *snsclient.FakeSNSClient {
+	return &snsclient.FakeSNSClient{
+		Region: "us-east-1",
+		ListTopicsPageOutputs: []*sns.ListTopicsOutput{
+			{Topics: make([]snsTypes.Topic, 100)},
+			{Topics: make([]snsTypes.Topic, 50)},
+		},
+		ErrOnListTopicsCall:        -1,
+		ErrOnListSubscriptionsCall: -1,
+	}
+}
+
+func TestTopicsJob_Execute(t *testing.T) {
+	snsFake := newFakeSNSClient()
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 600}
+	j, err := NewTopicsJob(TopicsJobConfig{SNSClient: snsFake, ServiceQuotasClient: sqFake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, topicsJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, metrics, 1) {
+		assert.Equal(t, topicsMetricName, metrics[0].Name)
+		assert.InDelta(t, 25, metrics[0].Value, 0.001)
+	}
+	assert.Equal(t, []string{topicsQuota.QuotaCode}, sqFake.RequestedQuotaCodes)
+}
+
+func TestTopicsJob_Error(t *testing.T) {
+	snsFake := newFakeSNSClient()
+	sqFake := &servicequotaclient.FakeServiceQuotaClient{Region: "us-east-1", QuotaValue: 600}
+	snsFake.ErrOnListTopicsCall = 1
+	j, _ := NewTopicsJob(TopicsJobConfig{SNSClient: snsFake, ServiceQuotasClient: sqFake})
+	jobtest.AssertExecuteFails(t, j)
+}
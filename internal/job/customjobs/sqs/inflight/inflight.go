@@ -0,0 +1,116 @@
+package inflight
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwTypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/cwclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// InFlightJob will implement the Job interface
+// SQS has no quota on the number of queues, its limit is the number of in flight
+// messages of each queue.  A single Metrics Insights query returns the queues with the
+// highest ApproximateNumberOfMessagesNotVisible, so the cost does not grow with the
+// number of queues.
+
+type InFlightJob struct {
+	CloudWatchClient cwclient.CloudWatchClient
+	jobName          string
+	region           string
+	Logger           logger.Logger
+}
+
+type InFlightJobConfig struct {
+	CloudWatchClient cwclient.CloudWatchClient
+	Logger           logger.Logger
+}
+
+const (
+	inFlightJobPrefix = "sqsInFlightMessages"
+	queueDimension    = "queue"
+	// maximum number of in flight messages per standard or FIFO queue
+	maxInFlightMessagesPerQueue = 120000
+	// only the queues with the most in flight messages get a metric
+	topQueues = 10
+
+	// source metric
+	queryID  = "inflight"
+	period   = 60
+	lookback = 5 * time.Minute
+
+	// cloudwatch metric names
+	inFlightMessagesPerQueueMetricName = "sqsInFlightMessagesPerQueue"
+)
+
+// inFlightQuery returns the fullest queues of the region, one result per queue labelled with its name
+var inFlightQuery = fmt.Sprintf(
+	`SELECT MAX(ApproximateNumberOfMessagesNotVisible) FROM SCHEMA("AWS/SQS", QueueName) GROUP BY QueueName ORDER BY MAX() DESC LIMIT %d`,
+	topQueues,
+)
+
+// NewInFlightJob will create a new InFlightJob
+func NewInFlightJob(config InFlightJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	job := &InFlightJob{
+		CloudWatchClient: config.CloudWatchClient,
+		jobName:          inFlightJobPrefix + "-" + config.CloudWatchClient.GetRegion(),
+		region:           config.CloudWatchClient.GetRegion(),
+		Logger:           config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns in flight messages per queue utilization for the fullest queues
+func (j *InFlightJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	now := time.Now()
+	output, err := j.CloudWatchClient.GetMetricData(ctx, &cloudwatch.GetMetricDataInput{
+		StartTime: aws.Time(now.Add(-lookback)),
+		EndTime:   aws.Time(now),
+		MetricDataQueries: []cwTypes.MetricDataQuery{
+			{
+				Id:         aws.String(queryID),
+				Expression: aws.String(inFlightQuery),
+				Period:     aws.Int32(period),
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var out []sharedtypes.CloudWatchMetric
+	for _, result := range output.MetricDataResults {
+		// queues without datapoints in the window have nothing in flight
+		if len(result.Values) == 0 {
+			continue
+		}
+		peak := result.Values[0]
+		for _, v := range result.Values[1:] {
+			peak = max(peak, v)
+		}
+		queue := aws.ToString(result.Label)
+		j.Logger.Debug("%s queue %s : %.0f in flight", j.GetJobName(), queue, peak)
+		out = append(out, utils.PercentMetric(inFlightMessagesPerQueueMetricName, utils.Utilization(peak, maxInFlightMessagesPerQueue), map[string]string{queueDimension: queue}, now))
+	}
+	return out, nil
+}
+
+// GetJobName return the name of the job
+func (j *InFlightJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *InFlightJob) GetRegion() string {
+	return j.region
+}
@@ -0,0 +1,46 @@
+package inflight
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cwTypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/cwclient"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInFlightJob_Execute(t *testing.T) {
+	cwFake := &cwclient.FakeCloudWatchClient{
+		Region: "us-east-1",
+		MetricDataResults: map[string][]cwTypes.MetricDataResult{queryID: {
+			{Id: aws.String(queryID), Label: aws.String("orders"), Values: []float64{60000, 90000, 30000}},
+			{Id: aws.String(queryID), Label: aws.String("payments.fifo"), Values: []float64{12000}},
+			{Id: aws.String(queryID), Label: aws.String("idle")},
+		}},
+	}
+	j, err := NewInFlightJob(InFlightJobConfig{CloudWatchClient: cwFake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, inFlightJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	got := map[string]float64{}
+	for _, m := range metrics {
+		assert.Equal(t, inFlightMessagesPerQueueMetricName, m.Name)
+		assert.Equal(t, cwTypes.StandardUnitPercent, m.Unit)
+		got[m.Metadata[queueDimension]] = m.Value
+	}
+	assert.InDeltaMapValues(t, map[string]float64{"orders": 75, "payments.fifo": 10}, got, 0.001)
+
+	// one region wide query, whatever the number of queues
+	if assert.Len(t, cwFake.GetMetricDataInputs, 1) {
+		assert.Equal(t, inFlightQuery, aws.ToString(cwFake.GetMetricDataInputs[0].MetricDataQueries[0].Expression))
+	}
+
+	cwFake.ErrGetMetricData = true
+	metrics, err = j.Execute(context.Background())
+	assert.Error(t, err)
+	assert.Nil(t, metrics)
+}
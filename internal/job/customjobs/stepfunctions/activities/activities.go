@@ -0,0 +1,79 @@
+package activities
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/sfnclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// ActivitiesJob will implement the Job interface
+// It counts the registered activities of the region against the documented maximum.
+
+type ActivitiesJob struct {
+	SFNClient sfnclient.SFNClient
+	jobName   string
+	region    string
+	Logger    logger.Logger
+}
+
+type ActivitiesJobConfig struct {
+	SFNClient sfnclient.SFNClient
+	Logger    logger.Logger
+}
+
+const (
+	activitiesJobPrefix = "stepfunctionsActivities"
+	// maximum number of registered activities per region
+	maxActivities = 10000
+
+	// cloudwatch metric names
+	activitiesMetricName = "stepfunctionsActivities"
+)
+
+// NewActivitiesJob will create a new ActivitiesJob
+func NewActivitiesJob(config ActivitiesJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	job := &ActivitiesJob{
+		SFNClient: config.SFNClient,
+		jobName:   activitiesJobPrefix + "-" + config.SFNClient.GetRegion(),
+		region:    config.SFNClient.GetRegion(),
+		Logger:    config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns activities utilization
+func (j *ActivitiesJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	count := 0
+	paginator := sfn.NewListActivitiesPaginator(j.SFNClient, &sfn.ListActivitiesInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		count += len(output.Activities)
+	}
+	j.Logger.Debug("%s activities : %d/%d", j.GetJobName(), count, maxActivities)
+
+	return []sharedtypes.CloudWatchMetric{
+		utils.PercentMetric(activitiesMetricName, utils.Utilization(float64(count), maxActivities), nil, time.Now()),
+	}, nil
+}
+
+// GetJobName return the name of the job
+func (j *ActivitiesJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *ActivitiesJob) GetRegion() string {
+	return j.region
+}
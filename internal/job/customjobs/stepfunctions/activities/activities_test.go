@@ -0,0 +1,47 @@
+package activities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+	sfnTypes "github.com/aws/aws-sdk-go-v2/service/sfn/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/sfnclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job/jobtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeSFNClient builds a fake with 2500 activities over two pages
+func newFakeSFNClient() *sfnclient.FakeSFNClient {
+	return &sfnclient.FakeSFNClient{
+		Region: "us-east-1",
+		ListActivitiesPageOutputs: []*sfn.ListActivitiesOutput{
+			{Activities: make([]sfnTypes.ActivityListItem, 2000)},
+			{Activities: make([]sfnTypes.ActivityListItem, 500)},
+		},
+		ErrOnListStateMachinesCall: -1,
+		ErrOnListActivitiesCall:    -1,
+	}
+}
+
+func TestActivitiesJob_Execute(t *testing.T) {
+	sfnFake := newFakeSFNClient()
+	j, err := NewActivitiesJob(ActivitiesJobConfig{SFNClient: sfnFake})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", j.GetRegion())
+	assert.Equal(t, activitiesJobPrefix+"-us-east-1", j.GetJobName())
+
+	metrics, err := j.Execute(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, metrics, 1) {
+		assert.Equal(t, activitiesMetricName, metrics[0].Name)
+		assert.InDelta(t, 25, metrics[0].Value, 0.001)
+	}
+}
+
+func TestActivitiesJob_Error(t *testing.T) {
+	sfnFake := newFakeSFNClient()
+	sfnFake.ErrOnListActivitiesCall = 1
+	j, _ := NewActivitiesJob(ActivitiesJobConfig{SFNClient: sfnFake})
+	jobtest.AssertExecuteFails(t, j)
+}
@@ -0,0 +1,79 @@
+package statemachines
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+	"github.com/outofoffice3/aws-samples/geras/internal/awsclients/sfnclient"
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	"github.com/outofoffice3/aws-samples/geras/internal/logger"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+	"github.com/outofoffice3/aws-samples/geras/internal/utils"
+)
+
+// StateMachinesJob will implement the Job interface
+// It counts the registered state machines of the region against the documented maximum.
+
+type StateMachinesJob struct {
+	SFNClient sfnclient.SFNClient
+	jobName   string
+	region    string
+	Logger    logger.Logger
+}
+
+type StateMachinesJobConfig struct {
+	SFNClient sfnclient.SFNClient
+	Logger    logger.Logger
+}
+
+const (
+	stateMachinesJobPrefix = "stepfunctionsStateMachines"
+	// maximum number of registered state machines per region
+	maxStateMachines = 10000
+
+	// cloudwatch metric names
+	stateMachinesMetricName = "stepfunctionsStateMachines"
+)
+
+// NewStateMachinesJob will create a new StateMachinesJob
+func NewStateMachinesJob(config StateMachinesJobConfig) (job.Job, error) {
+	if config.Logger == nil {
+		config.Logger = &logger.NoopLogger{}
+	}
+	job := &StateMachinesJob{
+		SFNClient: config.SFNClient,
+		jobName:   stateMachinesJobPrefix + "-" + config.SFNClient.GetRegion(),
+		region:    config.SFNClient.GetRegion(),
+		Logger:    config.Logger,
+	}
+	return job, nil
+}
+
+// Execute returns state machines utilization
+func (j *StateMachinesJob) Execute(ctx context.Context) ([]sharedtypes.CloudWatchMetric, error) {
+	count := 0
+	paginator := sfn.NewListStateMachinesPaginator(j.SFNClient, &sfn.ListStateMachinesInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		count += len(output.StateMachines)
+	}
+	j.Logger.Debug("%s state machines : %d/%d", j.GetJobName(), count, maxStateMachines)
+
+	return []sharedtypes.CloudWatchMetric{
+		utils.PercentMetric(stateMachinesMetricName, utils.Utilization(float64(count), maxStateMachines), nil, time.Now()),
+	}, nil
+}
+
+// GetJobName return the name of the job
+func (j *StateMachinesJob) GetJobName() string {
+	return j.jobName
+}
+
+// GetRegion return the region of the job
+func (j *StateMachinesJob) GetRegion() string {
+	return j.region
+}
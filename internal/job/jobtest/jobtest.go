@@ -0,0 +1,52 @@
+// Package jobtest holds the assertions shared by the custom job tests.
+package jobtest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/outofoffice3/aws-samples/geras/internal/job"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
+)
+
+// FindMetric returns the first metric with the given name whose dimensions match the
+// given key and value pairs.  An empty value matches a metric without that dimension.
+func FindMetric(metrics []sharedtypes.CloudWatchMetric, name string, dims ...string) (sharedtypes.CloudWatchMetric, bool) {
+	for _, m := range metrics {
+		if m.Name == name && hasDimensions(m, dims) {
+			return m, true
+		}
+	}
+	return sharedtypes.CloudWatchMetric{}, false
+}
+
+// hasDimensions reports whether the metric holds every key and value pair
+func hasDimensions(m sharedtypes.CloudWatchMetric, dims []string) bool {
+	for i := 0; i+1 < len(dims); i += 2 {
+		if m.Metadata[dims[i]] != dims[i+1] {
+			return false
+		}
+	}
+	return true
+}
+
+// CountMetrics returns the number of metrics with the given name
+func CountMetrics(metrics []sharedtypes.CloudWatchMetric, name string) int {
+	count := 0
+	for _, m := range metrics {
+		if m.Name == name {
+			count++
+		}
+	}
+	return count
+}
+
+// AssertExecuteFails runs the job and asserts it returns an error and no metrics
+func AssertExecuteFails(t *testing.T, j job.Job) {
+	t.Helper()
+	metrics, err := j.Execute(context.Background())
+	assert.Error(t, err)
+	assert.Nil(t, metrics)
+}
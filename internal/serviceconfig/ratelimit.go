@@ -0,0 +1,231 @@
+package serviceconfig
+
+import (
+	"fmt"
+	"maps"
+	"sort"
+
+	applogger "github.com/outofoffice3/aws-samples/geras/internal/logger"
+	"github.com/outofoffice3/aws-samples/geras/internal/tokenbucket"
+)
+
+// RateLimitService describes a service whose api calls can be counted by the rate limit solution.
+// APIs maps the name used in rateLimitAPIs to the eventName cloudtrail records for that call.
+// Limits holds the default token bucket of an api, keyed by the same name.  Apis without a
+// published bucket are left out and are only simulated when the config sets one.
+type RateLimitService struct {
+	EventSource string
+	APIs        map[string]string
+	Limits      map[string]tokenbucket.Limit
+}
+
+// rateLimitCatalog lists the services and apis that can be configured under rateLimitAPIs.
+// Adding an api here is all that is needed to make it valid in the config.
+var rateLimitCatalog = map[string]RateLimitService{
+	"sts": {
+		EventSource: "sts.amazonaws.com",
+		APIs: map[string]string{
+			"assumeRole":                "AssumeRole",
+			"assumeRoleWithWebIdentity": "AssumeRoleWithWebIdentity",
+			"assumeRoleWithSAML":        "AssumeRoleWithSAML",
+			"getSessionToken":           "GetSessionToken",
+			"getFederationToken":        "GetFederationToken",
+		},
+		Limits: map[string]tokenbucket.Limit{
+			"assumeRole":                {BucketSize: 600, RefillRate: 600},
+			"assumeRoleWithWebIdentity": {BucketSize: 600, RefillRate: 600},
+			"assumeRoleWithSAML":        {BucketSize: 600, RefillRate: 600},
+			"getSessionToken":           {BucketSize: 600, RefillRate: 600},
+			"getFederationToken":        {BucketSize: 600, RefillRate: 600},
+		},
+	},
+	"kms": {
+		EventSource: "kms.amazonaws.com",
+		APIs: map[string]string{
+			"decrypt":                         "Decrypt",
+			"encrypt":                         "Encrypt",
+			"generateDataKey":                 "GenerateDataKey",
+			"generateDataKeyWithoutPlaintext": "GenerateDataKeyWithoutPlaintext",
+		},
+		Limits: map[string]tokenbucket.Limit{
+			"decrypt":                         {BucketSize: 5500, RefillRate: 5500},
+			"encrypt":                         {BucketSize: 5500, RefillRate: 5500},
+			"generateDataKey":                 {BucketSize: 5500, RefillRate: 5500},
+			"generateDataKeyWithoutPlaintext": {BucketSize: 5500, RefillRate: 5500},
+		},
+	},
+	"secretsmanager": {
+		EventSource: "secretsmanager.amazonaws.com",
+		APIs: map[string]string{
+			"getSecretValue": "GetSecretValue",
+			"describeSecret": "DescribeSecret",
+		},
+		Limits: map[string]tokenbucket.Limit{
+			"getSecretValue": {BucketSize: 10000, RefillRate: 10000},
+			"describeSecret": {BucketSize: 10000, RefillRate: 10000},
+		},
+	},
+	"ssm": {
+		EventSource: "ssm.amazonaws.com",
+		APIs: map[string]string{
+			"getParameter":        "GetParameter",
+			"getParameters":       "GetParameters",
+			"getParametersByPath": "GetParametersByPath",
+		},
+		Limits: map[string]tokenbucket.Limit{
+			"getParameter":        {BucketSize: 40, RefillRate: 40},
+			"getParameters":       {BucketSize: 40, RefillRate: 40},
+			"getParametersByPath": {BucketSize: 40, RefillRate: 40},
+		},
+	},
+	"ec2": {
+		EventSource: "ec2.amazonaws.com",
+		APIs: map[string]string{
+			"describeInstances": "DescribeInstances",
+			"runInstances":      "RunInstances",
+		},
+		Limits: map[string]tokenbucket.Limit{
+			"describeInstances": {BucketSize: 100, RefillRate: 20},
+			"runInstances":      {BucketSize: 1000, RefillRate: 2},
+		},
+	},
+	"ecr": {
+		EventSource: "ecr.amazonaws.com",
+		APIs: map[string]string{
+			"getAuthorizationToken": "GetAuthorizationToken",
+		},
+		Limits: map[string]tokenbucket.Limit{
+			"getAuthorizationToken": {BucketSize: 500, RefillRate: 500},
+		},
+	},
+	"iam": {
+		EventSource: "iam.amazonaws.com",
+		APIs: map[string]string{
+			"getRole":    "GetRole",
+			"createRole": "CreateRole",
+		},
+	},
+}
+
+var (
+	ErrUnknownRateLimitService = fmt.Errorf("unknown rate limit service")
+	ErrInvalidRateLimitAPI     = fmt.Errorf("invalid rate limit api")
+	ErrInvalidTokenBucket      = fmt.Errorf("invalid token bucket")
+)
+
+// ValidateRateLimitAPIs validates the rateLimitAPIs of a service against the rate limit catalog
+func ValidateRateLimitAPIs(serviceName string, service ServiceConfig) error {
+	if len(service.RateLimitAPIs) == 0 {
+		return nil
+	}
+	catalog, ok := rateLimitCatalog[serviceName]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownRateLimitService, serviceName)
+	}
+	for _, api := range service.RateLimitAPIs {
+		if _, ok := catalog.APIs[api.Name]; !ok {
+			return fmt.Errorf("%w: %s %s", ErrInvalidRateLimitAPI, serviceName, api.Name)
+		}
+		if err := validateTokenBucket(rateLimitBucket(catalog, api)); err != nil {
+			return fmt.Errorf("%w: %s %s", err, serviceName, api.Name)
+		}
+	}
+	return nil
+}
+
+// rateLimitBucket returns the token bucket of an api, with the config overrides applied
+func rateLimitBucket(catalog RateLimitService, api RateLimitAPIs) tokenbucket.Limit {
+	limit := catalog.Limits[api.Name]
+	if api.BucketSize != 0 {
+		limit.BucketSize = api.BucketSize
+	}
+	if api.RefillRate != 0 {
+		limit.RefillRate = api.RefillRate
+	}
+	return limit
+}
+
+// validateTokenBucket accepts a complete bucket or no bucket at all
+func validateTokenBucket(limit tokenbucket.Limit) error {
+	if limit.BucketSize < 0 || limit.RefillRate < 0 {
+		return ErrInvalidTokenBucket
+	}
+	if (limit.BucketSize == 0) != (limit.RefillRate == 0) {
+		return ErrInvalidTokenBucket
+	}
+	return nil
+}
+
+// Validates the Rate Limit Config
+func ValidateRateLimitConfig(cfg TopLevelServiceConfig, logger applogger.Logger) error {
+	if logger == nil {
+		logger = &applogger.NoopLogger{}
+	}
+	for serviceName, serviceCfg := range cfg.Services {
+		if len(serviceCfg.RateLimitAPIs) == 0 {
+			logger.Debug("no rate limit config for service %s", serviceName)
+			continue
+		}
+		logger.Info("validating %s rate limit config", serviceName)
+		if err := ValidateRateLimitAPIs(serviceName, serviceCfg); err != nil {
+			logger.Error("invalid %s rate limit config : %v", serviceName, err)
+			return err
+		}
+	}
+	logger.Info("rate limit config validated")
+	return nil
+}
+
+// RateLimitAllowList holds the eventSource / eventName pairs enabled by the rateLimitAPIs of a config
+// and the token bucket of each of them
+type RateLimitAllowList struct {
+	events map[string]map[string]struct{} // eventSource -> eventName
+	limits map[string]tokenbucket.Limit   // tokenbucket.Key(eventSource, eventName) -> limit
+}
+
+// NewRateLimitAllowList validates the rate limit config and builds its allow list
+func NewRateLimitAllowList(cfg TopLevelServiceConfig) (*RateLimitAllowList, error) {
+	al := &RateLimitAllowList{
+		events: make(map[string]map[string]struct{}),
+		limits: make(map[string]tokenbucket.Limit),
+	}
+	for serviceName, serviceCfg := range cfg.Services {
+		if err := ValidateRateLimitAPIs(serviceName, serviceCfg); err != nil {
+			return nil, err
+		}
+		for _, api := range serviceCfg.RateLimitAPIs {
+			catalog := rateLimitCatalog[serviceName]
+			if al.events[catalog.EventSource] == nil {
+				al.events[catalog.EventSource] = make(map[string]struct{})
+			}
+			al.events[catalog.EventSource][catalog.APIs[api.Name]] = struct{}{}
+			if limit := rateLimitBucket(catalog, api); limit.Enabled() {
+				al.limits[tokenbucket.Key(catalog.EventSource, catalog.APIs[api.Name])] = limit
+			}
+		}
+	}
+	return al, nil
+}
+
+// Allowed reports whether the event is enabled in the config
+func (al *RateLimitAllowList) Allowed(eventSource, eventName string) bool {
+	_, ok := al.events[eventSource][eventName]
+	return ok
+}
+
+// Events returns the allowed events as sorted "eventSource:eventName" strings
+func (al *RateLimitAllowList) Events() []string {
+	var out []string
+	for source, names := range al.events {
+		for name := range names {
+			out = append(out, source+":"+name)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Limits returns the token buckets of the allowed events, keyed by tokenbucket.Key
+func (al *RateLimitAllowList) Limits() map[string]tokenbucket.Limit {
+	return maps.Clone(al.limits)
+}
@@ -0,0 +1,122 @@
+package serviceconfig
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/outofoffice3/aws-samples/geras/internal/tokenbucket"
+)
+
+func TestValidateRateLimitAPIs(t *testing.T) {
+	tests := []struct {
+		name    string
+		service string
+		input   ServiceConfig
+		wantErr error
+	}{
+		{"valid STS", "sts", ServiceConfig{RateLimitAPIs: []RateLimitAPIs{{Name: "assumeRole"}, {Name: "assumeRoleWithWebIdentity"}}}, nil},
+		{"valid KMS", "kms", ServiceConfig{RateLimitAPIs: []RateLimitAPIs{{Name: "decrypt"}}}, nil},
+		{"no apis", "unknown", ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "x"}}}, nil},
+		{"invalid STS", "sts", ServiceConfig{RateLimitAPIs: []RateLimitAPIs{{Name: "wrong"}}}, ErrInvalidRateLimitAPI},
+		{"api of another service", "sts", ServiceConfig{RateLimitAPIs: []RateLimitAPIs{{Name: "decrypt"}}}, ErrInvalidRateLimitAPI},
+		{"unknown service", "unknown", ServiceConfig{RateLimitAPIs: []RateLimitAPIs{{Name: "assumeRole"}}}, ErrUnknownRateLimitService},
+		{"bucket override", "ec2", ServiceConfig{RateLimitAPIs: []RateLimitAPIs{{Name: "describeInstances", BucketSize: 200, RefillRate: 40}}}, nil},
+		{"full bucket without default", "iam", ServiceConfig{RateLimitAPIs: []RateLimitAPIs{{Name: "getRole", BucketSize: 20, RefillRate: 20}}}, nil},
+		{"partial bucket without default", "iam", ServiceConfig{RateLimitAPIs: []RateLimitAPIs{{Name: "getRole", BucketSize: 20}}}, ErrInvalidTokenBucket},
+		{"negative refill rate", "ec2", ServiceConfig{RateLimitAPIs: []RateLimitAPIs{{Name: "describeInstances", RefillRate: -1}}}, ErrInvalidTokenBucket},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRateLimitAPIs(tt.service, tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateRateLimitConfig_UnknownServiceWithAPIs(t *testing.T) {
+	cfg := TopLevelServiceConfig{
+		Services: map[string]ServiceConfig{
+			"ec2":     {QuotaMetrics: []QuotaMetric{{Name: "networkInterfaces"}}},
+			"unknown": {RateLimitAPIs: []RateLimitAPIs{{Name: "assumeRole"}}},
+		},
+	}
+	if err := ValidateRateLimitConfig(cfg, nil); !errors.Is(err, ErrUnknownRateLimitService) {
+		t.Errorf("expected ErrUnknownRateLimitService, got %v", err)
+	}
+}
+
+func TestNewRateLimitAllowList(t *testing.T) {
+	cfg := TopLevelServiceConfig{
+		Services: map[string]ServiceConfig{
+			"ec2": {QuotaMetrics: []QuotaMetric{{Name: "networkInterfaces"}}},
+			"sts": {RateLimitAPIs: []RateLimitAPIs{{Name: "assumeRole"}, {Name: "assumeRoleWithWebIdentity"}}},
+			"kms": {RateLimitAPIs: []RateLimitAPIs{{Name: "decrypt"}}},
+		},
+	}
+	al, err := NewRateLimitAllowList(cfg)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := []string{
+		"kms.amazonaws.com:Decrypt",
+		"sts.amazonaws.com:AssumeRole",
+		"sts.amazonaws.com:AssumeRoleWithWebIdentity",
+	}
+	if got := al.Events(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected events %v, got %v", want, got)
+	}
+
+	checks := []struct {
+		source, name string
+		want         bool
+	}{
+		{"sts.amazonaws.com", "AssumeRole", true},
+		{"kms.amazonaws.com", "Decrypt", true},
+		{"sts.amazonaws.com", "GetSessionToken", false},
+		{"kms.amazonaws.com", "AssumeRole", false},
+		{"", "AssumeRole", false},
+	}
+	for _, c := range checks {
+		if got := al.Allowed(c.source, c.name); got != c.want {
+			t.Errorf("Allowed(%q, %q) = %v, want %v", c.source, c.name, got, c.want)
+		}
+	}
+}
+
+func TestNewRateLimitAllowList_Invalid(t *testing.T) {
+	cfg := TopLevelServiceConfig{
+		Services: map[string]ServiceConfig{
+			"sts": {RateLimitAPIs: []RateLimitAPIs{{Name: "wrong"}}},
+		},
+	}
+	if _, err := NewRateLimitAllowList(cfg); !errors.Is(err, ErrInvalidRateLimitAPI) {
+		t.Errorf("expected ErrInvalidRateLimitAPI, got %v", err)
+	}
+}
+
+func TestRateLimitAllowList_Limits(t *testing.T) {
+	cfg := TopLevelServiceConfig{
+		Services: map[string]ServiceConfig{
+			"ec2": {RateLimitAPIs: []RateLimitAPIs{{Name: "describeInstances", RefillRate: 40}, {Name: "runInstances"}}},
+			"iam": {RateLimitAPIs: []RateLimitAPIs{{Name: "getRole"}, {Name: "createRole", BucketSize: 10, RefillRate: 5}}},
+		},
+	}
+	al, err := NewRateLimitAllowList(cfg)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := map[string]tokenbucket.Limit{
+		tokenbucket.Key("ec2.amazonaws.com", "DescribeInstances"): {BucketSize: 100, RefillRate: 40},
+		tokenbucket.Key("ec2.amazonaws.com", "RunInstances"):      {BucketSize: 1000, RefillRate: 2},
+		tokenbucket.Key("iam.amazonaws.com", "CreateRole"):        {BucketSize: 10, RefillRate: 5},
+	}
+	if got := al.Limits(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected limits %v, got %v", want, got)
+	}
+}
@@ -18,9 +18,13 @@ type QuotaMetric struct {
 	Name string `json:"name"`
 }
 
-// RateLimitAPIs represent the api name that you would like to track
+// RateLimitAPIs represent the api name that you would like to track.
+// BucketSize and RefillRate override the token bucket of the api in the rate limit catalog.
+// They are the quota of the account and region, every function instance simulates the whole bucket.
 type RateLimitAPIs struct {
-	Name string `json:"name"`
+	Name       string  `json:"name"`
+	BucketSize float64 `json:"bucketSize,omitempty"`
+	RefillRate float64 `json:"refillRate,omitempty"`
 }
 
 // ServiceConfig represents the service configuration (iam, ec2, eks etc..)
@@ -30,10 +34,12 @@ type ServiceConfig struct {
 	RateLimitAPIs []RateLimitAPIs `json:"rateLimitAPIs,omitempty"`
 }
 
-// TopLevelServiceConfig represents the top level configuration structure
+// TopLevelServiceConfig represents the top level configuration structure.
+// RateLimitDimensions lists extra CallCount dimension sets of the rate limit solution
 type TopLevelServiceConfig struct {
-	Services map[string]ServiceConfig `json:"services"`
-	Regions  []string                 `json:"regions"`
+	Services            map[string]ServiceConfig `json:"services"`
+	Regions             []string                 `json:"regions"`
+	RateLimitDimensions [][]string               `json:"rateLimitDimensions,omitempty"`
 }
 
 // LoadConfig reads the configuration file at the given file path and unmarshals
@@ -79,109 +85,280 @@ func LoadConfigFromS3(bucket, key string, client s3client.S3Client) (*TopLevelSe
 	return &cfg, nil
 }
 
-// Validation Errors
-var (
-	ErrInvalidEC2Metric = fmt.Errorf("invalid EC2 quota metric")
-	ErrInvalidEKSMetric = fmt.Errorf("invalid EKS quota metric")
-	ErrInvalidIAMMetric = fmt.Errorf("invalid IAM quota metric")
-	ErrInvalidEBSMetric = fmt.Errorf("invalid EBS quota metric")
-	ErrInvalidVPCMetric = fmt.Errorf("invalid VPC quota metric")
-	ErrInvalidSTSApi    = fmt.Errorf("invalid STS api")
-)
-
-func ValidateEC2QuotaMetrics(service ServiceConfig) error {
-	validMetrics := map[string]struct{}{
-		"networkInterfaces": {},
-	}
-	for _, metric := range service.QuotaMetrics {
-		if _, ok := validMetrics[metric.Name]; !ok {
-			return fmt.Errorf("%w: %s", ErrInvalidEC2Metric, metric.Name)
-		}
-	}
-	return nil
+// globalServices are services whose quotas are account wide rather than regional.
+// Their jobs run once per invocation instead of once per configured region.
+var globalServices = map[string]struct{}{
+	"iam":           {},
+	"organizations": {},
+	"route53":       {},
+	"s3":            {},
 }
 
-func ValidateEKSQuotaMetrics(service ServiceConfig) error {
-	validAPIs := map[string]struct{}{
-		"listClusters": {},
-	}
-	for _, api := range service.QuotaMetrics {
-		if _, ok := validAPIs[api.Name]; !ok {
-			return fmt.Errorf("%w: %s", ErrInvalidEKSMetric, api.Name)
-		}
-	}
-	return nil
+// IsGlobalService reports whether the service is global and should only be scraped once
+func IsGlobalService(serviceName string) bool {
+	_, ok := globalServices[serviceName]
+	return ok
 }
 
-func ValidateIAMQuotaMetrics(service ServiceConfig) error {
-	validMetrics := map[string]struct{}{
-		"iamRoles":      {},
-		"oidcProviders": {},
-	}
-	for _, metric := range service.QuotaMetrics {
-		if _, ok := validMetrics[metric.Name]; !ok {
-			return fmt.Errorf("%w: %s", ErrInvalidIAMMetric, metric.Name)
-		}
-	}
-	return nil
-}
-
-func ValidateEBSQuotaMetrics(service ServiceConfig) error {
-	validMetrics := map[string]struct{}{
-		"gp3storage": {},
-	}
-	for _, metric := range service.QuotaMetrics {
-		if _, ok := validMetrics[metric.Name]; !ok {
-			return fmt.Errorf("%w: %s", ErrInvalidEBSMetric, metric.Name)
-		}
-	}
-	return nil
-}
+// Validation Errors
+var (
+	ErrInvalidEC2Metric            = fmt.Errorf("invalid EC2 quota metric")
+	ErrInvalidEKSMetric            = fmt.Errorf("invalid EKS quota metric")
+	ErrInvalidIAMMetric            = fmt.Errorf("invalid IAM quota metric")
+	ErrInvalidEBSMetric            = fmt.Errorf("invalid EBS quota metric")
+	ErrInvalidVPCMetric            = fmt.Errorf("invalid VPC quota metric")
+	ErrInvalidLambdaMetric         = fmt.Errorf("invalid Lambda quota metric")
+	ErrInvalidECSMetric            = fmt.Errorf("invalid ECS quota metric")
+	ErrInvalidECRMetric            = fmt.Errorf("invalid ECR quota metric")
+	ErrInvalidRDSMetric            = fmt.Errorf("invalid RDS quota metric")
+	ErrInvalidDynamoDBMetric       = fmt.Errorf("invalid DynamoDB quota metric")
+	ErrInvalidElastiCacheMetric    = fmt.Errorf("invalid ElastiCache quota metric")
+	ErrInvalidCloudFormationMetric = fmt.Errorf("invalid CloudFormation quota metric")
+	ErrInvalidRoute53Metric        = fmt.Errorf("invalid Route53 quota metric")
+	ErrInvalidS3Metric             = fmt.Errorf("invalid S3 quota metric")
+	ErrInvalidOrganizationsMetric  = fmt.Errorf("invalid Organizations quota metric")
+	ErrInvalidEventBridgeMetric    = fmt.Errorf("invalid EventBridge quota metric")
+	ErrInvalidSNSMetric            = fmt.Errorf("invalid SNS quota metric")
+	ErrInvalidSQSMetric            = fmt.Errorf("invalid SQS quota metric")
+	ErrInvalidStepFunctionsMetric  = fmt.Errorf("invalid StepFunctions quota metric")
+	ErrInvalidCloudWatchMetric     = fmt.Errorf("invalid CloudWatch quota metric")
+	ErrInvalidCloudWatchLogsMetric = fmt.Errorf("invalid CloudWatchLogs quota metric")
+	ErrInvalidKMSMetric            = fmt.Errorf("invalid KMS quota metric")
+	ErrInvalidACMMetric            = fmt.Errorf("invalid ACM quota metric")
+	ErrInvalidSecretsManagerMetric = fmt.Errorf("invalid SecretsManager quota metric")
+	ErrInvalidAPIGatewayMetric     = fmt.Errorf("invalid APIGateway quota metric")
+	ErrInvalidAppSyncMetric        = fmt.Errorf("invalid AppSync quota metric")
+	ErrInvalidKinesisMetric        = fmt.Errorf("invalid Kinesis quota metric")
+	ErrInvalidFirehoseMetric       = fmt.Errorf("invalid Firehose quota metric")
+	ErrInvalidGlueMetric           = fmt.Errorf("invalid Glue quota metric")
+	ErrInvalidAthenaMetric         = fmt.Errorf("invalid Athena quota metric")
+)
 
-func ValidateVPCQuotaMetrics(service ServiceConfig) error {
-	validMetrics := map[string]struct{}{
-		"nau": {},
-	}
-	for _, metric := range service.QuotaMetrics {
-		if _, ok := validMetrics[metric.Name]; !ok {
-			return fmt.Errorf("%w: %s", ErrInvalidVPCMetric, metric.Name)
-		}
-	}
-	return nil
+// QuotaService lists the quota metrics of a service and the error returned for a metric that is not one of them
+type QuotaService struct {
+	Err     error
+	Metrics map[string]struct{}
 }
 
-func ValidateSTSRateLimitApis(service ServiceConfig) error {
-	validRateLimitApis := map[string]struct{}{
-		"assumeRole":                {},
-		"assumeRoleWithWebIdentity": {},
-	}
-
-	for _, metric := range service.RateLimitAPIs {
-		if _, ok := validRateLimitApis[metric.Name]; !ok {
-			return fmt.Errorf("%w: %s", ErrInvalidSTSApi, metric.Name)
-		}
-	}
-	return nil
+// quotaCatalog lists the services and metrics that can be configured under quotaMetrics.
+// Adding a metric here is all that is needed to make it valid in the config.
+var quotaCatalog = map[string]QuotaService{
+	"ec2": {
+		Err: ErrInvalidEC2Metric,
+		Metrics: map[string]struct{}{
+			"networkInterfaces": {},
+			"autoScalingLimits": {},
+			"launchResources":   {},
+		},
+	},
+	"eks": {
+		Err: ErrInvalidEKSMetric,
+		Metrics: map[string]struct{}{
+			"listClusters":  {},
+			"clusterQuotas": {},
+		},
+	},
+	"iam": {
+		Err: ErrInvalidIAMMetric,
+		Metrics: map[string]struct{}{
+			"iamRoles":                {},
+			"oidcProviders":           {},
+			"accountResources":        {},
+			"attachedPoliciesPerRole": {},
+		},
+	},
+	"ebs": {
+		Err: ErrInvalidEBSMetric,
+		Metrics: map[string]struct{}{
+			"gp3storage": {},
+		},
+	},
+	"vpc": {
+		Err: ErrInvalidVPCMetric,
+		Metrics: map[string]struct{}{
+			"nau": {},
+		},
+	},
+	"lambda": {
+		Err: ErrInvalidLambdaMetric,
+		Metrics: map[string]struct{}{
+			"accountSettings": {},
+			"peakConcurrency": {},
+		},
+	},
+	"ecs": {
+		Err: ErrInvalidECSMetric,
+		Metrics: map[string]struct{}{
+			"clusters": {},
+			"services": {},
+		},
+	},
+	"ecr": {
+		Err: ErrInvalidECRMetric,
+		Metrics: map[string]struct{}{
+			"repositories": {},
+			"images":       {},
+		},
+	},
+	"rds": {
+		Err: ErrInvalidRDSMetric,
+		Metrics: map[string]struct{}{
+			"accountAttributes": {},
+		},
+	},
+	"dynamodb": {
+		Err: ErrInvalidDynamoDBMetric,
+		Metrics: map[string]struct{}{
+			"tables":   {},
+			"capacity": {},
+		},
+	},
+	"elasticache": {
+		Err: ErrInvalidElastiCacheMetric,
+		Metrics: map[string]struct{}{
+			"nodes": {},
+		},
+	},
+	"cloudformation": {
+		Err: ErrInvalidCloudFormationMetric,
+		Metrics: map[string]struct{}{
+			"stacks": {},
+		},
+	},
+	"route53": {
+		Err: ErrInvalidRoute53Metric,
+		Metrics: map[string]struct{}{
+			"accountLimits":    {},
+			"hostedZoneLimits": {},
+		},
+	},
+	"s3": {
+		Err: ErrInvalidS3Metric,
+		Metrics: map[string]struct{}{
+			"buckets": {},
+		},
+	},
+	"organizations": {
+		Err: ErrInvalidOrganizationsMetric,
+		Metrics: map[string]struct{}{
+			"accounts":            {},
+			"organizationalUnits": {},
+			"scpsPerTarget":       {},
+		},
+	},
+	"eventbridge": {
+		Err: ErrInvalidEventBridgeMetric,
+		Metrics: map[string]struct{}{
+			"rules":   {},
+			"targets": {},
+		},
+	},
+	"sns": {
+		Err: ErrInvalidSNSMetric,
+		Metrics: map[string]struct{}{
+			"topics":        {},
+			"subscriptions": {},
+		},
+	},
+	"sqs": {
+		Err: ErrInvalidSQSMetric,
+		Metrics: map[string]struct{}{
+			"inFlightMessages": {},
+		},
+	},
+	"stepfunctions": {
+		Err: ErrInvalidStepFunctionsMetric,
+		Metrics: map[string]struct{}{
+			"stateMachines": {},
+			"activities":    {},
+		},
+	},
+	"cloudwatch": {
+		Err: ErrInvalidCloudWatchMetric,
+		Metrics: map[string]struct{}{
+			"alarms":     {},
+			"dashboards": {},
+		},
+	},
+	"cloudwatchlogs": {
+		Err: ErrInvalidCloudWatchLogsMetric,
+		Metrics: map[string]struct{}{
+			"metricFilters":       {},
+			"subscriptionFilters": {},
+		},
+	},
+	"kms": {
+		Err: ErrInvalidKMSMetric,
+		Metrics: map[string]struct{}{
+			"keys":    {},
+			"aliases": {},
+		},
+	},
+	"acm": {
+		Err: ErrInvalidACMMetric,
+		Metrics: map[string]struct{}{
+			"certificates": {},
+		},
+	},
+	"secretsmanager": {
+		Err: ErrInvalidSecretsManagerMetric,
+		Metrics: map[string]struct{}{
+			"secrets": {},
+		},
+	},
+	"apigateway": {
+		Err: ErrInvalidAPIGatewayMetric,
+		Metrics: map[string]struct{}{
+			"restApis":         {},
+			"httpApis":         {},
+			"accountResources": {},
+			"throttling":       {},
+		},
+	},
+	"appsync": {
+		Err: ErrInvalidAppSyncMetric,
+		Metrics: map[string]struct{}{
+			"graphqlApis": {},
+		},
+	},
+	"kinesis": {
+		Err: ErrInvalidKinesisMetric,
+		Metrics: map[string]struct{}{
+			"streamLimits": {},
+		},
+	},
+	"firehose": {
+		Err: ErrInvalidFirehoseMetric,
+		Metrics: map[string]struct{}{
+			"deliveryStreams": {},
+		},
+	},
+	"glue": {
+		Err: ErrInvalidGlueMetric,
+		Metrics: map[string]struct{}{
+			"catalog": {},
+			"jobs":    {},
+		},
+	},
+	"athena": {
+		Err: ErrInvalidAthenaMetric,
+		Metrics: map[string]struct{}{
+			"workGroups": {},
+		},
+	},
 }
 
-// Validates the Rate Limit Config
-func ValidateRateLimitConfig(cfg TopLevelServiceConfig, logger applogger.Logger) error {
-	if logger == nil {
-		logger = &applogger.NoopLogger{}
+// ValidateQuotaMetrics validates the quotaMetrics of a service against the quota catalog
+func ValidateQuotaMetrics(serviceName string, service ServiceConfig) error {
+	catalog, ok := quotaCatalog[serviceName]
+	if !ok {
+		return nil
 	}
-	for serviceName, serviceCfg := range cfg.Services {
-		switch serviceName {
-		case "sts":
-			logger.Info("validating sts rate limit config")
-			if err := ValidateSTSRateLimitApis(serviceCfg); err != nil {
-				logger.Error("invalid sts rate limit config : %v", err)
-				return err
-			}
-		default:
-			logger.Warn("no rate limit config for service %s", serviceName)
+	for _, metric := range service.QuotaMetrics {
+		if _, ok := catalog.Metrics[metric.Name]; !ok {
+			return fmt.Errorf("%w: %s", catalog.Err, metric.Name)
 		}
 	}
-	logger.Info("rate limit config validated")
 	return nil
 }
 
@@ -190,34 +367,13 @@ func ValidateQuotaMetricConfig(cfg TopLevelServiceConfig, logger applogger.Logge
 		logger = &applogger.NoopLogger{}
 	}
 	for serviceName, serviceCfg := range cfg.Services {
-		switch serviceName {
-		case "ec2":
-			if err := ValidateEC2QuotaMetrics(serviceCfg); err != nil {
-				logger.Error("invalid ec2 quota config : %v", err)
-				return err
-			}
-		case "eks":
-			if err := ValidateEKSQuotaMetrics(serviceCfg); err != nil {
-				logger.Error("invalid eks quota config : %v", err)
-				return err
-			}
-		case "iam":
-			if err := ValidateIAMQuotaMetrics(serviceCfg); err != nil {
-				logger.Error("invalid iam quota config : %v", err)
-				return err
-			}
-		case "ebs":
-			if err := ValidateEBSQuotaMetrics(serviceCfg); err != nil {
-				logger.Error("invalid ebs quota config : %v", err)
-				return err
-			}
-		case "vpc":
-			if err := ValidateVPCQuotaMetrics(serviceCfg); err != nil {
-				logger.Error("invalid vpc quota config : %v", err)
-				return err
-			}
-		default:
+		if _, ok := quotaCatalog[serviceName]; !ok {
 			logger.Warn("no quota config for service %s", serviceName)
+			continue
+		}
+		if err := ValidateQuotaMetrics(serviceName, serviceCfg); err != nil {
+			logger.Error("invalid %s quota config : %v", serviceName, err)
+			return err
 		}
 	}
 	logger.Debug("quota metric config validated")
@@ -26,6 +26,16 @@ func (m *mockS3Client) PutObject(ctx context.Context, params *s3.PutObjectInput,
 	return &s3.PutObjectOutput{}, nil
 }
 
+// list buckets
+func (m *mockS3Client) ListBuckets(ctx context.Context, params *s3.ListBucketsInput, optFns ...func(*s3.Options)) (*s3.ListBucketsOutput, error) {
+	return &s3.ListBucketsOutput{}, nil
+}
+
+// list objects
+func (m *mockS3Client) ListObjectsV2(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+	return &s3.ListObjectsV2Output{}, nil
+}
+
 func (m *mockS3Client) GetRegion() string {
 	return ""
 }
@@ -148,90 +158,393 @@ func TestLoadConfigFromS3(t *testing.T) {
 func TestValidateFunctions(t *testing.T) {
 	tests := []struct {
 		name      string
-		validate  func(ServiceConfig) error
+		service   string
 		input     ServiceConfig
 		wantError bool
 	}{
 		{
 			name:      "valid EC2",
-			validate:  ValidateEC2QuotaMetrics,
-			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "networkInterfaces"}}},
+			service:   "ec2",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "networkInterfaces"}, {Name: "autoScalingLimits"}, {Name: "launchResources"}}},
 			wantError: false,
 		},
 		{
 			name:      "invalid EC2",
-			validate:  ValidateEC2QuotaMetrics,
+			service:   "ec2",
 			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "wrong"}}},
 			wantError: true,
 		},
 		{
 			name:      "valid EKS",
-			validate:  ValidateEKSQuotaMetrics,
+			service:   "eks",
 			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "listClusters"}}},
 			wantError: false,
 		},
+		{
+			name:      "valid EKS cluster quotas",
+			service:   "eks",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "clusterQuotas"}}},
+			wantError: false,
+		},
 		{
 			name:      "invalid EKS",
-			validate:  ValidateEKSQuotaMetrics,
+			service:   "eks",
 			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "wrong"}}},
 			wantError: true,
 		},
 		{
 			name:      "valid IAM",
-			validate:  ValidateIAMQuotaMetrics,
+			service:   "iam",
 			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "iamRoles"}}},
 			wantError: false,
 		},
+		{
+			name:      "valid IAM account resources",
+			service:   "iam",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "accountResources"}, {Name: "attachedPoliciesPerRole"}}},
+			wantError: false,
+		},
 		{
 			name:      "invalid IAM",
-			validate:  ValidateIAMQuotaMetrics,
+			service:   "iam",
 			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "wrong"}}},
 			wantError: true,
 		},
 		{
 			name:      "valid EBS",
-			validate:  ValidateEBSQuotaMetrics,
+			service:   "ebs",
 			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "gp3storage"}}},
 			wantError: false,
 		},
 		{
 			name:      "invalid EBS",
-			validate:  ValidateEBSQuotaMetrics,
+			service:   "ebs",
 			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "wrong"}}},
 			wantError: true,
 		},
 		{
 			name:      "valid VPC",
-			validate:  ValidateVPCQuotaMetrics,
+			service:   "vpc",
 			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "nau"}}},
 			wantError: false,
 		},
 		{
 			name:      "invalid VPC",
-			validate:  ValidateVPCQuotaMetrics,
+			service:   "vpc",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "wrong"}}},
+			wantError: true,
+		},
+		{
+			name:      "valid Lambda",
+			service:   "lambda",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "accountSettings"}, {Name: "peakConcurrency"}}},
+			wantError: false,
+		},
+		{
+			name:      "invalid Lambda",
+			service:   "lambda",
 			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "wrong"}}},
 			wantError: true,
 		},
 		{
-			name:      "valid STS",
-			validate:  ValidateSTSRateLimitApis,
-			input:     ServiceConfig{RateLimitAPIs: []RateLimitAPIs{{Name: "assumeRole"}}},
+			name:      "valid ECS",
+			service:   "ecs",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "clusters"}, {Name: "services"}}},
 			wantError: false,
 		},
 		{
-			name:      "invalid STS",
-			validate:  ValidateSTSRateLimitApis,
-			input:     ServiceConfig{RateLimitAPIs: []RateLimitAPIs{{Name: "wrong"}}},
+			name:      "invalid ECS",
+			service:   "ecs",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "wrong"}}},
+			wantError: true,
+		},
+		{
+			name:      "valid ECR",
+			service:   "ecr",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "repositories"}, {Name: "images"}}},
+			wantError: false,
+		},
+		{
+			name:      "invalid ECR",
+			service:   "ecr",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "wrong"}}},
+			wantError: true,
+		},
+		{
+			name:      "valid RDS",
+			service:   "rds",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "accountAttributes"}}},
+			wantError: false,
+		},
+		{
+			name:      "invalid RDS",
+			service:   "rds",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "wrong"}}},
+			wantError: true,
+		},
+		{
+			name:      "valid DynamoDB",
+			service:   "dynamodb",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "tables"}, {Name: "capacity"}}},
+			wantError: false,
+		},
+		{
+			name:      "invalid DynamoDB",
+			service:   "dynamodb",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "wrong"}}},
+			wantError: true,
+		},
+		{
+			name:      "valid ElastiCache",
+			service:   "elasticache",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "nodes"}}},
+			wantError: false,
+		},
+		{
+			name:      "invalid ElastiCache",
+			service:   "elasticache",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "wrong"}}},
+			wantError: true,
+		},
+		{
+			name:      "valid CloudFormation",
+			service:   "cloudformation",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "stacks"}}},
+			wantError: false,
+		},
+		{
+			name:      "invalid CloudFormation",
+			service:   "cloudformation",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "wrong"}}},
+			wantError: true,
+		},
+		{
+			name:      "valid Route53",
+			service:   "route53",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "accountLimits"}, {Name: "hostedZoneLimits"}}},
+			wantError: false,
+		},
+		{
+			name:      "invalid Route53",
+			service:   "route53",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "wrong"}}},
+			wantError: true,
+		},
+		{
+			name:      "valid S3",
+			service:   "s3",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "buckets"}}},
+			wantError: false,
+		},
+		{
+			name:      "invalid S3",
+			service:   "s3",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "wrong"}}},
+			wantError: true,
+		},
+		{
+			name:      "valid Organizations",
+			service:   "organizations",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "accounts"}, {Name: "organizationalUnits"}, {Name: "scpsPerTarget"}}},
+			wantError: false,
+		},
+		{
+			name:      "invalid Organizations",
+			service:   "organizations",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "wrong"}}},
+			wantError: true,
+		},
+		{
+			name:      "valid EventBridge",
+			service:   "eventbridge",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "rules"}, {Name: "targets"}}},
+			wantError: false,
+		},
+		{
+			name:      "invalid EventBridge",
+			service:   "eventbridge",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "wrong"}}},
+			wantError: true,
+		},
+		{
+			name:      "valid SNS",
+			service:   "sns",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "topics"}, {Name: "subscriptions"}}},
+			wantError: false,
+		},
+		{
+			name:      "invalid SNS",
+			service:   "sns",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "wrong"}}},
+			wantError: true,
+		},
+		{
+			name:      "valid SQS",
+			service:   "sqs",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "inFlightMessages"}}},
+			wantError: false,
+		},
+		{
+			name:      "invalid SQS",
+			service:   "sqs",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "wrong"}}},
+			wantError: true,
+		},
+		{
+			name:      "valid StepFunctions",
+			service:   "stepfunctions",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "stateMachines"}, {Name: "activities"}}},
+			wantError: false,
+		},
+		{
+			name:      "invalid StepFunctions",
+			service:   "stepfunctions",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "wrong"}}},
+			wantError: true,
+		},
+		{
+			name:      "valid CloudWatch",
+			service:   "cloudwatch",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "alarms"}, {Name: "dashboards"}}},
+			wantError: false,
+		},
+		{
+			name:      "invalid CloudWatch",
+			service:   "cloudwatch",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "wrong"}}},
+			wantError: true,
+		},
+		{
+			name:      "valid CloudWatchLogs",
+			service:   "cloudwatchlogs",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "metricFilters"}, {Name: "subscriptionFilters"}}},
+			wantError: false,
+		},
+		{
+			name:      "invalid CloudWatchLogs",
+			service:   "cloudwatchlogs",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "wrong"}}},
+			wantError: true,
+		},
+		{
+			name:      "valid KMS",
+			service:   "kms",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "keys"}, {Name: "aliases"}}},
+			wantError: false,
+		},
+		{
+			name:      "invalid KMS",
+			service:   "kms",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "wrong"}}},
+			wantError: true,
+		},
+		{
+			name:      "valid ACM",
+			service:   "acm",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "certificates"}}},
+			wantError: false,
+		},
+		{
+			name:      "invalid ACM",
+			service:   "acm",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "wrong"}}},
+			wantError: true,
+		},
+		{
+			name:      "valid SecretsManager",
+			service:   "secretsmanager",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "secrets"}}},
+			wantError: false,
+		},
+		{
+			name:      "invalid SecretsManager",
+			service:   "secretsmanager",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "wrong"}}},
+			wantError: true,
+		},
+		{
+			name:      "valid APIGateway",
+			service:   "apigateway",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "restApis"}, {Name: "httpApis"}, {Name: "accountResources"}, {Name: "throttling"}}},
+			wantError: false,
+		},
+		{
+			name:      "invalid APIGateway",
+			service:   "apigateway",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "wrong"}}},
+			wantError: true,
+		},
+		{
+			name:      "valid AppSync",
+			service:   "appsync",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "graphqlApis"}}},
+			wantError: false,
+		},
+		{
+			name:      "invalid AppSync",
+			service:   "appsync",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "wrong"}}},
+			wantError: true,
+		},
+		{
+			name:      "valid Kinesis",
+			service:   "kinesis",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "streamLimits"}}},
+			wantError: false,
+		},
+		{
+			name:      "invalid Kinesis",
+			service:   "kinesis",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "wrong"}}},
+			wantError: true,
+		},
+		{
+			name:      "valid Firehose",
+			service:   "firehose",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "deliveryStreams"}}},
+			wantError: false,
+		},
+		{
+			name:      "invalid Firehose",
+			service:   "firehose",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "wrong"}}},
+			wantError: true,
+		},
+		{
+			name:      "valid Glue",
+			service:   "glue",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "catalog"}, {Name: "jobs"}}},
+			wantError: false,
+		},
+		{
+			name:      "invalid Glue",
+			service:   "glue",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "wrong"}}},
+			wantError: true,
+		},
+		{
+			name:      "valid Athena",
+			service:   "athena",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "workGroups"}}},
+			wantError: false,
+		},
+		{
+			name:      "invalid Athena",
+			service:   "athena",
+			input:     ServiceConfig{QuotaMetrics: []QuotaMetric{{Name: "wrong"}}},
 			wantError: true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := tt.validate(tt.input)
+			err := ValidateQuotaMetrics(tt.service, tt.input)
 			if (err != nil) != tt.wantError {
 				t.Errorf("expected error=%v, got error=%v", tt.wantError, err != nil)
 			}
+			if tt.wantError && !errors.Is(err, quotaCatalog[tt.service].Err) {
+				t.Errorf("expected %v, got %v", quotaCatalog[tt.service].Err, err)
+			}
 		})
 	}
 }
@@ -311,3 +624,20 @@ func TestValidateQuotaMetricConfig(t *testing.T) {
 		}
 	})
 }
+
+func TestIsGlobalService(t *testing.T) {
+	cases := map[string]bool{
+		"route53":       true,
+		"iam":           true,
+		"s3":            true,
+		"organizations": true,
+		"ec2":           false,
+		"kms":           false,
+		"unknown":       false,
+	}
+	for service, want := range cases {
+		if got := IsGlobalService(service); got != want {
+			t.Errorf("IsGlobalService(%q) = %v, want %v", service, got, want)
+		}
+	}
+}
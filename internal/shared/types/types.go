@@ -10,16 +10,19 @@ import (
 
 // CloudTrailEvent represents a single cloudtrail event
 type CloudTrailEvent struct {
-	EventVersion string             `json:"eventVersion"`
-	UserIdentity UserIdentityDetail `json:"userIdentity"`
-	EventTime    time.Time          `json:"eventTime"`
-	EventSource  string             `json:"eventSource"`
-	EventName    string             `json:"eventName"`
-	AWSRegion    string             `json:"awsRegion"`
-	SourceIP     string             `json:"sourceIPAddress"`
-	UserAgent    string             `json:"userAgent"`
-	RequestID    string             `json:"requestID"`
-	EventID      string             `json:"eventID"`
+	EventVersion       string             `json:"eventVersion"`
+	UserIdentity       UserIdentityDetail `json:"userIdentity"`
+	EventTime          time.Time          `json:"eventTime"`
+	EventSource        string             `json:"eventSource"`
+	EventName          string             `json:"eventName"`
+	AWSRegion          string             `json:"awsRegion"`
+	SourceIP           string             `json:"sourceIPAddress"`
+	UserAgent          string             `json:"userAgent"`
+	RequestID          string             `json:"requestID"`
+	EventID            string             `json:"eventID"`
+	RecipientAccountID string             `json:"recipientAccountId"`
+	ErrorCode          string             `json:"errorCode,omitempty"`
+	ErrorMessage       string             `json:"errorMessage,omitempty"`
 }
 
 // Metric encapsulates the metric details, including metadataß
@@ -51,6 +54,17 @@ type ScheduledEvent struct {
 	Detail     json.RawMessage `json:"detail"`
 }
 
+// SNSNotification represents an SNS notification as delivered to an SQS queue
+// without raw message delivery. Message holds the original payload as a string.
+type SNSNotification struct {
+	Type      string `json:"Type"`
+	MessageID string `json:"MessageId"`
+	TopicArn  string `json:"TopicArn"`
+	Subject   string `json:"Subject"`
+	Message   string `json:"Message"`
+	Timestamp string `json:"Timestamp"`
+}
+
 // ErrorRecord represents one error event.
 type ErrorRecord struct {
 	Timestamp time.Time `json:"timestamp"`
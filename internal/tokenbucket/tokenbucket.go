@@ -0,0 +1,97 @@
+package tokenbucket
+
+import (
+	"sync"
+	"time"
+)
+
+// Limit describes an api token bucket.  The bucket holds at most BucketSize tokens,
+// every call takes one token and RefillRate tokens are added back every second.
+type Limit struct {
+	BucketSize float64
+	RefillRate float64
+}
+
+// Enabled reports whether the limit describes a bucket that can be simulated
+func (l Limit) Enabled() bool {
+	return l.BucketSize > 0 && l.RefillRate > 0
+}
+
+// Sample is the state of a bucket right after a call was replayed through it
+type Sample struct {
+	Remaining   float64 // tokens left in the bucket
+	Utilization float64 // percentage of the bucket in use
+}
+
+// Key returns the key a limit is stored under for an eventSource / eventName pair
+func Key(eventSource, eventName string) string {
+	return eventSource + ":" + eventName
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Simulator replays cloudtrail event timestamps through one token bucket per api and region.
+// Buckets start full, so the first calls after a cold start under report utilization.
+// Buckets are held in memory, so concurrent function instances each see part of the calls.
+// Events older than the last one seen for a bucket take a token without refilling it.
+type Simulator struct {
+	limits map[string]Limit // Key(eventSource, eventName) -> limit
+
+	mu      sync.Mutex
+	buckets map[string]map[string]*bucket // region -> key -> bucket
+}
+
+// NewSimulator creates a simulator for the given limits, keyed by Key(eventSource, eventName).
+// Limits that are not Enabled are ignored.
+func NewSimulator(limits map[string]Limit) *Simulator {
+	enabled := make(map[string]Limit, len(limits))
+	for key, limit := range limits {
+		if limit.Enabled() {
+			enabled[key] = limit
+		}
+	}
+	return &Simulator{
+		limits:  enabled,
+		buckets: make(map[string]map[string]*bucket),
+	}
+}
+
+// Observe replays one call made at time t.  It returns false when the api has no limit.
+func (s *Simulator) Observe(region, eventSource, eventName string, t time.Time) (Sample, bool) {
+	key := Key(eventSource, eventName)
+	limit, ok := s.limits[key]
+	if !ok {
+		return Sample{}, false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	regionBuckets, ok := s.buckets[region]
+	if !ok {
+		regionBuckets = make(map[string]*bucket)
+		s.buckets[region] = regionBuckets
+	}
+	b, ok := regionBuckets[key]
+	if !ok {
+		b = &bucket{tokens: limit.BucketSize, last: t}
+		regionBuckets[key] = b
+	}
+
+	// refill for the time elapsed since the previous call, capped at the bucket size
+	if elapsed := t.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = min(limit.BucketSize, b.tokens+elapsed*limit.RefillRate)
+		b.last = t
+	}
+
+	// an empty bucket means the call would have been throttled, which does not take a token
+	b.tokens = max(0, b.tokens-1)
+
+	return Sample{
+		Remaining:   b.tokens,
+		Utilization: (limit.BucketSize - b.tokens) / limit.BucketSize * 100,
+	}, true
+}
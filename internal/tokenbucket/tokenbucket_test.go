@@ -0,0 +1,70 @@
+package tokenbucket
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimulator_Observe(t *testing.T) {
+	sim := NewSimulator(map[string]Limit{
+		Key("ec2.amazonaws.com", "DescribeInstances"): {BucketSize: 10, RefillRate: 2},
+	})
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// a burst of 10 calls in the same second empties the bucket
+	var sample Sample
+	for i := 0; i < 10; i++ {
+		var ok bool
+		sample, ok = sim.Observe("us-east-1", "ec2.amazonaws.com", "DescribeInstances", start)
+		assert.True(t, ok)
+	}
+	assert.Equal(t, 0.0, sample.Remaining)
+	assert.Equal(t, 100.0, sample.Utilization)
+
+	// another call in the same second would be throttled, the bucket stays empty
+	sample, _ = sim.Observe("us-east-1", "ec2.amazonaws.com", "DescribeInstances", start)
+	assert.Equal(t, 0.0, sample.Remaining)
+
+	// two seconds refill 4 tokens, the call takes one
+	sample, _ = sim.Observe("us-east-1", "ec2.amazonaws.com", "DescribeInstances", start.Add(2*time.Second))
+	assert.Equal(t, 3.0, sample.Remaining)
+	assert.Equal(t, 70.0, sample.Utilization)
+
+	// an event delivered out of order takes a token without refilling
+	sample, _ = sim.Observe("us-east-1", "ec2.amazonaws.com", "DescribeInstances", start.Add(time.Second))
+	assert.Equal(t, 2.0, sample.Remaining)
+
+	// refill is capped at the bucket size
+	sample, _ = sim.Observe("us-east-1", "ec2.amazonaws.com", "DescribeInstances", start.Add(time.Hour))
+	assert.Equal(t, 9.0, sample.Remaining)
+	assert.Equal(t, 10.0, sample.Utilization)
+}
+
+func TestSimulator_RegionsAreIndependent(t *testing.T) {
+	sim := NewSimulator(map[string]Limit{
+		Key("sts.amazonaws.com", "AssumeRole"): {BucketSize: 2, RefillRate: 1},
+	})
+	now := time.Now()
+
+	sim.Observe("us-east-1", "sts.amazonaws.com", "AssumeRole", now)
+	sample, _ := sim.Observe("us-east-1", "sts.amazonaws.com", "AssumeRole", now)
+	assert.Equal(t, 0.0, sample.Remaining)
+
+	sample, _ = sim.Observe("eu-west-1", "sts.amazonaws.com", "AssumeRole", now)
+	assert.Equal(t, 1.0, sample.Remaining)
+}
+
+func TestSimulator_NoLimit(t *testing.T) {
+	sim := NewSimulator(map[string]Limit{
+		Key("iam.amazonaws.com", "GetRole"):    {},
+		Key("sts.amazonaws.com", "AssumeRole"): {BucketSize: 2, RefillRate: 1},
+	})
+
+	_, ok := sim.Observe("us-east-1", "iam.amazonaws.com", "GetRole", time.Now())
+	assert.False(t, ok, "disabled limits are not simulated")
+
+	_, ok = sim.Observe("us-east-1", "kms.amazonaws.com", "AssumeRole", time.Now())
+	assert.False(t, ok, "limits are matched on eventSource and eventName")
+}
@@ -1,9 +1,17 @@
 package utils
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"sort"
+	"strings"
 	"time"
+
+	cwTypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/smithy-go"
+	sharedtypes "github.com/outofoffice3/aws-samples/geras/internal/shared/types"
 )
 
 // validRegions is a set of valid AWS regions.
@@ -48,3 +56,82 @@ func MakeStreamName() string {
 	ts := time.Now().UTC().Format(LogStreamTimeLayout)
 	return fmt.Sprintf("%s-%s", ts, host)
 }
+
+// Utilization returns used as a percentage of limit.
+// A limit of zero or less yields zero utilization.
+func Utilization(used, limit float64) float64 {
+	if limit <= 0 {
+		return 0
+	}
+	return (used / limit) * 100
+}
+
+// PercentMetric builds a percent cloudwatch metric, usually from Utilization.
+func PercentMetric(name string, value float64, dims map[string]string, ts time.Time) sharedtypes.CloudWatchMetric {
+	return sharedtypes.CloudWatchMetric{
+		Name:      name,
+		Value:     value,
+		Unit:      cwTypes.StandardUnitPercent,
+		Metadata:  dims,
+		Timestamp: ts,
+	}
+}
+
+// NearDeadline reports whether less than margin is left before the context deadline.
+// Jobs that page through many resources use it to stop early and emit what they collected.
+func NearDeadline(ctx context.Context, margin time.Duration) bool {
+	deadline, ok := ctx.Deadline()
+	return ok && time.Until(deadline) < margin
+}
+
+// ResourceCount is the usage count of a single resource such as a cluster or repository.
+// Dimensions are carried through to the emitted metric.
+type ResourceCount struct {
+	Name       string
+	Count      int64
+	Dimensions map[string]string
+}
+
+// TopResourceCounts returns the n resources with the highest count, largest first.
+// Ties are ordered by name so the emitted set is stable between runs.
+func TopResourceCounts(counts []ResourceCount, n int) []ResourceCount {
+	sorted := make([]ResourceCount, len(counts))
+	copy(sorted, counts)
+	sort.Slice(sorted, func(a, b int) bool {
+		if sorted[a].Count != sorted[b].Count {
+			return sorted[a].Count > sorted[b].Count
+		}
+		return sorted[a].Name < sorted[b].Name
+	})
+	if n >= 0 && len(sorted) > n {
+		sorted = sorted[:n]
+	}
+	return sorted
+}
+
+// ResourceNameFromArn returns the resource name from an arn such as
+// arn:aws:ecs:us-east-1:123456789012:cluster/name. Input without a "/"
+// is returned unchanged.
+func ResourceNameFromArn(arn string) string {
+	if i := strings.LastIndex(arn, "/"); i >= 0 {
+		return arn[i+1:]
+	}
+	return arn
+}
+
+// accessDeniedCodes are the error codes AWS services return when the caller lacks permission
+var accessDeniedCodes = map[string]struct{}{
+	"AccessDenied":          {},
+	"AccessDeniedException": {},
+	"UnauthorizedOperation": {},
+}
+
+// IsAccessDenied reports whether err is an AWS api error caused by missing permissions
+func IsAccessDenied(err error) bool {
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	_, ok := accessDeniedCodes[apiErr.ErrorCode()]
+	return ok
+}
@@ -1,8 +1,14 @@
 package utils_test
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
+	cwTypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/smithy-go"
 	"github.com/outofoffice3/aws-samples/geras/internal/utils"
 	"github.com/stretchr/testify/assert"
 )
@@ -29,3 +35,60 @@ func TestIsValidRegion_InvalidRegions(t *testing.T) {
 		assert.False(t, utils.IsValidRegion(region), "expected %s to be invalid", region)
 	}
 }
+
+func TestUtilization(t *testing.T) {
+	assert.Equal(t, 50.0, utils.Utilization(5, 10), "expected 50 percent utilization")
+	assert.Equal(t, 0.0, utils.Utilization(5, 0), "expected zero utilization for zero limit")
+	assert.Equal(t, 0.0, utils.Utilization(0, 10), "expected zero utilization for zero usage")
+}
+
+func TestPercentMetric(t *testing.T) {
+	ts := time.Now()
+	m := utils.PercentMetric("name", 50, map[string]string{"k": "v"}, ts)
+	assert.Equal(t, "name", m.Name)
+	assert.Equal(t, 50.0, m.Value)
+	assert.Equal(t, cwTypes.StandardUnitPercent, m.Unit)
+	assert.Equal(t, map[string]string{"k": "v"}, m.Metadata)
+	assert.Equal(t, ts, m.Timestamp)
+}
+
+func TestNearDeadline(t *testing.T) {
+	assert.False(t, utils.NearDeadline(context.Background(), time.Hour), "no deadline is never near")
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	assert.False(t, utils.NearDeadline(ctx, time.Second))
+	assert.True(t, utils.NearDeadline(ctx, 2*time.Minute))
+}
+
+func TestTopResourceCounts(t *testing.T) {
+	counts := []utils.ResourceCount{
+		{Name: "b", Count: 5},
+		{Name: "a", Count: 5},
+		{Name: "c", Count: 9},
+		{Name: "d", Count: 1},
+	}
+	top := utils.TopResourceCounts(counts, 3)
+	if assert.Len(t, top, 3, "expected top 3 resources") {
+		assert.Equal(t, "c", top[0].Name, "largest count first")
+		assert.Equal(t, "a", top[1].Name, "ties ordered by name")
+		assert.Equal(t, "b", top[2].Name, "ties ordered by name")
+	}
+	assert.Equal(t, "b", counts[0].Name, "input should not be reordered")
+	assert.Len(t, utils.TopResourceCounts(counts, 10), 4, "n larger than input returns all")
+	assert.Empty(t, utils.TopResourceCounts(nil, 3), "nil input returns empty")
+}
+
+func TestResourceNameFromArn(t *testing.T) {
+	assert.Equal(t, "prod", utils.ResourceNameFromArn("arn:aws:ecs:us-east-1:123456789012:cluster/prod"), "expected cluster name")
+	assert.Equal(t, "api", utils.ResourceNameFromArn("arn:aws:ecs:us-east-1:123456789012:service/prod/api"), "expected service name")
+	assert.Equal(t, "plain", utils.ResourceNameFromArn("plain"), "expected input without a slash to be returned unchanged")
+}
+
+func TestIsAccessDenied(t *testing.T) {
+	denied := &smithy.GenericAPIError{Code: "AccessDeniedException", Message: "not authorized"}
+	assert.True(t, utils.IsAccessDenied(denied), "expected access denied api error")
+	assert.True(t, utils.IsAccessDenied(fmt.Errorf("wrapped: %w", denied)), "expected wrapped access denied api error")
+	assert.False(t, utils.IsAccessDenied(&smithy.GenericAPIError{Code: "ThrottlingException"}), "expected other api errors to be false")
+	assert.False(t, utils.IsAccessDenied(errors.New("AccessDenied")), "expected non api errors to be false")
+	assert.False(t, utils.IsAccessDenied(nil), "expected nil to be false")
+}
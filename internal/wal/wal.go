@@ -0,0 +1,288 @@
+package wal
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// FsyncPolicy decides when appended records are synced to disk
+type FsyncPolicy int
+
+const (
+	// FsyncOnRotate syncs a segment once, when it is sealed before a flush
+	FsyncOnRotate FsyncPolicy = iota
+	// FsyncAlways syncs after every append
+	FsyncAlways
+	// FsyncNever leaves syncing to the operating system
+	FsyncNever
+)
+
+const (
+	segmentExt = ".ndjson"
+	seqDigits  = 20
+)
+
+var ErrInvalidFsyncPolicy = errors.New("invalid fsync policy")
+
+// ParseFsyncPolicy parses "rotate", "always" or "never"
+func ParseFsyncPolicy(s string) (FsyncPolicy, error) {
+	switch strings.ToLower(s) {
+	case "rotate":
+		return FsyncOnRotate, nil
+	case "always":
+		return FsyncAlways, nil
+	case "never":
+		return FsyncNever, nil
+	}
+	return 0, fmt.Errorf("%w: %s", ErrInvalidFsyncPolicy, s)
+}
+
+// File is the part of *os.File used by the log
+type File interface {
+	io.Writer
+	Sync() error
+	Close() error
+}
+
+// FS is the file system the log is stored in, so tests can inject failures
+type FS interface {
+	OpenFile(name string, flag int, perm os.FileMode) (File, error)
+	ReadFile(name string) ([]byte, error)
+	Remove(name string) error
+	ReadDir(name string) ([]os.DirEntry, error)
+}
+
+// OSFS is the FS of the operating system
+type OSFS struct{}
+
+func (OSFS) OpenFile(name string, flag int, perm os.FileMode) (File, error) {
+	return os.OpenFile(name, flag, perm)
+}
+func (OSFS) ReadFile(name string) ([]byte, error)       { return os.ReadFile(name) }
+func (OSFS) Remove(name string) error                   { return os.Remove(name) }
+func (OSFS) ReadDir(name string) ([]os.DirEntry, error) { return os.ReadDir(name) }
+
+// Segment is one file of a log, named <prefix>.<seq><ext>
+type Segment struct {
+	Path   string
+	Prefix string
+	Seq    uint64
+}
+
+func segmentName(prefix string, seq uint64) string {
+	return fmt.Sprintf("%s.%0*d%s", prefix, seqDigits, seq, segmentExt)
+}
+
+func parseSegmentName(name string) (prefix string, seq uint64, ok bool) {
+	base, found := strings.CutSuffix(name, segmentExt)
+	if !found {
+		return "", 0, false
+	}
+	i := strings.LastIndex(base, ".")
+	if i <= 0 || len(base)-i-1 != seqDigits {
+		return "", 0, false
+	}
+	seq, err := strconv.ParseUint(base[i+1:], 10, 64)
+	if err != nil {
+		return "", 0, false
+	}
+	return base[:i], seq, true
+}
+
+// ListSegments returns every segment in dir, ordered by prefix and then oldest first
+func ListSegments(fs FS, dir string) ([]Segment, error) {
+	entries, err := fs.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var segments []Segment
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		prefix, seq, ok := parseSegmentName(entry.Name())
+		if !ok {
+			continue
+		}
+		segments = append(segments, Segment{Path: filepath.Join(dir, entry.Name()), Prefix: prefix, Seq: seq})
+	}
+	sort.Slice(segments, func(i, j int) bool {
+		if segments[i].Prefix != segments[j].Prefix {
+			return segments[i].Prefix < segments[j].Prefix
+		}
+		return segments[i].Seq < segments[j].Seq
+	})
+	return segments, nil
+}
+
+// ReadSegment returns the non empty lines of a segment.  The last line can be torn by a
+// crash during an append, callers are expected to skip lines they cannot parse.
+func ReadSegment(fs FS, segment Segment) ([][]byte, error) {
+	data, err := fs.ReadFile(segment.Path)
+	if err != nil {
+		return nil, err
+	}
+	var lines [][]byte
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		if len(line) > 0 {
+			lines = append(lines, line)
+		}
+	}
+	return lines, nil
+}
+
+// Config configures a Log.  FS defaults to OSFS.
+type Config struct {
+	Dir    string
+	Prefix string
+	Fsync  FsyncPolicy
+	FS     FS
+}
+
+// Log is a segmented write-ahead log of newline separated records.
+// Records are appended to the active segment.  Rotate seals it so it can be read and,
+// once its records are safely stored elsewhere, removed, while new records go to the
+// next segment.  Sealed segments survive a crash and are returned by Sealed on restart.
+type Log struct {
+	dir    string
+	prefix string
+	fsync  FsyncPolicy
+	fs     FS
+
+	mu      sync.Mutex
+	nextSeq uint64
+	active  File
+	current Segment
+	count   int
+	size    int64
+}
+
+// Open opens the log, after the segments left in the directory by a previous run
+func Open(config Config) (*Log, error) {
+	if config.FS == nil {
+		config.FS = OSFS{}
+	}
+	segments, err := ListSegments(config.FS, config.Dir)
+	if err != nil {
+		return nil, err
+	}
+	l := &Log{
+		dir:     config.Dir,
+		prefix:  config.Prefix,
+		fsync:   config.Fsync,
+		fs:      config.FS,
+		nextSeq: 1,
+	}
+	for _, s := range segments {
+		if s.Prefix == l.prefix && s.Seq >= l.nextSeq {
+			l.nextSeq = s.Seq + 1
+		}
+	}
+	return l, nil
+}
+
+// Append writes one record to the active segment, creating it when needed.
+// A failed write seals the segment so the next record starts a clean one.
+func (l *Log) Append(record []byte) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.active == nil {
+		segment := Segment{
+			Path:   filepath.Join(l.dir, segmentName(l.prefix, l.nextSeq)),
+			Prefix: l.prefix,
+			Seq:    l.nextSeq,
+		}
+		f, err := l.fs.OpenFile(segment.Path, os.O_CREATE|os.O_EXCL|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return err
+		}
+		l.nextSeq++
+		l.active = f
+		l.current = segment
+	}
+
+	line := make([]byte, 0, len(record)+1)
+	line = append(append(line, record...), '\n')
+	if _, err := l.active.Write(line); err != nil {
+		l.sealLocked()
+		return err
+	}
+	l.count++
+	l.size += int64(len(line))
+	if l.fsync == FsyncAlways {
+		if err := l.active.Sync(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Stats returns the records and bytes of the active segment
+func (l *Log) Stats() (count int, size int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.count, l.size
+}
+
+// Rotate seals the active segment, if any, so the next append starts a new one
+func (l *Log) Rotate() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.active == nil {
+		return nil
+	}
+	var syncErr error
+	if l.fsync == FsyncOnRotate {
+		syncErr = l.active.Sync()
+	}
+	return errors.Join(syncErr, l.sealLocked())
+}
+
+// sealLocked closes the active segment and resets its stats
+func (l *Log) sealLocked() error {
+	err := l.active.Close()
+	l.active = nil
+	l.count = 0
+	l.size = 0
+	return err
+}
+
+// Sealed returns the sealed segments of the log, oldest first
+func (l *Log) Sealed() ([]Segment, error) {
+	segments, err := ListSegments(l.fs, l.dir)
+	if err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var sealed []Segment
+	for _, s := range segments {
+		if s.Prefix != l.prefix || (l.active != nil && s.Seq == l.current.Seq) {
+			continue
+		}
+		sealed = append(sealed, s)
+	}
+	return sealed, nil
+}
+
+// Read returns the lines of a segment
+func (l *Log) Read(segment Segment) ([][]byte, error) {
+	return ReadSegment(l.fs, segment)
+}
+
+// Remove deletes a sealed segment once its records are no longer needed
+func (l *Log) Remove(segment Segment) error {
+	return l.fs.Remove(segment.Path)
+}
@@ -0,0 +1,289 @@
+package wal
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errInjected = errors.New("injected failure")
+
+// faultyFS is the os file system with failures injected per operation
+type faultyFS struct {
+	OSFS
+	mu    sync.Mutex
+	fail  map[string]int // operation -> failures left
+	syncs int
+}
+
+func newFaultyFS() *faultyFS {
+	return &faultyFS{fail: make(map[string]int)}
+}
+
+func (f *faultyFS) failNext(op string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.fail[op]++
+}
+
+func (f *faultyFS) check(op string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if op == "sync" {
+		f.syncs++
+	}
+	if f.fail[op] > 0 {
+		f.fail[op]--
+		return errInjected
+	}
+	return nil
+}
+
+func (f *faultyFS) OpenFile(name string, flag int, perm os.FileMode) (File, error) {
+	if err := f.check("open"); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(name, flag, perm)
+	if err != nil {
+		return nil, err
+	}
+	return &faultyFile{File: file, fs: f}, nil
+}
+
+func (f *faultyFS) ReadFile(name string) ([]byte, error) {
+	if err := f.check("read"); err != nil {
+		return nil, err
+	}
+	return os.ReadFile(name)
+}
+
+func (f *faultyFS) Remove(name string) error {
+	if err := f.check("remove"); err != nil {
+		return err
+	}
+	return os.Remove(name)
+}
+
+type faultyFile struct {
+	*os.File
+	fs *faultyFS
+}
+
+func (f *faultyFile) Write(p []byte) (int, error) {
+	if err := f.fs.check("write"); err != nil {
+		// a torn write keeps half of the record
+		n, _ := f.File.Write(p[:len(p)/2])
+		return n, err
+	}
+	return f.File.Write(p)
+}
+
+func (f *faultyFile) Sync() error {
+	if err := f.fs.check("sync"); err != nil {
+		return err
+	}
+	return f.File.Sync()
+}
+
+func (f *faultyFile) Close() error {
+	err := f.fs.check("close")
+	closeErr := f.File.Close()
+	if err != nil {
+		return err
+	}
+	return closeErr
+}
+
+func openTestLog(t *testing.T, dir string, fs FS, policy FsyncPolicy) *Log {
+	l, err := Open(Config{Dir: dir, Prefix: "emf_us-east-1", Fsync: policy, FS: fs})
+	require.NoError(t, err)
+	return l
+}
+
+func readAll(t *testing.T, l *Log) [][]string {
+	segments, err := l.Sealed()
+	require.NoError(t, err)
+	var out [][]string
+	for _, s := range segments {
+		lines, err := l.Read(s)
+		require.NoError(t, err)
+		var strs []string
+		for _, line := range lines {
+			strs = append(strs, string(line))
+		}
+		out = append(out, strs)
+	}
+	return out
+}
+
+func TestLog_AppendRotateRemove(t *testing.T) {
+	dir := t.TempDir()
+	l := openTestLog(t, dir, nil, FsyncOnRotate)
+
+	require.NoError(t, l.Append([]byte("a")))
+	require.NoError(t, l.Append([]byte("b")))
+	count, size := l.Stats()
+	assert.Equal(t, 2, count)
+	assert.Equal(t, int64(4), size)
+
+	// the active segment is not sealed yet
+	assert.Empty(t, readAll(t, l))
+
+	require.NoError(t, l.Rotate())
+	count, _ = l.Stats()
+	assert.Zero(t, count)
+	require.NoError(t, l.Append([]byte("c")))
+	require.NoError(t, l.Rotate())
+	assert.Equal(t, [][]string{{"a", "b"}, {"c"}}, readAll(t, l))
+
+	// rotating without records does not create a segment
+	require.NoError(t, l.Rotate())
+	segments, err := l.Sealed()
+	require.NoError(t, err)
+	require.Len(t, segments, 2)
+	assert.Equal(t, uint64(1), segments[0].Seq)
+	assert.Equal(t, filepath.Join(dir, "emf_us-east-1.00000000000000000001.ndjson"), segments[0].Path)
+
+	require.NoError(t, l.Remove(segments[0]))
+	assert.Equal(t, [][]string{{"c"}}, readAll(t, l))
+}
+
+func TestLog_ReopenContinuesSequence(t *testing.T) {
+	dir := t.TempDir()
+	l := openTestLog(t, dir, nil, FsyncOnRotate)
+	require.NoError(t, l.Append([]byte("a")))
+	require.NoError(t, l.Rotate())
+	require.NoError(t, l.Append([]byte("b"))) // never sealed, as after a crash
+
+	// other prefixes in the same directory are ignored
+	other, err := Open(Config{Dir: dir, Prefix: "emf_eu-west-1"})
+	require.NoError(t, err)
+	require.NoError(t, other.Append([]byte("x")))
+
+	reopened := openTestLog(t, dir, nil, FsyncOnRotate)
+	require.NoError(t, reopened.Append([]byte("c")))
+	require.NoError(t, reopened.Rotate())
+
+	assert.Equal(t, [][]string{{"a"}, {"b"}, {"c"}}, readAll(t, reopened))
+}
+
+func TestLog_FsyncPolicy(t *testing.T) {
+	tests := []struct {
+		policy FsyncPolicy
+		syncs  int
+	}{
+		{FsyncAlways, 3},
+		{FsyncOnRotate, 1},
+		{FsyncNever, 0},
+	}
+	for _, tt := range tests {
+		fs := newFaultyFS()
+		l := openTestLog(t, t.TempDir(), fs, tt.policy)
+		require.NoError(t, l.Append([]byte("a")))
+		require.NoError(t, l.Append([]byte("b")))
+		require.NoError(t, l.Append([]byte("c")))
+		require.NoError(t, l.Rotate())
+		assert.Equal(t, tt.syncs, fs.syncs, "policy %v", tt.policy)
+	}
+}
+
+func TestLog_Failures(t *testing.T) {
+	t.Run("open", func(t *testing.T) {
+		fs := newFaultyFS()
+		l := openTestLog(t, t.TempDir(), fs, FsyncOnRotate)
+		fs.failNext("open")
+		assert.ErrorIs(t, l.Append([]byte("lost")), errInjected)
+		require.NoError(t, l.Append([]byte("a")))
+		require.NoError(t, l.Rotate())
+		assert.Equal(t, [][]string{{"a"}}, readAll(t, l))
+	})
+
+	t.Run("torn write seals the segment", func(t *testing.T) {
+		fs := newFaultyFS()
+		l := openTestLog(t, t.TempDir(), fs, FsyncOnRotate)
+		require.NoError(t, l.Append([]byte("a")))
+		fs.failNext("write")
+		assert.ErrorIs(t, l.Append([]byte("torn-record")), errInjected)
+		require.NoError(t, l.Append([]byte("b")))
+		require.NoError(t, l.Rotate())
+		assert.Equal(t, [][]string{{"a", "torn-r"}, {"b"}}, readAll(t, l))
+	})
+
+	t.Run("sync on append", func(t *testing.T) {
+		fs := newFaultyFS()
+		l := openTestLog(t, t.TempDir(), fs, FsyncAlways)
+		fs.failNext("sync")
+		assert.ErrorIs(t, l.Append([]byte("a")), errInjected)
+		require.NoError(t, l.Rotate())
+		assert.Equal(t, [][]string{{"a"}}, readAll(t, l), "the record is written even when it could not be synced")
+	})
+
+	t.Run("sync and close on rotate", func(t *testing.T) {
+		fs := newFaultyFS()
+		l := openTestLog(t, t.TempDir(), fs, FsyncOnRotate)
+		require.NoError(t, l.Append([]byte("a")))
+		fs.failNext("sync")
+		fs.failNext("close")
+		assert.ErrorIs(t, l.Rotate(), errInjected)
+		require.NoError(t, l.Append([]byte("b")))
+		require.NoError(t, l.Rotate())
+		assert.Equal(t, [][]string{{"a"}, {"b"}}, readAll(t, l), "the segment is sealed anyway")
+	})
+
+	t.Run("read and remove", func(t *testing.T) {
+		fs := newFaultyFS()
+		l := openTestLog(t, t.TempDir(), fs, FsyncOnRotate)
+		require.NoError(t, l.Append([]byte("a")))
+		require.NoError(t, l.Rotate())
+		segments, err := l.Sealed()
+		require.NoError(t, err)
+
+		fs.failNext("read")
+		_, err = l.Read(segments[0])
+		assert.ErrorIs(t, err, errInjected)
+
+		fs.failNext("remove")
+		assert.ErrorIs(t, l.Remove(segments[0]), errInjected)
+		assert.Equal(t, [][]string{{"a"}}, readAll(t, l), "the segment is kept")
+	})
+}
+
+func TestParseFsyncPolicy(t *testing.T) {
+	for in, want := range map[string]FsyncPolicy{"rotate": FsyncOnRotate, "ALWAYS": FsyncAlways, "never": FsyncNever} {
+		got, err := ParseFsyncPolicy(in)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+	_, err := ParseFsyncPolicy("sometimes")
+	assert.ErrorIs(t, err, ErrInvalidFsyncPolicy)
+}
+
+func TestListSegments_IgnoresOtherFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{
+		"emf_us-east-1.ndjson",     // stash file of older versions
+		"emf_us-east-1.123.ndjson", // short sequence
+		"emf_us-east-1.00000000000000000002.ndjson",
+		"emf_eu-west-1.00000000000000000001.ndjson",
+		"notes.txt",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0644))
+	}
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "unlisted"), 0755))
+
+	segments, err := ListSegments(OSFS{}, dir)
+	require.NoError(t, err)
+	assert.Equal(t, []Segment{
+		{Path: filepath.Join(dir, "emf_eu-west-1.00000000000000000001.ndjson"), Prefix: "emf_eu-west-1", Seq: 1},
+		{Path: filepath.Join(dir, "emf_us-east-1.00000000000000000002.ndjson"), Prefix: "emf_us-east-1", Seq: 2},
+	}, segments)
+
+	missing, err := ListSegments(OSFS{}, filepath.Join(dir, "missing"))
+	assert.NoError(t, err)
+	assert.Empty(t, missing)
+}